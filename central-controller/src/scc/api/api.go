@@ -92,6 +92,16 @@ func NewRouter(
 	mgrset.Overlay = overlayObjectClient.(*manager.OverlayObjectManager)
 	createHandlerMapping(overlayObjectClient, verRouter, manager.OverlayCollection, manager.OverlayResource)
 
+	// overlay export/import/apply API
+	documentHandler := OverlayDocumentHandler{client: mgrset.Overlay}
+	olRouter.HandleFunc("/"+manager.ExportAction, documentHandler.exportHandler).Methods("GET")
+	verRouter.HandleFunc("/"+manager.OverlayCollection+"/"+manager.ImportAction, documentHandler.importHandler).Methods("POST")
+	olRouter.HandleFunc("/"+manager.ApplyAction, documentHandler.applyHandler).Methods("POST")
+
+	// event stream API
+	eventHandler := EventHandler{client: manager.GetEventStream()}
+	verRouter.HandleFunc("/"+manager.EventCollection, eventHandler.streamHandler).Methods("GET")
+
 	// proposal API
 	if proposalObjectClient == nil {
 		proposalObjectClient = manager.NewProposalObjectManager()
@@ -99,6 +109,10 @@ func NewRouter(
 	mgrset.Proposal = proposalObjectClient.(*manager.ProposalObjectManager)
 	createHandlerMapping(proposalObjectClient, olRouter, manager.ProposalCollection, manager.ProposalResource)
 
+	// proposal set API
+	mgrset.ProposalSet = manager.NewProposalSetObjectManager()
+	createHandlerMapping(mgrset.ProposalSet, olRouter, manager.ProposalSetCollection, manager.ProposalSetResource)
+
 	// hub API
 	if hubObjectClient == nil {
 		hubObjectClient = manager.NewHubObjectManager()
@@ -106,6 +120,10 @@ func NewRouter(
 	mgrset.Hub = hubObjectClient.(*manager.HubObjectManager)
 	createHandlerMapping(hubObjectClient, olRouter, manager.HubCollection, manager.HubResource)
 
+	// hub drain API
+	drainHandler := DrainHandler{client: mgrset.Hub}
+	hubRouter.HandleFunc("/"+manager.DrainAction, drainHandler.drainHandler).Methods("POST")
+
 	// hub-connection API
 	if hubConnObjectClient == nil {
 		hubConnObjectClient = manager.NewHubConnObjectManager()
@@ -134,6 +152,14 @@ func NewRouter(
 	mgrset.Device = deviceObjectClient.(*manager.DeviceObjectManager)
 	createHandlerMapping(deviceObjectClient, olRouter, manager.DeviceCollection, manager.DeviceResource)
 
+	// device registration API
+	registerHandler := RegisterHandler{client: mgrset.Device}
+	devRouter.HandleFunc("/"+manager.RegisterAction, registerHandler.registerHandler).Methods("POST")
+
+	// device bootstrap API
+	bootstrapHandler := BootstrapHandler{client: mgrset.Device}
+	verRouter.HandleFunc("/"+manager.BootstrapAction, bootstrapHandler.bootstrapHandler).Methods("POST")
+
 	// device-connection API
 	if deviceConnObjectClient == nil {
 		deviceConnObjectClient = manager.NewDeviceConnObjectManager()
@@ -155,6 +181,42 @@ func NewRouter(
 	mgrset.DeviceSite = deviceSiteObjectClient.(*manager.DeviceSiteObjectManager)
 	createHandlerMapping(deviceSiteObjectClient, devRouter, manager.SiteCollection, manager.SiteResource)
 
+	// hub/device firewall-rule API
+	mgrset.HubFirewallRule = manager.NewFirewallRuleObjectManager(true)
+	createHandlerMapping(mgrset.HubFirewallRule, hubRouter, manager.FirewallRuleCollection, manager.FirewallRuleResource)
+	mgrset.DevFirewallRule = manager.NewFirewallRuleObjectManager(false)
+	createHandlerMapping(mgrset.DevFirewallRule, devRouter, manager.FirewallRuleCollection, manager.FirewallRuleResource)
+
+	// hub/device firewall-forwarding API
+	mgrset.HubFirewallFwd = manager.NewFirewallFwdObjectManager(true)
+	createHandlerMapping(mgrset.HubFirewallFwd, hubRouter, manager.FirewallFwdCollection, manager.FirewallFwdResource)
+	mgrset.DevFirewallFwd = manager.NewFirewallFwdObjectManager(false)
+	createHandlerMapping(mgrset.DevFirewallFwd, devRouter, manager.FirewallFwdCollection, manager.FirewallFwdResource)
+
+	// hub/device mwan3-policy API
+	mgrset.HubMwan3Policy = manager.NewMwan3PolicyObjectManager(true)
+	createHandlerMapping(mgrset.HubMwan3Policy, hubRouter, manager.Mwan3PolicyCollection, manager.Mwan3PolicyResource)
+	mgrset.DevMwan3Policy = manager.NewMwan3PolicyObjectManager(false)
+	createHandlerMapping(mgrset.DevMwan3Policy, devRouter, manager.Mwan3PolicyCollection, manager.Mwan3PolicyResource)
+
+	// hub/device mwan3-rule API
+	mgrset.HubMwan3Rule = manager.NewMwan3RuleObjectManager(true)
+	createHandlerMapping(mgrset.HubMwan3Rule, hubRouter, manager.Mwan3RuleCollection, manager.Mwan3RuleResource)
+	mgrset.DevMwan3Rule = manager.NewMwan3RuleObjectManager(false)
+	createHandlerMapping(mgrset.DevMwan3Rule, devRouter, manager.Mwan3RuleCollection, manager.Mwan3RuleResource)
+
+	// hub/device route-rule API
+	mgrset.HubRouteRule = manager.NewRouteRuleObjectManager(true)
+	createHandlerMapping(mgrset.HubRouteRule, hubRouter, manager.RouteRuleCollection, manager.RouteRuleResource)
+	mgrset.DevRouteRule = manager.NewRouteRuleObjectManager(false)
+	createHandlerMapping(mgrset.DevRouteRule, devRouter, manager.RouteRuleCollection, manager.RouteRuleResource)
+
+	// hub/device application API
+	mgrset.HubApplication = manager.NewApplicationObjectManager(true)
+	createHandlerMapping(mgrset.HubApplication, hubRouter, manager.ApplicationCollection, manager.ApplicationResource)
+	mgrset.DevApplication = manager.NewApplicationObjectManager(false)
+	createHandlerMapping(mgrset.DevApplication, devRouter, manager.ApplicationCollection, manager.ApplicationResource)
+
 	// provider iprange API
 	if providerIpRangeObjectClient == nil {
 		providerIpRangeObjectClient = manager.NewIPRangeObjectManager(true)
@@ -183,31 +245,82 @@ func NewRouter(
 	mgrset.ClusterSync = clusterSyncObjectClient.(*manager.ClusterSyncObjectManager)
 	createHandlerMapping(clusterSyncObjectClient, olRouter, manager.ClusterSyncCollection, manager.ClusterSyncResource)
 
+	// hub/device pre-shared key API
+	mgrset.HubPSK = manager.NewPreSharedKeyObjectManager(true)
+	createHandlerMapping(mgrset.HubPSK, hubRouter, manager.PreSharedKeyCollection, manager.PreSharedKeyResource)
+	mgrset.DevPSK = manager.NewPreSharedKeyObjectManager(false)
+	createHandlerMapping(mgrset.DevPSK, devRouter, manager.PreSharedKeyCollection, manager.PreSharedKeyResource)
+
+	// security policy API
+	mgrset.SecurityPolicy = manager.NewSecurityPolicyObjectManager()
+	createHandlerMapping(mgrset.SecurityPolicy, olRouter, manager.SecurityPolicyCollection, manager.SecurityPolicyResource)
+
+	// traffic policy API
+	mgrset.TrafficPolicy = manager.NewTrafficPolicyObjectManager()
+	createHandlerMapping(mgrset.TrafficPolicy, olRouter, manager.TrafficPolicyCollection, manager.TrafficPolicyResource)
+	mgrset.DnsForwarder = manager.NewDnsForwarderObjectManager()
+	createHandlerMapping(mgrset.DnsForwarder, olRouter, manager.DnsForwarderCollection, manager.DnsForwarderResource)
+	mgrset.DnsRecord = manager.NewDnsRecordObjectManager()
+	createHandlerMapping(mgrset.DnsRecord, olRouter, manager.DnsRecordCollection, manager.DnsRecordResource)
+
+	// webhook API
+	mgrset.Webhook = manager.NewWebhookObjectManager()
+	createHandlerMapping(mgrset.Webhook, olRouter, manager.WebhookCollection, manager.WebhookResource)
+	webhookHandler := WebhookHandler{client: mgrset.Webhook}
+	olRouter.HandleFunc("/"+manager.WebhookCollection+"/{"+manager.WebhookResource+"}/"+manager.DeliveryCollection,
+		webhookHandler.deliveriesHandler).Methods("GET")
+
 	// create resource object manager
 	mgrset.Resource = manager.NewResourceObjectManager()
 
 	// Add depedency
 	overlayObjectClient.AddOwnResManager(proposalObjectClient)
+	overlayObjectClient.AddOwnResManager(mgrset.ProposalSet)
 	overlayObjectClient.AddOwnResManager(hubObjectClient)
 	overlayObjectClient.AddOwnResManager(deviceObjectClient)
 	overlayObjectClient.AddOwnResManager(ipRangeObjectClient)
 	overlayObjectClient.AddOwnResManager(certificateObjectClient)
 	overlayObjectClient.AddOwnResManager(clusterSyncObjectClient)
+	overlayObjectClient.AddOwnResManager(mgrset.SecurityPolicy)
+	overlayObjectClient.AddOwnResManager(mgrset.TrafficPolicy)
+	overlayObjectClient.AddOwnResManager(mgrset.DnsForwarder)
+	overlayObjectClient.AddOwnResManager(mgrset.DnsRecord)
+	overlayObjectClient.AddOwnResManager(mgrset.Webhook)
 	hubObjectClient.AddOwnResManager(hubDeviceObjectClient)
 	deviceObjectClient.AddOwnResManager(hubDeviceObjectClient)
 
 	proposalObjectClient.AddDepResManager(overlayObjectClient)
+	mgrset.ProposalSet.AddDepResManager(overlayObjectClient)
 	hubObjectClient.AddDepResManager(overlayObjectClient)
 	deviceObjectClient.AddDepResManager(overlayObjectClient)
 	ipRangeObjectClient.AddDepResManager(overlayObjectClient)
 	certificateObjectClient.AddDepResManager(overlayObjectClient)
 	clusterSyncObjectClient.AddDepResManager(overlayObjectClient)
+	mgrset.SecurityPolicy.AddDepResManager(overlayObjectClient)
+	mgrset.TrafficPolicy.AddDepResManager(overlayObjectClient)
+	mgrset.DnsForwarder.AddDepResManager(overlayObjectClient)
+	mgrset.DnsRecord.AddDepResManager(overlayObjectClient)
+	mgrset.Webhook.AddDepResManager(overlayObjectClient)
 	hubDeviceObjectClient.AddDepResManager(hubObjectClient)
 	hubConnObjectClient.AddDepResManager(hubObjectClient)
 	deviceConnObjectClient.AddDepResManager(deviceObjectClient)
 	hubCNFObjectClient.AddDepResManager(hubObjectClient)
 	deviceCNFObjectClient.AddDepResManager(deviceObjectClient)
 	deviceSiteObjectClient.AddDepResManager(deviceObjectClient)
+	mgrset.HubPSK.AddDepResManager(hubObjectClient)
+	mgrset.DevPSK.AddDepResManager(deviceObjectClient)
+
+	for _, mgr := range []manager.ControllerObjectManager{mgrset.HubFirewallRule, mgrset.HubFirewallFwd,
+		mgrset.HubMwan3Policy, mgrset.HubMwan3Rule, mgrset.HubRouteRule, mgrset.HubApplication} {
+		hubObjectClient.AddOwnResManager(mgr)
+		mgr.AddDepResManager(hubObjectClient)
+	}
+
+	for _, mgr := range []manager.ControllerObjectManager{mgrset.DevFirewallRule, mgrset.DevFirewallFwd,
+		mgrset.DevMwan3Policy, mgrset.DevMwan3Rule, mgrset.DevRouteRule, mgrset.DevApplication} {
+		deviceObjectClient.AddOwnResManager(mgr)
+		mgr.AddDepResManager(deviceObjectClient)
+	}
 
 	return router
 }
@@ -0,0 +1,79 @@
+/*
+* Copyright 2020 Intel Corporation, Inc
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+* implied.
+* See the License for the specific language governing permissions
+* and
+* limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/validation"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"io"
+	"net/http"
+)
+
+// BootstrapHandler handles the zero-touch onboarding of devices
+type BootstrapHandler struct {
+	client *manager.DeviceObjectManager
+}
+
+// bootstrapHandler onboards the device which presents its bootstrap token
+func (h BootstrapHandler) bootstrapHandler(w http.ResponseWriter, r *http.Request) {
+	var v module.BootstrapRequest
+
+	err := json.NewDecoder(r.Body).Decode(&v)
+	switch {
+	case err == io.EOF:
+		http.Error(w, "Empty body", http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	validate := validation.GetValidator("bootstrap")
+	isValid, msg := validate.Validate(v)
+	if isValid == false {
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+
+	vars := make(map[string]string)
+	vars[manager.OverlayResource] = v.Overlay
+	vars[manager.DeviceResource] = v.Device
+
+	_, err = h.client.CheckBootstrapToken(vars, v.Token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	ret, err := h.client.Bootstrap(vars, &v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
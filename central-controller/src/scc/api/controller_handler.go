@@ -26,6 +26,7 @@ import (
 	"github.com/gorilla/mux"
 	"io"
 	"net/http"
+	"strconv"
 )
 
 // ControllerHandler is used to store backend implementations objects
@@ -102,12 +103,41 @@ func (h ControllerHandler) getsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ret, err := h.client.GetObjects(vars)
+	opts, paged, err := manager.ParseListOptions(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	var ret interface{}
+	if !paged {
+		ret, err = h.client.GetObjects(vars)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		objs, total, next, err := manager.GetDBUtils().ListObjects(h.client, vars, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		ret = objs
+		if len(opts.Fields) > 0 {
+			ret, err = manager.ProjectObjects(objs, opts.Fields)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		if next != "" {
+			w.Header().Set("X-Continue", next)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
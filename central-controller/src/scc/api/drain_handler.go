@@ -0,0 +1,90 @@
+/*
+* Copyright 2020 Intel Corporation, Inc
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+* implied.
+* See the License for the specific language governing permissions
+* and
+* limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/validation"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+)
+
+// DrainHandler handles the migration of devices between hubs
+type DrainHandler struct {
+	client *manager.HubObjectManager
+}
+
+// drainHandler moves the devices of a hub to the target hub
+func (h DrainHandler) drainHandler(w http.ResponseWriter, r *http.Request) {
+	var v module.DrainRequest
+	vars := mux.Vars(r)
+
+	err := json.NewDecoder(r.Body).Decode(&v)
+	switch {
+	case err == io.EOF:
+		http.Error(w, "Empty body", http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	validate := validation.GetValidator("drain")
+	isValid, msg := validate.Validate(v)
+	if isValid == false {
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+
+	// Check resource depedency
+	err = manager.GetDBUtils().CheckDep(h.client, vars)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, err = h.client.GetObject(vars)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ret, err := h.client.Drain(vars, &v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the devices which failed to move are reported with the moved ones
+	status := http.StatusOK
+	if len(ret.Failed) > 0 {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
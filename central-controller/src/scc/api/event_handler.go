@@ -0,0 +1,128 @@
+/*
+* Copyright 2020 Intel Corporation, Inc
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+* implied.
+* See the License for the specific language governing permissions
+* and
+* limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// interval of the comments which keep an idle stream open
+const EVENT_KEEPALIVE_INTERVAL = 30 * time.Second
+
+// EventHandler streams the changes of the objects as server-sent events
+type EventHandler struct {
+	client *manager.EventStream
+}
+
+// streamHandler streams the events of an overlay (or of all the overlays).
+// A client resumes the stream by the Last-Event-ID header or the since query
+// parameter, otherwise only the new events are streamed. A stream which can
+// not be resumed since the events are not kept anymore is gone
+func (h EventHandler) streamHandler(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var since uint64
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("since")
+	}
+	if value != "" {
+		since, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid event id: "+value, http.StatusBadRequest)
+			return
+		}
+	} else {
+		since, err = h.client.LastSeq()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	past, events, cancel, err := h.client.Subscribe(r.URL.Query().Get("overlay"), since)
+	if err == manager.ErrEventsExpired {
+		// the client reloads the objects and streams the new events
+		http.Error(w, "Event "+strconv.FormatUint(since, 10)+" is too old: "+err.Error(), http.StatusGone)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range past {
+		if writeEvent(w, e) != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(EVENT_KEEPALIVE_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				// the client was too slow, it resumes from the last event
+				return
+			}
+			if writeEvent(w, e) != nil {
+				return
+			}
+		case <-ticker.C:
+			_, err = io.WriteString(w, ": keepalive\n\n")
+			if err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, e module.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Seq, e.Type, data)
+	return err
+}
@@ -0,0 +1,173 @@
+/*
+* Copyright 2020 Intel Corporation, Inc
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+* implied.
+* See the License for the specific language governing permissions
+* and
+* limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/validation"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// header carrying the passphrase of the secrets in the overlay document
+const PassphraseHeader = "X-Passphrase"
+
+// OverlayDocumentHandler handles the export, import and apply of the overlays
+type OverlayDocumentHandler struct {
+	client *manager.OverlayObjectManager
+}
+
+// exportHandler returns the definition of an overlay
+func (h OverlayDocumentHandler) exportHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	ret, err := h.client.Export(vars, r.Header.Get(PassphraseHeader))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// importHandler creates an overlay from its definition, the overlay is
+// renamed by the name query parameter
+func (h OverlayDocumentHandler) importHandler(w http.ResponseWriter, r *http.Request) {
+	var v module.OverlayDocument
+
+	err := json.NewDecoder(r.Body).Decode(&v)
+	switch {
+	case err == io.EOF:
+		http.Error(w, "Empty body", http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name != "" {
+		v.Overlay.Metadata.Name = name
+	}
+
+	validate := validation.GetValidator("overlay-document")
+	isValid, msg := validate.Validate(v)
+	if isValid == false {
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+
+	ret, err := h.client.Import(&v, name, r.Header.Get(PassphraseHeader))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the objects which failed are reported with the created ones
+	status := http.StatusCreated
+	if len(ret.Failed) > 0 {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// applyHandler makes the overlay match the desired state, the stored objects
+// which are not in the document are deleted with the prune query parameter
+// and nothing is changed with the dryRun query parameter
+func (h OverlayDocumentHandler) applyHandler(w http.ResponseWriter, r *http.Request) {
+	var v module.OverlayDocument
+	vars := mux.Vars(r)
+
+	err := json.NewDecoder(r.Body).Decode(&v)
+	switch {
+	case err == io.EOF:
+		http.Error(w, "Empty body", http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	flags := make(map[string]bool)
+	for _, flag := range []string{"prune", "dryRun"} {
+		value := r.URL.Query().Get(flag)
+		if value == "" {
+			continue
+		}
+		flags[flag], err = strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "Invalid "+flag+": "+value, http.StatusBadRequest)
+			return
+		}
+	}
+
+	overlay_name := vars[manager.OverlayResource]
+	if v.Overlay.Metadata.Name == "" {
+		v.Overlay.Metadata.Name = overlay_name
+	}
+	if v.Overlay.Metadata.Name != overlay_name {
+		http.Error(w, "Overlay "+v.Overlay.Metadata.Name+" is not "+overlay_name, http.StatusUnprocessableEntity)
+		return
+	}
+	v.Overlay.Kind = manager.OverlayKind
+
+	validate := validation.GetValidator("overlay-document")
+	isValid, msg := validate.Validate(v)
+	if isValid == false {
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+
+	ret, err := h.client.Apply(vars, &v, r.Header.Get(PassphraseHeader), flags["prune"], flags["dryRun"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the objects which failed are reported with the changes
+	status := http.StatusOK
+	if len(ret.Failed) > 0 {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
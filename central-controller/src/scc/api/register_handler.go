@@ -0,0 +1,74 @@
+/*
+* Copyright 2020 Intel Corporation, Inc
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+* implied.
+* See the License for the specific language governing permissions
+* and
+* limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// RegisterHandler handles the registration actions of devices
+type RegisterHandler struct {
+	client *manager.DeviceObjectManager
+}
+
+// registerHandler restarts the registration of a device
+func (h RegisterHandler) registerHandler(w http.ResponseWriter, r *http.Request) {
+	var err error
+	vars := mux.Vars(r)
+
+	// Check resource depedency
+	err = manager.GetDBUtils().CheckDep(h.client, vars)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ret, err := h.client.GetObject(vars)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch ret.(*module.DeviceObject).Status.Data[manager.RegStatus] {
+	case "success":
+		http.Error(w, "Device "+ret.GetMetadata().Name+" is registered already", http.StatusConflict)
+		return
+	case "bootstrap":
+		http.Error(w, "Device "+ret.GetMetadata().Name+" is waiting for bootstrap", http.StatusConflict)
+		return
+	}
+
+	ret, err = h.client.Register(vars)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
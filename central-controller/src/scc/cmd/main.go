@@ -35,6 +35,7 @@ import (
 	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 
 	rconfig "github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/config"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/query"
 )
 
 const default_rsync_name = "rsync"
@@ -52,6 +53,14 @@ func main() {
 		log.Fatalln("Exiting...")
 	}
 
+	// the lists are paged by the database queries
+	err = query.InitializeDatabaseConnection("scc")
+	if err != nil {
+		log.Println("Unable to initialize database connection...")
+		log.Println(err)
+		log.Fatalln("Exiting...")
+	}
+
 	err = contextDb.InitializeContextDatabase()
 	if err != nil {
 		log.Println("Unable to initialize database connection...")
@@ -123,6 +132,11 @@ func main() {
 	// create http server
 	httpRouter := api.NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	loggedRouter := handlers.LoggingHandler(os.Stdout, httpRouter)
+
+	// resume the device registrations left by the previous run
+	manager.GetRegistrationQueue().Start()
+	// check the health of the overlays for their webhooks
+	manager.GetWebhookNotifier().Start()
 	log.Println("Starting SDEWAN Central Controller API")
 
 	httpServer := &http.Server{
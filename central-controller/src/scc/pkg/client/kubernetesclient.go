@@ -125,17 +125,22 @@ func (c *KubernetesClient) KubernetesClientSet() (*kubernetes.Clientset, error)
 }
 
 func (c *KubernetesClient) IsReachable() bool {
-	clientset, err := c.KubernetesClientSet()
+	err := c.CheckReachable()
 	if err != nil {
 		log.Println(err)
 		return false
 	}
 
-	_, err = clientset.ServerVersion()
+	return true
+}
+
+// CheckReachable returns the error met when connecting to the cluster
+func (c *KubernetesClient) CheckReachable() error {
+	clientset, err := c.KubernetesClientSet()
 	if err != nil {
-		log.Println(err)
-		return false
+		return err
 	}
 
-	return true
+	_, err = clientset.ServerVersion()
+	return err
 }
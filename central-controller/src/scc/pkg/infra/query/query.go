@@ -0,0 +1,224 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package query filters, sorts and pages the objects stored by the emco db
+// package in the mongo database
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Operators of the filters
+const (
+	FilterEq        = "eq"
+	FilterNe        = "ne"
+	FilterIn        = "in"
+	FilterNotIn     = "nin"
+	FilterExists    = "exists"
+	FilterNotExists = "notexists"
+)
+
+// Filter matches the documents whose field at the path (relative to the
+// tag) meets the operator with the values
+type Filter struct {
+	Path   string
+	Op     string
+	Values []interface{}
+}
+
+// Sort orders the documents by the field at the path (relative to the tag)
+type Sort struct {
+	Path string
+	Desc bool
+}
+
+// Options filters, orders and pages the documents found by a query
+type Options struct {
+	Filters []Filter
+	Sort    []Sort
+	Skip    int64
+	// 0 returns all of the documents after the skipped ones
+	Limit int64
+}
+
+// the database of the queries, nil if it is not a mongo database
+var database *mongo.Database
+
+// InitializeDatabaseConnection connects to the database of the db package,
+// the queries are only enabled for a mongo database
+func InitializeDatabaseConnection(name string) error {
+	if config.GetConfiguration().DatabaseType != "mongo" {
+		return nil
+	}
+
+	clientOptions := options.Client()
+	clientOptions.ApplyURI("mongodb://" + config.GetConfiguration().DatabaseIP + ":27017")
+	if len(os.Getenv("DB_EMCO_USERNAME")) > 0 && len(os.Getenv("DB_EMCO_PASSWORD")) > 0 {
+		clientOptions.SetAuth(options.Credential{
+			AuthMechanism: "SCRAM-SHA-256",
+			AuthSource:    name,
+			Username:      os.Getenv("DB_EMCO_USERNAME"),
+			Password:      os.Getenv("DB_EMCO_PASSWORD")})
+	}
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Unable to connect to the database")
+	}
+
+	database = client.Database(name)
+	return nil
+}
+
+// Enabled checks whether the objects can be queried in the database
+func Enabled() bool {
+	return database != nil
+}
+
+// keyFilter matches the documents of the key like the db package does: the
+// empty fields of the key match any value of the documents of its type
+func keyFilter(key interface{}) (bson.M, error) {
+	var fields map[string]string
+	value, err := json.Marshal(key)
+	if err == nil {
+		err = json.Unmarshal(value, &fields)
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Invalid key %T %v", key, key)
+	}
+
+	names := []string{}
+	filter := bson.M{}
+	for k, v := range fields {
+		names = append(names, k)
+		if v != "" {
+			filter[k] = v
+		}
+	}
+	if len(filter) < len(fields) {
+		sort.Strings(names)
+		filter["keyId"] = fmt.Sprintf("{%s}", strings.Join(names, ","))
+	}
+	return filter, nil
+}
+
+// optionsFilter adds the filters of the options to the filter of the key
+func optionsFilter(filter bson.M, tag string, opts Options) (bson.M, error) {
+	and := []bson.M{filter}
+	for _, f := range opts.Filters {
+		var cond bson.M
+		switch f.Op {
+		case FilterEq, FilterNe:
+			if len(f.Values) != 1 {
+				return nil, pkgerrors.Errorf("Filter %s of %s requires one value", f.Op, f.Path)
+			}
+			cond = bson.M{"$" + f.Op: f.Values[0]}
+		case FilterIn, FilterNotIn:
+			cond = bson.M{"$" + f.Op: f.Values}
+		case FilterExists:
+			cond = bson.M{"$exists": true}
+		case FilterNotExists:
+			cond = bson.M{"$exists": false}
+		default:
+			return nil, pkgerrors.Errorf("Unknown filter operator %s", f.Op)
+		}
+		and = append(and, bson.M{tag + "." + f.Path: cond})
+	}
+	return bson.M{"$and": and}, nil
+}
+
+// findOptions orders, pages and projects the documents to the tag
+func findOptions(tag string, opts Options) *options.FindOptions {
+	fopts := options.Find().SetProjection(bson.D{
+		{Key: tag, Value: 1},
+		{Key: "_id", Value: 0},
+	})
+	if len(opts.Sort) > 0 {
+		order := bson.D{}
+		for _, s := range opts.Sort {
+			dir := 1
+			if s.Desc {
+				dir = -1
+			}
+			order = append(order, bson.E{Key: tag + "." + s.Path, Value: dir})
+		}
+		fopts.SetSort(order)
+	}
+	if opts.Skip > 0 {
+		fopts.SetSkip(opts.Skip)
+	}
+	if opts.Limit > 0 {
+		fopts.SetLimit(opts.Limit)
+	}
+	return fopts
+}
+
+// Find returns the tag values of the page of the documents of the key
+// matching the options, and the number of all the matching documents. The
+// values are unmarshaled by the db package
+func Find(coll string, key interface{}, tag string, opts Options) ([][]byte, int64, error) {
+	if database == nil {
+		return nil, 0, pkgerrors.New("The database can not be queried")
+	}
+
+	filter, err := keyFilter(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter, err = optionsFilter(filter, tag, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx := context.Background()
+	c := database.Collection(coll)
+	total, err := c.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "Find error")
+	}
+
+	cursor, err := c.Find(ctx, filter, findOptions(tag, opts))
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "Find error")
+	}
+	defer cursor.Close(ctx)
+
+	var result [][]byte
+	for cursor.Next(ctx) {
+		value, err := cursor.Current.LookupErr(tag)
+		if err != nil {
+			return nil, 0, pkgerrors.Wrap(err, "Unable to read data")
+		}
+		if value.Type == bson.TypeString {
+			result = append(result, []byte(value.StringValue()))
+		} else {
+			result = append(result, value.Value)
+		}
+	}
+	return result, total, cursor.Err()
+}
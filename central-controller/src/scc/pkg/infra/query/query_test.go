@@ -0,0 +1,93 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type testKey struct {
+	Overlay string `json:"overlay-name"`
+	Device  string `json:"device-name"`
+}
+
+func TestKeyFilter(t *testing.T) {
+	tcases := []struct {
+		name     string
+		key      testKey
+		expected bson.M
+	}{
+		{"Object", testKey{"overlay1", "device1"}, bson.M{"overlay-name": "overlay1", "device-name": "device1"}},
+		{"Collection", testKey{"overlay1", ""},
+			bson.M{"overlay-name": "overlay1", "keyId": "{device-name,overlay-name}"}},
+		{"All", testKey{}, bson.M{"keyId": "{device-name,overlay-name}"}},
+	}
+
+	for _, tcase := range tcases {
+		filter, err := keyFilter(tcase.key)
+		if err != nil {
+			t.Fatalf("%s: keyFilter() error = %s", tcase.name, err.Error())
+		}
+		if !reflect.DeepEqual(filter, tcase.expected) {
+			t.Errorf("%s: keyFilter() = %v, expected %v", tcase.name, filter, tcase.expected)
+		}
+	}
+}
+
+func TestOptionsFilter(t *testing.T) {
+	key := bson.M{"overlay-name": "overlay1"}
+	opts := Options{Filters: []Filter{
+		{Path: "specification.proxyhub", Op: FilterNe, Values: []interface{}{"hub1"}},
+		{Path: "metadata.labels.region", Op: FilterIn, Values: []interface{}{"emea", "apac"}},
+		{Path: "metadata.labels.tier", Op: FilterNotExists},
+	}}
+
+	filter, err := optionsFilter(key, "device", opts)
+	if err != nil {
+		t.Fatalf("optionsFilter() error = %s", err.Error())
+	}
+	expected := bson.M{"$and": []bson.M{
+		key,
+		{"device.specification.proxyhub": bson.M{"$ne": "hub1"}},
+		{"device.metadata.labels.region": bson.M{"$in": []interface{}{"emea", "apac"}}},
+		{"device.metadata.labels.tier": bson.M{"$exists": false}},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("optionsFilter() = %v, expected %v", filter, expected)
+	}
+
+	for _, f := range []Filter{
+		{Path: "metadata.name", Op: FilterEq},
+		{Path: "metadata.name", Op: "regex", Values: []interface{}{"device.*"}},
+	} {
+		if _, err := optionsFilter(key, "device", Options{Filters: []Filter{f}}); err == nil {
+			t.Errorf("optionsFilter() of %v succeeded", f)
+		}
+	}
+}
+
+func TestFindNotEnabled(t *testing.T) {
+	if Enabled() {
+		t.Fatal("Enabled() without a database")
+	}
+	if _, _, err := Find("resources", testKey{}, "device", Options{}); err == nil {
+		t.Errorf("Find() without a database succeeded")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/hex"
+	"net"
+	"sort"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+const (
+	STATIC_ROUTING = "static"
+	BGP_ROUTING    = "bgp"
+	// all the hubs and devices of an overlay are in the same AS, the hubs
+	// reflect the routes of the devices
+	BGP_AS       = "65000"
+	BGP_INSTANCE = "bgp"
+)
+
+func getRoutingMode(overlay string) string {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	o, err := GetManagerset().Overlay.GetObject(m)
+	if err == nil && o.(*module.OverlayObject).Specification.RoutingMode != "" {
+		return o.(*module.OverlayObject).Specification.RoutingMode
+	}
+
+	return STATIC_ROUTING
+}
+
+// bgpNeighborName returns the name of the neighbor resource of a remote end
+func bgpNeighborName(ip string) string {
+	if v4 := net.ParseIP(ip).To4(); v4 != nil {
+		return "bgp" + hex.EncodeToString(v4)
+	}
+	return "bgp" + format_ip_as_suffix(ip)
+}
+
+// bgpInstance returns the instance of a hub or a device, it is shared by
+// all the connections of the hub or the device
+func bgpInstance(router_id string, networks []string, table string) *resource.BgpInstanceResource {
+	return &resource.BgpInstanceResource{
+		Name:     BGP_INSTANCE,
+		As:       BGP_AS,
+		RouterId: router_id,
+		Networks: networks,
+		Table:    table,
+	}
+}
+
+// bgpNeighbor returns the iBGP session to a remote end, the routes learned
+// from the remote end go through dev
+func bgpNeighbor(remote_ip string, source_ip string, dev string, rr_client bool) *resource.BgpNeighborResource {
+	return &resource.BgpNeighborResource{
+		Name:                 bgpNeighborName(remote_ip),
+		Instance:             BGP_INSTANCE,
+		RemoteAddress:        remote_ip,
+		RemoteAs:             BGP_AS,
+		SourceAddress:        source_ip,
+		NextHopSelf:          true,
+		RouteReflectorClient: rr_client,
+		Dev:                  dev,
+	}
+}
+
+// deviceNetworks returns the networks advertised by a device: its overlay
+// ips and the subnets of its site
+func deviceNetworks(dev *module.DeviceObject) []string {
+	var networks []string
+	for _, ip := range dev.Status.DataIps {
+		networks = append(networks, ip+"/32")
+	}
+	sort.Strings(networks)
+
+	return append(networks, dev.Specification.Subnets...)
+}
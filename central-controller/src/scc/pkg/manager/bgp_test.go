@@ -0,0 +1,44 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+)
+
+func TestBgpNeighborName(t *testing.T) {
+	if name := bgpNeighborName("10.10.10.1"); name != "bgp0a0a0a01" {
+		t.Errorf("bgpNeighborName() = %s", name)
+	}
+}
+
+func TestDeviceNetworks(t *testing.T) {
+	dev := &module.DeviceObject{
+		Specification: module.DeviceObjectSpec{Subnets: []string{"192.168.1.0/24"}},
+		Status: module.DeviceObjectStatus{DataIps: map[string]string{
+			"Hub.hub2": "172.16.0.5",
+			"Hub.hub1": "172.16.0.3",
+		}},
+	}
+	expected := []string{"172.16.0.3/32", "172.16.0.5/32", "192.168.1.0/24"}
+	if networks := deviceNetworks(dev); !reflect.DeepEqual(networks, expected) {
+		t.Errorf("deviceNetworks() = %v, expected %v", networks, expected)
+	}
+}
@@ -132,11 +132,11 @@ func (c *CertificateObjectManager) GetCertName(name string, obj_type string) str
 		return overlay_manager.CertName(name)
 	case HubKey:
 		hub := module.HubObject{
-			Metadata: module.ObjectMetaData{name, "", "", ""}}
+			Metadata: module.ObjectMetaData{Name: name}}
 		return hub.GetCertName()
 	case DeviceKey:
 		device := module.DeviceObject{
-			Metadata: module.ObjectMetaData{name, "", "", ""}}
+			Metadata: module.ObjectMetaData{Name: name}}
 		return device.GetCertName()
 	}
 
@@ -308,6 +308,10 @@ func (c *CertificateObjectManager) GetObjects(m map[string]string) ([]module.Con
 	return t, err
 }
 
+func (c *CertificateObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *CertificateObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
 	return c.CreateEmptyObject(), pkgerrors.New("Not implemented")
 }
@@ -352,10 +356,10 @@ func (c *CertificateObjectManager) GetOrCreateCertificateByType(overlay_name str
 	switch dev_type {
 	case OverlayKey:
 		m[CertResource] = c.GetCertName(overlay_name, dev_type)
-		certObj = module.CertificateObject{Metadata: module.ObjectMetaData{overlay_name, "", InternalKey, ""}, Specification: module.CertificateObjectSpec{isCA, OverlayKey}}
+		certObj = module.CertificateObject{Metadata: module.ObjectMetaData{Name: overlay_name, UserData1: InternalKey}, Specification: module.CertificateObjectSpec{isCA, OverlayKey}}
 	case DeviceKey, HubKey:
 		m[CertResource] = c.GetCertName(dev_name, dev_type)
-		certObj = module.CertificateObject{Metadata: module.ObjectMetaData{dev_name, "", InternalKey, ""}, Specification: module.CertificateObjectSpec{isCA, dev_type}}
+		certObj = module.CertificateObject{Metadata: module.ObjectMetaData{Name: dev_name, UserData1: InternalKey}, Specification: module.CertificateObjectSpec{isCA, dev_type}}
 	}
 
 	t, err := c.GetObject(m)
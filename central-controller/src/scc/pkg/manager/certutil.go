@@ -218,3 +218,66 @@ func (c *CertUtil) GetSelfSignedCA() string {
 	ca, _, _ := c.GetKeypair(RootCertName, NameSpaceName)
 	return ca
 }
+
+func (c *CertUtil) GetCertificateRequest(name string, namespace string) (*certv1.CertificateRequest, error) {
+	return c.client.CertificateRequests(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (c *CertUtil) DeleteCertificateRequest(name string, namespace string) error {
+	return c.client.CertificateRequests(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// SignCertificateRequest signs a PEM encoded csr by the issuer and returns
+// the PEM encoded certificate
+func (c *CertUtil) SignCertificateRequest(name string, namespace string, issuer string, csr []byte) ([]byte, error) {
+	// Remove the request left by an earlier attempt
+	if _, err := c.GetCertificateRequest(name, namespace); err == nil {
+		c.DeleteCertificateRequest(name, namespace)
+	}
+
+	_, err := c.client.CertificateRequests(namespace).Create(context.TODO(), &certv1.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: certv1.CertificateRequestSpec{
+			Request: csr,
+			IssuerRef: cmmeta.ObjectReference{
+				Name: issuer,
+				Kind: "Issuer",
+			},
+			Usages: []certv1.KeyUsage{certv1.UsageDigitalSignature, certv1.UsageKeyEncipherment,
+				certv1.UsageServerAuth, certv1.UsageClientAuth},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer c.DeleteCertificateRequest(name, namespace)
+
+	var cert []byte
+	err = wait.PollImmediate(time.Second, time.Second*20,
+		func() (bool, error) {
+			cr, err := c.GetCertificateRequest(name, namespace)
+			if err != nil {
+				return false, err
+			}
+			for _, cond := range cr.Status.Conditions {
+				if certv1.CertificateRequestConditionInvalidRequest == cond.Type && cmmeta.ConditionTrue == cond.Status {
+					return false, pkgerrors.New("Invalid certificate request: " + cond.Message)
+				}
+				if certv1.CertificateRequestConditionReady == cond.Type && cmmeta.ConditionTrue == cond.Status && len(cr.Status.Certificate) > 0 {
+					cert = cr.Status.Certificate
+					return true, nil
+				}
+			}
+			log.Println("Waiting for CertificateRequest " + name + " to be ready.")
+			return false, nil
+		},
+	)
+
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Failed to sign certificate request "+name)
+	}
+
+	return cert, nil
+}
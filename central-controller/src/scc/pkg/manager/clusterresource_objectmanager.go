@@ -0,0 +1,256 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+type ClusterResourceObjectKey struct {
+	OverlayName  string `json:"overlay-name"`
+	ClusterName  string `json:"cluster-name"`
+	ResourceName string `json:"resource-name"`
+}
+
+// ClusterResourceObjectManager implements the ControllerObjectManager for
+// objects which are deployed as a single SDEWAN CR to a hub or a device
+type ClusterResourceObjectManager struct {
+	BaseObjectManager
+	isHub        bool
+	resourceName string
+	newObject    func() module.DeployableObject
+}
+
+func newClusterResourceObjectManager(isHub bool, meta string, resourceName string, newObject func() module.DeployableObject) *ClusterResourceObjectManager {
+	if isHub {
+		meta = "hub-" + meta
+	} else {
+		meta = "device-" + meta
+	}
+
+	return &ClusterResourceObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        meta,
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+		isHub,
+		resourceName,
+		newObject,
+	}
+}
+
+func NewFirewallRuleObjectManager(isHub bool) *ClusterResourceObjectManager {
+	return newClusterResourceObjectManager(isHub, "firewallrule", FirewallRuleResource,
+		func() module.DeployableObject { return &module.FirewallRuleObject{} })
+}
+
+func NewFirewallFwdObjectManager(isHub bool) *ClusterResourceObjectManager {
+	return newClusterResourceObjectManager(isHub, "firewallforwarding", FirewallFwdResource,
+		func() module.DeployableObject { return &module.FirewallForwardingObject{} })
+}
+
+func NewMwan3PolicyObjectManager(isHub bool) *ClusterResourceObjectManager {
+	return newClusterResourceObjectManager(isHub, "mwan3policy", Mwan3PolicyResource,
+		func() module.DeployableObject { return &module.Mwan3PolicyObject{} })
+}
+
+func NewMwan3RuleObjectManager(isHub bool) *ClusterResourceObjectManager {
+	return newClusterResourceObjectManager(isHub, "mwan3rule", Mwan3RuleResource,
+		func() module.DeployableObject { return &module.Mwan3RuleObject{} })
+}
+
+func NewRouteRuleObjectManager(isHub bool) *ClusterResourceObjectManager {
+	return newClusterResourceObjectManager(isHub, "routerule", RouteRuleResource,
+		func() module.DeployableObject { return &module.RouteRuleObject{} })
+}
+
+func NewApplicationObjectManager(isHub bool) *ClusterResourceObjectManager {
+	return newClusterResourceObjectManager(isHub, "application", ApplicationResource,
+		func() module.DeployableObject { return &module.ApplicationObject{} })
+}
+
+func (c *ClusterResourceObjectManager) GetResourceName() string {
+	return c.resourceName
+}
+
+func (c *ClusterResourceObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *ClusterResourceObjectManager) CreateEmptyObject() module.ControllerObject {
+	return c.newObject()
+}
+
+func (c *ClusterResourceObjectManager) getClusterName(m map[string]string) string {
+	if c.isHub {
+		return m[HubResource]
+	}
+	return m[DeviceResource]
+}
+
+func (c *ClusterResourceObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	key := ClusterResourceObjectKey{
+		OverlayName:  m[OverlayResource],
+		ClusterName:  c.getClusterName(m),
+		ResourceName: "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	meta_name := t.GetMetadata().Name
+	res_name := m[c.resourceName]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.ResourceName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.ResourceName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *ClusterResourceObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	v := c.newObject()
+	err := json.NewDecoder(r).Decode(v)
+
+	return v, err
+}
+
+// getCluster returns the hub or device the object belongs to
+func (c *ClusterResourceObjectManager) getCluster(m map[string]string) (module.ControllerObject, error) {
+	if c.isHub {
+		hub_name := m[HubResource]
+		hub, err := GetManagerset().Hub.GetObject(m)
+		if err != nil {
+			return hub, pkgerrors.Wrap(err, "Hub "+hub_name+" is not defined")
+		}
+		return hub, nil
+	}
+
+	device_name := m[DeviceResource]
+	dev, err := GetManagerset().Device.GetObject(m)
+	if err != nil {
+		return dev, pkgerrors.Wrap(err, "Device "+device_name+" is not defined")
+	}
+	return dev, nil
+}
+
+func (c *ClusterResourceObjectManager) deployResource(m map[string]string, t module.ControllerObject, update bool) error {
+	overlay_name := m[OverlayResource]
+
+	cluster, err := c.getCluster(m)
+	if err != nil {
+		return err
+	}
+
+	res := t.(module.DeployableObject).ToResource()
+	resutil := NewResUtil()
+	resutil.AddResource(cluster, "create", res)
+
+	return resutil.DeployUpdate(overlay_name, c.GetStoreMeta()+res.GetName(), "YAML", update)
+}
+
+func (c *ClusterResourceObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	err := c.deployResource(m, t, false)
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().CreateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *ClusterResourceObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *ClusterResourceObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *ClusterResourceObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *ClusterResourceObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	err := c.deployResource(m, t, true)
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().UpdateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *ClusterResourceObjectManager) DeleteObject(m map[string]string) error {
+	overlay_name := m[OverlayResource]
+	t, err := c.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	cluster, err := c.getCluster(m)
+	if err == nil {
+		// Undeploy the resource from hub or device
+		res := t.(module.DeployableObject).ToResource()
+		resutil := NewResUtil()
+		resutil.AddResource(cluster, "delete", &resource.EmptyResource{Name: res.GetName(), Type: res.GetType()})
+		err = resutil.Undeploy(overlay_name)
+		if err != nil {
+			log.Println(err)
+		}
+	} else {
+		log.Println(err)
+	}
+
+	// DB Operation
+	err = GetDBUtils().DeleteObject(c, m)
+
+	return err
+}
@@ -186,7 +186,7 @@ func (c *CNFObjectManager) GetObjects(m map[string]string) ([]module.ControllerO
 	}
 
 	return []module.ControllerObject{&module.CNFObject{
-		Metadata: module.ObjectMetaData{overlay_name + "." + cluster_name, "cnf informaiton", "", ""},
+		Metadata: module.ObjectMetaData{Name: overlay_name + "." + cluster_name, Description: "cnf informaiton"},
 		Status:   status,
 	}}, nil
 }
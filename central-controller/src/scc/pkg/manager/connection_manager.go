@@ -17,6 +17,7 @@
 package manager
 
 import (
+	"encoding/json"
 	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
 	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
 	pkgerrors "github.com/pkg/errors"
@@ -60,8 +61,12 @@ func (c *ConnectionManager) Deploy(overlay string, cm module.ConnectionObject, r
 	// add resource to cm
 	rm := resutil.GetResources()
 	for device, res := range rm {
-		for _, resource := range res.Resources {
-			cm.Info.AddResource(device, resource.Resource.GetName(), resource.Resource.GetType())
+		for _, r := range res.Resources {
+			data := ""
+			if r.Resource.GetType() == "Ipsec" {
+				data, _ = resource.GetResourceBuilder().ToString(r.Resource)
+			}
+			cm.Info.AddResource(device, r.Resource.GetName(), r.Resource.GetType(), data)
 		}
 	}
 
@@ -115,10 +120,25 @@ func (c *ConnectionManager) UpdateObject(overlay string, cm module.ConnectionObj
 		End2:        cm.Info.End2.Name,
 	}
 
-	err := db.DBconn.Insert(c.GetStoreName(), key, nil, c.GetStoreMeta(), cm)
+	// the state of the stored connection tells whether it changes
+	etype := module.EventCreated
+	prev, err := c.GetObject(overlay, cm.Info.End1.Name, cm.Info.End2.Name)
+	if err == nil {
+		etype = module.EventUpdated
+		if prev.(*module.ConnectionObject).Info.State != cm.Info.State {
+			etype = module.EventStateChanged
+		}
+	}
+
+	err = db.DBconn.Insert(c.GetStoreName(), key, nil, c.GetStoreMeta(), cm)
 	if err != nil {
 		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Unable to create the object")
 	}
+
+	c.publishEvent(overlay, etype, cm.Metadata.Name, &cm)
+	if etype != module.EventUpdated && cm.Info.State == module.StateEnum.Error {
+		GetWebhookNotifier().Notify(overlay, module.NotifyConnectionError, "Connection/"+cm.Metadata.Name, cm.Info.ErrorMessage)
+	}
 	return &cm, err
 }
 
@@ -204,6 +224,32 @@ func (c *ConnectionManager) GetObjects(overlay string, key string) ([]module.Con
 	return resp, nil
 }
 
+// GetOverlayObjects returns all the connections in the overlay
+func (c *ConnectionManager) GetOverlayObjects(overlay string) ([]module.ControllerObject, error) {
+	key := ConnectionKey{
+		OverlayName: overlay,
+		End1:        "",
+		End2:        "",
+	}
+
+	var resp []module.ControllerObject
+	values, err := db.DBconn.Find(c.GetStoreName(), key, c.GetStoreMeta())
+	if err != nil {
+		return []module.ControllerObject{}, pkgerrors.Wrap(err, "Get Overlay Objects")
+	}
+
+	for _, value := range values {
+		t := c.CreateEmptyObject()
+		err = db.DBconn.Unmarshal(value, t)
+		if err != nil {
+			return []module.ControllerObject{}, pkgerrors.Wrap(err, "Unmarshaling values")
+		}
+		resp = append(resp, t)
+	}
+
+	return resp, nil
+}
+
 func (c *ConnectionManager) DeleteObject(overlay string, key1 string, key2 string) error {
 	key := ConnectionKey{
 		OverlayName: overlay,
@@ -211,10 +257,20 @@ func (c *ConnectionManager) DeleteObject(overlay string, key1 string, key2 strin
 		End2:        key2,
 	}
 
-	err := db.DBconn.Remove(c.GetStoreName(), key)
+	name := ""
+	conn, err := c.GetObject(overlay, key1, key2)
+	if err == nil {
+		name = conn.GetMetadata().Name
+	}
+
+	err = db.DBconn.Remove(c.GetStoreName(), key)
 	if err != nil {
 		return pkgerrors.Wrap(err, "Delete Object")
 	}
+	c.publishEvent(overlay, module.EventDeleted, name, nil)
+
+	DeletePreSharedKey(overlay, key1, key2)
+	DeleteWireguardObject(overlay, key1, key2)
 
 	// Delete HubSite resource if required
 	t1, n1 := module.ParseEndName(key1)
@@ -237,3 +293,23 @@ func (c *ConnectionManager) DeleteObject(overlay string, key1 string, key2 strin
 
 	return err
 }
+
+// publishEvent publishes the change of a connection, cm is nil for a
+// deleted connection
+func (c *ConnectionManager) publishEvent(overlay string, etype string, name string, cm *module.ConnectionObject) {
+	e := module.Event{
+		Type:    etype,
+		Overlay: overlay,
+		Kind:    c.GetStoreMeta(),
+		Name:    name,
+	}
+	if cm != nil {
+		value, err := json.Marshal(cm)
+		if err != nil {
+			log.Println(err)
+		}
+		e.Object = value
+	}
+
+	GetEventStream().Publish(e)
+}
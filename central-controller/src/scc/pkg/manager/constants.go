@@ -27,6 +27,8 @@ const (
 	OverlayResource             = "overlay-name"
 	ProposalCollection          = "proposals"
 	ProposalResource            = "proposal-name"
+	ProposalSetCollection       = "proposal-sets"
+	ProposalSetResource         = "proposal-set-name"
 	HubCollection               = "hubs"
 	HubResource                 = "hub-name"
 	ConnectionCollection        = "connections"
@@ -43,6 +45,38 @@ const (
 	ClusterSyncResource         = "cluster-sync-object-name"
 	SiteCollection              = "sites"
 	SiteResource                = "site-name"
+	FirewallRuleCollection      = "firewall-rules"
+	FirewallRuleResource        = "firewall-rule-name"
+	FirewallFwdCollection       = "firewall-forwardings"
+	FirewallFwdResource         = "firewall-forwarding-name"
+	Mwan3PolicyCollection       = "mwan3-policies"
+	Mwan3PolicyResource         = "mwan3-policy-name"
+	Mwan3RuleCollection         = "mwan3-rules"
+	Mwan3RuleResource           = "mwan3-rule-name"
+	RouteRuleCollection         = "route-rules"
+	RouteRuleResource           = "route-rule-name"
+	ApplicationCollection       = "applications"
+	ApplicationResource         = "application-name"
+	SecurityPolicyCollection    = "security-policies"
+	SecurityPolicyResource      = "security-policy-name"
+	TrafficPolicyCollection     = "traffic-policies"
+	TrafficPolicyResource       = "traffic-policy-name"
+	DnsForwarderCollection      = "dns-forwarders"
+	DnsForwarderResource        = "dns-forwarder-name"
+	DnsRecordCollection         = "dns-records"
+	DnsRecordResource           = "dns-record-name"
+	PreSharedKeyCollection      = "preshared-keys"
+	PreSharedKeyResource        = "preshared-key-name"
+	EventCollection             = "events"
+	WebhookCollection           = "webhooks"
+	WebhookResource             = "webhook-name"
+	DeliveryCollection          = "deliveries"
+	RegisterAction              = "register"
+	BootstrapAction             = "bootstrap"
+	DrainAction                 = "drain"
+	ExportAction                = "export"
+	ImportAction                = "import"
+	ApplyAction                 = "apply"
 	Resource                    = "resource"
 	Resource_Status_NotDeployed = "NotDeployed"
 	Resource_Status_Deployed    = "Deployed"
@@ -84,6 +84,7 @@ func (d *DBUtils) CreateObject(c ControllerObjectManager, m map[string]string,
 		return c.CreateEmptyObject(), pkgerrors.New("Unable to create the object")
 	}
 
+	publishObjectEvent(c, m, module.EventCreated, c.GetResourceStoredName(t), t)
 	return t, nil
 }
 
@@ -149,6 +150,8 @@ func (d *DBUtils) UpdateObject(c ControllerObjectManager,
 	if err != nil {
 		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Updating DB Entry")
 	}
+
+	publishObjectEvent(c, m, module.EventUpdated, c.GetResourceStoredName(t), t)
 	return t, nil
 }
 
@@ -163,6 +166,7 @@ func (d *DBUtils) DeleteObject(c ControllerObjectManager, m map[string]string) e
 		return pkgerrors.Wrap(err, "Delete Object")
 	}
 
+	publishObjectEvent(c, m, module.EventDeleted, m[c.GetResourceName()], nil)
 	return nil
 }
 
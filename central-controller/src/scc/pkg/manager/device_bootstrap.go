@@ -0,0 +1,187 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+)
+
+const (
+	BootstrapTokenHash  = "BootstrapTokenHash"
+	BootstrapExpiry     = "BootstrapExpiry"
+	BOOTSTRAP_TOKEN_TTL = 24 * time.Hour
+	// certificate signed from the csr of a bootstrapped device
+	DeviceCert = "DeviceCert"
+	// the private key of a bootstrapped device never leaves the device, its
+	// IPsec resources refer to the key installed in the CNF instead
+	LOCAL_PRIVATE_KEY = "#local"
+)
+
+var bootstrap_mux = sync.Mutex{}
+
+func hashBootstrapToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckBootstrapToken returns the device which waits for the token
+func (c *DeviceObjectManager) CheckBootstrapToken(m map[string]string, token string) (module.ControllerObject, error) {
+	t, err := c.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), pkgerrors.New("Invalid bootstrap token")
+	}
+
+	to := t.(*module.DeviceObject)
+	if to.Status.Data[RegStatus] != "bootstrap" ||
+		subtle.ConstantTimeCompare([]byte(hashBootstrapToken(token)), []byte(to.Status.Data[BootstrapTokenHash])) != 1 {
+		return c.CreateEmptyObject(), pkgerrors.New("Invalid bootstrap token")
+	}
+
+	expiry, err := time.Parse(time.RFC3339, to.Status.Data[BootstrapExpiry])
+	if err != nil || time.Now().After(expiry) {
+		return c.CreateEmptyObject(), pkgerrors.New("Bootstrap token is expired")
+	}
+
+	return t, nil
+}
+
+// signDeviceCSR signs the csr of the device by the overlay issuer
+func signDeviceCSR(overlay_name string, to *module.DeviceObject, csr []byte) (string, error) {
+	block, _ := pem.Decode(csr)
+	if block == nil {
+		return "", pkgerrors.New("Fail to decode certificate request")
+	}
+
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Fail to parse certificate request")
+	}
+
+	err = req.CheckSignature()
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Invalid certificate request signature")
+	}
+
+	if req.Subject.CommonName != to.GetCertName() {
+		return "", pkgerrors.New("Certificate request common name must be " + to.GetCertName())
+	}
+
+	cu, err := GetCertUtil()
+	if err != nil {
+		return "", err
+	}
+
+	issuer := GetManagerset().Overlay.IssuerName(overlay_name)
+	cert, err := cu.SignCertificateRequest(to.GetCertName()+"-bootstrap", NameSpaceName, issuer, csr)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(cert), nil
+}
+
+// getDeviceKeypair returns the ca chain, the certificate and the private key
+// used by a device for its IPsec connections. A bootstrapped device uses the
+// certificate signed from its csr together with its own key, the keypair of
+// the other devices is generated by scc
+func getDeviceKeypair(overlay_name string, to *module.DeviceObject) (string, string, string, error) {
+	cert := to.Status.Data[DeviceCert]
+	if cert == "" {
+		return GetManagerset().Cert.GetOrCreateDC(overlay_name, to.Metadata.Name, false)
+	}
+
+	certMap := make(map[string]string)
+	certMap[namespaceKey] = NameSpaceName
+	certMap[OverlayKey] = GetManagerset().Cert.GetCertName(overlay_name, OverlayKey)
+	ca, err := GetCertChain(certMap)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return ca, cert, LOCAL_PRIVATE_KEY, nil
+}
+
+// Bootstrap onboards a device which presents a valid token: the device
+// certificate is signed and the device is registered with the kubeconfig
+// sent by the device. The token can not be used once the device is
+// onboarded
+func (c *DeviceObjectManager) Bootstrap(m map[string]string, req *module.BootstrapRequest) (*module.BootstrapResponse, error) {
+	bootstrap_mux.Lock()
+	defer bootstrap_mux.Unlock()
+
+	t, err := c.CheckBootstrapToken(m, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	to := t.(*module.DeviceObject)
+	overlay_name := m[OverlayResource]
+
+	csr, err := base64.StdEncoding.DecodeString(req.Csr)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Fail to decode certificate request")
+	}
+
+	cert, err := signDeviceCSR(overlay_name, to, csr)
+	if err != nil {
+		return nil, err
+	}
+
+	certMap := make(map[string]string)
+	certMap[namespaceKey] = NameSpaceName
+	certMap[OverlayKey] = GetManagerset().Cert.GetCertName(overlay_name, OverlayKey)
+	ca, err := GetCertChain(certMap)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("Bootstrap device " + to.Metadata.Name)
+	to.Specification.KubeConfig = req.KubeConfig
+	if len(req.PublicIps) > 0 {
+		to.Specification.PublicIps = req.PublicIps
+	}
+
+	err = c.setupConnectivity(m, to)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(to.Status.Data, BootstrapTokenHash)
+	delete(to.Status.Data, BootstrapExpiry)
+	to.Status.Data[DeviceCert] = cert
+	_, err = c.UpdateObject(m, t)
+	if err != nil {
+		return nil, err
+	}
+
+	err = GetRegistrationQueue().Enqueue(overlay_name, to.Metadata.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &module.BootstrapResponse{Cert: cert, CA: ca}, nil
+}
@@ -19,10 +19,10 @@ package manager
 import (
 	"io"
 	"log"
+	"reflect"
 	//"strconv"
 	"encoding/base64"
 	"encoding/json"
-	"github.com/matryer/runner"
 	"time"
 
 	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
@@ -37,8 +37,6 @@ const SCC_RESOURCE = "scc_ipsec_resource"
 const RegStatus = "RegStatus"
 const globalOverlay = "global"
 
-var task *runner.Task
-
 type DeviceObjectKey struct {
 	OverlayName string `json:"overlay-name"`
 	DeviceName  string `json:"device-name"`
@@ -117,7 +115,15 @@ func (c *DeviceObjectManager) ParseObject(r io.Reader) (module.ControllerObject,
 func (c *DeviceObjectManager) PreProcessing(m map[string]string, t module.ControllerObject) error {
 	to := t.(*module.DeviceObject)
 
-	ipr_manager := GetManagerset().ProviderIPRange
+	if to.Specification.KubeConfig == "" && to.Specification.BootstrapToken != "" {
+		// Wait for the device to present the token, only the hash of the
+		// token is saved
+		to.Status.Data[RegStatus] = "bootstrap"
+		to.Status.Data[BootstrapTokenHash] = hashBootstrapToken(to.Specification.BootstrapToken)
+		to.Status.Data[BootstrapExpiry] = time.Now().Add(BOOTSTRAP_TOKEN_TTL).Format(time.RFC3339)
+		to.Specification.BootstrapToken = ""
+		return nil
+	}
 
 	if to.Specification.KubeConfig == "" {
 		to.Status.Mode = 3
@@ -146,6 +152,13 @@ func (c *DeviceObjectManager) PreProcessing(m map[string]string, t module.Contro
 		return nil
 	}
 
+	return c.setupConnectivity(m, to)
+}
+
+// setupConnectivity verifies the kubeconfig of the device and prepares the
+// external connection of the device
+func (c *DeviceObjectManager) setupConnectivity(m map[string]string, to *module.DeviceObject) error {
+	ipr_manager := GetManagerset().ProviderIPRange
 	kubeutil := GetKubeConfigUtil()
 
 	local_public_ips := to.Specification.PublicIps
@@ -188,20 +201,16 @@ func (c *DeviceObjectManager) PreProcessing(m map[string]string, t module.Contro
 
 		resutil := NewResUtil()
 		scc := module.EmptyObject{
-			Metadata: module.ObjectMetaData{"local", "", "", ""}}
-
-		// Get all proposal resources
-		proposal := GetManagerset().Proposal
-		proposals, err := proposal.GetObjects(m)
-		if len(proposals) == 0 || err != nil {
-			log.Println("Missing Proposal in the overlay\n")
-			return pkgerrors.New("Error in getting proposals")
+			Metadata: module.ObjectMetaData{Name: "local"}}
+
+		// Get the proposal resources for the device
+		proposals, err := GetManagerset().ProposalSet.GetProposals(m[OverlayResource], SCCTODEVICE, []string{to.Metadata.Name})
+		if err != nil {
+			return err
 		}
 
-		var all_proposal []string
-		for i := 0; i < len(proposals); i++ {
-			proposal_obj := proposals[i].(*module.ProposalObject)
-			all_proposal = append(all_proposal, proposal_obj.Metadata.Name)
+		all_proposal := proposalNames(proposals)
+		for _, proposal_obj := range proposals {
 			pr := proposal_obj.ToResource()
 			resutil.AddResource(&scc, "create", pr)
 		}
@@ -241,6 +250,14 @@ func (c *DeviceObjectManager) PreProcessing(m map[string]string, t module.Contro
 			Connections:          scc_conn,
 		}
 
+		if getAuthMode(m[OverlayResource], []*module.DeviceObject{to}) == PSK_AUTH {
+			psk, err := GetOrCreatePreSharedKey(m[OverlayResource], SCC_END, module.CreateEndName(to.GetType(), to.Metadata.Name))
+			if err != nil {
+				return err
+			}
+			usePreSharedKey(&scc_ipsec_resource, psk)
+		}
+
 		// Add and deploy resource
 		resutil.AddResource(&scc, "create", &scc_ipsec_resource)
 		resutil.Deploy(globalOverlay, m[OverlayResource]+"localto"+to.Metadata.Name, "YAML")
@@ -251,7 +268,6 @@ func (c *DeviceObjectManager) PreProcessing(m map[string]string, t module.Contro
 
 	}
 	return nil
-
 }
 
 func (c *DeviceObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
@@ -260,23 +276,50 @@ func (c *DeviceObjectManager) CreateObject(m map[string]string, t module.Control
 		return c.CreateEmptyObject(), err
 	}
 
-	to := t.(*module.DeviceObject)
-	task = runner.Go(func(ShouldStop runner.S) error {
-		for to.Status.Data[RegStatus] == "pending" {
-			err = c.PostRegister(m, t)
-			if err != nil {
-				log.Println(err)
-			}
-			time.Sleep(5 * time.Second)
-			if ShouldStop() {
-				break
-			}
-		}
-		return nil
-	})
-
 	// DB Operation
 	t, err = GetDBUtils().CreateObject(c, m, t)
+	if err != nil {
+		return t, err
+	}
+
+	// The device is registered by the registration queue, a device
+	// waiting for bootstrap is queued once it presents its token
+	if t.(*module.DeviceObject).Status.Data[RegStatus] == "pending" {
+		err = GetRegistrationQueue().Enqueue(m[OverlayResource], t.GetMetadata().Name)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	return t, nil
+}
+
+// Register restarts the registration of a device which is not registered
+func (c *DeviceObjectManager) Register(m map[string]string) (module.ControllerObject, error) {
+	t, err := c.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	to := t.(*module.DeviceObject)
+	if to.Status.Data == nil {
+		to.Status.Data = make(map[string]string)
+	}
+	if to.Status.Data[RegStatus] == "success" {
+		return t, pkgerrors.New("Device " + to.Metadata.Name + " is registered already")
+	}
+	if to.Status.Data[RegStatus] == "bootstrap" {
+		return t, pkgerrors.New("Device " + to.Metadata.Name + " is waiting for bootstrap")
+	}
+
+	to.Status.Data[RegStatus] = "pending"
+	delete(to.Status.Data, RegAttempts)
+	delete(to.Status.Data, RegError)
+	t, err = c.UpdateObject(m, t)
+	if err != nil {
+		return t, err
+	}
+
+	err = GetRegistrationQueue().Enqueue(m[OverlayResource], to.Metadata.Name)
 	return t, err
 }
 
@@ -294,10 +337,26 @@ func (c *DeviceObjectManager) GetObjects(m map[string]string) ([]module.Controll
 	return t, err
 }
 
+func (c *DeviceObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *DeviceObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	old, old_err := c.GetObject(m)
+
 	// DB Operation
 	t, err := GetDBUtils().UpdateObject(c, m, t)
 
+	// the hubs, the security and traffic policies and the DNS forwarders
+	// may select the device by its labels
+	if err == nil && old_err == nil && !reflect.DeepEqual(old.GetMetadata().Labels, t.GetMetadata().Labels) {
+		reselectHubDevices(m[OverlayResource])
+		recompileSecurityPolicies(m[OverlayResource])
+		redeployTrafficPolicies(m[OverlayResource])
+		redeployDnsForwarders(m[OverlayResource])
+		redeployDnsRecords(m[OverlayResource])
+	}
+
 	return t, err
 }
 
@@ -307,14 +366,7 @@ func (c *DeviceObjectManager) DeleteObject(m map[string]string) error {
 		return nil
 	}
 
-	if task != nil && task.Running() {
-		task.Stop()
-		select {
-		case <-task.StopChan():
-		case <-time.After(2 * time.Second):
-			log.Println("Goroutine register device stopped")
-		}
-	}
+	GetRegistrationQueue().Remove(m[OverlayResource], m[DeviceResource])
 
 	overlay_manager := GetManagerset().Overlay
 	ipr_manager := GetManagerset().ProviderIPRange
@@ -332,28 +384,22 @@ func (c *DeviceObjectManager) DeleteObject(m map[string]string) error {
 		ipr_manager.Free("", to.Status.Ip)
 
 		scc := module.EmptyObject{
-			Metadata: module.ObjectMetaData{"local", "", "", ""}}
+			Metadata: module.ObjectMetaData{Name: "local"}}
 
 		resutils := NewResUtil()
 		r_str := to.Status.Data["scc_ipsec_resource"]
 		r, _ := resource.GetResourceBuilder().ToObject(r_str)
 		resutils.AddResource(&scc, "create", r)
 
-		// Get all proposal resources
-		proposal := GetManagerset().Proposal
-		proposals, err := proposal.GetObjects(m)
-		if len(proposals) == 0 || err != nil {
-			log.Println("Missing Proposal in the overlay")
-			return pkgerrors.New("Error in getting proposals")
-		}
-
-		for i := 0; i < len(proposals); i++ {
-			proposal_obj := proposals[i].(*module.ProposalObject)
-			pr := proposal_obj.ToResource()
-			resutils.AddResource(&scc, "create", pr)
+		// Remove the proposals used by the connection
+		if ipsec, ok := r.(*resource.IpsecResource); ok {
+			for _, p := range ipsec.CryptoProposal {
+				resutils.AddResource(&scc, "create", &resource.EmptyResource{Name: p, Type: "Proposal"})
+			}
 		}
 
 		resutils.Undeploy(globalOverlay)
+		DeletePreSharedKey(overlay_name, SCC_END, module.CreateEndName(to.GetType(), to.Metadata.Name))
 	}
 
 	log.Println("Delete device...")
@@ -371,10 +417,13 @@ func (c *DeviceObjectManager) DeleteObject(m map[string]string) error {
 		log.Println(err)
 	}
 
-	log.Println("Delete Certificate: " + to.GetCertName())
-	err = cert_manager.DeleteCertificateByType(overlay_name, to.Metadata.Name, DeviceKey)
-	if err != nil {
-		log.Println("Error in deleting device certificate")
+	// a bootstrapped device has no keypair in scc
+	if to.Status.Data[DeviceCert] == "" {
+		log.Println("Delete Certificate: " + to.GetCertName())
+		err = cert_manager.DeleteCertificateByType(overlay_name, to.Metadata.Name, DeviceKey)
+		if err != nil {
+			log.Println("Error in deleting device certificate")
+		}
 	}
 
 	// DB Operation
@@ -383,13 +432,17 @@ func (c *DeviceObjectManager) DeleteObject(m map[string]string) error {
 		log.Println(err)
 	}
 
+	recompileSecurityPolicies(overlay_name)
+	redeployTrafficPolicies(overlay_name)
+	redeployDnsForwarders(overlay_name)
+	redeployDnsRecords(overlay_name)
+
 	return err
 }
 
 func (c *DeviceObjectManager) PostRegister(m map[string]string, t module.ControllerObject) error {
 	overlay_name := m[OverlayResource]
 	overlay_manager := GetManagerset().Overlay
-	cert_manager := GetManagerset().Cert
 
 	to := t.(*module.DeviceObject)
 	log.Println("Registering device " + to.Metadata.Name + " ... ")
@@ -407,8 +460,7 @@ func (c *DeviceObjectManager) PostRegister(m map[string]string, t module.Control
 			return err
 		}
 
-		log.Println("Create Certificate: " + to.GetCertName())
-		_, _, _, err = cert_manager.GetOrCreateDC(overlay_name, to.Metadata.Name, false)
+		_, _, _, err = getDeviceKeypair(overlay_name, to)
 		if err != nil {
 			log.Println(err)
 			return err
@@ -422,7 +474,9 @@ func (c *DeviceObjectManager) PostRegister(m map[string]string, t module.Control
 
 		kube_config, _, err = kubeutil.checkKubeConfigAvail(kube_config, []string{to.Status.Ip}, DEFAULT_K8S_API_SERVER_PORT)
 		if err != nil {
-			//TODO: check the error type, and if is unauthorized then switch the status to failed.
+			if isUnauthorized(err) {
+				to.Status.Data[RegStatus] = "failed"
+			}
 			return err
 		}
 
@@ -443,8 +497,7 @@ func (c *DeviceObjectManager) PostRegister(m map[string]string, t module.Control
 			return err
 		}
 
-		log.Println("Create Certificate: " + to.GetCertName())
-		_, _, _, err = cert_manager.GetOrCreateDC(overlay_name, to.Metadata.Name, false)
+		_, _, _, err = getDeviceKeypair(overlay_name, to)
 		if err != nil {
 			log.Println(err)
 			return err
@@ -472,6 +525,14 @@ func (c *DeviceObjectManager) PostRegister(m map[string]string, t module.Control
 	}
 
 	c.UpdateObject(m, t)
+
+	if to.Status.Data[RegStatus] == "success" {
+		reselectHubDevices(overlay_name)
+		recompileSecurityPolicies(overlay_name)
+		redeployTrafficPolicies(overlay_name)
+		redeployDnsForwarders(overlay_name)
+		redeployDnsRecords(overlay_name)
+	}
 	return nil
 }
 
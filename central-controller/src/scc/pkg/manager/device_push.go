@@ -0,0 +1,114 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// the overlay-wide objects (e.g. the traffic policies) are pushed to the
+// registered devices selected by their labels and follow the changes of
+// the devices, their status keeps the devices they are deployed to
+
+// selectRegisteredDevices returns the registered devices of the overlay
+// selected by the label selector, all of them if the selector is empty
+func selectRegisteredDevices(overlay string, label_selector string) (map[string]module.ControllerObject, error) {
+	selector, err := labels.Parse(label_selector)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	devs, err := GetManagerset().Device.GetObjects(m)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]module.ControllerObject)
+	for _, dev := range devs {
+		if dev.(*module.DeviceObject).Status.Data[RegStatus] != "success" {
+			continue
+		}
+		if label_selector == "" || selector.Matches(labels.Set(dev.GetMetadata().Labels)) {
+			selected[dev.GetMetadata().Name] = dev
+		}
+	}
+
+	return selected, nil
+}
+
+// undeployFromDevices removes a resource from the devices it is deployed
+// to which are not kept
+func undeployFromDevices(overlay string, res_type string, res_name string, deployed []string, keep []string) error {
+	kept := make(map[string]bool)
+	for _, dev := range keep {
+		kept[dev] = true
+	}
+
+	resutil := NewResUtil()
+	found := false
+	for _, dev := range deployed {
+		if !kept[dev] {
+			resutil.AddResource(&module.DeviceObject{Metadata: module.ObjectMetaData{Name: dev}}, "delete",
+				&resource.EmptyResource{Name: res_name, Type: res_type})
+			found = true
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	return resutil.Undeploy(overlay)
+}
+
+// deployToDevices deploys a resource to the selected devices and removes it
+// from the devices which are not selected anymore, it returns the devices
+// the resource is deployed to
+func deployToDevices(overlay string, app_name string, r resource.ISdewanResource, label_selector string, deployed []string) ([]string, error) {
+	devs, err := selectRegisteredDevices(overlay, label_selector)
+	if err != nil {
+		return deployed, err
+	}
+
+	names := []string{}
+	for name := range devs {
+		names = append(names, name)
+	}
+	names = uniqueStrings(names)
+
+	err = undeployFromDevices(overlay, r.GetType(), r.GetName(), deployed, names)
+	if err != nil {
+		log.Println(err)
+	}
+
+	if len(names) == 0 {
+		return names, nil
+	}
+
+	resutil := NewResUtil()
+	for _, name := range names {
+		resutil.AddResource(devs[name], "create", r)
+	}
+
+	return names, resutil.DeployUpdate(overlay, app_name, "YAML", true)
+}
@@ -24,6 +24,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"io"
 	"log"
+	"strconv"
 )
 
 type DeviceSiteObjectKey struct {
@@ -101,22 +102,46 @@ func (c *DeviceSiteObjectManager) ParseObject(r io.Reader) (module.ControllerObj
 	return &v, err
 }
 
+// hubSiteName returns the name of the HubSite resource of a site for a hub,
+// the primary hub keeps the name used when only one hub was supported
+func hubSiteName(site_name string, hubs []string, hub string) string {
+	if len(hubs) > 0 && hubs[0] == hub {
+		return format_resource_name(site_name, "")
+	}
+	return format_resource_name(site_name, hub)
+}
+
+// hubSiteProbability returns the share of the remaining flows which are sent
+// to the i-th hub in ecmp mode, the last hub takes all of the remaining ones
+func hubSiteProbability(i int, n int) string {
+	if i >= n-1 {
+		return ""
+	}
+	return strconv.FormatFloat(1/float64(n-i), 'f', 4, 64)
+}
+
 func (c *DeviceSiteObjectManager) deployResource(m map[string]string, t module.ControllerObject, update bool) error {
 	overlay_name := m[OverlayResource]
 	device_name := m[DeviceResource]
 
 	to := t.(*module.SiteObject)
 	site_name := to.Metadata.Name
+	hubs := to.Specification.Hubs
 
-	if len(to.Specification.Hubs) < 1 {
+	if len(hubs) < 1 {
 		return pkgerrors.New("Hub is required")
 	}
 
-	// Todo: support multiple hubs
-	hub := to.Specification.Hubs[0]
 	devConn := GetManagerset().DeviceConn
-	if !devConn.IsConnectedHub(overlay_name, device_name, hub) {
-		return pkgerrors.New("Hub does not connect to the device")
+	for i, hub := range hubs {
+		if !devConn.IsConnectedHub(overlay_name, device_name, hub) {
+			return pkgerrors.New("Hub " + hub + " does not connect to the device")
+		}
+		for _, h := range hubs[:i] {
+			if h == hub {
+				return pkgerrors.New("Hub " + hub + " is duplicated")
+			}
+		}
 	}
 
 	dev_manager := GetManagerset().Device
@@ -125,21 +150,59 @@ func (c *DeviceSiteObjectManager) deployResource(m map[string]string, t module.C
 		return pkgerrors.Wrap(err, "Device "+device_name+" is not defined")
 	}
 
-	// Deploy HubSite resource to device
+	// Deploy a HubSite resource to device for each hub
 	devobj := dev.(*module.DeviceObject)
 	resutil := NewResUtil()
-	resutil.AddResource(dev, "create", &resource.HubSiteResource{
-		Name:      format_resource_name(site_name, ""),
-		Type:      "Device",
-		Site:      to.Specification.Url,
-		Subnet:    to.Specification.Subnet,
-		HubIP:     "",
-		DevicePIP: devobj.Status.DataIps[module.CreateEndName("Hub", hub)], // Todo: check if device mode = 1
-	})
+	for i, hub := range hubs {
+		probability := ""
+		if to.IsECMP() {
+			probability = hubSiteProbability(i, len(hubs))
+		}
+		resutil.AddResource(dev, "create", &resource.HubSiteResource{
+			Name:        hubSiteName(site_name, hubs, hub),
+			Type:        "Device",
+			Site:        to.Specification.Url,
+			Subnet:      to.Specification.Subnet,
+			HubIP:       "",
+			DevicePIP:   devobj.Status.DataIps[module.CreateEndName("Hub", hub)], // Todo: check if device mode = 1
+			Priority:    i + 1,
+			Probability: probability,
+		})
+	}
 
 	err = resutil.DeployUpdate(overlay_name, "hubsite"+to.Metadata.Name, "YAML", update)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if update {
+		// Undeploy the HubSite resources of the hubs removed from the site
+		old, err := c.GetObject(m)
+		if err != nil {
+			return nil
+		}
+		old_hubs := old.(*module.SiteObject).Specification.Hubs
+		resutil = NewResUtil()
+		for _, hub := range old_hubs {
+			name := hubSiteName(site_name, old_hubs, hub)
+			found := false
+			for _, h := range hubs {
+				if hubSiteName(site_name, hubs, h) == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				resutil.AddResource(dev, "delete", &resource.EmptyResource{Name: name, Type: "HubSite"})
+			}
+		}
+		err = resutil.Undeploy(overlay_name)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
 }
 
 func (c *DeviceSiteObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
@@ -151,6 +214,9 @@ func (c *DeviceSiteObjectManager) CreateObject(m map[string]string, t module.Con
 
 	// DB Operation
 	t, err = GetDBUtils().CreateObject(c, m, t)
+	if err == nil {
+		recompileSecurityPolicies(m[OverlayResource])
+	}
 
 	return t, err
 }
@@ -169,6 +235,10 @@ func (c *DeviceSiteObjectManager) GetObjects(m map[string]string) ([]module.Cont
 	return t, err
 }
 
+func (c *DeviceSiteObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *DeviceSiteObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
 	err := c.deployResource(m, t, true)
 	if err != nil {
@@ -178,6 +248,9 @@ func (c *DeviceSiteObjectManager) UpdateObject(m map[string]string, t module.Con
 
 	// DB Operation
 	t, err = GetDBUtils().UpdateObject(c, m, t)
+	if err == nil {
+		recompileSecurityPolicies(m[OverlayResource])
+	}
 
 	return t, err
 }
@@ -195,9 +268,11 @@ func (c *DeviceSiteObjectManager) DeleteObject(m map[string]string) error {
 	dev_manager := GetManagerset().Device
 	dev, err := dev_manager.GetObject(m)
 
-	// Undeploy HubSite resource to device
+	// Undeploy HubSite resources to device
 	resutil := NewResUtil()
-	resutil.AddResource(dev, "delete", &resource.EmptyResource{format_resource_name(site_name, ""), "HubSite"})
+	for _, hub := range to.Specification.Hubs {
+		resutil.AddResource(dev, "delete", &resource.EmptyResource{Name: hubSiteName(site_name, to.Specification.Hubs, hub), Type: "HubSite"})
+	}
 	err = resutil.Undeploy(overlay_name)
 	if err != nil {
 		log.Println(err)
@@ -205,6 +280,9 @@ func (c *DeviceSiteObjectManager) DeleteObject(m map[string]string) error {
 
 	// DB Operation
 	err = GetDBUtils().DeleteObject(c, m)
+	if err == nil {
+		recompileSecurityPolicies(overlay_name)
+	}
 
 	return err
 }
@@ -214,13 +292,31 @@ func (c *DeviceSiteObjectManager) TryDeleteSite(overlay string, hub string, devi
 	m[OverlayResource] = overlay
 	m[DeviceResource] = device
 
-	// get all sites and delete site which uses hub as proxy
+	// get all sites which use hub as proxy, remove the hub from the site
+	// and delete the site if no hub is left
 	ts, _ := c.GetObjects(m)
 	for _, t := range ts {
 		to := t.(*module.SiteObject)
-		if len(to.Specification.Hubs) > 0 && hub == to.Specification.Hubs[0] {
-			m[SiteResource] = to.Metadata.Name
+		var hubs []string
+		for _, h := range to.Specification.Hubs {
+			if h != hub {
+				hubs = append(hubs, h)
+			}
+		}
+		if len(hubs) == len(to.Specification.Hubs) {
+			continue
+		}
+
+		m[SiteResource] = to.Metadata.Name
+		if len(hubs) == 0 {
 			c.DeleteObject(m)
+			continue
+		}
+
+		to.Specification.Hubs = hubs
+		_, err := c.UpdateObject(m, to)
+		if err != nil {
+			log.Println(err)
 		}
 	}
 
@@ -0,0 +1,240 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+// serialize the deployment of the DNS forwarders and records, which is
+// triggered by device registration as well as by the REST API
+var dns_mux = sync.Mutex{}
+
+type DnsForwarderObjectKey struct {
+	OverlayName   string `json:"overlay-name"`
+	ForwarderName string `json:"dns-forwarder-name"`
+}
+
+// DnsForwarderObjectManager implements the ControllerObjectManager
+type DnsForwarderObjectManager struct {
+	BaseObjectManager
+}
+
+func NewDnsForwarderObjectManager() *DnsForwarderObjectManager {
+	return &DnsForwarderObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        "dnsforwarder",
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+	}
+}
+
+func (c *DnsForwarderObjectManager) GetResourceName() string {
+	return DnsForwarderResource
+}
+
+func (c *DnsForwarderObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *DnsForwarderObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.DnsForwarderObject{}
+}
+
+func (c *DnsForwarderObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	overlay_name := m[OverlayResource]
+	key := DnsForwarderObjectKey{
+		OverlayName:   overlay_name,
+		ForwarderName: "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	to := t.(*module.DnsForwarderObject)
+	meta_name := to.Metadata.Name
+	res_name := m[DnsForwarderResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.ForwarderName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.ForwarderName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *DnsForwarderObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.DnsForwarderObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+func (c *DnsForwarderObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	to := t.(*module.DnsForwarderObject)
+	to.Status = module.DnsForwarderObjectStatus{}
+	err := c.deployForwarder(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		// the object is not saved, so remove whatever got deployed
+		c.undeployForwarder(m[OverlayResource], to)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().CreateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *DnsForwarderObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *DnsForwarderObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *DnsForwarderObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *DnsForwarderObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	// keep track of the devices of the previous version
+	old, err := c.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	to := t.(*module.DnsForwarderObject)
+	to.Status = old.(*module.DnsForwarderObject).Status
+	err = c.deployForwarder(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().UpdateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *DnsForwarderObjectManager) DeleteObject(m map[string]string) error {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	t, err := c.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	to := t.(*module.DnsForwarderObject)
+	err = c.undeployForwarder(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+	}
+
+	// DB Operation
+	err = GetDBUtils().DeleteObject(c, m)
+
+	return err
+}
+
+// Redeploy re-deploys all the DNS forwarders of the overlay, it is called
+// whenever the devices of the overlay change
+func (c *DnsForwarderObjectManager) Redeploy(overlay string) {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+
+	forwarders, err := c.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, p := range forwarders {
+		to := p.(*module.DnsForwarderObject)
+		err = c.deployForwarder(overlay, to)
+		if err != nil {
+			log.Println("Fail to redeploy DNS forwarder " + to.Metadata.Name + ": " + err.Error())
+		}
+
+		// status is saved even on error so that the devices are tracked
+		m[DnsForwarderResource] = to.Metadata.Name
+		_, err = GetDBUtils().UpdateObject(c, m, to)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// redeployDnsForwarders is a helper for the other managers
+func redeployDnsForwarders(overlay string) {
+	mgr := GetManagerset().DnsForwarder
+	if mgr != nil {
+		mgr.Redeploy(overlay)
+	}
+}
+
+func (c *DnsForwarderObjectManager) undeployForwarder(overlay string, to *module.DnsForwarderObject) error {
+	r := to.ToResource()
+	return undeployFromDevices(overlay, r.GetType(), r.GetName(), to.Status.Devices, []string{})
+}
+
+func (c *DnsForwarderObjectManager) deployForwarder(overlay string, to *module.DnsForwarderObject) error {
+	devices, err := deployToDevices(overlay, "dnsforwarder"+to.Metadata.Name, to.ToResource(),
+		to.Specification.DeviceSelector, to.Status.Devices)
+	to.Status.Devices = devices
+
+	return err
+}
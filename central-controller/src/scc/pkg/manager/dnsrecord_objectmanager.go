@@ -0,0 +1,235 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+type DnsRecordObjectKey struct {
+	OverlayName string `json:"overlay-name"`
+	RecordName  string `json:"dns-record-name"`
+}
+
+// DnsRecordObjectManager implements the ControllerObjectManager
+type DnsRecordObjectManager struct {
+	BaseObjectManager
+}
+
+func NewDnsRecordObjectManager() *DnsRecordObjectManager {
+	return &DnsRecordObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        "dnsrecord",
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+	}
+}
+
+func (c *DnsRecordObjectManager) GetResourceName() string {
+	return DnsRecordResource
+}
+
+func (c *DnsRecordObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *DnsRecordObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.DnsRecordObject{}
+}
+
+func (c *DnsRecordObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	overlay_name := m[OverlayResource]
+	key := DnsRecordObjectKey{
+		OverlayName: overlay_name,
+		RecordName:  "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	to := t.(*module.DnsRecordObject)
+	meta_name := to.Metadata.Name
+	res_name := m[DnsRecordResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.RecordName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.RecordName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *DnsRecordObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.DnsRecordObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+func (c *DnsRecordObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	to := t.(*module.DnsRecordObject)
+	to.Status = module.DnsRecordObjectStatus{}
+	err := c.deployRecord(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		// the object is not saved, so remove whatever got deployed
+		c.undeployRecord(m[OverlayResource], to)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().CreateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *DnsRecordObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *DnsRecordObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *DnsRecordObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *DnsRecordObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	// keep track of the devices of the previous version
+	old, err := c.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	to := t.(*module.DnsRecordObject)
+	to.Status = old.(*module.DnsRecordObject).Status
+	err = c.deployRecord(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().UpdateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *DnsRecordObjectManager) DeleteObject(m map[string]string) error {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	t, err := c.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	to := t.(*module.DnsRecordObject)
+	err = c.undeployRecord(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+	}
+
+	// DB Operation
+	err = GetDBUtils().DeleteObject(c, m)
+
+	return err
+}
+
+// Redeploy re-deploys all the DNS records of the overlay, it is called
+// whenever the devices of the overlay change
+func (c *DnsRecordObjectManager) Redeploy(overlay string) {
+	dns_mux.Lock()
+	defer dns_mux.Unlock()
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+
+	records, err := c.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, p := range records {
+		to := p.(*module.DnsRecordObject)
+		err = c.deployRecord(overlay, to)
+		if err != nil {
+			log.Println("Fail to redeploy DNS record " + to.Metadata.Name + ": " + err.Error())
+		}
+
+		// status is saved even on error so that the devices are tracked
+		m[DnsRecordResource] = to.Metadata.Name
+		_, err = GetDBUtils().UpdateObject(c, m, to)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// redeployDnsRecords is a helper for the other managers
+func redeployDnsRecords(overlay string) {
+	mgr := GetManagerset().DnsRecord
+	if mgr != nil {
+		mgr.Redeploy(overlay)
+	}
+}
+
+func (c *DnsRecordObjectManager) undeployRecord(overlay string, to *module.DnsRecordObject) error {
+	r := to.ToResource()
+	return undeployFromDevices(overlay, r.GetType(), r.GetName(), to.Status.Devices, []string{})
+}
+
+func (c *DnsRecordObjectManager) deployRecord(overlay string, to *module.DnsRecordObject) error {
+	devices, err := deployToDevices(overlay, "dnsrecord"+to.Metadata.Name, to.ToResource(),
+		to.Specification.DeviceSelector, to.Status.Devices)
+	to.Status.Devices = devices
+
+	return err
+}
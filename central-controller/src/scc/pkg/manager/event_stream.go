@@ -0,0 +1,248 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+const (
+	// number of the last events kept in the database
+	EVENT_RETENTION = 1000
+	// number of the events queued for a subscriber before it is dropped
+	EVENT_BUFFER_SIZE = 100
+)
+
+// EventKey is the key of a stored event. The sequence number is stored with
+// its own key field so that the events do not collide with the objects in
+// the same collection
+type EventKey struct {
+	Seq string `json:"event-seq"`
+}
+
+// ErrEventsExpired is returned to a subscriber which resumes the stream from
+// an event which is not kept anymore, it has to reload the objects
+var ErrEventsExpired = pkgerrors.New("The events are not kept anymore")
+
+type eventSubscriber struct {
+	overlay string
+	events  chan module.Event
+}
+
+// EventStream sends the changes of the objects to the subscribers. The last
+// events are stored in the database with their sequence numbers so that a
+// subscriber can resume the stream after it reconnects, also when scc
+// restarts
+type EventStream struct {
+	storeName   string
+	tagMeta     string
+	mux         sync.Mutex
+	seq         uint64
+	loaded      bool
+	subscribers map[*eventSubscriber]bool
+}
+
+var eventstream = EventStream{
+	storeName:   StoreName,
+	tagMeta:     "event",
+	subscribers: make(map[*eventSubscriber]bool),
+}
+
+func GetEventStream() *EventStream {
+	return &eventstream
+}
+
+func (s *EventStream) getEvents() ([]module.Event, error) {
+	var events []module.Event
+	values, err := db.DBconn.Find(s.storeName, EventKey{}, s.tagMeta)
+	if err != nil {
+		return events, err
+	}
+
+	for _, value := range values {
+		var e module.Event
+		err = db.DBconn.Unmarshal(value, &e)
+		if err != nil {
+			return events, pkgerrors.Wrap(err, "Unmarshaling value")
+		}
+		events = append(events, e)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Seq < events[j].Seq
+	})
+	return events, nil
+}
+
+// load continues the sequence numbers of the stored events, the caller
+// holds the lock
+func (s *EventStream) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	events, err := s.getEvents()
+	if err != nil {
+		return pkgerrors.Wrap(err, "Unable to load the events")
+	}
+	if len(events) > 0 {
+		s.seq = events[len(events)-1].Seq
+	}
+	s.loaded = true
+	return nil
+}
+
+// Publish stores the event and sends it to the subscribers of its overlay.
+// A subscriber which does not keep up is dropped, it resumes the stream
+// from the last event it received
+func (s *EventStream) Publish(e module.Event) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	err := s.load()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	s.seq++
+	e.Seq = s.seq
+	e.Time = time.Now().UTC()
+	err = db.DBconn.Insert(s.storeName, EventKey{Seq: strconv.FormatUint(e.Seq, 10)}, nil, s.tagMeta, e)
+	if err != nil {
+		log.Println("Unable to save event " + strconv.FormatUint(e.Seq, 10) + ": " + err.Error())
+	}
+	if e.Seq > EVENT_RETENTION {
+		// the oldest event may be gone already
+		db.DBconn.Remove(s.storeName, EventKey{Seq: strconv.FormatUint(e.Seq-EVENT_RETENTION, 10)})
+	}
+
+	for sub := range s.subscribers {
+		if sub.overlay != "" && sub.overlay != e.Overlay {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			log.Println("Event subscriber is dropped at event " + strconv.FormatUint(e.Seq, 10))
+			close(sub.events)
+			delete(s.subscribers, sub)
+		}
+	}
+}
+
+// LastSeq returns the sequence number of the last event
+func (s *EventStream) LastSeq() (uint64, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	err := s.load()
+	return s.seq, err
+}
+
+// Subscribe returns the stored events of the overlay after the sequence
+// number since, and a channel of the events which follow them. All of the
+// overlays are subscribed if overlay is empty. ErrEventsExpired is returned
+// if the events after since are not all kept anymore. The channel is closed
+// when the subscriber is dropped, cancel has to be called once the
+// subscriber stops reading
+func (s *EventStream) Subscribe(overlay string, since uint64) ([]module.Event, <-chan module.Event, func(), error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if s.seq > EVENT_RETENTION && since < s.seq-EVENT_RETENTION {
+		return nil, nil, nil, ErrEventsExpired
+	}
+
+	past := []module.Event{}
+	if since < s.seq {
+		events, err := s.getEvents()
+		if err != nil {
+			return nil, nil, nil, pkgerrors.Wrap(err, "Unable to load the events")
+		}
+		for _, e := range events {
+			if e.Seq > since && (overlay == "" || e.Overlay == overlay) {
+				past = append(past, e)
+			}
+		}
+	}
+
+	sub := &eventSubscriber{
+		overlay: overlay,
+		events:  make(chan module.Event, EVENT_BUFFER_SIZE),
+	}
+	s.subscribers[sub] = true
+
+	cancel := func() {
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		if s.subscribers[sub] {
+			close(sub.events)
+			delete(s.subscribers, sub)
+		}
+	}
+	return past, sub.events, cancel, nil
+}
+
+// publishObjectEvent publishes the change of an object stored by a manager,
+// obj is nil for a deleted object
+func publishObjectEvent(c ControllerObjectManager, m map[string]string, etype string,
+	name string, obj module.ControllerObject) {
+	e := module.Event{
+		Type:    etype,
+		Overlay: m[OverlayResource],
+		Kind:    c.GetStoreMeta(),
+		Name:    name,
+	}
+	if c.GetResourceName() == OverlayResource {
+		e.Overlay = name
+	}
+
+	if obj != nil {
+		// copy the object to remove its secrets
+		value, err := json.Marshal(obj)
+		if err == nil {
+			o := c.CreateEmptyObject()
+			err = json.Unmarshal(value, o)
+			if err == nil {
+				for _, field := range secretFields(o) {
+					field.SetString("")
+				}
+				e.Object, err = json.Marshal(o)
+			}
+		}
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	GetEventStream().Publish(e)
+}
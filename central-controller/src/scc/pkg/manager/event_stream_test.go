@@ -0,0 +1,71 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+func TestSubscribeSince(t *testing.T) {
+	db.DBconn = &upsertMockDB{}
+	s := &EventStream{
+		storeName:   StoreName,
+		tagMeta:     "event",
+		subscribers: make(map[*eventSubscriber]bool),
+	}
+	for i := 0; i < EVENT_RETENTION+5; i++ {
+		s.Publish(module.Event{Type: module.EventUpdated, Overlay: "overlay1", Name: "overlay1"})
+	}
+
+	tcases := []struct {
+		name  string
+		since uint64
+		// number of the past events returned
+		expected int
+		expired  bool
+	}{
+		{"Last", EVENT_RETENTION + 5, 0, false},
+		{"Recent", EVENT_RETENTION, 5, false},
+		{"OldestKept", 5, EVENT_RETENTION, false},
+		{"Stale", 4, 0, true},
+		{"Start", 0, 0, true},
+	}
+
+	for _, tcase := range tcases {
+		past, _, cancel, err := s.Subscribe("overlay1", tcase.since)
+		if tcase.expired {
+			if err != ErrEventsExpired {
+				t.Errorf("%s: Subscribe(%d) error = %v, expected the events expired", tcase.name, tcase.since, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Subscribe(%d) error = %s", tcase.name, tcase.since, err.Error())
+		}
+		cancel()
+		if len(past) != tcase.expected || (len(past) > 0 && past[0].Seq != tcase.since+1) {
+			t.Errorf("%s: Subscribe(%d) returned %d events, expected %d from %d", tcase.name, tcase.since,
+				len(past), tcase.expected, tcase.since+1)
+		}
+	}
+	if len(s.subscribers) != 0 {
+		t.Errorf("Subscribe() of stale events kept %d subscribers", len(s.subscribers))
+	}
+}
@@ -0,0 +1,326 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	DRAIN_VERIFY_INTERVAL = 5 * time.Second
+	DRAIN_VERIFY_TIMEOUT  = 2 * time.Minute
+
+	// state of the CRs which are applied to the CNF
+	CR_STATE_IN_SYNC = "In Sync"
+)
+
+// only one drain runs at a time, the devices are moved one by one
+var drain_mux = &sync.Mutex{}
+
+// Drain moves the devices connected to a hub to the target hub. For each
+// device the connection to the target hub is set up and verified, then the
+// delegated internet traffic and the sites are moved before the connection
+// to the drained hub is removed, so that the traffic of the device is not
+// interrupted. A device which fails to move stays connected to the hub
+func (c *HubObjectManager) Drain(m map[string]string, req *module.DrainRequest) (module.DrainResponse, error) {
+	drain_mux.Lock()
+	defer drain_mux.Unlock()
+
+	overlay_name := m[OverlayResource]
+	hub_name := m[HubResource]
+	target := req.TargetHub
+	ret := module.DrainResponse{
+		Migrated: []string{},
+		Failed:   map[string]string{},
+	}
+
+	if target == hub_name {
+		return ret, pkgerrors.New("Hub " + hub_name + " can not be drained to itself")
+	}
+
+	mt := make(map[string]string)
+	mt[OverlayResource] = overlay_name
+	mt[HubResource] = target
+	_, err := c.GetObject(mt)
+	if err != nil {
+		return ret, pkgerrors.Wrap(err, "Hub "+target+" is not defined")
+	}
+
+	devices := req.Devices
+	if len(devices) == 0 {
+		dev_names, err := GetManagerset().HubConn.GetConnectedDevices(overlay_name, hub_name)
+		if err != nil {
+			return ret, err
+		}
+		for _, dev_name := range dev_names {
+			_, name := module.ParseEndName(strings.SplitN(dev_name, "..", 2)[0])
+			devices = append(devices, name)
+		}
+	}
+
+	devConn := GetManagerset().DeviceConn
+	for _, device_name := range devices {
+		if !devConn.IsConnectedHub(overlay_name, device_name, hub_name) {
+			ret.Failed[device_name] = "Device is not connected to hub " + hub_name
+			continue
+		}
+
+		log.Println("Move device " + device_name + " from hub " + hub_name + " to hub " + target)
+		err = c.migrateDevice(overlay_name, hub_name, target, device_name)
+		if err != nil {
+			log.Println(err)
+			ret.Failed[device_name] = err.Error()
+			continue
+		}
+		ret.Migrated = append(ret.Migrated, device_name)
+	}
+
+	return ret, nil
+}
+
+func (c *HubObjectManager) migrateDevice(overlay_name string, hub_name string, target string, device_name string) error {
+	dev_manager := GetManagerset().Device
+	hubdev_manager := GetManagerset().HubDevice
+	ds_manager := GetManagerset().DeviceSite
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay_name
+	m[HubResource] = target
+	m[DeviceResource] = device_name
+
+	dev, err := dev_manager.GetObject(m)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Device "+device_name+" is not defined")
+	}
+
+	delegated_hubs := dev.(*module.DeviceObject).GetDelegatedHubs()
+	is_delegated := false
+	is_target_delegated := false
+	for _, h := range delegated_hubs {
+		is_delegated = is_delegated || h == hub_name
+		is_target_delegated = is_target_delegated || h == target
+	}
+
+	// Connect the device to the target hub
+	created := false
+	if GetManagerset().DeviceConn.IsConnectedHub(overlay_name, device_name, target) {
+		if is_delegated && !is_target_delegated {
+			return pkgerrors.New("Hub " + target + " is connected to the device but does not delegate its internet traffic")
+		}
+	} else {
+		_, err = hubdev_manager.CreateObject(m, &module.HubDeviceObject{
+			Metadata: module.ObjectMetaData{Name: device_name},
+			Specification: module.HubDeviceObjectSpec{
+				Device:        device_name,
+				IsDelegateHub: is_delegated,
+			},
+		})
+		if err != nil {
+			return pkgerrors.Wrap(err, "Fail to connect the device to hub "+target)
+		}
+		created = true
+	}
+
+	err = c.verifyConnection(overlay_name, target, device_name)
+	if err != nil {
+		if created {
+			// remove the connection which is not working
+			hubdev_manager.DeleteObject(m)
+		}
+		return err
+	}
+
+	// Move the sites to the target hub, the target hub keeps the priority
+	// of the drained hub
+	m[HubResource] = hub_name
+	sites, err := ds_manager.GetObjects(m)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Fail to get the sites of the device")
+	}
+	for _, t := range sites {
+		to := t.(*module.SiteObject)
+		hubs := []string{}
+		found := false
+		for _, h := range to.Specification.Hubs {
+			if h == hub_name {
+				found = true
+				h = target
+			}
+			if h == target && containsHub(hubs, target) {
+				continue
+			}
+			hubs = append(hubs, h)
+		}
+		if !found {
+			continue
+		}
+
+		to.Specification.Hubs = hubs
+		m[SiteResource] = to.Metadata.Name
+		_, err = ds_manager.UpdateObject(m, to)
+		delete(m, SiteResource)
+		if err != nil {
+			return pkgerrors.Wrap(err, "Fail to move site "+to.Metadata.Name)
+		}
+	}
+
+	// Remove the connection to the drained hub, the delegated internet
+	// traffic falls over to the target hub
+	return hubdev_manager.DeleteObject(m)
+}
+
+func containsHub(hubs []string, hub string) bool {
+	for _, h := range hubs {
+		if h == hub {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyConnection waits for the tunnel resources of a hub-device
+// connection to be applied on both ends. The resources of the GitOps clusters can not
+// be queried and are not checked
+func (c *HubObjectManager) verifyConnection(overlay_name string, hub_name string, device_name string) error {
+	conn, err := GetConnectionManager().GetObject(overlay_name,
+		module.CreateEndName("Hub", hub_name),
+		module.CreateEndName("Device", device_name))
+	if err != nil {
+		return pkgerrors.Wrap(err, "Connection to hub "+hub_name+" is not found")
+	}
+	co := conn.(*module.ConnectionObject)
+	if co.Info.State != module.StateEnum.Deployed {
+		return pkgerrors.New("Connection to hub " + hub_name + " is not deployed: " + co.Info.ErrorMessage)
+	}
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay_name
+	m[HubResource] = hub_name
+	m[DeviceResource] = device_name
+	hub, err := GetManagerset().Hub.GetObject(m)
+	if err != nil {
+		return err
+	}
+	dev, err := GetManagerset().Device.GetObject(m)
+	if err != nil {
+		return err
+	}
+
+	type queryEnd struct {
+		obj module.ControllerObject
+		res QueryResource
+	}
+	hub_res, dev_res := connectionQueryResources(getTunnelType(overlay_name), co, hub_name, device_name)
+	var ends []queryEnd
+	if hub.(*module.HubObject).Specification.KubeConfig != "" {
+		for _, res := range hub_res {
+			ends = append(ends, queryEnd{hub, res})
+		}
+	}
+	if dev.(*module.DeviceObject).Status.Mode != 3 {
+		for _, res := range dev_res {
+			ends = append(ends, queryEnd{dev, res})
+		}
+	}
+	if len(ends) == 0 {
+		return nil
+	}
+
+	resutil := NewResUtil()
+	for _, end := range ends {
+		resutil.AddQueryResource(end.obj, end.res)
+	}
+
+	err = wait.PollImmediate(DRAIN_VERIFY_INTERVAL, DRAIN_VERIFY_TIMEOUT,
+		func() (bool, error) {
+			_, err := resutil.Query("ewo-drain-app")
+			if err != nil {
+				log.Println(err)
+				return false, nil
+			}
+			for _, end := range ends {
+				name := end.res.Resource.Gvk.Kind + " " + end.res.Resource.Name
+				val, err := resutil.GetKindResourceData(end.obj, end.res.Resource.Gvk.Kind,
+					end.res.Resource.Namespace, end.res.Resource.Name)
+				if err != nil {
+					log.Println(err)
+					return false, nil
+				}
+				var cr struct {
+					Status struct {
+						State   string `json:"state"`
+						Message string `json:"message"`
+					} `json:"status"`
+				}
+				err = json.Unmarshal([]byte(val), &cr)
+				if err != nil || cr.Status.State != CR_STATE_IN_SYNC {
+					log.Println("Waiting for " + name + " on " + end.obj.GetMetadata().Name + ": " + cr.Status.State + " " + cr.Status.Message)
+					return false, nil
+				}
+			}
+			return true, nil
+		},
+	)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Connection to hub "+hub_name+" is not applied")
+	}
+
+	return nil
+}
+
+// connectionQueryResources returns the CRs of the tunnel of a hub-device
+// connection on the hub and on the device: the IPsec site and host, or the
+// WireGuard interfaces and peers named after the ip of the other end
+func connectionQueryResources(tunnel string, co *module.ConnectionObject, hub_name string,
+	device_name string) ([]QueryResource, []QueryResource) {
+	if tunnel == WIREGUARD_TUNNEL {
+		_, _, dev_ip := co.GetPeer("Hub", hub_name)
+		_, _, hub_ip := co.GetPeer("Device", device_name)
+		hub_if := wireguardIfName(dev_ip)
+		dev_if := wireguardIfName(hub_ip)
+		hub_res := []QueryResource{
+			crQueryResource("WireguardInterface", hub_if),
+			crQueryResource("WireguardPeer", hub_if),
+		}
+		dev_res := []QueryResource{
+			crQueryResource("WireguardInterface", dev_if),
+			crQueryResource("WireguardPeer", dev_if),
+		}
+		return hub_res, dev_res
+	}
+
+	return []QueryResource{crQueryResource("IpsecSite", format_resource_name(hub_name, device_name))},
+		[]QueryResource{crQueryResource("IpsecHost", format_resource_name(device_name, hub_name))}
+}
+
+func crQueryResource(kind string, name string) QueryResource {
+	return QueryResource{
+		Resource: ReadResource{
+			Gvk:       schema.GroupVersionKind{Group: "batch.sdewan.akraino.org", Version: "v1alpha1", Kind: kind},
+			Name:      name,
+			Namespace: "default",
+		}}
+}
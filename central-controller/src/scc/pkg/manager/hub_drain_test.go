@@ -0,0 +1,73 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+)
+
+func TestConnectionQueryResources(t *testing.T) {
+	co := &module.ConnectionObject{Info: module.ConnectionInfo{
+		End1: module.ConnectionEnd{Name: "Hub.hub-2", Type: "Hub", IP: "192.168.0.1"},
+		End2: module.ConnectionEnd{Name: "Device.device1", Type: "Device", IP: "192.168.0.2"},
+	}}
+
+	// the kind and the name of the CRs queried on the hub and on the device
+	type cr struct {
+		kind string
+		name string
+	}
+	tcases := []struct {
+		name   string
+		tunnel string
+		hub    []cr
+		dev    []cr
+	}{
+		{
+			name:   "Ipsec",
+			tunnel: IPSEC_TUNNEL,
+			hub:    []cr{{"IpsecSite", "hub2device1"}},
+			dev:    []cr{{"IpsecHost", "device1hub2"}},
+		},
+		{
+			name:   "Wireguard",
+			tunnel: WIREGUARD_TUNNEL,
+			hub:    []cr{{"WireguardInterface", "wgc0a80002"}, {"WireguardPeer", "wgc0a80002"}},
+			dev:    []cr{{"WireguardInterface", "wgc0a80001"}, {"WireguardPeer", "wgc0a80001"}},
+		},
+	}
+
+	crs := func(res []QueryResource) []cr {
+		ret := []cr{}
+		for _, r := range res {
+			ret = append(ret, cr{r.Resource.Gvk.Kind, r.Resource.Name})
+		}
+		return ret
+	}
+	for _, tcase := range tcases {
+		hub_res, dev_res := connectionQueryResources(tcase.tunnel, co, "hub-2", "device1")
+		if !reflect.DeepEqual(crs(hub_res), tcase.hub) {
+			t.Errorf("%s: connectionQueryResources() of the hub = %v, expected %v", tcase.name, crs(hub_res), tcase.hub)
+		}
+		if !reflect.DeepEqual(crs(dev_res), tcase.dev) {
+			t.Errorf("%s: connectionQueryResources() of the device = %v, expected %v", tcase.name, crs(dev_res), tcase.dev)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+	"reflect"
 
 	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
 	pkgerrors "github.com/pkg/errors"
@@ -194,6 +195,11 @@ func (c *HubObjectManager) CreateObject(m map[string]string, t module.Controller
 		t, err = GetDBUtils().CreateObject(c, m, t)
 	}
 
+	// the security policies may select the hub by its labels
+	if err == nil {
+		recompileSecurityPolicies(overlay_name)
+	}
+
 	return t, err
 }
 
@@ -211,10 +217,21 @@ func (c *HubObjectManager) GetObjects(m map[string]string) ([]module.ControllerO
 	return t, err
 }
 
+func (c *HubObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *HubObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	old, old_err := c.GetObject(m)
+
 	// DB Operation
 	t, err := GetDBUtils().UpdateObject(c, m, t)
 
+	// the security policies may select the hub by its labels
+	if err == nil && old_err == nil && !reflect.DeepEqual(old.GetMetadata().Labels, t.GetMetadata().Labels) {
+		recompileSecurityPolicies(m[OverlayResource])
+	}
+
 	return t, err
 }
 
@@ -228,6 +245,22 @@ func (c *HubObjectManager) DeleteObject(m map[string]string) error {
 
 	overlay_manager := GetManagerset().Overlay
 	certManager := GetManagerset().Cert
+	to := t.(*module.HubObject)
+
+	// the devices delegated to the hub are detached first, so that their
+	// default route is switched to their next delegate hub before the
+	// connections are removed
+	for _, device_name := range append([]string{}, to.Status.DelegateDevices...) {
+		dm := make(map[string]string)
+		for k, v := range m {
+			dm[k] = v
+		}
+		dm[DeviceResource] = device_name
+		err = GetManagerset().HubDevice.detachDevice(dm)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 
 	// Reset all IpSec connection setup by this device
 	err = overlay_manager.DeleteConnections(m, t)
@@ -235,7 +268,6 @@ func (c *HubObjectManager) DeleteObject(m map[string]string) error {
 		log.Println(err)
 	}
 
-	to := t.(*module.HubObject)
 	log.Println("Delete Certificate: " + to.GetCertName())
 	err = certManager.DeleteCertificateByType(m[OverlayResource], to.Metadata.Name, HubKey)
 	if err != nil {
@@ -256,6 +288,9 @@ func (c *HubObjectManager) DeleteObject(m map[string]string) error {
 	err = GetDBUtils().DeleteObject(c, m)
 	if err != nil {
 		log.Println(err)
+	} else {
+		// remove the hub from the security policies
+		recompileSecurityPolicies(m[OverlayResource])
 	}
 
 	return err
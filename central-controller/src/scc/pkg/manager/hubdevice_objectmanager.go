@@ -20,10 +20,12 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+	"strings"
 
 	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
 	pkgerrors "github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type HubDeviceObjectKey struct {
@@ -84,14 +86,217 @@ func (c *HubDeviceObjectManager) ParseObject(r io.Reader) (module.ControllerObje
 }
 
 func (c *HubDeviceObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
-	// Setup hub-device connection
+	to := t.(*module.HubDeviceObject)
+	if to.Specification.DeviceSelector == "" {
+		err := c.attachDevice(m, to.Specification.Device, to.Specification.IsDelegateHub)
+		if err != nil {
+			return c.CreateEmptyObject(), err
+		}
+
+		// a device attached explicitly may be selected again
+		err = c.excludeDevice(m, to.Specification.Device, false)
+		if err != nil {
+			log.Println(err)
+		}
+
+		recompileSecurityPolicies(m[OverlayResource])
+		return t, nil
+	}
+
+	if to.Specification.Device != "" {
+		return c.CreateEmptyObject(), pkgerrors.New("Device and device selector are exclusive")
+	}
+	_, err := labels.Parse(to.Specification.DeviceSelector)
+	if err != nil {
+		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Invalid device selector")
+	}
+
+	hub, err := GetManagerset().Hub.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Hub "+m[HubResource]+" is not defined")
+	}
+
+	// the selector is kept to attach the devices registered or labeled later
+	hub_obj := hub.(*module.HubObject)
+	found := false
+	for i, sel := range hub_obj.Status.DeviceSelectors {
+		if sel.Selector == to.Specification.DeviceSelector {
+			hub_obj.Status.DeviceSelectors[i].IsDelegateHub = to.Specification.IsDelegateHub
+			found = true
+		}
+	}
+	if !found {
+		hub_obj.Status.DeviceSelectors = append(hub_obj.Status.DeviceSelectors, module.HubDeviceSelector{
+			Selector:      to.Specification.DeviceSelector,
+			IsDelegateHub: to.Specification.IsDelegateHub,
+		})
+	}
+	_, err = GetManagerset().Hub.UpdateObject(m, hub_obj)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	failed := c.applySelectors(m)
+	recompileSecurityPolicies(m[OverlayResource])
+
+	if len(failed) > 0 {
+		return c.CreateEmptyObject(), pkgerrors.New("Fail to attach devices " + strings.Join(failed, ", ") + " to hub " + m[HubResource])
+	}
+	return t, nil
+}
+
+// Reselect evaluates the device selectors of all the hubs of the overlay
+// again, it is called when a device is registered or its labels change
+func (c *HubDeviceObjectManager) Reselect(overlay string) {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	hubs, err := GetManagerset().Hub.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	changed := false
+	for _, hub := range hubs {
+		if len(hub.(*module.HubObject).Status.DeviceSelectors) == 0 {
+			continue
+		}
+		m[HubResource] = hub.GetMetadata().Name
+		failed := c.applySelectors(m)
+		if len(failed) > 0 {
+			log.Println("Fail to attach devices " + strings.Join(failed, ", ") + " to hub " + hub.GetMetadata().Name)
+		}
+		changed = true
+	}
+
+	if changed {
+		recompileSecurityPolicies(overlay)
+	}
+}
+
+func reselectHubDevices(overlay string) {
+	mgr := GetManagerset().HubDevice
+	if mgr != nil {
+		mgr.Reselect(overlay)
+	}
+}
+
+// applySelectors attaches the registered devices selected by the device
+// selectors of the hub which are not attached yet, and detaches the devices
+// attached by a selector which are not selected anymore. It returns the
+// devices which fail to be attached
+func (c *HubDeviceObjectManager) applySelectors(m map[string]string) []string {
+	failed := []string{}
+	hub, err := GetManagerset().Hub.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return failed
+	}
+	selectors := hub.(*module.HubObject).Status.DeviceSelectors
+
+	devs, err := GetManagerset().Device.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+		return failed
+	}
+
+	selected := make(map[string]bool)
+	hub_end := module.CreateEndName("Hub", m[HubResource])
+	for i, sel := range selectors {
+		selector, err := labels.Parse(sel.Selector)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		attached := []string{}
+		for _, dev := range devs {
+			device := dev.(*module.DeviceObject)
+			if containsString(sel.Excluded, device.Metadata.Name) ||
+				device.Status.Data[RegStatus] != "success" || !selector.Matches(labels.Set(device.Metadata.Labels)) {
+				continue
+			}
+			selected[device.Metadata.Name] = true
+
+			_, err = GetConnectionManager().GetObject(m[OverlayResource], hub_end,
+				module.CreateEndName(device.GetType(), device.Metadata.Name))
+			if err == nil {
+				if containsString(sel.Devices, device.Metadata.Name) {
+					attached = append(attached, device.Metadata.Name)
+				}
+				continue
+			}
+
+			err = c.attachDevice(m, device.Metadata.Name, sel.IsDelegateHub)
+			if err != nil {
+				log.Println(err)
+				failed = append(failed, device.Metadata.Name)
+				continue
+			}
+			attached = append(attached, device.Metadata.Name)
+		}
+
+		for _, name := range sel.Devices {
+			if !containsString(attached, name) {
+				attached = append(attached, name)
+			}
+		}
+		selectors[i].Devices = attached
+	}
+
+	// the devices attached by a selector which are not selected anymore
+	// by any of the selectors are detached
+	for i, sel := range selectors {
+		attached := []string{}
+		for _, name := range sel.Devices {
+			if selected[name] {
+				attached = append(attached, name)
+				continue
+			}
+
+			d := make(map[string]string)
+			for k, v := range m {
+				d[k] = v
+			}
+			d[DeviceResource] = name
+			_, err = GetManagerset().Device.GetObject(d)
+			if err != nil {
+				continue
+			}
+			log.Println("Device " + name + " is not selected anymore, detach it from hub " + m[HubResource])
+			err = c.detachDevice(d)
+			if err != nil {
+				log.Println(err)
+				attached = append(attached, name)
+			}
+		}
+		selectors[i].Devices = attached
+	}
+
+	// the hub is updated by attaching the devices, save the selectors to
+	// its current status
+	hub, err = GetManagerset().Hub.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return failed
+	}
+	hub.(*module.HubObject).Status.DeviceSelectors = selectors
+	_, err = GetManagerset().Hub.UpdateObject(m, hub)
+	if err != nil {
+		log.Println(err)
+	}
+	return failed
+}
+
+// attachDevice sets up the connection between the hub and the device
+func (c *HubDeviceObjectManager) attachDevice(vars map[string]string, device_name string, is_delegated_connection bool) error {
+	m := make(map[string]string)
+	for k, v := range vars {
+		m[k] = v
+	}
 	overlay_name := m[OverlayResource]
 	hub_name := m[HubResource]
-	//device_name :=m[DeviceResource]
-	to := t.(*module.HubDeviceObject)
-	device_name := to.Specification.Device
 	m[DeviceResource] = device_name
-	is_delegated_connection := to.Specification.IsDelegateHub
 
 	hub_manager := GetManagerset().Hub
 	dev_manager := GetManagerset().Device
@@ -100,34 +305,30 @@ func (c *HubDeviceObjectManager) CreateObject(m map[string]string, t module.Cont
 
 	hub, err := hub_manager.GetObject(m)
 	if err != nil {
-		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Hub "+hub_name+" is not defined")
+		return pkgerrors.Wrap(err, "Hub "+hub_name+" is not defined")
 	}
 
 	dev, err := dev_manager.GetObject(m)
 	if err != nil {
-		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Device "+device_name+" is not defined")
+		return pkgerrors.Wrap(err, "Device "+device_name+" is not defined")
 	}
 
 	device := dev.(*module.DeviceObject)
 	if device.Status.Data[RegStatus] != "success" {
 		log.Println("Device registration not ready")
-		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Device "+device_name+" registration is not ready")
-	}
-
-	if device.Status.DelegatedHub != "" {
-		is_delegated_connection = false
+		return pkgerrors.New("Device " + device_name + " registration is not ready")
 	}
 
 	_, err = conn_manager.GetObject(overlay_name,
 		module.CreateEndName(hub.GetType(), hub.GetMetadata().Name),
 		module.CreateEndName(dev.GetType(), dev.GetMetadata().Name))
 	if err == nil {
-		return c.CreateEmptyObject(), pkgerrors.New("The connection between Hub " + hub_name + " and Device " + device_name + " is already created")
+		return pkgerrors.New("The connection between Hub " + hub_name + " and Device " + device_name + " is already created")
 	}
 
 	err = overlay_namager.SetupConnection(m, hub, dev, HUBTODEVICE, NameSpaceName, is_delegated_connection)
 	if err != nil {
-		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Fail to setup connection between "+hub_name+" and "+device_name)
+		return pkgerrors.Wrap(err, "Fail to setup connection between "+hub_name+" and "+device_name)
 	}
 
 	if is_delegated_connection {
@@ -135,11 +336,12 @@ func (c *HubDeviceObjectManager) CreateObject(m map[string]string, t module.Cont
 		hub_obj.Status.DelegateDevices = append(hub_obj.Status.DelegateDevices, device_name)
 		hub_manager.UpdateObject(m, hub_obj)
 
-		device.Status.DelegatedHub = hub_name
+		device.Status.DelegatedHubs = append(device.GetDelegatedHubs(), hub_name)
+		device.Status.DelegatedHub = device.Status.DelegatedHubs[0]
 		dev_manager.UpdateObject(m, device)
 	}
 
-	return t, nil
+	return nil
 }
 
 func (c *HubDeviceObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
@@ -155,6 +357,47 @@ func (c *HubDeviceObjectManager) UpdateObject(m map[string]string, t module.Cont
 }
 
 func (c *HubDeviceObjectManager) DeleteObject(m map[string]string) error {
+	// a device detached explicitly is not attached by the selectors anymore
+	err := c.excludeDevice(m, m[DeviceResource], true)
+	if err != nil {
+		return err
+	}
+
+	err = c.detachDevice(m)
+	if err != nil {
+		return err
+	}
+
+	recompileSecurityPolicies(m[OverlayResource])
+	return nil
+}
+
+// excludeDevice excludes the device from the device selectors of the hub,
+// or includes it again
+func (c *HubDeviceObjectManager) excludeDevice(m map[string]string, device_name string, exclude bool) error {
+	hub, err := GetManagerset().Hub.GetObject(m)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Hub "+m[HubResource]+" is not defined")
+	}
+
+	hub_obj := hub.(*module.HubObject)
+	if len(hub_obj.Status.DeviceSelectors) == 0 {
+		return nil
+	}
+	for i, sel := range hub_obj.Status.DeviceSelectors {
+		sel.Devices = removeString(sel.Devices, device_name)
+		sel.Excluded = removeString(sel.Excluded, device_name)
+		if exclude {
+			sel.Excluded = append(sel.Excluded, device_name)
+		}
+		hub_obj.Status.DeviceSelectors[i] = sel
+	}
+	_, err = GetManagerset().Hub.UpdateObject(m, hub_obj)
+	return err
+}
+
+// detachDevice removes the connection between the hub and the device
+func (c *HubDeviceObjectManager) detachDevice(m map[string]string) error {
 	// Delete hub-device connection
 	overlay_name := m[OverlayResource]
 	hub_name := m[HubResource]
@@ -178,10 +421,33 @@ func (c *HubDeviceObjectManager) DeleteObject(m map[string]string) error {
 	dev_obj := dev.(*module.DeviceObject)
 	hub_obj := hub.(*module.HubObject)
 
-	if dev_obj.Status.DelegatedHub == hub_obj.Metadata.Name {
+	// the next delegate hub takes over the internet traffic of the device
+	new_delegated_hub := ""
+	delegated_hubs := []string{}
+	for _, item := range dev_obj.GetDelegatedHubs() {
+		if item != hub_obj.Metadata.Name {
+			delegated_hubs = append(delegated_hubs, item)
+		}
+	}
+	if len(delegated_hubs) != len(dev_obj.GetDelegatedHubs()) {
+		is_primary := dev_obj.Status.DelegatedHub == hub_obj.Metadata.Name
+		dev_obj.Status.DelegatedHubs = delegated_hubs
 		dev_obj.Status.DelegatedHub = ""
+		if len(dev_obj.Status.DelegatedHubs) > 0 {
+			dev_obj.Status.DelegatedHub = dev_obj.Status.DelegatedHubs[0]
+		}
 		dev_manager.UpdateObject(m, dev_obj)
 
+		if is_primary {
+			new_delegated_hub = dev_obj.Status.DelegatedHub
+			if new_delegated_hub == "" {
+				err = overlay_manager.DeleteDelegateRoute(m, dev_obj)
+				if err != nil {
+					log.Println(err)
+				}
+			}
+		}
+
 		for i, item := range hub_obj.Status.DelegateDevices {
 			if item == dev_obj.Metadata.Name {
 				hub_obj.Status.DelegateDevices = append(hub_obj.Status.DelegateDevices[:i], hub_obj.Status.DelegateDevices[i+1:]...)
@@ -191,6 +457,25 @@ func (c *HubDeviceObjectManager) DeleteObject(m map[string]string) error {
 		hub_manager.UpdateObject(m, hub_obj)
 	}
 
+	// the default route is switched to the next delegate hub in place
+	// before the connection to this hub is removed, so that the internet
+	// traffic of the device is not interrupted. The route is removed with
+	// the connection if it can not be switched
+	route_switched := false
+	if new_delegated_hub != "" {
+		m[HubResource] = new_delegated_hub
+		new_hub, err := hub_manager.GetObject(m)
+		if err == nil {
+			err = overlay_manager.SetupDelegateRoute(m, new_hub.(*module.HubObject), dev_obj)
+		}
+		if err != nil {
+			log.Println(err)
+		} else {
+			route_switched = true
+		}
+		m[HubResource] = hub_name
+	}
+
 	conn, err := conn_manager.GetObject(overlay_name,
 		module.CreateEndName(hub.GetType(), hub.GetMetadata().Name),
 		module.CreateEndName(dev.GetType(), dev.GetMetadata().Name))
@@ -198,6 +483,17 @@ func (c *HubDeviceObjectManager) DeleteObject(m map[string]string) error {
 		log.Println(err)
 	} else {
 		conn_obj := conn.(*module.ConnectionObject)
+		if route_switched {
+			// the default route is kept for the next delegate hub
+			route_name := delegateResourceName(dev_obj.Metadata.Name, "")
+			resources := []module.ConnectionResource{}
+			for _, r := range conn_obj.Info.Resources {
+				if r.Type != "Route" || r.Name != route_name {
+					resources = append(resources, r)
+				}
+			}
+			conn_obj.Info.Resources = resources
+		}
 		err = overlay_manager.DeleteConnection(m, *conn_obj)
 		if err != nil {
 			log.Println(err)
@@ -206,3 +502,22 @@ func (c *HubDeviceObjectManager) DeleteObject(m map[string]string) error {
 
 	return nil
 }
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(strs []string, str string) []string {
+	ret := []string{}
+	for _, s := range strs {
+		if s != str {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
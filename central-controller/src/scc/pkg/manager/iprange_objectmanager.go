@@ -223,6 +223,10 @@ func (c *IPRangeObjectManager) GetObjects(m map[string]string) ([]module.Control
 	return t, err
 }
 
+func (c *IPRangeObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *IPRangeObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
 	// DB Operation
 	t, err := GetDBUtils().UpdateObject(c, m, t)
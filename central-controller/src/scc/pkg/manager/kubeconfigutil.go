@@ -131,10 +131,15 @@ func (c *KubeConfigUtil) checkKubeConfigAvail(conf []byte, ips []string, port st
 			return []byte(""), "", pkgerrors.New("Error in updating kubeconfig")
 		}
 		kubeclient = client.NewClient("", "", []byte(conf))
-		is_reachable := kubeclient.IsReachable()
-		if is_reachable == true {
+		err = kubeclient.CheckReachable()
+		if err == nil {
 			return conf, ip, nil
 		}
+		log.Println(err)
+		// the credentials are rejected, other ips would not help
+		if isUnauthorized(err) {
+			return []byte(""), "", pkgerrors.Wrap(err, "Cluster rejects the kubeconfig")
+		}
 	}
 	return []byte(""), "", pkgerrors.New("No public ip found workable for the cluster")
 }
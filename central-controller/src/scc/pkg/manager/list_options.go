@@ -0,0 +1,652 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/query"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// default order of the objects, it keeps the pages stable
+const DEFAULT_LIST_SORT = "metadata.name"
+
+// ListFilter matches the objects whose field equals (or with Not, does not
+// equal) the value. A field path without dots (e.g. regStatus) matches the
+// field at any depth of the object
+type ListFilter struct {
+	Path  string
+	Not   bool
+	Value string
+}
+
+// ListOptions selects, orders, pages and projects the objects of a
+// collection. The continue token is returned with a page which is followed
+// by more objects
+type ListOptions struct {
+	Limit    int
+	Continue string
+	Filters  []ListFilter
+	Selector labels.Selector
+	// field path of the order, descending if it starts with -
+	Sort   string
+	Fields []string
+	cursor *listCursor
+}
+
+// listCursor is the position of the next page
+type listCursor struct {
+	Offset int `json:"o"`
+}
+
+// listItem is an object with the value of its sort field
+type listItem struct {
+	obj   module.ControllerObject
+	value interface{}
+	name  string
+}
+
+// storeListManager is implemented by the managers whose GetObjects returns
+// the objects of their store as they are. Their lists are selected, sorted
+// and paged by the database query
+type storeListManager interface {
+	IsStoreListed() bool
+}
+
+// ParseListOptions reads the list options from the query parameters limit,
+// continue, filter (path=value or path!=value, comma separated or
+// repeated), labelSelector, sort and fields (comma separated). It returns
+// false if none is given
+func ParseListOptions(query url.Values) (ListOptions, bool, error) {
+	opts := ListOptions{}
+	given := false
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return opts, true, pkgerrors.New("Invalid limit: " + value)
+		}
+		opts.Limit = limit
+		given = true
+	}
+
+	if value := query.Get("continue"); value != "" {
+		var cursor listCursor
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		if err == nil {
+			err = json.Unmarshal(data, &cursor)
+		}
+		if err != nil || cursor.Offset < 0 {
+			return opts, true, pkgerrors.New("Invalid continue token: " + value)
+		}
+		opts.Continue = value
+		opts.cursor = &cursor
+		given = true
+	}
+
+	for _, values := range query["filter"] {
+		for _, value := range strings.Split(values, ",") {
+			if value == "" {
+				continue
+			}
+			f := ListFilter{}
+			parts := strings.SplitN(value, "!=", 2)
+			if len(parts) == 2 {
+				f.Not = true
+			} else {
+				parts = strings.SplitN(value, "=", 2)
+			}
+			if len(parts) != 2 || parts[0] == "" {
+				return opts, true, pkgerrors.New("Invalid filter: " + value)
+			}
+			f.Path, f.Value = parts[0], parts[1]
+			opts.Filters = append(opts.Filters, f)
+			given = true
+		}
+	}
+
+	if value := query.Get("labelSelector"); value != "" {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return opts, true, pkgerrors.Wrap(err, "Invalid label selector")
+		}
+		opts.Selector = selector
+		given = true
+	}
+
+	if value := query.Get("sort"); value != "" {
+		opts.Sort = value
+		given = true
+	}
+
+	if value := query.Get("fields"); value != "" {
+		for _, field := range strings.Split(value, ",") {
+			if field != "" {
+				opts.Fields = append(opts.Fields, field)
+			}
+		}
+		given = true
+	}
+
+	return opts, given, nil
+}
+
+// ListObjects returns the page of the objects of the manager selected by the
+// options, the total number of the selected objects and the continue token
+// of the next page (empty for the last page)
+func (d *DBUtils) ListObjects(c ControllerObjectManager, m map[string]string,
+	opts ListOptions) ([]module.ControllerObject, int, string, error) {
+	if query.Enabled() {
+		if l, ok := c.(storeListManager); ok && l.IsStoreListed() {
+			if fopts, ok := findOptions(c.CreateEmptyObject(), opts); ok {
+				return d.queryObjects(c, m, opts, fopts)
+			}
+		}
+	}
+
+	objs, err := c.GetObjects(m)
+	if err != nil {
+		return []module.ControllerObject{}, 0, "", err
+	}
+	return listObjects(objs, opts)
+}
+
+// queryObjects lists the objects with the database query
+func (d *DBUtils) queryObjects(c ControllerObjectManager, m map[string]string,
+	opts ListOptions, fopts query.Options) ([]module.ControllerObject, int, string, error) {
+	key, err := c.GetStoreKey(m, c.CreateEmptyObject(), true)
+	if err != nil {
+		return []module.ControllerObject{}, 0, "", err
+	}
+
+	values, total, err := query.Find(c.GetStoreName(), key, c.GetStoreMeta(), fopts)
+	if err != nil {
+		return []module.ControllerObject{}, 0, "", pkgerrors.Wrap(err, "List Objects")
+	}
+
+	page := []module.ControllerObject{}
+	for _, value := range values {
+		t := c.CreateEmptyObject()
+		err = db.DBconn.Unmarshal(value, t)
+		if err != nil {
+			return []module.ControllerObject{}, 0, "", pkgerrors.Wrap(err, "Unmarshaling values")
+		}
+		page = append(page, t)
+	}
+
+	end := int(fopts.Skip) + len(page)
+	next := ""
+	if opts.Limit > 0 && end < int(total) {
+		next = continueToken(end)
+	}
+	return page, int(total), next, nil
+}
+
+// listObjects selects, sorts and pages the objects in memory
+func listObjects(objs []module.ControllerObject, opts ListOptions) ([]module.ControllerObject, int, string, error) {
+	sort_path := opts.Sort
+	desc := strings.HasPrefix(sort_path, "-")
+	sort_path = strings.TrimPrefix(sort_path, "-")
+	if sort_path == "" {
+		sort_path = DEFAULT_LIST_SORT
+	}
+
+	items := []listItem{}
+	for _, obj := range objs {
+		fields, err := objectFields(obj)
+		if err != nil {
+			return []module.ControllerObject{}, 0, "", err
+		}
+		if (opts.Selector != nil && !opts.Selector.Matches(labels.Set(obj.GetMetadata().Labels))) ||
+			!matchFilters(fields, opts.Filters) {
+			continue
+		}
+		value, _ := lookupField(fields, sort_path)
+		items = append(items, listItem{obj, value, obj.GetMetadata().Name})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if r := compareValues(items[i].value, items[j].value); r != 0 {
+			return (r < 0) != desc
+		}
+		return items[i].name < items[j].name
+	})
+
+	start := 0
+	if opts.cursor != nil {
+		start = opts.cursor.Offset
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+
+	end := len(items)
+	next := ""
+	if opts.Limit > 0 && start+opts.Limit < end {
+		end = start + opts.Limit
+		next = continueToken(end)
+	}
+
+	page := []module.ControllerObject{}
+	for _, item := range items[start:end] {
+		page = append(page, item.obj)
+	}
+	return page, len(items), next, nil
+}
+
+func continueToken(offset int) string {
+	data, _ := json.Marshal(listCursor{Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// findOptions translates the list options to the database query of the
+// objects of the type of obj. It returns false if a field of the options
+// can not be queried in the database
+func findOptions(obj module.ControllerObject, opts ListOptions) (query.Options, bool) {
+	fopts := query.Options{}
+	t := reflect.TypeOf(obj)
+
+	for _, f := range opts.Filters {
+		path, ft, ok := storeField(t, f.Path)
+		if !ok || !isScalar(ft) {
+			return fopts, false
+		}
+		value := storeValue(ft, f.Value)
+		switch {
+		case f.Not:
+			fopts.Filters = append(fopts.Filters, query.Filter{Path: path, Op: query.FilterNe, Values: []interface{}{value}})
+		case f.Value == "":
+			// a missing field matches the empty value
+			fopts.Filters = append(fopts.Filters, query.Filter{Path: path, Op: query.FilterIn, Values: []interface{}{value, nil}})
+		default:
+			fopts.Filters = append(fopts.Filters, query.Filter{Path: path, Op: query.FilterEq, Values: []interface{}{value}})
+		}
+	}
+
+	requirements := labels.Requirements{}
+	if opts.Selector != nil {
+		requirements, _ = opts.Selector.Requirements()
+	}
+	labels_path, _, _ := storeField(t, "metadata.labels")
+	for _, r := range requirements {
+		// the keys with dots are not valid field names of a query
+		if strings.ContainsAny(r.Key(), ".$") {
+			return fopts, false
+		}
+		f := query.Filter{Path: labels_path + "." + r.Key()}
+		for _, v := range r.Values().List() {
+			f.Values = append(f.Values, v)
+		}
+		switch r.Operator() {
+		case selection.Equals, selection.DoubleEquals:
+			f.Op = query.FilterEq
+		case selection.NotEquals:
+			f.Op = query.FilterNe
+		case selection.In:
+			f.Op = query.FilterIn
+		case selection.NotIn:
+			f.Op = query.FilterNotIn
+		case selection.Exists:
+			f.Op = query.FilterExists
+		case selection.DoesNotExist:
+			f.Op = query.FilterNotExists
+		default:
+			return fopts, false
+		}
+		fopts.Filters = append(fopts.Filters, f)
+	}
+
+	sort_path := opts.Sort
+	desc := strings.HasPrefix(sort_path, "-")
+	sort_path = strings.TrimPrefix(sort_path, "-")
+	if sort_path == "" {
+		sort_path = DEFAULT_LIST_SORT
+	}
+	path, ft, ok := storeField(t, sort_path)
+	if !ok || !isScalar(ft) {
+		return fopts, false
+	}
+	name, _, _ := storeField(t, DEFAULT_LIST_SORT)
+	fopts.Sort = []query.Sort{{Path: path, Desc: desc}, {Path: name}}
+
+	if opts.cursor != nil {
+		fopts.Skip = int64(opts.cursor.Offset)
+	}
+	fopts.Limit = int64(opts.Limit)
+	return fopts, true
+}
+
+// jsonName returns the json name of the struct field, empty if it is not
+// marshaled
+func jsonName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	switch {
+	case name == "-" || f.Anonymous || f.PkgPath != "":
+		return ""
+	case name == "":
+		return f.Name
+	}
+	return name
+}
+
+// bsonName returns the name of the struct field in the database
+func bsonName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("bson"), ",")[0]; name != "" {
+		return name
+	}
+	return strings.ToLower(f.Name)
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+// storeField returns the path in the database and the type of the field of
+// the json path in the objects of type t. A path without dots is searched
+// at any depth as lookupField does. It returns false if the field can not
+// be queried, e.g. it is encrypted or it may be the key of a map
+func storeField(t reflect.Type, path string) (string, reflect.Type, bool) {
+	names := strings.Split(path, ".")
+	if len(names) == 1 {
+		return searchStoreField(t, path)
+	}
+
+	fields := []string{}
+	for _, name := range names {
+		t = elemType(t)
+		switch t.Kind() {
+		case reflect.Struct:
+			found := false
+			for i := 0; i < t.NumField(); i++ {
+				f := t.Field(i)
+				if jsonName(f) == "" || !strings.EqualFold(jsonName(f), name) {
+					continue
+				}
+				if _, ok := f.Tag.Lookup("encrypted"); ok {
+					return "", nil, false
+				}
+				fields = append(fields, bsonName(f))
+				t = f.Type
+				found = true
+				break
+			}
+			if !found {
+				return "", nil, false
+			}
+		case reflect.Map:
+			if strings.ContainsAny(name, "$") {
+				return "", nil, false
+			}
+			fields = append(fields, name)
+			t = t.Elem()
+		default:
+			return "", nil, false
+		}
+	}
+	return strings.Join(fields, "."), t, true
+}
+
+// searchStoreField finds the field in the struct or in its nested structs,
+// breadth first. The search fails if a map is met before the field, as the
+// field may be one of its keys
+func searchStoreField(t reflect.Type, name string) (string, reflect.Type, bool) {
+	type level struct {
+		path string
+		t    reflect.Type
+	}
+	current := []level{{"", elemType(t)}}
+	for len(current) > 0 {
+		next := []level{}
+		for _, l := range current {
+			if l.t.Kind() == reflect.Map {
+				return "", nil, false
+			}
+			if l.t.Kind() != reflect.Struct {
+				continue
+			}
+			for i := 0; i < l.t.NumField(); i++ {
+				f := l.t.Field(i)
+				if jsonName(f) == "" {
+					continue
+				}
+				path := strings.TrimPrefix(l.path+"."+bsonName(f), ".")
+				if strings.EqualFold(jsonName(f), name) {
+					if _, ok := f.Tag.Lookup("encrypted"); ok {
+						return "", nil, false
+					}
+					return path, f.Type, true
+				}
+				next = append(next, level{path, elemType(f.Type)})
+			}
+		}
+		current = next
+	}
+	return "", nil, false
+}
+
+// isScalar checks whether the values of the type (or its elements) are
+// compared as strings or numbers
+func isScalar(t reflect.Type) bool {
+	switch elemType(t).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return false
+	}
+	return true
+}
+
+// storeValue converts the filter value to the type of the field
+func storeValue(t reflect.Type, value string) interface{} {
+	switch elemType(t).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case reflect.Bool:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// ProjectObjects returns the objects with only the given fields
+func ProjectObjects(objs []module.ControllerObject, paths []string) ([]map[string]interface{}, error) {
+	ret := []map[string]interface{}{}
+	for _, obj := range objs {
+		fields, err := objectFields(obj)
+		if err != nil {
+			return ret, err
+		}
+
+		projected := make(map[string]interface{})
+		for _, path := range paths {
+			projectField(fields, projected, strings.Split(path, "."))
+		}
+		ret = append(ret, projected)
+	}
+	return ret, nil
+}
+
+func objectFields(obj module.ControllerObject) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	value, err := json.Marshal(obj)
+	if err != nil {
+		return fields, err
+	}
+
+	d := json.NewDecoder(strings.NewReader(string(value)))
+	d.UseNumber()
+	err = d.Decode(&fields)
+	return fields, err
+}
+
+// findKey returns the key of the map which matches the name ignoring case
+func findKey(fields map[string]interface{}, name string) (string, bool) {
+	if _, ok := fields[name]; ok {
+		return name, true
+	}
+	for k := range fields {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// lookupField returns the value of the field path, a path without dots is
+// searched at any depth
+func lookupField(fields map[string]interface{}, path string) (interface{}, bool) {
+	names := strings.Split(path, ".")
+	if len(names) == 1 {
+		return searchField(fields, path)
+	}
+
+	var value interface{} = fields
+	for _, name := range names {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		k, ok := findKey(m, name)
+		if !ok {
+			return nil, false
+		}
+		value = m[k]
+	}
+	return value, true
+}
+
+// searchField finds the field in the map or in its nested maps, breadth
+// first
+func searchField(fields map[string]interface{}, name string) (interface{}, bool) {
+	level := []map[string]interface{}{fields}
+	for len(level) > 0 {
+		next := []map[string]interface{}{}
+		for _, m := range level {
+			if k, ok := findKey(m, name); ok {
+				return m[k], true
+			}
+			keys := []string{}
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if nested, ok := m[k].(map[string]interface{}); ok {
+					next = append(next, nested)
+				}
+			}
+		}
+		level = next
+	}
+	return nil, false
+}
+
+func matchFilters(fields map[string]interface{}, filters []ListFilter) bool {
+	for _, f := range filters {
+		value, _ := lookupField(fields, f.Path)
+		matched := false
+		if values, ok := value.([]interface{}); ok {
+			for _, v := range values {
+				matched = matched || valueString(v) == f.Value
+			}
+		} else {
+			matched = valueString(value) == f.Value
+		}
+		if matched == f.Not {
+			return false
+		}
+	}
+	return true
+}
+
+func valueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number, bool:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// compareValues compares the values as numbers if both are numbers, or as
+// strings otherwise
+func compareValues(v1 interface{}, v2 interface{}) int {
+	s1, s2 := valueString(v1), valueString(v2)
+	f1, err1 := strconv.ParseFloat(s1, 64)
+	f2, err2 := strconv.ParseFloat(s2, 64)
+	switch {
+	case err1 == nil && err2 == nil && f1 < f2:
+		return -1
+	case err1 == nil && err2 == nil && f1 > f2:
+		return 1
+	case err1 == nil && err2 == nil:
+		return 0
+	}
+	return strings.Compare(s1, s2)
+}
+
+// projectField copies the field path from the object to the projection
+func projectField(fields map[string]interface{}, projected map[string]interface{}, names []string) {
+	k, ok := findKey(fields, names[0])
+	if !ok {
+		return
+	}
+	if len(names) == 1 {
+		projected[k] = fields[k]
+		return
+	}
+
+	nested, ok := fields[k].(map[string]interface{})
+	if !ok {
+		return
+	}
+	p, ok := projected[k].(map[string]interface{})
+	if !ok {
+		p = make(map[string]interface{})
+		projected[k] = p
+	}
+	projectField(nested, p, names[1:])
+}
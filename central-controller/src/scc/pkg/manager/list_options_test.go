@@ -0,0 +1,219 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/query"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+)
+
+// listTestManager lists a fixed set of webhooks
+type listTestManager struct {
+	WebhookObjectManager
+	objs []module.ControllerObject
+}
+
+func (c *listTestManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	return c.objs, nil
+}
+
+func newListTestManager() *listTestManager {
+	labels := map[string]map[string]string{
+		"webhook1": {"region": "emea", "tier": "gold"},
+		"webhook2": {"region": "apac"},
+		"webhook3": {"region": "emea", "tier": "silver"},
+	}
+	webhook := func(name string, url string, events ...string) module.ControllerObject {
+		return &module.WebhookObject{
+			Metadata:      module.ObjectMetaData{Name: name, Labels: labels[name]},
+			Specification: module.WebhookObjectSpec{Url: url, Events: events},
+		}
+	}
+	return &listTestManager{
+		WebhookObjectManager: *NewWebhookObjectManager(),
+		objs: []module.ControllerObject{
+			webhook("webhook3", "http://b", module.NotifyConnectionError),
+			webhook("webhook1", "http://a", module.NotifyRegistrationFailed, module.NotifyConnectionError),
+			webhook("webhook2", "http://a"),
+			webhook("webhook4", "http://c", module.NotifyRegistrationFailed),
+		},
+	}
+}
+
+func listTest(t *testing.T, query string) ([]string, int, string) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, _, err := ParseListOptions(values)
+	if err != nil {
+		t.Fatalf("Parse %s: %s", query, err.Error())
+	}
+
+	objs, total, next, err := GetDBUtils().ListObjects(newListTestManager(), map[string]string{}, opts)
+	if err != nil {
+		t.Fatalf("List %s: %s", query, err.Error())
+	}
+	names := []string{}
+	for _, obj := range objs {
+		names = append(names, obj.GetMetadata().Name)
+	}
+	return names, total, next
+}
+
+func expectNames(t *testing.T, query string, names []string, expected ...string) {
+	if len(names) != len(expected) {
+		t.Fatalf("%s: expected %v, got %v", query, expected, names)
+	}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Fatalf("%s: expected %v, got %v", query, expected, names)
+		}
+	}
+}
+
+func TestListObjects(t *testing.T) {
+	tcases := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"webhook1", "webhook2", "webhook3", "webhook4"}},
+		{"filter=spec.url=http://a", []string{"webhook1", "webhook2"}},
+		{"filter=url!=http://a", []string{"webhook3", "webhook4"}},
+		{"filter=events=registration-failed", []string{"webhook1", "webhook4"}},
+		{"filter=url=http://a,events=connection-error", []string{"webhook1"}},
+		{"sort=-spec.url", []string{"webhook4", "webhook3", "webhook1", "webhook2"}},
+		{"labelSelector=region=emea", []string{"webhook1", "webhook3"}},
+		{"labelSelector=region==emea,tier!=gold", []string{"webhook3"}},
+		{"labelSelector=region in (apac, emea),!tier", []string{"webhook2"}},
+		{"labelSelector=region notin (apac)", []string{"webhook1", "webhook3", "webhook4"}},
+		{"labelSelector=tier", []string{"webhook1", "webhook3"}},
+		{"labelSelector=region=emea&filter=url=http://b", []string{"webhook3"}},
+	}
+
+	for _, tcase := range tcases {
+		names, total, next := listTest(t, tcase.query)
+		expectNames(t, tcase.query, names, tcase.expected...)
+		if total != len(tcase.expected) || next != "" {
+			t.Errorf("%s: unexpected total %d or continue %s", tcase.query, total, next)
+		}
+	}
+}
+
+func TestListObjectsPages(t *testing.T) {
+	query := "sort=url&limit=3"
+	names, total, next := listTest(t, query)
+	expectNames(t, query, names, "webhook1", "webhook2", "webhook3")
+	if total != 4 || next == "" {
+		t.Fatalf("%s: unexpected total %d or continue %s", query, total, next)
+	}
+
+	query = query + "&continue=" + next
+	names, total, next = listTest(t, query)
+	expectNames(t, query, names, "webhook4")
+	if total != 4 || next != "" {
+		t.Fatalf("%s: unexpected total %d or continue %s", query, total, next)
+	}
+}
+
+func TestListOptionsInvalid(t *testing.T) {
+	for _, query := range []string{"limit=-1", "limit=a", "filter=url", "filter==a", "continue=xyz",
+		"labelSelector=region,", "labelSelector=region in emea", "labelSelector=!"} {
+		values, _ := url.ParseQuery(query)
+		_, _, err := ParseListOptions(values)
+		if err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+}
+
+func TestProjectObjects(t *testing.T) {
+	ret, err := ProjectObjects(newListTestManager().objs[:1], []string{"metadata.name", "spec.url"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ret) != 1 || len(ret[0]) != 2 {
+		t.Fatalf("Unexpected projection %v", ret)
+	}
+	spec := ret[0]["spec"].(map[string]interface{})
+	if len(spec) != 1 || spec["url"] != "http://b" {
+		t.Fatalf("Unexpected projection %v", ret)
+	}
+}
+
+func TestStoreField(t *testing.T) {
+	tcases := []struct {
+		path     string
+		expected string
+		valid    bool
+	}{
+		{"metadata.name", "metadata.name", true},
+		{"spec.proxyHubPort", "specification.proxyhubport", true},
+		{"proxyHubPort", "specification.proxyhubport", true},
+		{"status.data.RegStatus", "status.data.RegStatus", true},
+		{"metadata.labels.region", "metadata.labels.region", true},
+		// may be a key of the maps of the status
+		{"regStatus", "", false},
+		{"spec.kubeConfig", "", false},
+		{"spec.unknown", "", false},
+	}
+
+	for _, tcase := range tcases {
+		path, _, ok := storeField(reflect.TypeOf(&module.DeviceObject{}), tcase.path)
+		if ok != tcase.valid || path != tcase.expected {
+			t.Errorf("%s: storeField() = %s %v, expected %s %v", tcase.path, path, ok, tcase.expected, tcase.valid)
+		}
+	}
+}
+
+func TestFindOptions(t *testing.T) {
+	values, _ := url.ParseQuery("filter=proxyHubPort=8080,spec.proxyHub!=hub1&labelSelector=region in (emea),!tier&sort=-spec.proxyHub&limit=2")
+	opts, _, err := ParseListOptions(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fopts, ok := findOptions(&module.DeviceObject{}, opts)
+	if !ok {
+		t.Fatal("findOptions() failed")
+	}
+	expected := query.Options{
+		Filters: []query.Filter{
+			{Path: "specification.proxyhubport", Op: query.FilterEq, Values: []interface{}{int64(8080)}},
+			{Path: "specification.proxyhub", Op: query.FilterNe, Values: []interface{}{"hub1"}},
+			{Path: "metadata.labels.region", Op: query.FilterIn, Values: []interface{}{"emea"}},
+			{Path: "metadata.labels.tier", Op: query.FilterNotExists},
+		},
+		Sort:  []query.Sort{{Path: "specification.proxyhub", Desc: true}, {Path: "metadata.name"}},
+		Limit: 2,
+	}
+	if !reflect.DeepEqual(fopts, expected) {
+		t.Errorf("findOptions() = %v, expected %v", fopts, expected)
+	}
+
+	for _, query := range []string{"filter=regStatus=pending", "sort=metadata.labels", "labelSelector=app.kubernetes.io/name=x"} {
+		values, _ := url.ParseQuery(query)
+		opts, _, _ := ParseListOptions(values)
+		if _, ok := findOptions(&module.DeviceObject{}, opts); ok {
+			t.Errorf("%s: expected to be listed in memory", query)
+		}
+	}
+}
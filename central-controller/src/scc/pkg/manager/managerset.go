@@ -19,6 +19,7 @@ package manager
 type Managerset struct {
 	Overlay         *OverlayObjectManager
 	Proposal        *ProposalObjectManager
+	ProposalSet     *ProposalSetObjectManager
 	Hub             *HubObjectManager
 	HubConn         *HubConnObjectManager
 	HubDevice       *HubDeviceObjectManager
@@ -32,6 +33,25 @@ type Managerset struct {
 	ClusterSync     *ClusterSyncObjectManager
 	Resource        *ResourceObjectManager
 	DeviceSite      *DeviceSiteObjectManager
+	HubFirewallRule *ClusterResourceObjectManager
+	DevFirewallRule *ClusterResourceObjectManager
+	HubFirewallFwd  *ClusterResourceObjectManager
+	DevFirewallFwd  *ClusterResourceObjectManager
+	HubMwan3Policy  *ClusterResourceObjectManager
+	DevMwan3Policy  *ClusterResourceObjectManager
+	HubMwan3Rule    *ClusterResourceObjectManager
+	DevMwan3Rule    *ClusterResourceObjectManager
+	HubRouteRule    *ClusterResourceObjectManager
+	DevRouteRule    *ClusterResourceObjectManager
+	HubApplication  *ClusterResourceObjectManager
+	DevApplication  *ClusterResourceObjectManager
+	SecurityPolicy  *SecurityPolicyObjectManager
+	TrafficPolicy   *TrafficPolicyObjectManager
+	DnsForwarder    *DnsForwarderObjectManager
+	DnsRecord       *DnsRecordObjectManager
+	HubPSK          *PreSharedKeyObjectManager
+	DevPSK          *PreSharedKeyObjectManager
+	Webhook         *WebhookObjectManager
 }
 
 var mgrset = Managerset{}
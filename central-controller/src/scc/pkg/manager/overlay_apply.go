@@ -0,0 +1,235 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Apply makes the overlay match the desired state in the document. The
+// objects which are not stored are created and the objects which differ are
+// updated (or replaced if they can not be updated) in the order of their
+// dependencies, then the stored objects which are not in the document are
+// deleted in the reverse order if prune is set. The secrets are only
+// compared for the kinds whose secret is their content (the pre-shared
+// keys), a redacted secret keeps the stored value. In a dry run the changes
+// are only returned
+func (c *OverlayObjectManager) Apply(m map[string]string, doc *module.OverlayDocument, passphrase string,
+	prune bool, dryRun bool) (module.ApplyResponse, error) {
+	document_mux.Lock()
+	defer document_mux.Unlock()
+
+	overlay_name := m[OverlayResource]
+	ret := module.ApplyResponse{
+		Changes: []module.ApplyChange{},
+		Failed:  map[string]string{},
+	}
+
+	open, err := documentOpener(doc, passphrase)
+	if err != nil {
+		return ret, err
+	}
+
+	kinds := documentKinds()
+	desired := make(map[string]bool)
+	for i := range doc.Objects {
+		dobj := &doc.Objects[i]
+		if dobj.Anchor != "" {
+			err = resolveAnchor(kinds, overlay_name, dobj)
+			if err != nil {
+				return ret, err
+			}
+		}
+		if findKind(kinds, dobj.Kind) == nil {
+			return ret, pkgerrors.New("Unknown kind " + dobj.Kind)
+		}
+		if desired[dobj.GetKey()] {
+			return ret, pkgerrors.New("Object " + dobj.GetKey() + " is duplicated")
+		}
+		desired[dobj.GetKey()] = true
+	}
+
+	plain := func(s string) (string, error) {
+		return s, nil
+	}
+
+	changes := []documentChange{}
+	stored := []module.DocumentObject{}
+	overlay_kind := documentKind{OverlayKind, "", "", c}
+	overlay, err := c.GetObject(m)
+	if err != nil {
+		changes = append(changes, documentChange{module.ActionCreate, overlay_kind, doc.Overlay, nil})
+	} else {
+		current, err := toDocumentObject(OverlayKind, "", overlay, plain)
+		if err != nil {
+			return ret, err
+		}
+		if len(doc.Overlay.Spec) > 0 && !sameDocumentObject(overlay_kind, doc.Overlay, current) {
+			changes = append(changes, documentChange{module.ActionUpdate, overlay_kind, doc.Overlay, &current})
+		}
+
+		stored, err = collectDocumentObjects(m, plain)
+		if err != nil {
+			return ret, err
+		}
+	}
+
+	stored_objs := make(map[string]*module.DocumentObject)
+	for i := range stored {
+		stored_objs[stored[i].GetKey()] = &stored[i]
+	}
+
+	for _, k := range kinds {
+		for _, dobj := range doc.Objects {
+			if dobj.Kind != k.kind {
+				continue
+			}
+			current, ok := stored_objs[dobj.GetKey()]
+			switch {
+			case !ok:
+				changes = append(changes, documentChange{module.ActionCreate, k, dobj, nil})
+			case sameDocumentObject(k, dobj, *current) && sameSecrets(k, dobj, *current, open):
+			case k.mgr.IsOperationSupported("PUT"):
+				changes = append(changes, documentChange{module.ActionUpdate, k, dobj, current})
+			default:
+				changes = append(changes, documentChange{module.ActionReplace, k, dobj, current})
+			}
+		}
+	}
+
+	if prune {
+		for i := len(kinds) - 1; i >= 0; i-- {
+			for _, dobj := range stored {
+				if dobj.Kind == kinds[i].kind && !desired[dobj.GetKey()] {
+					changes = append(changes, documentChange{module.ActionDelete, kinds[i], dobj, nil})
+				}
+			}
+		}
+	}
+
+	for _, change := range changes {
+		ret.Changes = append(ret.Changes, module.ApplyChange{Action: change.action, Object: change.obj.GetKey()})
+	}
+	if dryRun {
+		return ret, nil
+	}
+
+	_, ret.Failed = applyDocumentChanges(overlay_name, changes, open)
+	return ret, nil
+}
+
+func findKind(kinds []documentKind, kind string) *documentKind {
+	for i := range kinds {
+		if kinds[i].kind == kind {
+			return &kinds[i]
+		}
+	}
+	return nil
+}
+
+// resolveAnchor sets the kind and the parent of the object from the url of
+// its collection, e.g. overlays/overlay1/hubs/hub1/devices
+func resolveAnchor(kinds []documentKind, overlay_name string, dobj *module.DocumentObject) error {
+	parts := strings.Split(strings.Trim(dobj.Anchor, "/"), "/")
+	if len(parts) < 3 || parts[0] != OverlayCollection || parts[1] != overlay_name {
+		return pkgerrors.New("Anchor " + dobj.Anchor + " is not in overlay " + overlay_name)
+	}
+
+	parent := ""
+	parent_name := ""
+	collection := parts[2]
+	switch {
+	case len(parts) == 5 && parts[2] == HubCollection:
+		parent, parent_name, collection = HubResource, parts[3], parts[4]
+	case len(parts) == 5 && parts[2] == DeviceCollection:
+		parent, parent_name, collection = DeviceResource, parts[3], parts[4]
+	case len(parts) != 3:
+		return pkgerrors.New("Anchor " + dobj.Anchor + " is not supported")
+	}
+
+	for _, k := range kinds {
+		if k.collection == collection && k.parent == parent {
+			dobj.Kind = k.kind
+			dobj.Parent = parent_name
+			return nil
+		}
+	}
+	return pkgerrors.New("Anchor " + dobj.Anchor + " is not supported")
+}
+
+// sameDocumentObject checks whether the objects are the same apart from the
+// secrets, both are decoded so that the omitted fields are compared with
+// their default values
+func sameDocumentObject(k documentKind, dobj1 module.DocumentObject, dobj2 module.DocumentObject) bool {
+	var values [][]byte
+	for _, dobj := range []module.DocumentObject{dobj1, dobj2} {
+		obj, err := decodeDocumentObject(k, dobj)
+		if err != nil {
+			return false
+		}
+		for _, field := range secretFields(obj) {
+			field.SetString("")
+		}
+		value, err := json.Marshal(obj)
+		if err != nil {
+			return false
+		}
+		values = append(values, value)
+	}
+	return bytes.Equal(values[0], values[1])
+}
+
+// sameSecrets checks whether the secrets of the desired object match the
+// stored object for the kinds whose secret is their content, a redacted
+// secret matches the stored one
+func sameSecrets(k documentKind, desired module.DocumentObject, stored module.DocumentObject,
+	open func(string) (string, error)) bool {
+	if !secretKinds[k.kind] {
+		return true
+	}
+
+	obj1, err := decodeDocumentObject(k, desired)
+	if err != nil {
+		return false
+	}
+	obj2, err := decodeDocumentObject(k, stored)
+	if err != nil {
+		return false
+	}
+	stored_fields := secretFields(obj2)
+	for i, field := range secretFields(obj1) {
+		if field.String() == module.RedactedSecret {
+			continue
+		}
+		s := field.String()
+		if s != "" {
+			s, err = open(s)
+			if err != nil {
+				return false
+			}
+		}
+		if s != stored_fields[i].String() {
+			return false
+		}
+	}
+	return true
+}
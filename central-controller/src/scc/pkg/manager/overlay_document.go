@@ -0,0 +1,648 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/validation"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	DOCUMENT_REGISTRATION_INTERVAL = 5 * time.Second
+	DOCUMENT_REGISTRATION_TIMEOUT  = 5 * time.Minute
+
+	// key derivation of the passphrase which encrypts the secrets
+	PASSPHRASE_ITERATIONS  = 100000
+	PASSPHRASE_SALT_LENGTH = 16
+	PASSPHRASE_KEY_LENGTH  = 32
+
+	OverlayKind            = "Overlay"
+	DeviceKind             = "Device"
+	HubDeviceKind          = "HubDevice"
+	HubPreSharedKeyKind    = "HubPreSharedKey"
+	DevicePreSharedKeyKind = "DevicePreSharedKey"
+)
+
+// only one import or apply changes the overlays at a time
+var document_mux = &sync.Mutex{}
+
+// documentKind binds a kind of object in the overlay document to its
+// manager, the parent is the hub or device resource owning the objects and
+// the collection is the url of the objects under the overlay or the parent
+type documentKind struct {
+	kind       string
+	parent     string
+	collection string
+	mgr        ControllerObjectManager
+}
+
+// documentChange is a change of an object of the overlay, the stored object
+// is the object being updated or replaced
+type documentChange struct {
+	action string
+	kind   documentKind
+	obj    module.DocumentObject
+	stored *module.DocumentObject
+}
+
+// documentKinds returns the kinds of object in the order they are created
+func documentKinds() []documentKind {
+	mgrset := GetManagerset()
+	return sortKinds([]documentKind{
+		{"Proposal", "", ProposalCollection, mgrset.Proposal},
+		{"ProposalSet", "", ProposalSetCollection, mgrset.ProposalSet},
+		{"IPRange", "", IPRangeCollection, mgrset.IPRange},
+		{"Certificate", "", CertCollection, mgrset.Cert},
+		{"ClusterSync", "", ClusterSyncCollection, mgrset.ClusterSync},
+		{"Hub", "", HubCollection, mgrset.Hub},
+		{DeviceKind, "", DeviceCollection, mgrset.Device},
+		{HubPreSharedKeyKind, HubResource, PreSharedKeyCollection, mgrset.HubPSK},
+		{DevicePreSharedKeyKind, DeviceResource, PreSharedKeyCollection, mgrset.DevPSK},
+		{HubDeviceKind, HubResource, DeviceCollection, mgrset.HubDevice},
+		{"Site", DeviceResource, SiteCollection, mgrset.DeviceSite},
+		{"HubFirewallRule", HubResource, FirewallRuleCollection, mgrset.HubFirewallRule},
+		{"HubFirewallForwarding", HubResource, FirewallFwdCollection, mgrset.HubFirewallFwd},
+		{"HubMwan3Policy", HubResource, Mwan3PolicyCollection, mgrset.HubMwan3Policy},
+		{"HubMwan3Rule", HubResource, Mwan3RuleCollection, mgrset.HubMwan3Rule},
+		{"HubRouteRule", HubResource, RouteRuleCollection, mgrset.HubRouteRule},
+		{"HubApplication", HubResource, ApplicationCollection, mgrset.HubApplication},
+		{"DeviceFirewallRule", DeviceResource, FirewallRuleCollection, mgrset.DevFirewallRule},
+		{"DeviceFirewallForwarding", DeviceResource, FirewallFwdCollection, mgrset.DevFirewallFwd},
+		{"DeviceMwan3Policy", DeviceResource, Mwan3PolicyCollection, mgrset.DevMwan3Policy},
+		{"DeviceMwan3Rule", DeviceResource, Mwan3RuleCollection, mgrset.DevMwan3Rule},
+		{"DeviceRouteRule", DeviceResource, RouteRuleCollection, mgrset.DevRouteRule},
+		{"DeviceApplication", DeviceResource, ApplicationCollection, mgrset.DevApplication},
+		{"SecurityPolicy", "", SecurityPolicyCollection, mgrset.SecurityPolicy},
+		{"TrafficPolicy", "", TrafficPolicyCollection, mgrset.TrafficPolicy},
+		{"DnsForwarder", "", DnsForwarderCollection, mgrset.DnsForwarder},
+		{"DnsRecord", "", DnsRecordCollection, mgrset.DnsRecord},
+		{"Webhook", "", WebhookCollection, mgrset.Webhook},
+	})
+}
+
+// sortKinds orders the kinds so that the managers which a kind depends on
+// (see AddDepResManager) come first, the listed order is kept otherwise for
+// the dependencies which are not registered (e.g. the proposals of a
+// proposal set)
+func sortKinds(kinds []documentKind) []documentKind {
+	pending := make(map[ControllerObjectManager]bool)
+	for _, k := range kinds {
+		pending[k.mgr] = true
+	}
+
+	ready := func(k documentKind) bool {
+		if k.mgr == nil {
+			return true
+		}
+		for _, dep := range k.mgr.GetDepResManagers() {
+			if pending[dep] {
+				return false
+			}
+		}
+		return true
+	}
+
+	sorted := []documentKind{}
+	for len(kinds) > 0 {
+		i := 0
+		for i < len(kinds)-1 && !ready(kinds[i]) {
+			i++
+		}
+		sorted = append(sorted, kinds[i])
+		delete(pending, kinds[i].mgr)
+		kinds = append(kinds[:i:i], kinds[i+1:]...)
+	}
+	return sorted
+}
+
+// kinds of the parents of the objects
+var parentKinds = map[string]string{
+	HubResource:    "Hub",
+	DeviceResource: DeviceKind,
+}
+
+// kinds whose secret is their content, so the secrets are compared by apply
+var secretKinds = map[string]bool{
+	HubPreSharedKeyKind:    true,
+	DevicePreSharedKeyKind: true,
+}
+
+// Export returns the definition of the overlay. The secrets of the objects
+// are encrypted with a key derived from the passphrase, or redacted if no
+// passphrase is given. Objects created by the scc and the state of the
+// overlay (e.g. allocated ips, connections) are not exported
+func (c *OverlayObjectManager) Export(m map[string]string, passphrase string) (module.OverlayDocument, error) {
+	overlay_name := m[OverlayResource]
+	doc := module.OverlayDocument{
+		Version: module.OverlayDocumentVersion,
+		Secrets: module.SecretsRedacted,
+	}
+
+	seal := func(s string) (string, error) {
+		return module.RedactedSecret, nil
+	}
+	if passphrase != "" {
+		salt := make([]byte, PASSPHRASE_SALT_LENGTH)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return doc, err
+		}
+		gcm, err := passphraseCipher(passphrase, salt)
+		if err != nil {
+			return doc, err
+		}
+		doc.Secrets = module.SecretsEncrypted
+		doc.Salt = base64.StdEncoding.EncodeToString(salt)
+		seal = func(s string) (string, error) {
+			return encryptSecret(gcm, s)
+		}
+	}
+
+	overlay, err := c.GetObject(m)
+	if err != nil {
+		return doc, pkgerrors.Wrap(err, "Overlay "+overlay_name+" is not defined")
+	}
+	doc.Overlay, err = toDocumentObject(OverlayKind, "", overlay, seal)
+	if err != nil {
+		return doc, err
+	}
+
+	doc.Objects, err = collectDocumentObjects(m, seal)
+	return doc, err
+}
+
+// collectDocumentObjects returns the objects of the overlay in the order
+// they are created
+func collectDocumentObjects(m map[string]string, seal func(string) (string, error)) ([]module.DocumentObject, error) {
+	overlay_name := m[OverlayResource]
+	dobjs := []module.DocumentObject{}
+
+	parents := make(map[string][]string)
+	for resource, mgr := range map[string]ControllerObjectManager{
+		HubResource: GetManagerset().Hub, DeviceResource: GetManagerset().Device} {
+		objs, err := mgr.GetObjects(m)
+		if err != nil {
+			return dobjs, err
+		}
+		for _, obj := range objs {
+			parents[resource] = append(parents[resource], obj.GetMetadata().Name)
+		}
+	}
+
+	for _, k := range documentKinds() {
+		names := []string{""}
+		if k.parent != "" {
+			names = parents[k.parent]
+		}
+
+		for _, parent := range names {
+			mp := map[string]string{OverlayResource: overlay_name}
+			if parent != "" {
+				mp[k.parent] = parent
+			}
+
+			var objs []module.ControllerObject
+			var err error
+			switch k.kind {
+			case HubDeviceKind:
+				objs, err = exportHubDevices(mp)
+			case HubPreSharedKeyKind, DevicePreSharedKeyKind:
+				objs, err = exportPreSharedKeys(k.mgr.(*PreSharedKeyObjectManager), mp)
+			default:
+				objs, err = k.mgr.GetObjects(mp)
+			}
+			if err != nil {
+				return dobjs, pkgerrors.Wrap(err, "Fail to export "+k.kind)
+			}
+
+			for _, obj := range objs {
+				if obj.GetMetadata().UserData1 == InternalKey {
+					continue
+				}
+				dobj, err := toDocumentObject(k.kind, parent, obj, seal)
+				if err != nil {
+					return dobjs, err
+				}
+				dobjs = append(dobjs, dobj)
+			}
+		}
+	}
+
+	return dobjs, nil
+}
+
+// exportHubDevices returns the devices connected to the hub, they are
+// saved as connections in the db
+func exportHubDevices(m map[string]string) ([]module.ControllerObject, error) {
+	dev_names, err := GetManagerset().HubConn.GetConnectedDevices(m[OverlayResource], m[HubResource])
+	if err != nil {
+		return []module.ControllerObject{}, err
+	}
+
+	var objs []module.ControllerObject
+	for _, dev_name := range dev_names {
+		_, name := module.ParseEndName(strings.SplitN(dev_name, "..", 2)[0])
+		m[DeviceResource] = name
+		dev, err := GetManagerset().Device.GetObject(m)
+		if err != nil {
+			return []module.ControllerObject{}, err
+		}
+		objs = append(objs, &module.HubDeviceObject{
+			Metadata: module.ObjectMetaData{Name: name},
+			Specification: module.HubDeviceObjectSpec{
+				Device:        name,
+				IsDelegateHub: containsHub(dev.(*module.DeviceObject).GetDelegatedHubs(), m[HubResource]),
+			},
+		})
+	}
+	return objs, nil
+}
+
+// exportPreSharedKeys returns the keys of the connections of the hub or the
+// device, a key is exported once by the end which owns it
+func exportPreSharedKeys(mgr *PreSharedKeyObjectManager, m map[string]string) ([]module.ControllerObject, error) {
+	keys, err := mgr.GetObjects(m)
+	if err != nil {
+		return []module.ControllerObject{}, err
+	}
+
+	var objs []module.ControllerObject
+	for _, key := range keys {
+		if ownsPreSharedKey(mgr.endName(m), key.GetMetadata().Name) {
+			objs = append(objs, key)
+		}
+	}
+	return objs, nil
+}
+
+// Import creates the overlay and its objects from the document, the overlay
+// is renamed if name is not empty. The import stops if the overlay can not
+// be created, otherwise the objects which fail are reported together with
+// the objects depending on them
+func (c *OverlayObjectManager) Import(doc *module.OverlayDocument, name string, passphrase string) (module.ImportResponse, error) {
+	document_mux.Lock()
+	defer document_mux.Unlock()
+
+	ret := module.ImportResponse{
+		Created: []string{},
+		Failed:  map[string]string{},
+	}
+
+	open, err := documentOpener(doc, passphrase)
+	if err != nil {
+		return ret, err
+	}
+
+	if name != "" {
+		doc.Overlay.Metadata.Name = name
+	}
+	overlay_name := doc.Overlay.Metadata.Name
+	ret.Overlay = overlay_name
+	m := map[string]string{OverlayResource: overlay_name}
+
+	_, err = c.GetObject(m)
+	if err == nil {
+		return ret, pkgerrors.New("Overlay " + overlay_name + " is available already")
+	}
+	overlay := documentChange{module.ActionCreate, documentKind{OverlayKind, "", "", c}, doc.Overlay, nil}
+	err = applyDocumentChange(overlay_name, overlay, open)
+	if err != nil {
+		return ret, pkgerrors.Wrap(err, "Fail to create overlay "+overlay_name)
+	}
+	ret.Created = append(ret.Created, doc.Overlay.GetKey())
+
+	kinds := documentKinds()
+	known := make(map[string]bool)
+	for _, k := range kinds {
+		known[k.kind] = true
+	}
+	for _, dobj := range doc.Objects {
+		if !known[dobj.Kind] {
+			ret.Failed[dobj.GetKey()] = "Unknown kind " + dobj.Kind
+		}
+	}
+
+	changes := []documentChange{}
+	for _, k := range kinds {
+		for _, dobj := range doc.Objects {
+			if dobj.Kind == k.kind {
+				changes = append(changes, documentChange{module.ActionCreate, k, dobj, nil})
+			}
+		}
+	}
+
+	created, failed := applyDocumentChanges(overlay_name, changes, open)
+	ret.Created = append(ret.Created, created...)
+	for key, msg := range failed {
+		ret.Failed[key] = msg
+	}
+
+	return ret, nil
+}
+
+// documentOpener returns the function which reveals the secrets of the
+// document
+func documentOpener(doc *module.OverlayDocument, passphrase string) (func(string) (string, error), error) {
+	if doc.Secrets != module.SecretsEncrypted {
+		return func(s string) (string, error) {
+			if s == module.RedactedSecret {
+				return "", pkgerrors.New("Secret is redacted")
+			}
+			return s, nil
+		}, nil
+	}
+
+	if passphrase == "" {
+		return nil, pkgerrors.New("Passphrase is required for the encrypted secrets")
+	}
+	salt, err := base64.StdEncoding.DecodeString(doc.Salt)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid salt")
+	}
+	gcm, err := passphraseCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	return func(s string) (string, error) {
+		return decryptSecret(gcm, s)
+	}, nil
+}
+
+// applyDocumentChanges applies the changes in order and returns the objects
+// changed, the changes of the objects owned by a hub or device which failed
+// are skipped
+func applyDocumentChanges(overlay_name string, changes []documentChange,
+	open func(string) (string, error)) ([]string, map[string]string) {
+	changed := []string{}
+	failed := map[string]string{}
+	devices := []string{}
+	registered := false
+
+	for _, change := range changes {
+		key := change.obj.GetKey()
+		if change.action != module.ActionDelete {
+			if change.kind.kind == HubDeviceKind && !registered {
+				// the connections to the hubs are set up once the devices
+				// are registered
+				waitRegistration(overlay_name, devices)
+				registered = true
+			}
+
+			deps := []string{}
+			if change.kind.parent != "" {
+				deps = append(deps, parentKinds[change.kind.parent]+"/"+change.obj.Parent)
+			}
+			if change.kind.kind == HubDeviceKind {
+				deps = append(deps, DeviceKind+"/"+change.obj.Metadata.Name)
+			}
+			if dep := failedDependency(failed, deps); dep != "" {
+				failed[key] = "Depends on " + dep
+				continue
+			}
+		}
+
+		err := applyDocumentChange(overlay_name, change, open)
+		if err != nil {
+			log.Println(err)
+			failed[key] = err.Error()
+			continue
+		}
+		changed = append(changed, key)
+		if change.kind.kind == DeviceKind && change.action == module.ActionCreate {
+			devices = append(devices, change.obj.Metadata.Name)
+		}
+	}
+
+	return changed, failed
+}
+
+func failedDependency(failed map[string]string, deps []string) string {
+	for _, dep := range deps {
+		if _, ok := failed[dep]; ok {
+			return dep
+		}
+	}
+	return ""
+}
+
+// waitRegistration waits for the devices queued for registration
+func waitRegistration(overlay_name string, devices []string) {
+	dev_manager := GetManagerset().Device
+	m := map[string]string{OverlayResource: overlay_name}
+	err := wait.PollImmediate(DOCUMENT_REGISTRATION_INTERVAL, DOCUMENT_REGISTRATION_TIMEOUT,
+		func() (bool, error) {
+			for _, device := range devices {
+				m[DeviceResource] = device
+				dev, err := dev_manager.GetObject(m)
+				if err == nil && dev.(*module.DeviceObject).Status.Data[RegStatus] == "pending" {
+					log.Println("Waiting for the registration of device " + device)
+					return false, nil
+				}
+			}
+			return true, nil
+		},
+	)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// applyDocumentChange makes the change like it is requested through the API
+func applyDocumentChange(overlay_name string, change documentChange, open func(string) (string, error)) error {
+	k := change.kind
+	m := map[string]string{OverlayResource: overlay_name}
+	if k.parent != "" {
+		m[k.parent] = change.obj.Parent
+	}
+
+	if change.action == module.ActionDelete || change.action == module.ActionReplace {
+		m[k.mgr.GetResourceName()] = change.obj.Metadata.Name
+		err := GetDBUtils().CheckDep(k.mgr, m)
+		if err != nil {
+			return err
+		}
+		err = GetDBUtils().CheckOwn(k.mgr, m)
+		if err != nil {
+			return err
+		}
+		err = k.mgr.DeleteObject(m)
+		if err != nil || change.action == module.ActionDelete {
+			return err
+		}
+		delete(m, k.mgr.GetResourceName())
+	}
+
+	obj, err := decodeDocumentObject(k, change.obj)
+	if err != nil {
+		return err
+	}
+
+	// the redacted secrets of a stored object are kept
+	var stored_fields []reflect.Value
+	if change.stored != nil {
+		stored, err := decodeDocumentObject(k, *change.stored)
+		if err != nil {
+			return err
+		}
+		stored_fields = secretFields(stored)
+	}
+
+	for i, field := range secretFields(obj) {
+		if field.String() == module.RedactedSecret && stored_fields != nil {
+			field.SetString(stored_fields[i].String())
+			continue
+		}
+		if field.String() == "" {
+			continue
+		}
+		s, err := open(field.String())
+		if err != nil {
+			return err
+		}
+		field.SetString(s)
+	}
+
+	validate := validation.GetValidator(k.mgr.GetStoreMeta())
+	isValid, msg := validate.Validate(obj)
+	if isValid == false {
+		return pkgerrors.New(msg)
+	}
+
+	err = GetDBUtils().CheckDep(k.mgr, m)
+	if err != nil {
+		return err
+	}
+
+	if change.action == module.ActionUpdate {
+		m[k.mgr.GetResourceName()] = k.mgr.GetResourceStoredName(obj)
+		_, err = k.mgr.UpdateObject(m, obj)
+		return err
+	}
+
+	// the objects which are not created through the API (e.g. the keys of
+	// the connections) may be created by the scc meanwhile, they are set
+	if k.mgr.IsOperationSupported("GET") && k.mgr.IsOperationSupported("POST") {
+		m[k.mgr.GetResourceName()] = k.mgr.GetResourceStoredName(obj)
+		_, err = k.mgr.GetObject(m)
+		if err == nil {
+			return pkgerrors.New("Resource " + obj.GetMetadata().Name + " is available already")
+		}
+	}
+
+	_, err = k.mgr.CreateObject(m, obj)
+	return err
+}
+
+// decodeDocumentObject returns the object like it is posted to the API
+func decodeDocumentObject(k documentKind, dobj module.DocumentObject) (module.ControllerObject, error) {
+	body, err := json.Marshal(struct {
+		Metadata module.ObjectMetaData `json:"metadata"`
+		Spec     json.RawMessage       `json:"spec,omitempty"`
+	}{dobj.Metadata, dobj.Spec})
+	if err != nil {
+		return nil, err
+	}
+
+	return k.mgr.ParseObject(bytes.NewReader(body))
+}
+
+// toDocumentObject returns the metadata and the spec of the object with
+// the secrets sealed
+func toDocumentObject(kind string, parent string, obj module.ControllerObject,
+	seal func(string) (string, error)) (module.DocumentObject, error) {
+	dobj := module.DocumentObject{Kind: kind, Parent: parent}
+
+	for _, field := range secretFields(obj) {
+		if field.String() == "" {
+			continue
+		}
+		s, err := seal(field.String())
+		if err != nil {
+			return dobj, err
+		}
+		field.SetString(s)
+	}
+
+	value, err := json.Marshal(obj)
+	if err != nil {
+		return dobj, err
+	}
+	err = json.Unmarshal(value, &dobj)
+	return dobj, err
+}
+
+// secretFields returns the fields of the spec which are encrypted in the db
+func secretFields(obj module.ControllerObject) []reflect.Value {
+	var fields []reflect.Value
+	v := reflect.ValueOf(obj).Elem().FieldByName("Specification")
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if _, ok := f.Tag.Lookup("encrypted"); ok && f.Tag.Get("json") != "-" && f.Type.Kind() == reflect.String {
+			fields = append(fields, v.Field(i))
+		}
+	}
+	return fields
+}
+
+func passphraseCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key := pbkdf2.Key([]byte(passphrase), salt, PASSPHRASE_ITERATIONS, PASSPHRASE_KEY_LENGTH, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptSecret(gcm cipher.AEAD, s string) (string, error) {
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(s), nil)), nil
+}
+
+func decryptSecret(gcm cipher.AEAD, s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(data) < gcm.NonceSize() {
+		return "", pkgerrors.New("Invalid encrypted secret")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", pkgerrors.New("Fail to decrypt the secret, the passphrase may be wrong")
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+const testPreSharedKey = "0123456789abcdef0123456789abcdef"
+
+// newDocumentTestOverlay stores overlay1 with device1 in a mock db, with
+// the managers of the kinds of the overlay document
+func newDocumentTestOverlay(t *testing.T) {
+	db.DBconn = &upsertMockDB{}
+	mgrset = Managerset{
+		Overlay:         NewOverlayObjectManager(),
+		Proposal:        NewProposalObjectManager(),
+		ProposalSet:     NewProposalSetObjectManager(),
+		Hub:             NewHubObjectManager(),
+		HubConn:         NewHubConnObjectManager(),
+		HubDevice:       NewHubDeviceObjectManager(),
+		Device:          NewDeviceObjectManager(),
+		IPRange:         NewIPRangeObjectManager(false),
+		Cert:            NewCertificateObjectManager(),
+		ClusterSync:     NewClusterSyncObjectManager(),
+		DeviceSite:      NewDeviceSiteObjectManager(),
+		HubFirewallRule: NewFirewallRuleObjectManager(true),
+		DevFirewallRule: NewFirewallRuleObjectManager(false),
+		HubFirewallFwd:  NewFirewallFwdObjectManager(true),
+		DevFirewallFwd:  NewFirewallFwdObjectManager(false),
+		HubMwan3Policy:  NewMwan3PolicyObjectManager(true),
+		DevMwan3Policy:  NewMwan3PolicyObjectManager(false),
+		HubMwan3Rule:    NewMwan3RuleObjectManager(true),
+		DevMwan3Rule:    NewMwan3RuleObjectManager(false),
+		HubRouteRule:    NewRouteRuleObjectManager(true),
+		DevRouteRule:    NewRouteRuleObjectManager(false),
+		HubApplication:  NewApplicationObjectManager(true),
+		DevApplication:  NewApplicationObjectManager(false),
+		SecurityPolicy:  NewSecurityPolicyObjectManager(),
+		TrafficPolicy:   NewTrafficPolicyObjectManager(),
+		DnsForwarder:    NewDnsForwarderObjectManager(),
+		DnsRecord:       NewDnsRecordObjectManager(),
+		HubPSK:          NewPreSharedKeyObjectManager(true),
+		DevPSK:          NewPreSharedKeyObjectManager(false),
+		Webhook:         NewWebhookObjectManager(),
+	}
+
+	m := map[string]string{OverlayResource: "overlay1"}
+	_, err := GetDBUtils().CreateObject(mgrset.Overlay, m, &module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{AuthMode: PSK_AUTH},
+	})
+	if err != nil {
+		t.Fatalf("Create overlay1: %s", err.Error())
+	}
+	_, err = GetDBUtils().CreateObject(mgrset.Device, m, &module.DeviceObject{
+		Metadata:      module.ObjectMetaData{Name: "device1"},
+		Specification: module.DeviceObjectSpec{PublicIps: []string{"10.10.10.1"}},
+	})
+	if err != nil {
+		t.Fatalf("Create device1: %s", err.Error())
+	}
+}
+
+func TestOwnsPreSharedKey(t *testing.T) {
+	tcases := []struct {
+		end      string
+		peer     string
+		expected bool
+	}{
+		{"Hub.hub1", "Device.device1", true},
+		{"Device.device1", "Hub.hub1", false},
+		{"Device.device1", SCC_END, true},
+		{SCC_END, "Device.device1", false},
+		{"Hub.hub1", "Hub.hub2", true},
+		{"Hub.hub2", "Hub.hub1", false},
+		{"Device.device1", "Device.device2", true},
+		{"Device.device2", "Device.device1", false},
+	}
+
+	for _, tcase := range tcases {
+		if owns := ownsPreSharedKey(tcase.end, tcase.peer); owns != tcase.expected {
+			t.Errorf("ownsPreSharedKey(%s, %s) = %v, expected %v", tcase.end, tcase.peer, owns, tcase.expected)
+		}
+	}
+}
+
+func TestPreSharedKeyRoundTrip(t *testing.T) {
+	newDocumentTestOverlay(t)
+	setPreSharedKey("overlay1", SCC_END, "Device.device1", testPreSharedKey)
+	// the key of a hub is exported by the hub, not by the device
+	setPreSharedKey("overlay1", "Hub.hub1", "Device.device1", testPreSharedKey)
+
+	m := map[string]string{OverlayResource: "overlay1"}
+	tcases := []struct {
+		name       string
+		passphrase string
+		secrets    string
+	}{
+		{"Encrypted", "passphrase1", module.SecretsEncrypted},
+		{"Redacted", "", module.SecretsRedacted},
+	}
+
+	for _, tcase := range tcases {
+		doc, err := mgrset.Overlay.Export(m, tcase.passphrase)
+		if err != nil {
+			t.Fatalf("%s: Export() error = %s", tcase.name, err.Error())
+		}
+		keys := []module.DocumentObject{}
+		for _, dobj := range doc.Objects {
+			if secretKinds[dobj.Kind] {
+				keys = append(keys, dobj)
+			}
+		}
+		if doc.Secrets != tcase.secrets || len(keys) != 1 || keys[0].GetKey() != "DevicePreSharedKey/device1/SCC.local" {
+			t.Fatalf("%s: Export() secrets %s, keys %v", tcase.name, doc.Secrets, keys)
+		}
+
+		open, err := documentOpener(&doc, tcase.passphrase)
+		if err != nil {
+			t.Fatalf("%s: documentOpener() error = %s", tcase.name, err.Error())
+		}
+		k := *findKind(documentKinds(), DevicePreSharedKeyKind)
+		obj, _ := decodeDocumentObject(k, keys[0])
+		sealed := obj.(*module.PreSharedKeyObject).Specification.Key
+		if tcase.secrets == module.SecretsRedacted {
+			if sealed != module.RedactedSecret {
+				t.Errorf("%s: Export() key = %s, expected it redacted", tcase.name, sealed)
+			}
+			continue
+		}
+		if sealed == testPreSharedKey {
+			t.Errorf("%s: Export() key is not encrypted", tcase.name)
+		}
+
+		// the key is imported before the connection of the device is set up
+		DeletePreSharedKey("overlay1", SCC_END, "Device.device1")
+		err = applyDocumentChange("overlay1", documentChange{module.ActionCreate, k, keys[0], nil}, open)
+		if err != nil {
+			t.Fatalf("%s: import error = %s", tcase.name, err.Error())
+		}
+		if key, _ := getPreSharedKey("overlay1", SCC_END, "Device.device1"); key != testPreSharedKey {
+			t.Errorf("%s: imported key = %s, expected %s", tcase.name, key, testPreSharedKey)
+		}
+	}
+}
+
+func TestApplyPrunesPreSharedKeys(t *testing.T) {
+	newDocumentTestOverlay(t)
+	setPreSharedKey("overlay1", SCC_END, "Device.device1", testPreSharedKey)
+	// the key of a connection which is removed
+	setPreSharedKey("overlay1", "Device.device1", "Device.device2", testPreSharedKey)
+
+	m := map[string]string{OverlayResource: "overlay1"}
+	doc, err := mgrset.Overlay.Export(m, "")
+	if err != nil {
+		t.Fatalf("Export() error = %s", err.Error())
+	}
+	desired := []module.DocumentObject{}
+	for _, dobj := range doc.Objects {
+		if dobj.GetKey() != "DevicePreSharedKey/device1/Device.device2" {
+			desired = append(desired, dobj)
+		}
+	}
+	if len(desired) != len(doc.Objects)-1 {
+		t.Fatalf("Export() objects %v, expected the key of device2", doc.Objects)
+	}
+	doc.Objects = desired
+
+	res, err := mgrset.Overlay.Apply(m, &doc, "", true, true)
+	if err != nil {
+		t.Fatalf("Apply() error = %s", err.Error())
+	}
+	expected := []module.ApplyChange{{Action: module.ActionDelete, Object: "DevicePreSharedKey/device1/Device.device2"}}
+	if !reflect.DeepEqual(res.Changes, expected) {
+		t.Errorf("Apply() changes = %v, expected %v", res.Changes, expected)
+	}
+
+	res, err = mgrset.Overlay.Apply(m, &doc, "", true, false)
+	if err != nil || len(res.Failed) != 0 {
+		t.Fatalf("Apply() error = %v, failed %v", err, res.Failed)
+	}
+	if _, err := getPreSharedKey("overlay1", "Device.device1", "Device.device2"); err == nil {
+		t.Errorf("Apply() kept the pruned key of device2")
+	}
+	if key, _ := getPreSharedKey("overlay1", SCC_END, "Device.device1"); key != testPreSharedKey {
+		t.Errorf("Apply() changed the key of the scc to %s", key)
+	}
+
+	// a new key of the document is applied
+	doc.Secrets = ""
+	for i, dobj := range doc.Objects {
+		if dobj.Kind == DevicePreSharedKeyKind {
+			doc.Objects[i].Spec = []byte(`{"key":"fedcba9876543210fedcba9876543210"}`)
+		}
+	}
+	res, err = mgrset.Overlay.Apply(m, &doc, "", true, true)
+	if err != nil {
+		t.Fatalf("Apply() error = %s", err.Error())
+	}
+	expected = []module.ApplyChange{{Action: module.ActionUpdate, Object: "DevicePreSharedKey/device1/SCC.local"}}
+	if !reflect.DeepEqual(res.Changes, expected) {
+		t.Errorf("Apply() changes = %v, expected %v", res.Changes, expected)
+	}
+}
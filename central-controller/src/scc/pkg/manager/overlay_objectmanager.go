@@ -44,6 +44,7 @@ const (
 	HUBTOHUB                    = "hub-to-hub"
 	HUBTODEVICE                 = "hub-to-device"
 	DEVICETODEVICE              = "device-to-device"
+	SCCTODEVICE                 = "scc-to-device"
 	BYCONFIG                    = "%config"
 	ANY                         = "%any"
 	BASE_PROTOCOL               = "TCP"
@@ -165,6 +166,10 @@ func (c *OverlayObjectManager) GetObjects(m map[string]string) ([]module.Control
 	return t, err
 }
 
+func (c *OverlayObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *OverlayObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
 	// DB Operation
 	t, err := GetDBUtils().UpdateObject(c, m, t)
@@ -209,35 +214,44 @@ func (c *OverlayObjectManager) CertName(name string) string {
 //Set up Connection between objects
 //Passing the original map resource, the two objects, connection type("hub-to-hub", "hub-to-device", "device-to-device") and namespace name.
 func (c *OverlayObjectManager) SetupConnection(m map[string]string, m1 module.ControllerObject, m2 module.ControllerObject, conntype string, namespace string, is_delegated bool) error {
-	//Get all proposals available in the overlay
 	resutil := NewResUtil()
 	hubConn := GetManagerset().HubConn
 	hub_manager := GetManagerset().Hub
 	dev_manager := GetManagerset().Device
-	cert_manager := GetManagerset().Cert
 	overlay_name := m[OverlayResource]
 
-	proposal := GetManagerset().Proposal
-	proposals, err := proposal.GetObjects(m)
-	if len(proposals) == 0 || err != nil {
-		log.Println("Missing Proposal in the overlay\n")
-		return pkgerrors.New("Error in getting proposals")
+	// Get the proposals bound to the connection type or to the devices
+	var devices []string
+	for _, o := range []module.ControllerObject{m1, m2} {
+		if dev, ok := o.(*module.DeviceObject); ok {
+			devices = append(devices, dev.Metadata.Name)
+		}
 	}
-	var all_proposals []string
-	//	var proposalresources []*resource.ProposalResource
-	for i := 0; i < len(proposals); i++ {
-		proposal_obj := proposals[i].(*module.ProposalObject)
-		all_proposals = append(all_proposals, proposal_obj.Metadata.Name)
-		pr := proposal_obj.ToResource()
-		//		proposalresources = append(proposalresources, pr)
-
-		// Add proposal resources
-		resutil.AddResource(m1, "create", pr)
-		resutil.AddResource(m2, "create", pr)
+	proposals, err := GetManagerset().ProposalSet.GetProposals(overlay_name, conntype, devices)
+	if err != nil {
+		return err
 	}
+	all_proposals := proposalNames(proposals)
+
+	// only the hub-to-device connections may use WireGuard
+	tunnel := IPSEC_TUNNEL
+	if conntype == HUBTODEVICE {
+		tunnel = getTunnelType(overlay_name)
+	}
+	if tunnel == IPSEC_TUNNEL {
+		for _, proposal_obj := range proposals {
+			pr := proposal_obj.ToResource()
+
+			// Add proposal resources
+			resutil.AddResource(m1, "create", pr)
+			resutil.AddResource(m2, "create", pr)
+		}
+	}
+
+	// with bgp, the routes are learned from the iBGP sessions over the
+	// tunnels instead of being added to every hub and device
+	routing := getRoutingMode(overlay_name)
 
-	var obj1_ipsec_resource resource.IpsecResource
-	var obj2_ipsec_resource resource.IpsecResource
 	var obj1_ip string
 	var obj2_ip string
 
@@ -249,14 +263,216 @@ func (c *OverlayObjectManager) SetupConnection(m map[string]string, m1 module.Co
 		obj1_ip = obj1.Status.Ip
 		obj2_ip = obj2.Status.Ip
 
+		if routing == BGP_ROUTING {
+			// the hubs are in a full mesh, each one reflects the routes of its devices
+			resutil.AddResource(m1, "create", bgpInstance(obj1_ip, nil, "default"))
+			resutil.AddResource(m1, "create", bgpNeighbor(obj2_ip, "", "vti_"+obj2_ip, false))
+			resutil.AddResource(m2, "create", bgpInstance(obj2_ip, nil, "default"))
+			resutil.AddResource(m2, "create", bgpNeighbor(obj1_ip, "", "vti_"+obj1_ip, false))
+		} else {
+			// for each edge connect to hub2(obj2), add Route in hub1(obj1)
+			// Todo: handle the error the route rule may fail if the vti interface is not exist
+			dev_names, _ := hubConn.GetConnectedDevices(overlay_name, obj2.Metadata.Name)
+			for _, dev_name := range dev_names {
+				log.Println(dev_name)
+				strs := strings.SplitN(dev_name, "..", 2)
+				if len(strs) == 2 {
+					log.Println("Route Rule in " + obj1.Metadata.Name + " : " + strs[1] + " via " + obj2.Metadata.Name)
+					resutil.AddResource(m1, "create", &resource.RouteResource{
+						Name:        strs[1] + "-" + obj2_ip,
+						Destination: strs[1],
+						Device:      "vti_" + obj2_ip, // Todo: use the right ifname
+						Table:       "default",        // Todo: need check
+					})
+				}
+			}
+		}
+	case HUBTODEVICE:
+		obj1 := m1.(*module.HubObject)
+		obj2 := m2.(*module.DeviceObject)
+
+		obj1_ip = obj1.Status.Ip
+		obj2_ip, _ = dev_manager.AllocateIP(m, m2, module.CreateEndName(obj1.GetType(), obj1.Metadata.Name))
+
+		if tunnel == WIREGUARD_TUNNEL {
+			hub_res, dev_res, err := wireguardResources(overlay_name, obj1, obj2, obj1_ip, obj2_ip)
+			if err != nil {
+				return err
+			}
+			for _, r := range hub_res {
+				resutil.AddResource(m1, "create", r)
+			}
+			for _, r := range dev_res {
+				resutil.AddResource(m2, "create", r)
+			}
+		}
+
+		hubName := obj1.GetType() + "." + obj1.Metadata.Name
+
+		if routing == BGP_ROUTING {
+			// the device is a route reflector client of the hub
+			hub_dev := ""
+			if tunnel == WIREGUARD_TUNNEL {
+				hub_dev = wireguardIfName(obj2_ip)
+			}
+			resutil.AddResource(m1, "create", bgpInstance(obj1_ip, nil, "default"))
+			resutil.AddResource(m1, "create", bgpNeighbor(obj2_ip, "", hub_dev, true))
+			resutil.AddResource(m2, "create", bgpInstance(obj2.Status.Ip, deviceNetworks(obj2), "cnf"))
+			resutil.AddResource(m2, "create", bgpNeighbor(obj1_ip, obj2_ip, tunnelDevice(tunnel, obj2.Status.Ip, obj1_ip), false))
+		} else {
+			// for each hub, add route (e.g. to obj2 via obj1)
+			hubs, _ := hub_manager.GetObjects(m)
+			for _, hub_obj := range hubs {
+				if hub_obj.GetMetadata().Name != obj1.GetMetadata().Name {
+					resutil.AddResource(hub_obj, "create", &resource.RouteResource{
+						Name:        obj2_ip + "-" + obj1_ip,
+						Destination: obj2_ip,
+						Device:      "vti_" + obj1_ip, // Todo: use the right ifname
+						Table:       "default",        // Todo: need check
+					})
+				}
+			}
+		}
+		// for each edge connect to obj1 (1) add route( e.g. to obj2 via obj1) (2) add SNAT (e.g. to obj2 --to-source edge ip)
+		// with bgp, the devices learn the routes of each other from the hub
+		// and reach each other without the SNAT rules
+		dev_names := []string{}
+		if routing != BGP_ROUTING {
+			dev_names, _ = hubConn.GetConnectedDevices(overlay_name, obj1.Metadata.Name)
+		}
+		mm := make(map[string]string)
+		mm[OverlayResource] = overlay_name
+
+		for _, dev_name := range dev_names {
+			strs := strings.SplitN(dev_name, "..", 2)
+			if len(strs) == 2 {
+				mm[DeviceResource] = strings.Replace(strs[0], "Device.", "", 1)
+				dev_obj, err := dev_manager.GetObject(mm)
+				if err == nil {
+					dev := dev_obj.(*module.DeviceObject)
+					log.Println("Route Rule in " + strs[0] + " : " + obj2_ip + " via " + obj1.Metadata.Name)
+					resutil.AddResource(dev_obj, "create", &resource.RouteResource{
+						Name:        obj2_ip + "-" + obj1_ip,
+						Destination: obj2_ip,
+						Device:      tunnelDevice(tunnel, dev.Status.Ip, obj1_ip), // Todo: how to get net1
+						Table:       "cnf",                                        // Todo: need check
+					})
+
+					log.Println("NAT Rule in " + strs[0] + " to " + obj2.Metadata.Name)
+					resutil.AddResource(dev_obj, "create", &resource.FirewallNatResource{
+						Name:          obj2_ip + "-" + dev.Metadata.Name,
+						DestinationIP: obj2_ip,
+						Dest:          "#source",
+						SourceDestIP:  dev.Status.DataIps[hubName],
+						Index:         "1",
+						Target:        "SNAT",
+					})
+
+					log.Println("Route Rule in " + obj2_ip + " to " + dev.Metadata.Name)
+					resutil.AddResource(obj2, "create", &resource.RouteResource{
+						Name:        dev.Status.DataIps[hubName] + "-" + obj1_ip,
+						Destination: dev.Status.DataIps[hubName],
+						Device:      tunnelDevice(tunnel, obj2.Status.Ip, obj1_ip),
+						Table:       "cnf",
+					})
+
+					log.Println("NAT Rule in " + obj2_ip + " to " + dev.Metadata.Name)
+					resutil.AddResource(obj2, "create", &resource.FirewallNatResource{
+						Name:          dev.Status.DataIps[hubName] + "-" + obj2.Metadata.Name,
+						DestinationIP: dev.Status.DataIps[hubName],
+						Dest:          "#source",
+						SourceDestIP:  obj2_ip,
+						Index:         "1",
+						Target:        "SNAT",
+					})
+
+				} else {
+					log.Println("error in getting device")
+					log.Println(err)
+				}
+			}
+		}
+
+		if is_delegated {
+			log.Println("adding rules for delegate connections")
+			// the default route goes to the primary hub, the SNAT rules of
+			// the other hubs are appended after the one of the primary hub
+			nat_name := delegateResourceName(obj2.Metadata.Name, "")
+			if obj2.Status.DelegatedHub == "" || obj2.Status.DelegatedHub == obj1.Metadata.Name {
+				resutil.AddResource(m2, "create", delegateRoute(obj2.Metadata.Name, obj1_ip, obj2_ip, tunnel))
+			} else {
+				nat_name = delegateResourceName(obj2.Metadata.Name, obj1.Metadata.Name)
+			}
+			resutil.AddResource(m2, "create", &resource.FirewallNatResource{
+				Name:         nat_name,
+				SourceDestIP: obj2_ip,
+				Dest:         "#source",
+				Index:        "0",
+				Target:       "SNAT",
+			})
+		}
+	case DEVICETODEVICE:
+		obj1 := m1.(*module.DeviceObject)
+		obj2 := m2.(*module.DeviceObject)
+
+		obj1_ip = obj1.Status.Ip
+		obj2_ip = obj2.Status.Ip
+	default:
+		return pkgerrors.New("Unknown connection type")
+	}
+
+	if tunnel == IPSEC_TUNNEL {
+		obj1_ipsec_resource, obj2_ipsec_resource, err := ipsecResources(overlay_name, m1, m2, conntype, obj1_ip, obj2_ip, all_proposals)
+		if err != nil {
+			return err
+		}
+
+		resutil.AddResource(m1, "create", obj1_ipsec_resource)
+		resutil.AddResource(m2, "create", obj2_ipsec_resource)
+	}
+
+	log.Println("cend1 ip:", obj1_ip)
+	log.Println("cend2 ip:", obj2_ip)
+	cend1 := module.NewConnectionEnd(m1, obj1_ip)
+	cend2 := module.NewConnectionEnd(m2, obj2_ip)
+	co := module.NewConnectionObject(cend1, cend2)
+
+	cm := GetConnectionManager()
+	err = cm.Deploy(m[OverlayResource], co, resutil)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Unable to create the object: fail to deploy resource")
+	}
+
+	return nil
+}
+
+// ipsecResources renders the IPsec resources of both ends of a connection
+func ipsecResources(overlay_name string, m1 module.ControllerObject, m2 module.ControllerObject, conntype string,
+	obj1_ip string, obj2_ip string, all_proposals []string) (*resource.IpsecResource, *resource.IpsecResource, error) {
+	cert_manager := GetManagerset().Cert
+
+	var obj1_ipsec_resource resource.IpsecResource
+	var obj2_ipsec_resource resource.IpsecResource
+	var device_objs []*module.DeviceObject
+	for _, o := range []module.ControllerObject{m1, m2} {
+		if dev, ok := o.(*module.DeviceObject); ok {
+			device_objs = append(device_objs, dev)
+		}
+	}
+
+	switch conntype {
+	case HUBTOHUB:
+		obj1 := m1.(*module.HubObject)
+		obj2 := m2.(*module.HubObject)
+
 		//Keypair
 		obj1_ca, obj1_crt, obj1_key, err := cert_manager.GetOrCreateCertificateByType(overlay_name, obj1.Metadata.Name, HubKey, false)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		obj2_ca, obj2_crt, obj2_key, err := cert_manager.GetOrCreateCertificateByType(overlay_name, obj2.Metadata.Name, HubKey, false)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		//IpsecResources
@@ -308,34 +524,14 @@ func (c *OverlayObjectManager) SetupConnection(m map[string]string, m1 module.Co
 			ForceCryptoProposal:  FORCECRYPTOPROPOSAL,
 			Connections:          conn2,
 		}
-
-		// for each edge connect to hub2(obj2), add Route in hub1(obj1)
-		// Todo: handle the error the route rule may fail if the vti interface is not exist
-		dev_names, _ := hubConn.GetConnectedDevices(overlay_name, obj2.Metadata.Name)
-		for _, dev_name := range dev_names {
-			log.Println(dev_name)
-			strs := strings.SplitN(dev_name, "..", 2)
-			if len(strs) == 2 {
-				log.Println("Route Rule in " + obj1.Metadata.Name + " : " + strs[1] + " via " + obj2.Metadata.Name)
-				resutil.AddResource(m1, "create", &resource.RouteResource{
-					Name:        strs[1] + "-" + obj2_ip,
-					Destination: strs[1],
-					Device:      "vti_" + obj2_ip, // Todo: use the right ifname
-					Table:       "default",        // Todo: need check
-				})
-			}
-		}
 	case HUBTODEVICE:
 		obj1 := m1.(*module.HubObject)
 		obj2 := m2.(*module.DeviceObject)
 
-		obj1_ip = obj1.Status.Ip
-		obj2_ip, _ = dev_manager.AllocateIP(m, m2, module.CreateEndName(obj1.GetType(), obj1.Metadata.Name))
-
 		//Keypair
 		obj1_ca, obj1_crt, obj1_key, err := cert_manager.GetOrCreateCertificateByType(overlay_name, obj1.Metadata.Name, HubKey, false)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		obj1_conn := resource.Connection{
@@ -364,9 +560,9 @@ func (c *OverlayObjectManager) SetupConnection(m map[string]string, m1 module.Co
 			Connections:          obj1_conn,
 		}
 
-		obj2_ca, obj2_crt, obj2_key, err := cert_manager.GetOrCreateCertificateByType(overlay_name, obj2.Metadata.Name, DeviceKey, false)
+		obj2_ca, obj2_crt, obj2_key, err := getDeviceKeypair(overlay_name, obj2)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		//IpsecResources
@@ -393,108 +589,18 @@ func (c *OverlayObjectManager) SetupConnection(m map[string]string, m1 module.Co
 			ForceCryptoProposal:  FORCECRYPTOPROPOSAL,
 			Connections:          obj2_conn,
 		}
-
-		hubName := obj1.GetType() + "." + obj1.Metadata.Name
-
-		// for each hub, add route (e.g. to obj2 via obj1)
-		hubs, _ := hub_manager.GetObjects(m)
-		for _, hub_obj := range hubs {
-			if hub_obj.GetMetadata().Name != obj1.GetMetadata().Name {
-				resutil.AddResource(hub_obj, "create", &resource.RouteResource{
-					Name:        obj2_ip + "-" + obj1_ip,
-					Destination: obj2_ip,
-					Device:      "vti_" + obj1_ip, // Todo: use the right ifname
-					Table:       "default",        // Todo: need check
-				})
-			}
-		}
-		// for each edge connect to obj1 (1) add route( e.g. to obj2 via obj1) (2) add SNAT (e.g. to obj2 --to-source edge ip)
-		dev_names, _ := hubConn.GetConnectedDevices(overlay_name, obj1.Metadata.Name)
-		mm := make(map[string]string)
-		mm[OverlayResource] = overlay_name
-
-		for _, dev_name := range dev_names {
-			strs := strings.SplitN(dev_name, "..", 2)
-			if len(strs) == 2 {
-				mm[DeviceResource] = strings.Replace(strs[0], "Device.", "", 1)
-				dev_obj, err := dev_manager.GetObject(mm)
-				dev := dev_obj.(*module.DeviceObject)
-				if err == nil {
-					log.Println("Route Rule in " + strs[0] + " : " + obj2_ip + " via " + obj1.Metadata.Name)
-					resutil.AddResource(dev_obj, "create", &resource.RouteResource{
-						Name:        obj2_ip + "-" + obj1_ip,
-						Destination: obj2_ip,
-						Device:      "#" + dev.Status.Ip, // Todo: how to get net1
-						Table:       "cnf",               // Todo: need check
-					})
-
-					log.Println("NAT Rule in " + strs[0] + " to " + obj2.Metadata.Name)
-					resutil.AddResource(dev_obj, "create", &resource.FirewallNatResource{
-						Name:          obj2_ip + "-" + dev.Metadata.Name,
-						DestinationIP: obj2_ip,
-						Dest:          "#source",
-						SourceDestIP:  dev.Status.DataIps[hubName],
-						Index:         "1",
-						Target:        "SNAT",
-					})
-
-					log.Println("Route Rule in " + obj2_ip + " to " + dev.Metadata.Name)
-					resutil.AddResource(obj2, "create", &resource.RouteResource{
-						Name:        dev.Status.DataIps[hubName] + "-" + obj1_ip,
-						Destination: dev.Status.DataIps[hubName],
-						Device:      "#" + obj2.Status.Ip,
-						Table:       "cnf",
-					})
-
-					log.Println("NAT Rule in " + obj2_ip + " to " + dev.Metadata.Name)
-					resutil.AddResource(obj2, "create", &resource.FirewallNatResource{
-						Name:          dev.Status.DataIps[hubName] + "-" + obj2.Metadata.Name,
-						DestinationIP: dev.Status.DataIps[hubName],
-						Dest:          "#source",
-						SourceDestIP:  obj2_ip,
-						Index:         "1",
-						Target:        "SNAT",
-					})
-
-				} else {
-					log.Println("error in getting device")
-					log.Println(err)
-				}
-			}
-		}
-
-		if is_delegated {
-			log.Println("adding rules for delegate connections")
-			resutil.AddResource(m2, "create", &resource.RouteResource{
-				Name:        "default4" + obj2.Metadata.Name,
-				Destination: "default",
-				Gateway:     obj1_ip,
-				Device:      "#" + obj2_ip,
-				Table:       "cnf",
-			})
-			resutil.AddResource(m2, "create", &resource.FirewallNatResource{
-				Name:         "default4" + obj2.Metadata.Name,
-				SourceDestIP: obj2_ip,
-				Dest:         "#source",
-				Index:        "0",
-				Target:       "SNAT",
-			})
-		}
 	case DEVICETODEVICE:
 		obj1 := m1.(*module.DeviceObject)
 		obj2 := m2.(*module.DeviceObject)
 
-		obj1_ip = obj1.Status.Ip
-		obj2_ip = obj2.Status.Ip
-
 		//Keypair
-		obj1_ca, obj1_crt, obj1_key, err := cert_manager.GetOrCreateCertificateByType(overlay_name, obj1.Metadata.Name, DeviceKey, false)
+		obj1_ca, obj1_crt, obj1_key, err := getDeviceKeypair(overlay_name, obj1)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		obj2_ca, obj2_crt, obj2_key, err := cert_manager.GetOrCreateCertificateByType(overlay_name, obj2.Metadata.Name, DeviceKey, false)
+		obj2_ca, obj2_crt, obj2_key, err := getDeviceKeypair(overlay_name, obj2)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		conn := resource.Connection{
@@ -534,25 +640,62 @@ func (c *OverlayObjectManager) SetupConnection(m map[string]string, m1 module.Co
 			Connections:          conn,
 		}
 	default:
-		return pkgerrors.New("Unknown connection type")
+		return nil, nil, pkgerrors.New("Unknown connection type")
 	}
 
-	resutil.AddResource(m1, "create", &obj1_ipsec_resource)
-	resutil.AddResource(m2, "create", &obj2_ipsec_resource)
+	// Use a pre-shared key if required by the overlay or the devices
+	if getAuthMode(overlay_name, device_objs) == PSK_AUTH {
+		key, err := GetOrCreatePreSharedKey(overlay_name,
+			module.CreateEndName(m1.GetType(), m1.GetMetadata().Name),
+			module.CreateEndName(m2.GetType(), m2.GetMetadata().Name))
+		if err != nil {
+			return nil, nil, err
+		}
+		usePreSharedKey(&obj1_ipsec_resource, key)
+		usePreSharedKey(&obj2_ipsec_resource, key)
+	}
 
-	log.Println("cend1 ip:", obj1_ip)
-	log.Println("cend2 ip:", obj2_ip)
-	cend1 := module.NewConnectionEnd(m1, obj1_ip)
-	cend2 := module.NewConnectionEnd(m2, obj2_ip)
-	co := module.NewConnectionObject(cend1, cend2)
+	return &obj1_ipsec_resource, &obj2_ipsec_resource, nil
+}
 
-	cm := GetConnectionManager()
-	err = cm.Deploy(m[OverlayResource], co, resutil)
-	if err != nil {
-		return pkgerrors.Wrap(err, "Unable to create the object: fail to deploy resource")
+func delegateResourceName(device string, hub string) string {
+	if hub == "" {
+		return "default4" + device
 	}
+	return "default4" + device + "-" + hub
+}
 
-	return nil
+// delegateRoute returns the default route of a device through a hub, there
+// is no gateway on a WireGuard interface
+func delegateRoute(device string, hub_ip string, device_ip string, tunnel string) *resource.RouteResource {
+	gateway := hub_ip
+	if tunnel == WIREGUARD_TUNNEL {
+		gateway = ""
+	}
+	return &resource.RouteResource{
+		Name:        delegateResourceName(device, ""),
+		Destination: "default",
+		Gateway:     gateway,
+		Device:      "#" + device_ip,
+		Table:       "cnf",
+	}
+}
+
+// SetupDelegateRoute moves the default route of a device to a new primary
+// delegate hub, a route already deployed is updated in place
+func (c *OverlayObjectManager) SetupDelegateRoute(m map[string]string, hub *module.HubObject, dev *module.DeviceObject) error {
+	resutil := NewResUtil()
+	resutil.AddResource(dev, "create", delegateRoute(dev.Metadata.Name, hub.Status.Ip,
+		dev.Status.DataIps[module.CreateEndName(hub.GetType(), hub.Metadata.Name)], getTunnelType(m[OverlayResource])))
+	return resutil.DeployUpdate(m[OverlayResource], "delegate"+dev.Metadata.Name, "YAML", true)
+}
+
+// DeleteDelegateRoute removes the default route of a device to its primary
+// delegate hub
+func (c *OverlayObjectManager) DeleteDelegateRoute(m map[string]string, dev *module.DeviceObject) error {
+	resutil := NewResUtil()
+	resutil.AddResource(dev, "delete", &resource.EmptyResource{Name: delegateResourceName(dev.Metadata.Name, ""), Type: "Route"})
+	return resutil.Undeploy(m[OverlayResource])
 }
 
 func (c *OverlayObjectManager) DeleteConnection(m map[string]string, conn module.ConnectionObject) error {
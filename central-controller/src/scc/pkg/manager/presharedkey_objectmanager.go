@@ -0,0 +1,487 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+const (
+	PSK_AUTH   = "psk"
+	PSK_LENGTH = 32
+	// end name of the scc in the connections of the devices in mode 2
+	SCC_END = "SCC.local"
+)
+
+type PreSharedKeyObjectKey struct {
+	OverlayName string `json:"overlay-name"`
+	End1        string `json:"end1-name"`
+	End2        string `json:"end2-name"`
+}
+
+// PreSharedKeyObjectManager implements the ControllerObjectManager, the keys
+// can only be read or rotated through the API. They are created and deleted
+// by the import and the apply of the overlay documents
+type PreSharedKeyObjectManager struct {
+	BaseObjectManager
+	isHub bool
+}
+
+func NewPreSharedKeyObjectManager(isHub bool) *PreSharedKeyObjectManager {
+	object_meta := "device-presharedkey"
+	if isHub {
+		object_meta = "hub-presharedkey"
+	}
+
+	return &PreSharedKeyObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        object_meta,
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+		isHub,
+	}
+}
+
+func (c *PreSharedKeyObjectManager) GetResourceName() string {
+	return PreSharedKeyResource
+}
+
+func (c *PreSharedKeyObjectManager) IsOperationSupported(oper string) bool {
+	if oper == "GETS" || oper == "GET" || oper == "PUT" {
+		return true
+	}
+	return false
+}
+
+func (c *PreSharedKeyObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.PreSharedKeyObject{}
+}
+
+// keys are shared by both ends, so they are stored with the ends sorted
+func pskStoreKey(overlay string, end1 string, end2 string) PreSharedKeyObjectKey {
+	if end1 > end2 {
+		end1, end2 = end2, end1
+	}
+
+	return PreSharedKeyObjectKey{
+		OverlayName: overlay,
+		End1:        end1,
+		End2:        end2,
+	}
+}
+
+func (c *PreSharedKeyObjectManager) endName(m map[string]string) string {
+	if c.isHub {
+		return module.CreateEndName("Hub", m[HubResource])
+	}
+	return module.CreateEndName("Device", m[DeviceResource])
+}
+
+func (c *PreSharedKeyObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	if isCollection == true {
+		return pskStoreKey(m[OverlayResource], c.endName(m), ""), nil
+	}
+
+	to := t.(*module.PreSharedKeyObject)
+	meta_name := to.Metadata.Name
+	res_name := m[PreSharedKeyResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return PreSharedKeyObjectKey{}, pkgerrors.New("Resource name unmatched metadata name")
+		}
+	} else {
+		if meta_name == "" {
+			return PreSharedKeyObjectKey{}, pkgerrors.New("Unable to find resource name")
+		}
+		res_name = meta_name
+	}
+
+	return pskStoreKey(m[OverlayResource], c.endName(m), res_name), nil
+}
+
+func (c *PreSharedKeyObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.PreSharedKeyObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+// CreateObject sets the key of a connection before the connection is set up,
+// the key of a connection set up already is rotated. The key is set by the
+// end which owns it, like it is exported
+func (c *PreSharedKeyObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	overlay := m[OverlayResource]
+	end := c.endName(m)
+	peer := t.GetMetadata().Name
+
+	if !ownsPreSharedKey(end, peer) {
+		return c.CreateEmptyObject(), pkgerrors.New("The key of " + end + " and " + peer + " is set by " + peer)
+	}
+	key := t.(*module.PreSharedKeyObject).Specification.Key
+	if key == "" {
+		return c.CreateEmptyObject(), pkgerrors.New("The key of " + peer + " is required")
+	}
+
+	m[PreSharedKeyResource] = peer
+	_, err := getPreSharedKey(overlay, end, peer)
+	if err == nil {
+		return c.UpdateObject(m, t)
+	}
+
+	proposal_mux.Lock()
+	err = setPreSharedKey(overlay, end, peer, key)
+	proposal_mux.Unlock()
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	return c.GetObject(m)
+}
+
+func (c *PreSharedKeyObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	peer := m[PreSharedKeyResource]
+	key, err := getPreSharedKey(m[OverlayResource], c.endName(m), peer)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	return &module.PreSharedKeyObject{
+		Metadata:      module.ObjectMetaData{Name: peer},
+		Specification: module.PreSharedKeyObjectSpec{Key: key},
+	}, nil
+}
+
+func (c *PreSharedKeyObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	overlay := m[OverlayResource]
+	end := c.endName(m)
+
+	var resp []module.ControllerObject
+	for _, key := range []PreSharedKeyObjectKey{{overlay, end, ""}, {overlay, "", end}} {
+		values, err := db.DBconn.Find(c.GetStoreName(), key, "presharedkey")
+		if err != nil {
+			return []module.ControllerObject{}, pkgerrors.Wrap(err, "Get PreSharedKey Objects")
+		}
+
+		for _, value := range values {
+			t := c.CreateEmptyObject().(*module.PreSharedKeyObject)
+			err = db.DBconn.Unmarshal(value, t)
+			if err != nil {
+				return []module.ControllerObject{}, pkgerrors.Wrap(err, "Unmarshaling values")
+			}
+			t.Metadata.Name = t.GetPeer(end)
+			t.Specification.Ends = nil
+			resp = append(resp, t)
+		}
+	}
+
+	return resp, nil
+}
+
+// UpdateObject rotates the key of a connection, a new key is generated
+// unless one is provided
+func (c *PreSharedKeyObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	overlay := m[OverlayResource]
+	end := c.endName(m)
+	peer := m[PreSharedKeyResource]
+
+	proposal_mux.Lock()
+	defer proposal_mux.Unlock()
+
+	_, err := getPreSharedKey(overlay, end, peer)
+	if err != nil {
+		return c.CreateEmptyObject(), pkgerrors.New("No psk connection between " + end + " and " + peer)
+	}
+
+	key := t.(*module.PreSharedKeyObject).Specification.Key
+	if key == "" {
+		key, err = generatePreSharedKey()
+		if err != nil {
+			return c.CreateEmptyObject(), err
+		}
+	}
+
+	err = setPreSharedKey(overlay, end, peer, key)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	if end == SCC_END || peer == SCC_END {
+		_, dev_name := module.ParseEndName(end)
+		if end == SCC_END {
+			_, dev_name = module.ParseEndName(peer)
+		}
+		err = updateSccPreSharedKey(overlay, dev_name, key)
+	} else {
+		err = updateConnectionPreSharedKey(overlay, end, peer, key)
+	}
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), pkgerrors.Wrap(err, "Fail to deploy the new key")
+	}
+
+	return c.GetObject(m)
+}
+
+// DeleteObject drops the key set for a connection, a new key is generated
+// for a connection which is still set up
+func (c *PreSharedKeyObjectManager) DeleteObject(m map[string]string) error {
+	overlay := m[OverlayResource]
+	end := c.endName(m)
+	peer := m[PreSharedKeyResource]
+
+	_, err := getPreSharedKey(overlay, end, peer)
+	if err != nil {
+		return pkgerrors.New("No psk connection between " + end + " and " + peer)
+	}
+
+	if !pskConnectionExists(overlay, end, peer) {
+		DeletePreSharedKey(overlay, end, peer)
+		return nil
+	}
+
+	_, err = c.UpdateObject(m, c.CreateEmptyObject())
+	return err
+}
+
+// pskConnectionExists checks whether the connection of a key is set up, the
+// connection of the scc exists as long as the device
+func pskConnectionExists(overlay string, end string, peer string) bool {
+	if end == SCC_END || peer == SCC_END {
+		_, dev_name := module.ParseEndName(end)
+		if end == SCC_END {
+			_, dev_name = module.ParseEndName(peer)
+		}
+		m := map[string]string{OverlayResource: overlay, DeviceResource: dev_name}
+		_, err := GetManagerset().Device.GetObject(m)
+		return err == nil
+	}
+
+	_, err := GetConnectionManager().GetObject(overlay, end, peer)
+	return err == nil
+}
+
+// ownsPreSharedKey checks whether the end owns the key shared with the peer:
+// a hub owns the keys of its devices, a device owns the key of the scc, and
+// the end with the lower name owns the key of two hubs or two devices
+func ownsPreSharedKey(end string, peer string) bool {
+	rank := func(name string) int {
+		switch t, _ := module.ParseEndName(name); t {
+		case "Hub":
+			return 0
+		case "Device":
+			return 1
+		}
+		return 2
+	}
+
+	if rank(end) != rank(peer) {
+		return rank(end) < rank(peer)
+	}
+	return end < peer
+}
+
+func generatePreSharedKey() (string, error) {
+	b := make([]byte, PSK_LENGTH)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Fail to generate pre-shared key")
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func getPreSharedKey(overlay string, end1 string, end2 string) (string, error) {
+	value, err := db.DBconn.Find(StoreName, pskStoreKey(overlay, end1, end2), "presharedkey")
+	if err != nil {
+		return "", err
+	}
+
+	if len(value) == 0 {
+		return "", pkgerrors.New("No Object")
+	}
+
+	var obj module.PreSharedKeyObject
+	err = db.DBconn.Unmarshal(value[0], &obj)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Unmarshaling value")
+	}
+
+	return obj.Specification.Key, nil
+}
+
+func setPreSharedKey(overlay string, end1 string, end2 string, key string) error {
+	k := pskStoreKey(overlay, end1, end2)
+	obj := module.PreSharedKeyObject{
+		Metadata: module.ObjectMetaData{Name: module.CreateConnectionName(k.End1, k.End2)},
+		Specification: module.PreSharedKeyObjectSpec{
+			Key:  key,
+			Ends: []string{k.End1, k.End2},
+		},
+	}
+
+	err := db.DBconn.Insert(StoreName, k, nil, "presharedkey", obj)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Unable to save pre-shared key")
+	}
+
+	return nil
+}
+
+// GetOrCreatePreSharedKey returns the key of a connection, a new key is
+// generated for a new connection
+func GetOrCreatePreSharedKey(overlay string, end1 string, end2 string) (string, error) {
+	key, err := getPreSharedKey(overlay, end1, end2)
+	if err == nil && key != "" {
+		return key, nil
+	}
+
+	key, err = generatePreSharedKey()
+	if err != nil {
+		return "", err
+	}
+
+	return key, setPreSharedKey(overlay, end1, end2, key)
+}
+
+func DeletePreSharedKey(overlay string, end1 string, end2 string) {
+	err := db.DBconn.Remove(StoreName, pskStoreKey(overlay, end1, end2))
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// usePreSharedKey switches an ipsec resource to psk authentication
+func usePreSharedKey(r *resource.IpsecResource, key string) {
+	r.AuthenticationMethod = PSK_AUTH
+	r.PresharedKey = key
+	r.PublicCert = ""
+	r.PrivateCert = ""
+	r.SharedCA = ""
+}
+
+// getAuthMode returns the authentication method for a connection: psk if
+// required by one of the devices (or by the overlay), pubkey otherwise
+func getAuthMode(overlay string, devices []*module.DeviceObject) string {
+	overlay_mode := PUBKEY_AUTH
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	o, err := GetManagerset().Overlay.GetObject(m)
+	if err == nil && o.(*module.OverlayObject).Specification.AuthMode != "" {
+		overlay_mode = o.(*module.OverlayObject).Specification.AuthMode
+	}
+
+	if len(devices) == 0 {
+		return overlay_mode
+	}
+
+	for _, dev := range devices {
+		mode := dev.Specification.AuthMode
+		if mode == "" {
+			mode = overlay_mode
+		}
+		if mode == PSK_AUTH {
+			return PSK_AUTH
+		}
+	}
+
+	return PUBKEY_AUTH
+}
+
+func updateConnectionPreSharedKey(overlay string, end1 string, end2 string, key string) error {
+	conn_manager := GetConnectionManager()
+	co, err := conn_manager.GetObject(overlay, end1, end2)
+	if err != nil {
+		return err
+	}
+
+	conn := co.(*module.ConnectionObject)
+	resutil := NewResUtil()
+	for i, r := range conn.Info.Resources {
+		if r.Type != "Ipsec" || r.Data == "" {
+			continue
+		}
+
+		dev, err := module.GetObjectBuilder().ToObject(r.ConnObject)
+		if err != nil {
+			return err
+		}
+
+		res, err := resource.GetResourceBuilder().ToObject(r.Data)
+		if err != nil {
+			return err
+		}
+
+		ipsec := res.(*resource.IpsecResource)
+		usePreSharedKey(ipsec, key)
+		conn.Info.Resources[i].Data, _ = resource.GetResourceBuilder().ToString(ipsec)
+		resutil.AddResource(dev, "create", ipsec)
+	}
+
+	err = resutil.DeployUpdate(overlay, conn.Metadata.Name, "YAML", true)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn_manager.UpdateObject(overlay, *conn)
+	return err
+}
+
+func updateSccPreSharedKey(overlay string, dev_name string, key string) error {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	m[DeviceResource] = dev_name
+
+	dev_manager := GetManagerset().Device
+	dev, err := dev_manager.GetObject(m)
+	if err != nil {
+		return err
+	}
+
+	to := dev.(*module.DeviceObject)
+	res, err := resource.GetResourceBuilder().ToObject(to.Status.Data[SCC_RESOURCE])
+	if err != nil {
+		return err
+	}
+
+	ipsec := res.(*resource.IpsecResource)
+	usePreSharedKey(ipsec, key)
+
+	scc := module.EmptyObject{
+		Metadata: module.ObjectMetaData{Name: "local"}}
+	resutil := NewResUtil()
+	resutil.AddResource(&scc, "create", ipsec)
+	err = resutil.DeployUpdate(globalOverlay, overlay+"localto"+dev_name, "YAML", true)
+	if err != nil {
+		return err
+	}
+
+	to.Status.Data[SCC_RESOURCE], _ = resource.GetResourceBuilder().ToString(ipsec)
+	_, err = dev_manager.UpdateObject(m, to)
+	return err
+}
@@ -18,7 +18,9 @@ package manager
 
 import (
 	"encoding/json"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/infra/validation"
 	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/go-playground/validator/v10"
 	pkgerrors "github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"io"
@@ -35,6 +37,17 @@ type ProposalObjectManager struct {
 }
 
 func NewProposalObjectManager() *ProposalObjectManager {
+	validate := validation.GetValidator("proposal")
+	validate.RegisterValidation("ipsec_encryption", func(fl validator.FieldLevel) bool {
+		return module.IsValidEncryption(fl.Field().String())
+	})
+	validate.RegisterValidation("ipsec_hash", func(fl validator.FieldLevel) bool {
+		return module.IsValidHash(fl.Field().String())
+	})
+	validate.RegisterValidation("ipsec_dhgroup", func(fl validator.FieldLevel) bool {
+		return module.IsValidDhGroup(fl.Field().String())
+	})
+
 	return &ProposalObjectManager{
 		BaseObjectManager{
 			storeName:      StoreName,
@@ -99,6 +112,9 @@ func (c *ProposalObjectManager) ParseObject(r io.Reader) (module.ControllerObjec
 func (c *ProposalObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
 	// DB Operation
 	t, err := GetDBUtils().CreateObject(c, m, t)
+	if err == nil {
+		updateConnectionProposals(m[OverlayResource])
+	}
 
 	return t, err
 }
@@ -117,16 +133,39 @@ func (c *ProposalObjectManager) GetObjects(m map[string]string) ([]module.Contro
 	return t, err
 }
 
+func (c *ProposalObjectManager) IsStoreListed() bool {
+	return true
+}
+
 func (c *ProposalObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
 	// DB Operation
 	t, err := GetDBUtils().UpdateObject(c, m, t)
+	if err == nil {
+		updateConnectionProposals(m[OverlayResource])
+	}
 
 	return t, err
 }
 
 func (c *ProposalObjectManager) DeleteObject(m map[string]string) error {
+	set_manager := GetManagerset().ProposalSet
+	if set_manager != nil && set_manager.IsProposalInUse(m[OverlayResource], m[ProposalResource]) {
+		return pkgerrors.New("Proposal " + m[ProposalResource] + " is used by a proposal set")
+	}
+
 	// DB Operation
 	err := GetDBUtils().DeleteObject(c, m)
+	if err == nil {
+		updateConnectionProposals(m[OverlayResource])
+	}
 
 	return err
 }
+
+// updateConnectionProposals re-renders the IPsec resources of the overlay
+func updateConnectionProposals(overlay string) {
+	set_manager := GetManagerset().ProposalSet
+	if set_manager != nil {
+		set_manager.UpdateConnections(overlay)
+	}
+}
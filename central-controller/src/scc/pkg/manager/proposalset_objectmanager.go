@@ -0,0 +1,560 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+// serialize the re-rendering of the IPsec resources
+var proposal_mux = sync.Mutex{}
+
+type ProposalSetObjectKey struct {
+	OverlayName     string `json:"overlay-name"`
+	ProposalSetName string `json:"proposal-set-name"`
+}
+
+// ProposalSetObjectManager implements the ControllerObjectManager
+type ProposalSetObjectManager struct {
+	BaseObjectManager
+}
+
+func NewProposalSetObjectManager() *ProposalSetObjectManager {
+	return &ProposalSetObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        "proposalset",
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+	}
+}
+
+func (c *ProposalSetObjectManager) GetResourceName() string {
+	return ProposalSetResource
+}
+
+func (c *ProposalSetObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *ProposalSetObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.ProposalSetObject{}
+}
+
+func (c *ProposalSetObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	overlay_name := m[OverlayResource]
+	key := ProposalSetObjectKey{
+		OverlayName:     overlay_name,
+		ProposalSetName: "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	to := t.(*module.ProposalSetObject)
+	meta_name := to.Metadata.Name
+	res_name := m[ProposalSetResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.ProposalSetName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.ProposalSetName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *ProposalSetObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.ProposalSetObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+func (c *ProposalSetObjectManager) checkProposals(m map[string]string, to *module.ProposalSetObject) error {
+	proposal_manager := GetManagerset().Proposal
+	pm := make(map[string]string)
+	pm[OverlayResource] = m[OverlayResource]
+
+	for _, p := range to.Specification.Proposals {
+		pm[ProposalResource] = p
+		_, err := proposal_manager.GetObject(pm)
+		if err != nil {
+			return pkgerrors.New("Proposal " + p + " is not defined in the overlay")
+		}
+	}
+
+	return nil
+}
+
+func (c *ProposalSetObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	err := c.checkProposals(m, t.(*module.ProposalSetObject))
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().CreateObject(c, m, t)
+	if err == nil {
+		c.UpdateConnections(m[OverlayResource])
+	}
+
+	return t, err
+}
+
+func (c *ProposalSetObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *ProposalSetObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *ProposalSetObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *ProposalSetObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	err := c.checkProposals(m, t.(*module.ProposalSetObject))
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().UpdateObject(c, m, t)
+	if err == nil {
+		c.UpdateConnections(m[OverlayResource])
+	}
+
+	return t, err
+}
+
+func (c *ProposalSetObjectManager) DeleteObject(m map[string]string) error {
+	// DB Operation
+	err := GetDBUtils().DeleteObject(c, m)
+	if err == nil {
+		c.UpdateConnections(m[OverlayResource])
+	}
+
+	return err
+}
+
+// IsProposalInUse checks whether a proposal is referenced by a proposal set
+func (c *ProposalSetObjectManager) IsProposalInUse(overlay string, proposal string) bool {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+
+	sets, err := c.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+
+	for _, s := range sets {
+		if s.(*module.ProposalSetObject).HasProposal(proposal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetProposals returns the proposals for a connection of the given type.
+// Sets bound to one of the devices take precedence over the sets bound to
+// the connection type. All the proposals of the overlay are used if no set
+// applies.
+func (c *ProposalSetObjectManager) GetProposals(overlay string, conntype string, devices []string) ([]*module.ProposalObject, error) {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+
+	proposals, err := GetManagerset().Proposal.GetObjects(m)
+	if len(proposals) == 0 || err != nil {
+		log.Println("Missing Proposal in the overlay")
+		return nil, pkgerrors.New("Error in getting proposals")
+	}
+
+	proposal_map := make(map[string]*module.ProposalObject)
+	for _, p := range proposals {
+		proposal_map[p.GetMetadata().Name] = p.(*module.ProposalObject)
+	}
+
+	sets, err := c.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+	}
+
+	var names []string
+	for _, dev := range devices {
+		for _, s := range sets {
+			so := s.(*module.ProposalSetObject)
+			if so.HasDevice(dev) {
+				names = append(names, so.Specification.Proposals...)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		for _, s := range sets {
+			so := s.(*module.ProposalSetObject)
+			if so.HasConnectionType(conntype) {
+				names = append(names, so.Specification.Proposals...)
+			}
+		}
+	}
+
+	var ret []*module.ProposalObject
+	if len(names) == 0 {
+		for _, p := range proposals {
+			ret = append(ret, p.(*module.ProposalObject))
+		}
+		return ret, nil
+	}
+
+	added := make(map[string]bool)
+	for _, n := range names {
+		p, ok := proposal_map[n]
+		if !ok {
+			log.Println("Proposal " + n + " is not defined in the overlay")
+			continue
+		}
+		if !added[n] {
+			added[n] = true
+			ret = append(ret, p)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, pkgerrors.New("No proposal available for " + conntype + " connection")
+	}
+
+	return ret, nil
+}
+
+func proposalNames(proposals []*module.ProposalObject) []string {
+	var names []string
+	for _, p := range proposals {
+		names = append(names, p.Metadata.Name)
+	}
+
+	return names
+}
+
+func getConnectionType(conn *module.ConnectionObject) (string, []string) {
+	t1, n1 := module.ParseEndName(conn.Info.End1.Name)
+	t2, n2 := module.ParseEndName(conn.Info.End2.Name)
+
+	switch {
+	case t1 == "Hub" && t2 == "Hub":
+		return HUBTOHUB, []string{}
+	case t1 == "Device" && t2 == "Device":
+		return DEVICETODEVICE, []string{n1, n2}
+	case t1 == "Device":
+		return HUBTODEVICE, []string{n1}
+	default:
+		return HUBTODEVICE, []string{n2}
+	}
+}
+
+// UpdateConnections re-renders the IPsec resources of the overlay whenever
+// the proposals or the proposal sets change
+func (c *ProposalSetObjectManager) UpdateConnections(overlay string) {
+	proposal_mux.Lock()
+	defer proposal_mux.Unlock()
+
+	conns, err := GetConnectionManager().GetOverlayObjects(overlay)
+	if err != nil {
+		log.Println(err)
+	}
+
+	for _, conn := range conns {
+		err = c.updateConnection(overlay, conn.(*module.ConnectionObject))
+		if err != nil {
+			log.Println("Fail to update proposals of connection " + conn.GetMetadata().Name + ": " + err.Error())
+		}
+	}
+
+	// devices connected through the scc
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	devices, err := GetManagerset().Device.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+	}
+
+	for _, dev := range devices {
+		to := dev.(*module.DeviceObject)
+		if to.Status.Mode != 2 || to.Status.Data[SCC_RESOURCE] == "" {
+			continue
+		}
+
+		err = c.updateSccConnection(overlay, to)
+		if err != nil {
+			log.Println("Fail to update proposals of device " + to.Metadata.Name + ": " + err.Error())
+		}
+	}
+}
+
+func setIpsecProposals(data string, names []string) (*resource.IpsecResource, []string, error) {
+	r, err := resource.GetResourceBuilder().ToObject(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ipsec, ok := r.(*resource.IpsecResource)
+	if !ok {
+		return nil, nil, pkgerrors.New("Not an ipsec resource")
+	}
+
+	old := ipsec.CryptoProposal
+	ipsec.CryptoProposal = names
+	ipsec.Connections.CryptoProposal = names
+
+	return ipsec, old, nil
+}
+
+// getConnectionEnd returns the current hub or device object of a connection end
+func getConnectionEnd(overlay string, end module.ConnectionEnd) (module.ControllerObject, error) {
+	t, name := module.ParseEndName(end.Name)
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	if t == "Hub" {
+		m[HubResource] = name
+		return GetManagerset().Hub.GetObject(m)
+	}
+
+	m[DeviceResource] = name
+	return GetManagerset().Device.GetObject(m)
+}
+
+// rebuildIpsecResources renders again the IPsec resources of a connection
+// from its hub and device objects, they are returned by resource name
+func rebuildIpsecResources(overlay string, conn *module.ConnectionObject, conntype string, names []string) (map[string]*resource.IpsecResource, error) {
+	end1 := conn.Info.End1
+	end2 := conn.Info.End2
+	if conntype == HUBTODEVICE && end1.Type == "Device" {
+		end1, end2 = end2, end1
+	}
+
+	m1, err := getConnectionEnd(overlay, end1)
+	if err != nil {
+		return nil, err
+	}
+	m2, err := getConnectionEnd(overlay, end2)
+	if err != nil {
+		return nil, err
+	}
+
+	r1, r2, err := ipsecResources(overlay, m1, m2, conntype, end1.IP, end2.IP, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]*resource.IpsecResource{r1.Name: r1, r2.Name: r2}, nil
+}
+
+func (c *ProposalSetObjectManager) updateConnection(overlay string, conn *module.ConnectionObject) error {
+	conntype, devices := getConnectionType(conn)
+	proposals, err := c.GetProposals(overlay, conntype, devices)
+	if err != nil {
+		return err
+	}
+
+	names := proposalNames(proposals)
+	selected := make(map[string]*module.ProposalObject)
+	for _, p := range proposals {
+		selected[p.Metadata.Name] = p
+	}
+
+	add_util := NewResUtil()
+	update_util := NewResUtil()
+	delete_util := NewResUtil()
+
+	// proposals already deployed for the connection, per cluster
+	deployed := make(map[string]map[string]bool)
+	ipsec_clusters := make(map[string]module.ControllerObject)
+	var resources []module.ConnectionResource
+	var rebuilt map[string]*resource.IpsecResource
+
+	for _, r := range conn.Info.Resources {
+		co, err := module.GetObjectBuilder().ToObject(r.ConnObject)
+		if err != nil {
+			log.Println(err)
+			resources = append(resources, r)
+			continue
+		}
+
+		switch r.Type {
+		case "Proposal":
+			if p, ok := selected[r.Name]; ok {
+				update_util.AddResource(co, "create", p.ToResource())
+				if deployed[r.ConnObject] == nil {
+					deployed[r.ConnObject] = make(map[string]bool)
+				}
+				deployed[r.ConnObject][r.Name] = true
+				resources = append(resources, r)
+			} else {
+				delete_util.AddResource(co, "delete", &resource.EmptyResource{Name: r.Name, Type: r.Type})
+			}
+		case "Ipsec":
+			var ipsec *resource.IpsecResource
+			if r.Data == "" {
+				// the connection was set up before the resources were kept
+				if rebuilt == nil {
+					rebuilt, err = rebuildIpsecResources(overlay, conn, conntype, names)
+					if err != nil {
+						return pkgerrors.Wrap(err, "Fail to re-render the Ipsec resources")
+					}
+				}
+				ipsec = rebuilt[r.Name]
+				if ipsec == nil {
+					return pkgerrors.New("Ipsec resource " + r.Name + " can not be re-rendered")
+				}
+			} else {
+				ipsec, _, err = setIpsecProposals(r.Data, names)
+				if err != nil {
+					log.Println(err)
+					resources = append(resources, r)
+					continue
+				}
+			}
+
+			r.Data, _ = resource.GetResourceBuilder().ToString(ipsec)
+			update_util.AddResource(co, "create", ipsec)
+			ipsec_clusters[r.ConnObject] = co
+			resources = append(resources, r)
+		default:
+			resources = append(resources, r)
+		}
+	}
+
+	// newly selected proposals
+	for co_str, co := range ipsec_clusters {
+		for _, p := range proposals {
+			if !deployed[co_str][p.Metadata.Name] {
+				add_util.AddResource(co, "create", p.ToResource())
+				resources = append(resources, module.ConnectionResource{ConnObject: co_str, Name: p.Metadata.Name, Type: "Proposal"})
+			}
+		}
+	}
+
+	err = add_util.Deploy(overlay, conn.Metadata.Name, "YAML")
+	if err != nil {
+		return err
+	}
+
+	err = update_util.DeployUpdate(overlay, conn.Metadata.Name, "YAML", true)
+	if err != nil {
+		return err
+	}
+
+	err = delete_util.Undeploy(overlay)
+	if err != nil {
+		log.Println(err)
+	}
+
+	conn.Info.Resources = resources
+	_, err = GetConnectionManager().UpdateObject(overlay, *conn)
+
+	return err
+}
+
+func (c *ProposalSetObjectManager) updateSccConnection(overlay string, to *module.DeviceObject) error {
+	proposals, err := c.GetProposals(overlay, SCCTODEVICE, []string{to.Metadata.Name})
+	if err != nil {
+		return err
+	}
+
+	names := proposalNames(proposals)
+	ipsec, old, err := setIpsecProposals(to.Status.Data[SCC_RESOURCE], names)
+	if err != nil {
+		return err
+	}
+
+	scc := module.EmptyObject{
+		Metadata: module.ObjectMetaData{Name: "local"}}
+	app_name := overlay + "localto" + to.Metadata.Name
+
+	add_util := NewResUtil()
+	update_util := NewResUtil()
+	delete_util := NewResUtil()
+
+	deployed := make(map[string]bool)
+	for _, n := range old {
+		deployed[n] = true
+	}
+
+	for _, p := range proposals {
+		if deployed[p.Metadata.Name] {
+			update_util.AddResource(&scc, "create", p.ToResource())
+			delete(deployed, p.Metadata.Name)
+		} else {
+			add_util.AddResource(&scc, "create", p.ToResource())
+		}
+	}
+	update_util.AddResource(&scc, "create", ipsec)
+
+	for n := range deployed {
+		delete_util.AddResource(&scc, "delete", &resource.EmptyResource{Name: n, Type: "Proposal"})
+	}
+
+	err = add_util.Deploy(globalOverlay, app_name, "YAML")
+	if err != nil {
+		return err
+	}
+
+	err = update_util.DeployUpdate(globalOverlay, app_name, "YAML", true)
+	if err != nil {
+		return err
+	}
+
+	err = delete_util.Undeploy(globalOverlay)
+	if err != nil {
+		log.Println(err)
+	}
+
+	to.Status.Data[SCC_RESOURCE], _ = resource.GetResourceBuilder().ToString(ipsec)
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	m[DeviceResource] = to.Metadata.Name
+	_, err = GetManagerset().Device.UpdateObject(m, to)
+
+	return err
+}
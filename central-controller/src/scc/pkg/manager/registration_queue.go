@@ -0,0 +1,393 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	RegAttempts = "RegAttempts"
+	RegError    = "RegError"
+
+	REG_INTERVAL     = 5 * time.Second
+	REG_MAX_BACKOFF  = 5 * time.Minute
+	REG_MAX_ATTEMPTS = 100
+	REG_WORKERS      = 10
+)
+
+// outcomes of a registration attempt
+const (
+	regRetry = iota
+	regSucceeded
+	regFailed
+)
+
+// RegistrationKey is the key of a pending registration. The device is
+// stored with its own key field so that the entries do not collide with
+// the device objects in the same collection
+type RegistrationKey struct {
+	OverlayName string `json:"overlay-name"`
+	DeviceName  string `json:"registration-device-name"`
+}
+
+// RegistrationEntry records the progress of a device registration
+type RegistrationEntry struct {
+	OverlayName string    `json:"overlay-name"`
+	DeviceName  string    `json:"device-name"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next-attempt"`
+	LastError   string    `json:"last-error"`
+	// changed by every Enqueue, so that a running attempt does not
+	// overwrite the entry of a device queued again meanwhile
+	Version int64 `json:"version"`
+}
+
+// RegistrationQueue registers the pending devices in the background. The
+// queue is stored in the database so that the registrations are resumed
+// when scc restarts
+type RegistrationQueue struct {
+	storeName string
+	tagMeta   string
+	// serializes the updates of the entries
+	mux sync.Mutex
+	// per device locks, held while a registration attempt is running
+	locks   map[string]*sync.Mutex
+	started bool
+}
+
+var regqueue = RegistrationQueue{
+	storeName: StoreName,
+	tagMeta:   "registration",
+	locks:     make(map[string]*sync.Mutex),
+}
+
+func GetRegistrationQueue() *RegistrationQueue {
+	return &regqueue
+}
+
+func lockName(overlay string, device string) string {
+	return overlay + "/" + device
+}
+
+func (q *RegistrationQueue) lock(overlay string, device string) *sync.Mutex {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	name := lockName(overlay, device)
+	l, ok := q.locks[name]
+	if !ok {
+		l = &sync.Mutex{}
+		q.locks[name] = l
+	}
+	return l
+}
+
+func (q *RegistrationQueue) save(e RegistrationEntry) error {
+	key := RegistrationKey{OverlayName: e.OverlayName, DeviceName: e.DeviceName}
+	err := db.DBconn.Insert(q.storeName, key, nil, q.tagMeta, e)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Unable to save registration of "+e.DeviceName)
+	}
+	return nil
+}
+
+func (q *RegistrationQueue) remove(overlay string, device string) {
+	err := db.DBconn.Remove(q.storeName, RegistrationKey{OverlayName: overlay, DeviceName: device})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (q *RegistrationQueue) getEntries() ([]RegistrationEntry, error) {
+	var entries []RegistrationEntry
+	values, err := db.DBconn.Find(q.storeName, RegistrationKey{}, q.tagMeta)
+	if err != nil {
+		return entries, err
+	}
+
+	for _, value := range values {
+		var e RegistrationEntry
+		err = db.DBconn.Unmarshal(value, &e)
+		if err != nil {
+			return entries, pkgerrors.Wrap(err, "Unmarshaling value")
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (q *RegistrationQueue) getEntry(overlay string, device string) (*RegistrationEntry, error) {
+	values, err := db.DBconn.Find(q.storeName, RegistrationKey{OverlayName: overlay, DeviceName: device}, q.tagMeta)
+	if err != nil || len(values) == 0 {
+		return nil, err
+	}
+
+	var e RegistrationEntry
+	err = db.DBconn.Unmarshal(values[0], &e)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Unmarshaling value")
+	}
+	return &e, nil
+}
+
+// Enqueue adds a device to the queue, a device already in the queue is
+// registered again from the first attempt
+func (q *RegistrationQueue) Enqueue(overlay string, device string) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	return q.save(RegistrationEntry{
+		OverlayName: overlay,
+		DeviceName:  device,
+		NextAttempt: time.Now(),
+		Version:     time.Now().UnixNano(),
+	})
+}
+
+// finish saves the entry after an attempt, or removes it and its lock from
+// the queue if done is set. Nothing is changed if the device is removed or
+// queued again while the attempt runs
+func (q *RegistrationQueue) finish(e RegistrationEntry, done bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	cur, err := q.getEntry(e.OverlayName, e.DeviceName)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if cur == nil {
+		return
+	}
+	if cur.Version != e.Version {
+		log.Println("Device " + e.DeviceName + " is queued again, keep its new registration")
+		return
+	}
+
+	if done {
+		q.remove(e.OverlayName, e.DeviceName)
+		delete(q.locks, lockName(e.OverlayName, e.DeviceName))
+		return
+	}
+
+	err = q.save(e)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// Remove drops a device from the queue. It waits for the running attempt
+// of the device to finish
+func (q *RegistrationQueue) Remove(overlay string, device string) {
+	l := q.lock(overlay, device)
+	l.Lock()
+	defer l.Unlock()
+
+	q.remove(overlay, device)
+	q.mux.Lock()
+	delete(q.locks, lockName(overlay, device))
+	q.mux.Unlock()
+}
+
+// Start resumes the registrations stored in the database and starts the
+// worker loop. Devices left in pending state by an earlier version of scc
+// are added to the queue
+func (q *RegistrationQueue) Start() {
+	q.mux.Lock()
+	if q.started {
+		q.mux.Unlock()
+		return
+	}
+	q.started = true
+	q.mux.Unlock()
+
+	entries, err := q.getEntries()
+	if err != nil {
+		log.Println(err)
+	}
+	queued := make(map[string]bool)
+	for _, e := range entries {
+		queued[e.OverlayName+"/"+e.DeviceName] = true
+	}
+
+	m := make(map[string]string)
+	overlays, err := GetManagerset().Overlay.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+	}
+	for _, overlay := range overlays {
+		overlay_name := overlay.GetMetadata().Name
+		m[OverlayResource] = overlay_name
+		devices, err := GetManagerset().Device.GetObjects(m)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		for _, t := range devices {
+			dev := t.(*module.DeviceObject)
+			if dev.Status.Data[RegStatus] != "pending" || queued[overlay_name+"/"+dev.Metadata.Name] {
+				continue
+			}
+			log.Println("Resume registration of device " + dev.Metadata.Name)
+			err = q.Enqueue(overlay_name, dev.Metadata.Name)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	log.Println("Resume " + strconv.Itoa(len(entries)) + " device registrations")
+	go q.run()
+}
+
+func (q *RegistrationQueue) run() {
+	sem := make(chan struct{}, REG_WORKERS)
+	for {
+		entries, err := q.getEntries()
+		if err != nil {
+			log.Println(err)
+		}
+
+		now := time.Now()
+		for _, e := range entries {
+			if e.NextAttempt.After(now) {
+				continue
+			}
+
+			l := q.lock(e.OverlayName, e.DeviceName)
+			// skip the devices whose attempt is still running
+			if !l.TryLock() {
+				continue
+			}
+
+			sem <- struct{}{}
+			go func(e RegistrationEntry, l *sync.Mutex) {
+				defer func() { <-sem }()
+				defer l.Unlock()
+				q.attempt(e)
+			}(e, l)
+		}
+
+		time.Sleep(REG_INTERVAL)
+	}
+}
+
+// backoff returns the delay before the next attempt
+func backoff(attempts int) time.Duration {
+	d := REG_INTERVAL
+	for i := 1; i < attempts && d < REG_MAX_BACKOFF; i++ {
+		d = d * 2
+	}
+	if d > REG_MAX_BACKOFF {
+		d = REG_MAX_BACKOFF
+	}
+	return d
+}
+
+// isUnauthorized checks whether the device rejects the credentials of the
+// kubeconfig, which is not recovered by retrying
+func isUnauthorized(err error) bool {
+	cause := pkgerrors.Cause(err)
+	return apierrors.IsUnauthorized(cause) || apierrors.IsForbidden(cause)
+}
+
+// attemptOutcome returns the outcome of an attempt from the registration
+// status of the device after the attempt and the error of the attempt
+func attemptOutcome(attempts int, status string, err error) int {
+	if err == nil && status == "success" {
+		return regSucceeded
+	}
+	if status == "failed" || (err != nil && isUnauthorized(err)) || attempts >= REG_MAX_ATTEMPTS {
+		return regFailed
+	}
+	return regRetry
+}
+
+func (q *RegistrationQueue) attempt(e RegistrationEntry) {
+	dev_manager := GetManagerset().Device
+	m := make(map[string]string)
+	m[OverlayResource] = e.OverlayName
+	m[DeviceResource] = e.DeviceName
+
+	// the entry may have been removed or queued again while waiting for the lock
+	cur, err := q.getEntry(e.OverlayName, e.DeviceName)
+	if err != nil || cur == nil {
+		return
+	}
+	e = *cur
+
+	t, err := dev_manager.GetObject(m)
+	if err != nil {
+		log.Println("Device " + e.DeviceName + " is removed, drop its registration")
+		q.remove(e.OverlayName, e.DeviceName)
+		return
+	}
+
+	to := t.(*module.DeviceObject)
+	if to.Status.Data == nil {
+		to.Status.Data = make(map[string]string)
+	}
+	if to.Status.Data[RegStatus] != "pending" {
+		q.remove(e.OverlayName, e.DeviceName)
+		return
+	}
+
+	err = dev_manager.PostRegister(m, t)
+	e.Attempts++
+	outcome := attemptOutcome(e.Attempts, to.Status.Data[RegStatus], err)
+	if outcome == regSucceeded {
+		log.Println("Device " + e.DeviceName + " is registered")
+		q.finish(e, true)
+		return
+	}
+
+	if err == nil {
+		err = pkgerrors.New("Registration status is " + to.Status.Data[RegStatus])
+	}
+	log.Println(err)
+
+	// a partial registration is retried from the beginning
+	if to.Status.Data[RegStatus] != "failed" {
+		to.Status.Data[RegStatus] = "pending"
+	}
+
+	e.LastError = err.Error()
+	to.Status.Data[RegAttempts] = strconv.Itoa(e.Attempts)
+	to.Status.Data[RegError] = e.LastError
+
+	if outcome == regFailed {
+		log.Println("Registration of device " + e.DeviceName + " failed after " + strconv.Itoa(e.Attempts) + " attempts")
+		to.Status.Data[RegStatus] = "failed"
+		dev_manager.UpdateObject(m, t)
+		q.finish(e, true)
+		GetWebhookNotifier().Notify(e.OverlayName, module.NotifyRegistrationFailed, DeviceKind+"/"+e.DeviceName, e.LastError)
+		return
+	}
+
+	dev_manager.UpdateObject(m, t)
+	e.NextAttempt = time.Now().Add(backoff(e.Attempts))
+	q.finish(e, false)
+}
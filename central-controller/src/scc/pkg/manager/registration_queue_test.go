@@ -0,0 +1,180 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// upsertMockDB replaces the value stored with a key and finds nothing for a
+// missing key like mongo does
+type upsertMockDB struct {
+	db.NewMockDB
+}
+
+func (m *upsertMockDB) Insert(table string, key db.Key, query interface{}, tag string, data interface{}) error {
+	m.NewMockDB.Remove(table, key)
+	return m.NewMockDB.Insert(table, key, query, tag, data)
+}
+
+func (m *upsertMockDB) Find(table string, key db.Key, tag string) ([][]byte, error) {
+	values, err := m.NewMockDB.Find(table, key, tag)
+	if len(values) == 0 {
+		return nil, err
+	}
+	return values, err
+}
+
+func newTestRegistrationQueue() *RegistrationQueue {
+	db.DBconn = &upsertMockDB{}
+	return &RegistrationQueue{
+		storeName: StoreName,
+		tagMeta:   "registration",
+		locks:     make(map[string]*sync.Mutex),
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tcases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{0, REG_INTERVAL},
+		{1, REG_INTERVAL},
+		{2, 2 * REG_INTERVAL},
+		{4, 8 * REG_INTERVAL},
+		{6, 32 * REG_INTERVAL},
+		{7, REG_MAX_BACKOFF},
+		{REG_MAX_ATTEMPTS, REG_MAX_BACKOFF},
+	}
+
+	for _, tcase := range tcases {
+		if d := backoff(tcase.attempts); d != tcase.expected {
+			t.Errorf("backoff(%d) = %s, expected %s", tcase.attempts, d, tcase.expected)
+		}
+	}
+}
+
+func TestAttemptOutcome(t *testing.T) {
+	unauthorized := apierrors.NewUnauthorized("invalid token")
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "default", errors.New("denied"))
+
+	tcases := []struct {
+		name     string
+		attempts int
+		status   string
+		err      error
+		expected int
+	}{
+		{"Success", 1, "success", nil, regSucceeded},
+		{"SuccessAtMaxAttempts", REG_MAX_ATTEMPTS, "success", nil, regSucceeded},
+		{"Pending", 1, "pending", nil, regRetry},
+		{"Error", 1, "pending", errors.New("timeout"), regRetry},
+		{"ErrorAfterSuccess", 1, "success", errors.New("timeout"), regRetry},
+		{"Failed", 1, "failed", nil, regFailed},
+		{"Unauthorized", 1, "pending", unauthorized, regFailed},
+		{"WrappedUnauthorized", 1, "pending", pkgerrors.Wrap(unauthorized, "Get namespaces"), regFailed},
+		{"Forbidden", 1, "pending", forbidden, regFailed},
+		{"MaxAttempts", REG_MAX_ATTEMPTS, "pending", errors.New("timeout"), regFailed},
+		{"BelowMaxAttempts", REG_MAX_ATTEMPTS - 1, "pending", errors.New("timeout"), regRetry},
+	}
+
+	for _, tcase := range tcases {
+		if outcome := attemptOutcome(tcase.attempts, tcase.status, tcase.err); outcome != tcase.expected {
+			t.Errorf("%s: attemptOutcome() = %d, expected %d", tcase.name, outcome, tcase.expected)
+		}
+	}
+}
+
+func TestFinishKeepsNewerRegistration(t *testing.T) {
+	q := newTestRegistrationQueue()
+	if err := q.Enqueue("overlay1", "device1"); err != nil {
+		t.Fatalf("Enqueue() error = %s", err.Error())
+	}
+	stale, _ := q.getEntry("overlay1", "device1")
+	time.Sleep(time.Millisecond)
+	if err := q.Enqueue("overlay1", "device1"); err != nil {
+		t.Fatalf("Enqueue() error = %s", err.Error())
+	}
+	queued, _ := q.getEntry("overlay1", "device1")
+	if queued.Version == stale.Version {
+		t.Fatalf("Enqueue() kept the version %d", stale.Version)
+	}
+
+	// the stale attempt neither saves its retry nor removes the entry
+	stale.Attempts = 3
+	stale.LastError = "timeout"
+	for _, done := range []bool{false, true} {
+		q.finish(*stale, done)
+		cur, _ := q.getEntry("overlay1", "device1")
+		if cur == nil || cur.Version != queued.Version || cur.Attempts != 0 || cur.LastError != "" {
+			t.Errorf("finish(done: %v) of a stale attempt changed the entry to %+v", done, cur)
+		}
+	}
+
+	// the attempt of the current registration is saved
+	retry := *queued
+	retry.Attempts = 1
+	retry.LastError = "timeout"
+	q.finish(retry, false)
+	if cur, _ := q.getEntry("overlay1", "device1"); cur == nil || cur.Attempts != 1 || cur.LastError != "timeout" {
+		t.Errorf("finish() of a retry saved %+v", cur)
+	}
+}
+
+func TestFinishTerminalRemovesLock(t *testing.T) {
+	q := newTestRegistrationQueue()
+	q.Enqueue("overlay1", "device1")
+	e, _ := q.getEntry("overlay1", "device1")
+	q.lock("overlay1", "device1")
+
+	e.Attempts = REG_MAX_ATTEMPTS
+	q.finish(*e, true)
+	if cur, _ := q.getEntry("overlay1", "device1"); cur != nil {
+		t.Errorf("finish() of a terminal attempt kept the entry %+v", cur)
+	}
+	if _, ok := q.locks[lockName("overlay1", "device1")]; ok {
+		t.Errorf("finish() of a terminal attempt kept the lock of the device")
+	}
+}
+
+func TestRemoveDeletesLock(t *testing.T) {
+	q := newTestRegistrationQueue()
+	q.Enqueue("overlay1", "device1")
+	q.Enqueue("overlay1", "device2")
+	q.lock("overlay1", "device1")
+	q.lock("overlay1", "device2")
+
+	q.Remove("overlay1", "device1")
+	if cur, _ := q.getEntry("overlay1", "device1"); cur != nil {
+		t.Errorf("Remove() kept the entry %+v", cur)
+	}
+	if _, ok := q.locks[lockName("overlay1", "device1")]; ok {
+		t.Errorf("Remove() kept the lock of the device")
+	}
+	if _, ok := q.locks[lockName("overlay1", "device2")]; !ok {
+		t.Errorf("Remove() deleted the lock of another device")
+	}
+}
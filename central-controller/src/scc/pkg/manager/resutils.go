@@ -326,14 +326,24 @@ func (d *ResUtil) DeployUpdate(overlay string, app_name string, format string, u
 	for device, res := range d.resmap {
 		m[DeviceResource] = device.GetType() + "." + device.GetMetadata().Name
 
-		for _, resource := range res.Resources {
+		for _, dres := range res.Resources {
+			// never hand a malformed CR over to rsync
+			err := resource.Validate(dres.Resource, d.TargetName(device))
+			if err != nil {
+				isErr = true
+				dres.Status = 2
+				errMessage = errMessage + " " + dres.Resource.GetName()
+				log.Println(err)
+				continue
+			}
+
 			operation := 1
-			m["Name"] = resource.Resource.GetName()
-			m["Type"] = resource.Resource.GetType()
+			m["Name"] = dres.Resource.GetName()
+			m["Type"] = dres.Resource.GetType()
 			robj, err := res_manager.GetObject(m)
 			resobj := robj.(*module.ResourceObject)
 			if err != nil {
-				// create a new resource object
+				// create a new dres object
 				resobj.Metadata.Name = m["Name"]
 				resobj.Specification.Hash = ""
 				resobj.Specification.ContextId = ""
@@ -341,7 +351,7 @@ func (d *ResUtil) DeployUpdate(overlay string, app_name string, format string, u
 				resobj.Specification.Status = Resource_Status_NotDeployed
 			}
 
-			resource_data := resource.Resource.ToYaml(d.TargetName(device))
+			resource_data := dres.Resource.ToYaml(d.TargetName(device))
 			resource_data_hash_byte := sha256.Sum256([]byte(resource_data))
 			resource_data_hash := string(resource_data_hash_byte[:])
 			if resobj.Specification.Ref > 0 && resource_data_hash != resobj.Specification.Hash {
@@ -350,18 +360,18 @@ func (d *ResUtil) DeployUpdate(overlay string, app_name string, format string, u
 
 			switch operation {
 			case 1:
-				// Add resource
-				if resource.Status != 1 {
-					// resource is not deployed or failed to deploy
+				// Add dres
+				if dres.Status != 1 {
+					// dres is not deployed or failed to deploy
 					if resobj.Specification.Ref == 0 {
-						// resource needs to be deployed
-						cid, err := d.DeployOneResource(overlay, app_name, format, device, resource)
+						// dres needs to be deployed
+						cid, err := d.DeployOneResource(overlay, app_name, format, device, dres)
 						if err != nil {
 							isErr = true
-							resource.Status = 2
-							errMessage = errMessage + " " + resource.Resource.GetName()
+							dres.Status = 2
+							errMessage = errMessage + " " + dres.Resource.GetName()
 						} else {
-							resource.Status = 1
+							dres.Status = 1
 							resobj.Specification.Hash = resource_data_hash
 							resobj.Specification.ContextId = cid
 							resobj.Specification.Ref = 1
@@ -374,21 +384,21 @@ func (d *ResUtil) DeployUpdate(overlay string, app_name string, format string, u
 						if !update {
 							resobj.Specification.Ref += 1
 						}
-						resource.Status = 1
+						dres.Status = 1
 						res_manager.UpdateObject(m, resobj)
 					}
 				}
 			case 2:
-				// Update resource
-				if resource.Status != 1 {
-					err := d.UpdateOneResource(overlay, resobj.Specification.ContextId, device, getResourceName(resource), resource_data)
+				// Update dres
+				if dres.Status != 1 {
+					err := d.UpdateOneResource(overlay, resobj.Specification.ContextId, device, getResourceName(dres), resource_data)
 					if err != nil {
 						isErr = true
-						resource.Status = 2
-						errMessage = errMessage + " " + resource.Resource.GetName()
+						dres.Status = 2
+						errMessage = errMessage + " " + dres.Resource.GetName()
 						log.Println(err)
 					} else {
-						resource.Status = 1
+						dres.Status = 1
 						// add ref
 						resobj.Specification.Hash = resource_data_hash
 						if !update {
@@ -613,6 +623,12 @@ func (d *ResUtil) Query(app_name string) (string, error) {
 }
 
 func (d *ResUtil) GetResourceData(device module.ControllerObject, ns string, name string) (string, error) {
+	return d.GetKindResourceData(device, "", ns, name)
+}
+
+// GetKindResourceData returns the queried resource of the kind, the
+// resources of any kind match if kind is empty
+func (d *ResUtil) GetKindResourceData(device module.ControllerObject, kind string, ns string, name string) (string, error) {
 	if d.qryCtxId == "" {
 		return "", pkgerrors.New("Query failed to be executed.")
 	}
@@ -662,7 +678,8 @@ func (d *ResUtil) GetResourceData(device module.ControllerObject, ns string, nam
 	}
 
 	for _, resource := range d.qryResmap[device].Resources {
-		if ns == resource.Resource.Namespace && name == resource.Resource.Name {
+		if ns == resource.Resource.Namespace && name == resource.Resource.Name &&
+			(kind == "" || kind == resource.Resource.Gvk.Kind) {
 			rdh, _ := ac.GetLevelHandle(resource.Handle, "definition")
 			if rdh != nil {
 				ret, err := ac.GetValue(rdh)
@@ -0,0 +1,682 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	DEFAULT_POLICY_ACTION    = "ACCEPT"
+	DEFAULT_POLICY_PROTO     = "all"
+	DEFAULT_POLICY_RULE_TYPE = "FirewallRule"
+)
+
+// serialize policy compilation, which is triggered by device registration
+// as well as by the REST API
+var policy_mux = sync.Mutex{}
+
+type SecurityPolicyObjectKey struct {
+	OverlayName string `json:"overlay-name"`
+	PolicyName  string `json:"security-policy-name"`
+}
+
+// SecurityPolicyObjectManager implements the ControllerObjectManager
+type SecurityPolicyObjectManager struct {
+	BaseObjectManager
+}
+
+// policyEnd is a resolved PolicyEndpoint
+type policyEnd struct {
+	any      bool
+	addrs    []string
+	clusters map[string]module.ControllerObject
+}
+
+func NewSecurityPolicyObjectManager() *SecurityPolicyObjectManager {
+	return &SecurityPolicyObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        "securitypolicy",
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+	}
+}
+
+func (c *SecurityPolicyObjectManager) GetResourceName() string {
+	return SecurityPolicyResource
+}
+
+func (c *SecurityPolicyObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *SecurityPolicyObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.SecurityPolicyObject{}
+}
+
+func (c *SecurityPolicyObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	overlay_name := m[OverlayResource]
+	key := SecurityPolicyObjectKey{
+		OverlayName: overlay_name,
+		PolicyName:  "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	to := t.(*module.SecurityPolicyObject)
+	meta_name := to.Metadata.Name
+	res_name := m[SecurityPolicyResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.PolicyName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.PolicyName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *SecurityPolicyObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.SecurityPolicyObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+func (c *SecurityPolicyObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	policy_mux.Lock()
+	defer policy_mux.Unlock()
+
+	to := t.(*module.SecurityPolicyObject)
+	to.Status = module.SecurityPolicyObjectStatus{}
+	err := c.deployPolicies(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		// the object is not saved, so remove whatever got deployed
+		c.undeployRules(m[OverlayResource], to.Status.RuleType, to.Status.Rules,
+			map[string][]resource.ISdewanResource{}, map[string]module.ControllerObject{})
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().CreateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *SecurityPolicyObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *SecurityPolicyObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *SecurityPolicyObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *SecurityPolicyObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	policy_mux.Lock()
+	defer policy_mux.Unlock()
+
+	// keep track of the rules deployed by the previous version
+	old, err := c.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	to := t.(*module.SecurityPolicyObject)
+	to.Status = old.(*module.SecurityPolicyObject).Status
+	if to.Specification.Order != old.(*module.SecurityPolicyObject).Specification.Order {
+		// the rules are moved, so re-create them at their new position
+		err = c.undeployRules(m[OverlayResource], to.Status.RuleType, to.Status.Rules,
+			map[string][]resource.ISdewanResource{}, map[string]module.ControllerObject{})
+		if err != nil {
+			log.Println(err)
+		}
+		to.Status.Rules = nil
+	}
+	err = c.deployPolicies(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().UpdateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *SecurityPolicyObjectManager) DeleteObject(m map[string]string) error {
+	policy_mux.Lock()
+	defer policy_mux.Unlock()
+
+	t, err := c.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	to := t.(*module.SecurityPolicyObject)
+	err = c.undeployRules(m[OverlayResource], to.Status.RuleType, to.Status.Rules,
+		map[string][]resource.ISdewanResource{}, map[string]module.ControllerObject{})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// DB Operation
+	err = GetDBUtils().DeleteObject(c, m)
+
+	return err
+}
+
+// Recompile re-deploys the rules of all the security policies in the overlay,
+// it is called whenever devices, hubs or sites of the overlay change
+func (c *SecurityPolicyObjectManager) Recompile(overlay string) {
+	policy_mux.Lock()
+	defer policy_mux.Unlock()
+
+	err := c.deployPolicies(overlay, nil)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// sortPolicies sorts the policies by their order, then by their name
+func sortPolicies(policies []*module.SecurityPolicyObject) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Specification.Order != policies[j].Specification.Order {
+			return policies[i].Specification.Order < policies[j].Specification.Order
+		}
+		return policies[i].Metadata.Name < policies[j].Metadata.Name
+	})
+}
+
+// addsRules checks whether rules not deployed before are part of the new rule set
+func addsRules(deployed map[string][]string, rules map[string][]string) bool {
+	for k, names := range rules {
+		old := make(map[string]bool)
+		for _, name := range deployed[k] {
+			old[name] = true
+		}
+		for _, name := range names {
+			if !old[name] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// deployPolicies deploys the rules of target and of the policies following it,
+// or of all the policies of the overlay if target is nil. The CNF evaluates
+// the firewall rules in the order they are created, so once a policy adds a
+// rule the rules of all the policies following it are re-created behind it.
+// The status of the policies other than target is saved.
+func (c *SecurityPolicyObjectManager) deployPolicies(overlay string, target *module.SecurityPolicyObject) error {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+
+	objs, err := c.GetObjects(m)
+	if err != nil {
+		return err
+	}
+
+	policies := []*module.SecurityPolicyObject{}
+	for _, obj := range objs {
+		p := obj.(*module.SecurityPolicyObject)
+		if target != nil && p.Metadata.Name == target.Metadata.Name {
+			continue
+		}
+		policies = append(policies, p)
+	}
+	if target != nil {
+		policies = append(policies, target)
+	}
+	sortPolicies(policies)
+
+	started := target == nil
+	recreate := false
+	var ret error
+	for _, p := range policies {
+		if p == target {
+			started = true
+		}
+		if !started {
+			continue
+		}
+
+		if recreate && len(p.Status.Rules) > 0 {
+			err = c.undeployRules(overlay, p.Status.RuleType, p.Status.Rules,
+				map[string][]resource.ISdewanResource{}, map[string]module.ControllerObject{})
+			if err != nil {
+				log.Println(err)
+			}
+			p.Status.Rules = nil
+		}
+
+		deployed := p.Status.Rules
+		err = c.deployRules(overlay, p)
+		if err != nil {
+			if p == target {
+				ret = err
+			} else {
+				log.Println("Fail to deploy security policy " + p.Metadata.Name + ": " + err.Error())
+			}
+		}
+		if addsRules(deployed, p.Status.Rules) {
+			recreate = true
+		}
+
+		if p != target {
+			// status is saved even on error so that deployed rules are tracked
+			m[SecurityPolicyResource] = p.Metadata.Name
+			_, err = GetDBUtils().UpdateObject(c, m, p)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	return ret
+}
+
+// recompileSecurityPolicies is a helper for the other managers
+func recompileSecurityPolicies(overlay string) {
+	mgr := GetManagerset().SecurityPolicy
+	if mgr != nil {
+		mgr.Recompile(overlay)
+	}
+}
+
+func (c *SecurityPolicyObjectManager) addCluster(e *policyEnd, cluster module.ControllerObject) {
+	e.clusters[module.CreateEndName(cluster.GetType(), cluster.GetMetadata().Name)] = cluster
+}
+
+func (c *SecurityPolicyObjectManager) getRegisteredDevice(overlay string, name string) (*module.DeviceObject, error) {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	m[DeviceResource] = name
+
+	dev, err := GetManagerset().Device.GetObject(m)
+	if err != nil {
+		return nil, err
+	}
+
+	dev_obj := dev.(*module.DeviceObject)
+	if dev_obj.Status.Data[RegStatus] != "success" {
+		return nil, pkgerrors.New("Device " + name + " registration is not ready")
+	}
+
+	return dev_obj, nil
+}
+
+func (c *SecurityPolicyObjectManager) getHub(overlay string, name string) (*module.HubObject, error) {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	m[HubResource] = name
+
+	hub, err := GetManagerset().Hub.GetObject(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return hub.(*module.HubObject), nil
+}
+
+// addDevice adds the device and the hubs it connects to as enforcement points
+func (c *SecurityPolicyObjectManager) addDevice(overlay string, e *policyEnd, dev *module.DeviceObject) {
+	c.addCluster(e, dev)
+
+	hub_names, _ := GetManagerset().DeviceConn.GetConnectedHubs(overlay, dev.Metadata.Name)
+	for _, hub_name := range hub_names {
+		strs := strings.SplitN(hub_name, "..", 2)
+		hub, err := c.getHub(overlay, strings.Replace(strs[0], "Hub.", "", 1))
+		if err == nil {
+			c.addCluster(e, hub)
+		}
+	}
+}
+
+// selectObjects returns the names of the objects selected by their labels
+func selectObjects(mgr ControllerObjectManager, overlay string, label_selector string) ([]string, error) {
+	names := []string{}
+	if label_selector == "" {
+		return names, nil
+	}
+
+	selector, err := labels.Parse(label_selector)
+	if err != nil {
+		return names, err
+	}
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	objs, err := mgr.GetObjects(m)
+	if err != nil {
+		return names, err
+	}
+
+	for _, obj := range objs {
+		if selector.Matches(labels.Set(obj.GetMetadata().Labels)) {
+			names = append(names, obj.GetMetadata().Name)
+		}
+	}
+	return names, nil
+}
+
+func (c *SecurityPolicyObjectManager) resolveEndpoint(overlay string, ep module.PolicyEndpoint) (policyEnd, error) {
+	e := policyEnd{
+		any:      ep.IsAny(),
+		addrs:    []string{},
+		clusters: make(map[string]module.ControllerObject),
+	}
+
+	selected_devs, err := selectObjects(GetManagerset().Device, overlay, ep.DeviceSelector)
+	if err != nil {
+		return e, err
+	}
+	selected_hubs, err := selectObjects(GetManagerset().Hub, overlay, ep.HubSelector)
+	if err != nil {
+		return e, err
+	}
+
+	for _, name := range uniqueStrings(append(append([]string{}, ep.Devices...), selected_devs...)) {
+		dev, err := c.getRegisteredDevice(overlay, name)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		if dev.Status.Ip != "" {
+			e.addrs = append(e.addrs, dev.Status.Ip)
+		}
+		for _, ip := range dev.Status.DataIps {
+			e.addrs = append(e.addrs, ip)
+		}
+		c.addDevice(overlay, &e, dev)
+	}
+
+	for _, name := range ep.Sites {
+		strs := strings.SplitN(name, "/", 2)
+		dev, err := c.getRegisteredDevice(overlay, strs[0])
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		m := make(map[string]string)
+		m[OverlayResource] = overlay
+		m[DeviceResource] = strs[0]
+		m[SiteResource] = strs[1]
+		site, err := GetManagerset().DeviceSite.GetObject(m)
+		if err != nil {
+			log.Println("Site " + name + " is not defined")
+			continue
+		}
+
+		site_obj := site.(*module.SiteObject)
+		e.addrs = append(e.addrs, site_obj.Specification.Subnet)
+		c.addDevice(overlay, &e, dev)
+	}
+
+	for _, name := range uniqueStrings(append(append([]string{}, ep.Hubs...), selected_hubs...)) {
+		hub, err := c.getHub(overlay, name)
+		if err != nil {
+			log.Println("Hub " + name + " is not defined")
+			continue
+		}
+
+		e.addrs = append(e.addrs, hub.Specification.PublicIps...)
+		c.addCluster(&e, hub)
+	}
+
+	e.addrs = append(e.addrs, ep.Cidrs...)
+	e.addrs = uniqueStrings(e.addrs)
+
+	return e, nil
+}
+
+// getAllClusters returns all the hubs and registered devices of the overlay
+func (c *SecurityPolicyObjectManager) getAllClusters(overlay string) map[string]module.ControllerObject {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	clusters := make(map[string]module.ControllerObject)
+
+	hubs, _ := GetManagerset().Hub.GetObjects(m)
+	for _, hub := range hubs {
+		clusters[module.CreateEndName(hub.GetType(), hub.GetMetadata().Name)] = hub
+	}
+
+	devs, _ := GetManagerset().Device.GetObjects(m)
+	for _, dev := range devs {
+		if dev.(*module.DeviceObject).Status.Data[RegStatus] == "success" {
+			clusters[module.CreateEndName(dev.GetType(), dev.GetMetadata().Name)] = dev
+		}
+	}
+
+	return clusters
+}
+
+// compile translates the policy into firewall rules for each hub or device
+func (c *SecurityPolicyObjectManager) compile(overlay string, to *module.SecurityPolicyObject) (map[string][]resource.ISdewanResource, map[string]module.ControllerObject, error) {
+	spec := to.Specification
+	proto := spec.Proto
+	if proto == "" {
+		proto = DEFAULT_POLICY_PROTO
+	}
+	if spec.Port != "" && proto != "tcp" && proto != "udp" {
+		return nil, nil, pkgerrors.New("Port is only supported for tcp and udp")
+	}
+
+	action := spec.Action
+	if action == "" {
+		action = DEFAULT_POLICY_ACTION
+	}
+
+	src, err := c.resolveEndpoint(overlay, spec.Source)
+	if err != nil {
+		return nil, nil, err
+	}
+	dst, err := c.resolveEndpoint(overlay, spec.Destination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules := make(map[string][]resource.ISdewanResource)
+	clusters := make(map[string]module.ControllerObject)
+
+	// a policy referencing objects which do not exist (yet) has nothing to enforce
+	if (!src.any && len(src.addrs) == 0) || (!dst.any && len(dst.addrs) == 0) {
+		return rules, clusters, nil
+	}
+
+	for k, v := range src.clusters {
+		clusters[k] = v
+	}
+	for k, v := range dst.clusters {
+		clusters[k] = v
+	}
+	if len(clusters) == 0 {
+		clusters = c.getAllClusters(overlay)
+	}
+
+	src_addrs := src.addrs
+	if src.any {
+		src_addrs = []string{""}
+	}
+	dst_addrs := dst.addrs
+	if dst.any {
+		dst_addrs = []string{""}
+	}
+
+	var base []resource.FirewallRuleResource
+	for _, s := range src_addrs {
+		for _, d := range dst_addrs {
+			base = append(base, resource.FirewallRuleResource{
+				Name:            "sp" + format_resource_name(to.Metadata.Name, "") + "-" + strconv.Itoa(len(base)),
+				Source:          "*",
+				SourceIP:        s,
+				Dest:            "*",
+				DestinationIP:   d,
+				DestinationPort: spec.Port,
+				Protocol:        proto,
+				Target:          action,
+			})
+		}
+	}
+
+	for k := range clusters {
+		for i := range base {
+			if spec.RuleType == "NetworkFirewallRule" {
+				rules[k] = append(rules[k], &resource.NetworkFirewallRuleResource{FirewallRuleResource: base[i]})
+			} else {
+				r := base[i]
+				rules[k] = append(rules[k], &r)
+			}
+		}
+	}
+
+	return rules, clusters, nil
+}
+
+// getClusterStub returns an object identifying a hub or device which may
+// not exist anymore, so that its rules can still be removed
+func getClusterStub(name string) module.ControllerObject {
+	strs := strings.SplitN(name, ".", 2)
+	if strs[0] == "Hub" {
+		return &module.HubObject{Metadata: module.ObjectMetaData{Name: strs[1]}}
+	}
+	return &module.DeviceObject{Metadata: module.ObjectMetaData{Name: strs[1]}}
+}
+
+// undeployRules removes the deployed rules which are not part of the new rule set
+func (c *SecurityPolicyObjectManager) undeployRules(overlay string, rule_type string, deployed map[string][]string,
+	rules map[string][]resource.ISdewanResource, clusters map[string]module.ControllerObject) error {
+	if rule_type == "" {
+		rule_type = DEFAULT_POLICY_RULE_TYPE
+	}
+
+	resutil := NewResUtil()
+	found := false
+	for k, names := range deployed {
+		keep := make(map[string]bool)
+		for _, r := range rules[k] {
+			if r.GetType() == rule_type {
+				keep[r.GetName()] = true
+			}
+		}
+
+		cluster, ok := clusters[k]
+		if !ok {
+			cluster = getClusterStub(k)
+		}
+
+		for _, name := range names {
+			if !keep[name] {
+				resutil.AddResource(cluster, "delete", &resource.EmptyResource{Name: name, Type: rule_type})
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	return resutil.Undeploy(overlay)
+}
+
+func (c *SecurityPolicyObjectManager) deployRules(overlay string, to *module.SecurityPolicyObject) error {
+	rules, clusters, err := c.compile(overlay, to)
+	if err != nil {
+		return err
+	}
+
+	err = c.undeployRules(overlay, to.Status.RuleType, to.Status.Rules, rules, clusters)
+	if err != nil {
+		log.Println(err)
+	}
+
+	resutil := NewResUtil()
+	deployed := make(map[string][]string)
+	for k, reses := range rules {
+		for _, r := range reses {
+			resutil.AddResource(clusters[k], "create", r)
+			deployed[k] = append(deployed[k], r.GetName())
+		}
+	}
+
+	to.Status.Rules = deployed
+	to.Status.RuleType = to.Specification.RuleType
+	if to.Status.RuleType == "" {
+		to.Status.RuleType = DEFAULT_POLICY_RULE_TYPE
+	}
+
+	if len(deployed) == 0 {
+		return nil
+	}
+
+	return resutil.DeployUpdate(overlay, "securitypolicy"+to.Metadata.Name, "YAML", true)
+}
+
+func uniqueStrings(strs []string) []string {
+	seen := make(map[string]bool)
+	ret := []string{}
+	for _, s := range strs {
+		if s != "" && !seen[s] {
+			seen[s] = true
+			ret = append(ret, s)
+		}
+	}
+	sort.Strings(ret)
+
+	return ret
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+)
+
+func TestSortPolicies(t *testing.T) {
+	policy := func(name string, order int) *module.SecurityPolicyObject {
+		return &module.SecurityPolicyObject{
+			Metadata:      module.ObjectMetaData{Name: name},
+			Specification: module.SecurityPolicyObjectSpec{Order: order},
+		}
+	}
+
+	policies := []*module.SecurityPolicyObject{policy("deny-all", 20), policy("b", 10), policy("allow-ssh", 10), policy("default", 0)}
+	sortPolicies(policies)
+
+	names := []string{}
+	for _, p := range policies {
+		names = append(names, p.Metadata.Name)
+	}
+	expected := []string{"default", "allow-ssh", "b", "deny-all"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("sortPolicies() = %v, expected %v", names, expected)
+	}
+}
+
+func TestAddsRules(t *testing.T) {
+	tcases := []struct {
+		name     string
+		deployed map[string][]string
+		rules    map[string][]string
+		adds     bool
+	}{
+		{"NoRules", nil, nil, false},
+		{"FirstDeploy", nil, map[string][]string{"Hub.hub1": {"sp1-0"}}, true},
+		{"Unchanged", map[string][]string{"Hub.hub1": {"sp1-0"}}, map[string][]string{"Hub.hub1": {"sp1-0"}}, false},
+		{"RemovedRule", map[string][]string{"Hub.hub1": {"sp1-0", "sp1-1"}}, map[string][]string{"Hub.hub1": {"sp1-0"}}, false},
+		{"NewRule", map[string][]string{"Hub.hub1": {"sp1-0"}}, map[string][]string{"Hub.hub1": {"sp1-0", "sp1-1"}}, true},
+		{"NewCluster", map[string][]string{"Hub.hub1": {"sp1-0"}}, map[string][]string{"Hub.hub1": {"sp1-0"}, "Device.dev1": {"sp1-0"}}, true},
+	}
+
+	for _, tcase := range tcases {
+		if adds := addsRules(tcase.deployed, tcase.rules); adds != tcase.adds {
+			t.Errorf("%s: addsRules() = %v, expected %v", tcase.name, adds, tcase.adds)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+// serialize the deployment of the traffic policies, which is triggered by
+// device registration as well as by the REST API
+var traffic_mux = sync.Mutex{}
+
+type TrafficPolicyObjectKey struct {
+	OverlayName string `json:"overlay-name"`
+	PolicyName  string `json:"traffic-policy-name"`
+}
+
+// TrafficPolicyObjectManager implements the ControllerObjectManager
+type TrafficPolicyObjectManager struct {
+	BaseObjectManager
+}
+
+func NewTrafficPolicyObjectManager() *TrafficPolicyObjectManager {
+	return &TrafficPolicyObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        "trafficpolicy",
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+	}
+}
+
+func (c *TrafficPolicyObjectManager) GetResourceName() string {
+	return TrafficPolicyResource
+}
+
+func (c *TrafficPolicyObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *TrafficPolicyObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.TrafficPolicyObject{}
+}
+
+func (c *TrafficPolicyObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	overlay_name := m[OverlayResource]
+	key := TrafficPolicyObjectKey{
+		OverlayName: overlay_name,
+		PolicyName:  "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	to := t.(*module.TrafficPolicyObject)
+	meta_name := to.Metadata.Name
+	res_name := m[TrafficPolicyResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.PolicyName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.PolicyName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *TrafficPolicyObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.TrafficPolicyObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+func (c *TrafficPolicyObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	traffic_mux.Lock()
+	defer traffic_mux.Unlock()
+
+	to := t.(*module.TrafficPolicyObject)
+	to.Status = module.TrafficPolicyObjectStatus{}
+	err := checkTrafficClasses(to)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	err = c.checkNetwork(m, to)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	err = c.deployPolicy(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		// the object is not saved, so remove whatever got deployed
+		c.undeployPolicy(m[OverlayResource], to)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().CreateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *TrafficPolicyObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *TrafficPolicyObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *TrafficPolicyObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *TrafficPolicyObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	traffic_mux.Lock()
+	defer traffic_mux.Unlock()
+
+	// keep track of the devices of the previous version
+	old, err := c.GetObject(m)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	to := t.(*module.TrafficPolicyObject)
+	to.Status = old.(*module.TrafficPolicyObject).Status
+	err = checkTrafficClasses(to)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	err = c.checkNetwork(m, to)
+	if err != nil {
+		return c.CreateEmptyObject(), err
+	}
+
+	err = c.deployPolicy(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+		return c.CreateEmptyObject(), err
+	}
+
+	// DB Operation
+	t, err = GetDBUtils().UpdateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *TrafficPolicyObjectManager) DeleteObject(m map[string]string) error {
+	traffic_mux.Lock()
+	defer traffic_mux.Unlock()
+
+	t, err := c.GetObject(m)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	to := t.(*module.TrafficPolicyObject)
+	err = c.undeployPolicy(m[OverlayResource], to)
+	if err != nil {
+		log.Println(err)
+	}
+
+	// DB Operation
+	err = GetDBUtils().DeleteObject(c, m)
+
+	return err
+}
+
+// Redeploy re-deploys all the traffic policies of the overlay, it is called
+// whenever the devices of the overlay change
+func (c *TrafficPolicyObjectManager) Redeploy(overlay string) {
+	traffic_mux.Lock()
+	defer traffic_mux.Unlock()
+
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+
+	policies, err := c.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, p := range policies {
+		to := p.(*module.TrafficPolicyObject)
+		err = c.deployPolicy(overlay, to)
+		if err != nil {
+			log.Println("Fail to redeploy traffic policy " + to.Metadata.Name + ": " + err.Error())
+		}
+
+		// status is saved even on error so that the devices are tracked
+		m[TrafficPolicyResource] = to.Metadata.Name
+		_, err = GetDBUtils().UpdateObject(c, m, to)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// redeployTrafficPolicies is a helper for the other managers
+func redeployTrafficPolicies(overlay string) {
+	mgr := GetManagerset().TrafficPolicy
+	if mgr != nil {
+		mgr.Redeploy(overlay)
+	}
+}
+
+// checkTrafficClasses checks what the validation tags cannot: the class
+// names are unique and their rates fit in the egress rate
+func checkTrafficClasses(to *module.TrafficPolicyObject) error {
+	egress, _ := strconv.Atoi(to.Specification.EgressRate)
+	names := make(map[string]bool)
+	total := 0
+	for _, cl := range to.Specification.Classes {
+		if names[cl.Name] {
+			return pkgerrors.New("Duplicate traffic class " + cl.Name)
+		}
+		names[cl.Name] = true
+
+		rate, _ := strconv.Atoi(cl.Rate)
+		if ceil, err := strconv.Atoi(cl.Ceil); err == nil && ceil < rate {
+			return pkgerrors.New("Ceil of traffic class " + cl.Name + " is lower than its rate")
+		}
+		total += rate
+	}
+
+	if total > egress {
+		return pkgerrors.New("The rates of the traffic classes exceed the egress rate")
+	}
+
+	return nil
+}
+
+// checkNetwork checks that the devices selected by the policy have no other
+// policy on its network, the CNF rejects a second policy of an interface
+func (c *TrafficPolicyObjectManager) checkNetwork(m map[string]string, to *module.TrafficPolicyObject) error {
+	devs, err := selectRegisteredDevices(m[OverlayResource], to.Specification.DeviceSelector)
+	if err != nil {
+		return err
+	}
+
+	policies, err := c.GetObjects(map[string]string{OverlayResource: m[OverlayResource]})
+	if err != nil {
+		return err
+	}
+
+	return checkNetworkPolicies(to, devs, policies)
+}
+
+// checkNetworkPolicies checks the devices against the ones the other
+// policies of the same network are deployed to
+func checkNetworkPolicies(to *module.TrafficPolicyObject, devs map[string]module.ControllerObject, policies []module.ControllerObject) error {
+	for _, p := range policies {
+		other := p.(*module.TrafficPolicyObject)
+		if other.Metadata.Name == to.Metadata.Name || other.Specification.Network != to.Specification.Network {
+			continue
+		}
+
+		for _, dev := range other.Status.Devices {
+			if _, ok := devs[dev]; ok {
+				return pkgerrors.New("Network " + to.Specification.Network + " of device " + dev +
+					" is used by traffic policy " + other.Metadata.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *TrafficPolicyObjectManager) undeployPolicy(overlay string, to *module.TrafficPolicyObject) error {
+	r := to.ToResource()
+	return undeployFromDevices(overlay, r.GetType(), r.GetName(), to.Status.Devices, []string{})
+}
+
+func (c *TrafficPolicyObjectManager) deployPolicy(overlay string, to *module.TrafficPolicyObject) error {
+	devices, err := deployToDevices(overlay, "trafficpolicy"+to.Metadata.Name, to.ToResource(),
+		to.Specification.DeviceSelector, to.Status.Devices)
+	to.Status.Devices = devices
+
+	return err
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+)
+
+func TestCheckTrafficClasses(t *testing.T) {
+	tcases := []struct {
+		name    string
+		classes []module.TrafficClass
+		valid   bool
+	}{
+		{"NoClass", nil, true},
+		{"Classes", []module.TrafficClass{{Name: "voice", Rate: "10000"}, {Name: "video", Rate: "50000", Ceil: "100000"}}, true},
+		{"DuplicateName", []module.TrafficClass{{Name: "voice", Rate: "10000"}, {Name: "voice", Rate: "10000"}}, false},
+		{"CeilLowerThanRate", []module.TrafficClass{{Name: "video", Rate: "50000", Ceil: "10000"}}, false},
+		{"RatesExceedEgress", []module.TrafficClass{{Name: "voice", Rate: "60000"}, {Name: "video", Rate: "50000"}}, false},
+	}
+
+	for _, tcase := range tcases {
+		to := &module.TrafficPolicyObject{
+			Specification: module.TrafficPolicyObjectSpec{Network: "pnetwork", EgressRate: "100000", Classes: tcase.classes},
+		}
+		if err := checkTrafficClasses(to); (err == nil) != tcase.valid {
+			t.Errorf("%s: checkTrafficClasses() = %v, expected valid %v", tcase.name, err, tcase.valid)
+		}
+	}
+}
+
+func TestCheckNetworkPolicies(t *testing.T) {
+	policy := func(name string, network string, devices ...string) *module.TrafficPolicyObject {
+		return &module.TrafficPolicyObject{
+			Metadata:      module.ObjectMetaData{Name: name},
+			Specification: module.TrafficPolicyObjectSpec{Network: network},
+			Status:        module.TrafficPolicyObjectStatus{Devices: devices},
+		}
+	}
+	policies := []module.ControllerObject{
+		policy("gold", "pnetwork", "dev1", "dev2"),
+		policy("silver", "onetwork", "dev3"),
+	}
+
+	tcases := []struct {
+		name    string
+		policy  *module.TrafficPolicyObject
+		devices []string
+		valid   bool
+	}{
+		{"OtherDevices", policy("bronze", "pnetwork"), []string{"dev3"}, true},
+		{"OtherNetwork", policy("bronze", "lnetwork"), []string{"dev1", "dev3"}, true},
+		{"SameDevice", policy("bronze", "pnetwork"), []string{"dev2", "dev3"}, false},
+		{"SamePolicy", policy("gold", "pnetwork"), []string{"dev1", "dev2"}, true},
+		{"NoDevice", policy("bronze", "onetwork"), nil, true},
+	}
+
+	for _, tcase := range tcases {
+		devs := make(map[string]module.ControllerObject)
+		for _, dev := range tcase.devices {
+			devs[dev] = &module.DeviceObject{Metadata: module.ObjectMetaData{Name: dev}}
+		}
+		if err := checkNetworkPolicies(tcase.policy, devs, policies); (err == nil) != tcase.valid {
+			t.Errorf("%s: checkNetworkPolicies() = %v, expected valid %v", tcase.name, err, tcase.valid)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+const (
+	WebhookSignatureHeader = "X-SCC-Signature"
+	WebhookEventHeader     = "X-SCC-Event"
+	WebhookDeliveryHeader  = "X-SCC-Delivery"
+
+	WEBHOOK_TIMEOUT      = 10 * time.Second
+	WEBHOOK_INTERVAL     = 5 * time.Second
+	WEBHOOK_MAX_ATTEMPTS = 5
+	// number of the last deliveries kept for a webhook
+	WEBHOOK_DELIVERY_RETENTION = 100
+	// interval of the checks of the certificates and the cnfs
+	WEBHOOK_CHECK_INTERVAL = 10 * time.Minute
+	// certificates which expire within this time are notified
+	WEBHOOK_CERT_EXPIRY = 30 * 24 * time.Hour
+
+	// status reported in CNFStatus for a cnf which is not running
+	CNF_NOT_AVAILABLE = "Not Available"
+)
+
+// WebhookDeliveryKey is the key of a delivery. The webhook is stored with
+// its own key field so that the deliveries do not collide with the webhook
+// objects in the same collection
+type WebhookDeliveryKey struct {
+	OverlayName string `json:"overlay-name"`
+	WebhookName string `json:"delivery-webhook-name"`
+	DeliveryId  string `json:"delivery-id"`
+}
+
+// healthAlert is a health condition of an overlay found by the checks
+type healthAlert struct {
+	overlay string
+	event   string
+	object  string
+	message string
+}
+
+// WebhookNotifier posts the health events of the overlays to the webhooks
+// which subscribe them. A payload is retried with backoff until the webhook
+// accepts it and each attempt is recorded in the database. The certificates
+// and the cnfs are checked periodically, a condition is notified once until
+// it is resolved
+type WebhookNotifier struct {
+	storeName string
+	tagMeta   string
+	mux       sync.Mutex
+	client    *http.Client
+	// delay before the first retry, doubled for each retry
+	interval time.Duration
+	alerted  map[string]bool
+	started  bool
+}
+
+var webhooknotifier = WebhookNotifier{
+	storeName: StoreName,
+	tagMeta:   "webhook-delivery",
+	client:    &http.Client{Timeout: WEBHOOK_TIMEOUT},
+	interval:  WEBHOOK_INTERVAL,
+	alerted:   make(map[string]bool),
+}
+
+func GetWebhookNotifier() *WebhookNotifier {
+	return &webhooknotifier
+}
+
+// SignWebhookPayload returns the signature of a payload posted to a webhook
+// with the secret, a receiver computes it over the raw body to verify the
+// X-SCC-Signature header
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify posts the event to the webhooks of the overlay which subscribe it,
+// the object is the kind and the name of the object in trouble. The
+// payloads are posted in the background
+func (n *WebhookNotifier) Notify(overlay string, event string, object string, message string) {
+	mgr := GetManagerset().Webhook
+	if mgr == nil {
+		return
+	}
+
+	webhooks, err := mgr.GetObjects(map[string]string{OverlayResource: overlay})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, t := range webhooks {
+		w := t.(*module.WebhookObject)
+		if !w.IsSubscribed(event) {
+			continue
+		}
+
+		id := fmt.Sprintf("%d-%04d", time.Now().UnixNano(), rand.Intn(10000))
+		d := module.WebhookDelivery{
+			Id:      id,
+			Webhook: w.Metadata.Name,
+			Payload: module.WebhookPayload{
+				Id:      id,
+				Time:    time.Now().UTC(),
+				Event:   event,
+				Overlay: overlay,
+				Object:  object,
+				Message: message,
+			},
+		}
+		go n.deliver(overlay, *w, d)
+	}
+}
+
+// deliver posts the payload until the webhook accepts it or the attempts
+// run out
+func (n *WebhookNotifier) deliver(overlay string, w module.WebhookObject, d module.WebhookDelivery) {
+	body, err := json.Marshal(d.Payload)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	delay := n.interval
+	for {
+		d.Attempts++
+		d.LastAttempt = time.Now().UTC()
+		d.StatusCode, err = n.post(w, d, body)
+		d.Delivered = err == nil
+		d.LastError = ""
+		if err != nil {
+			d.LastError = err.Error()
+		}
+		n.saveDelivery(overlay, d)
+
+		if d.Delivered || d.Attempts >= WEBHOOK_MAX_ATTEMPTS {
+			break
+		}
+		time.Sleep(delay)
+		delay = delay * 2
+	}
+
+	if !d.Delivered {
+		log.Println("Webhook " + w.Metadata.Name + " failed to receive " + d.Payload.Event + " after " +
+			strconv.Itoa(d.Attempts) + " attempts: " + d.LastError)
+	}
+}
+
+func (n *WebhookNotifier) post(w module.WebhookObject, d module.WebhookDelivery, body []byte) (int, error) {
+	req, err := http.NewRequest("POST", w.Specification.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, d.Payload.Event)
+	req.Header.Set(WebhookDeliveryHeader, d.Id)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(w.Specification.Secret, body))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, pkgerrors.New("Webhook responded " + resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// saveDelivery records the delivery and drops the oldest deliveries of the
+// webhook
+func (n *WebhookNotifier) saveDelivery(overlay string, d module.WebhookDelivery) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	key := WebhookDeliveryKey{OverlayName: overlay, WebhookName: d.Webhook, DeliveryId: d.Id}
+	err := db.DBconn.Insert(n.storeName, key, nil, n.tagMeta, d)
+	if err != nil {
+		log.Println("Unable to save delivery " + d.Id + ": " + err.Error())
+		return
+	}
+
+	deliveries, err := n.GetDeliveries(overlay, d.Webhook)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for i := WEBHOOK_DELIVERY_RETENTION; i < len(deliveries); i++ {
+		n.removeDelivery(overlay, deliveries[i])
+	}
+}
+
+func (n *WebhookNotifier) removeDelivery(overlay string, d module.WebhookDelivery) {
+	key := WebhookDeliveryKey{OverlayName: overlay, WebhookName: d.Webhook, DeliveryId: d.Id}
+	err := db.DBconn.Remove(n.storeName, key)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// GetDeliveries returns the recorded deliveries of a webhook, the latest
+// first
+func (n *WebhookNotifier) GetDeliveries(overlay string, webhook string) ([]module.WebhookDelivery, error) {
+	deliveries := []module.WebhookDelivery{}
+	key := WebhookDeliveryKey{OverlayName: overlay, WebhookName: webhook, DeliveryId: ""}
+	values, err := db.DBconn.Find(n.storeName, key, n.tagMeta)
+	if err != nil {
+		return deliveries, pkgerrors.Wrap(err, "Get Webhook Deliveries")
+	}
+
+	for _, value := range values {
+		var d module.WebhookDelivery
+		err = db.DBconn.Unmarshal(value, &d)
+		if err != nil {
+			return deliveries, pkgerrors.Wrap(err, "Unmarshaling value")
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].Payload.Time.After(deliveries[j].Payload.Time)
+	})
+	return deliveries, nil
+}
+
+// Start starts the periodic checks of the certificates and the cnfs
+func (n *WebhookNotifier) Start() {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if n.started {
+		return
+	}
+	n.started = true
+
+	go func() {
+		for {
+			n.check()
+			time.Sleep(WEBHOOK_CHECK_INTERVAL)
+		}
+	}()
+}
+
+// check notifies the health conditions found since the last check, only
+// the overlays with subscribed webhooks are checked
+func (n *WebhookNotifier) check() {
+	mgrset := GetManagerset()
+	alerts := make(map[string]healthAlert)
+
+	overlays, err := mgrset.Overlay.GetObjects(map[string]string{})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, overlay := range overlays {
+		overlay_name := overlay.GetMetadata().Name
+		webhooks, err := mgrset.Webhook.GetObjects(map[string]string{OverlayResource: overlay_name})
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		certs, cnfs := false, false
+		for _, t := range webhooks {
+			w := t.(*module.WebhookObject)
+			certs = certs || w.IsSubscribed(module.NotifyCertificateExpiring)
+			cnfs = cnfs || w.IsSubscribed(module.NotifyCNFNotAvailable)
+		}
+		if certs {
+			checkCertificates(overlay_name, alerts)
+		}
+		if cnfs {
+			checkCNFs(overlay_name, alerts)
+		}
+	}
+
+	n.mux.Lock()
+	notified := n.alerted
+	n.alerted = make(map[string]bool)
+	for key := range alerts {
+		n.alerted[key] = true
+	}
+	n.mux.Unlock()
+
+	for key, alert := range alerts {
+		if !notified[key] {
+			n.Notify(alert.overlay, alert.event, alert.object, alert.message)
+		}
+	}
+}
+
+// checkCertificates finds the certificates of the overlay which expire soon
+func checkCertificates(overlay_name string, alerts map[string]healthAlert) {
+	cert_manager := GetManagerset().Cert
+	certs, err := cert_manager.GetObjects(map[string]string{OverlayResource: overlay_name})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	cu, err := GetCertUtil()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, t := range certs {
+		to := t.(*module.CertificateObject)
+		cert_name := cert_manager.GetCertName(to.Metadata.Name, to.Specification.ClusterType)
+		cert, _, err := cu.GetKeypair(cert_name, NameSpaceName)
+		if err != nil {
+			continue
+		}
+
+		expiry, err := certificateExpiry(cert)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if time.Until(expiry) < WEBHOOK_CERT_EXPIRY {
+			alerts[overlay_name+"/certificate/"+cert_name+"/"+expiry.String()] = healthAlert{
+				overlay: overlay_name,
+				event:   module.NotifyCertificateExpiring,
+				object:  "Certificate/" + to.Metadata.Name,
+				message: "Certificate " + cert_name + " expires at " + expiry.UTC().Format(time.RFC3339),
+			}
+		}
+	}
+}
+
+// certificateExpiry returns the expiry time of a base64 encoded PEM
+// certificate
+func certificateExpiry(cert string) (time.Time, error) {
+	data, err := base64.StdEncoding.DecodeString(cert)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, pkgerrors.New("Invalid certificate")
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.NotAfter, nil
+}
+
+// checkCNFs finds the cnfs of the hubs and the registered devices of the
+// overlay which are reported as not available
+func checkCNFs(overlay_name string, alerts map[string]healthAlert) {
+	mgrset := GetManagerset()
+	m := map[string]string{OverlayResource: overlay_name}
+
+	hubs, err := mgrset.Hub.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+	}
+	for _, hub := range hubs {
+		checkClusterCNFs(mgrset.HubCNF, overlay_name, HubResource, hub.GetMetadata().Name, "HubCNF", alerts)
+	}
+
+	devices, err := mgrset.Device.GetObjects(m)
+	if err != nil {
+		log.Println(err)
+	}
+	for _, t := range devices {
+		dev := t.(*module.DeviceObject)
+		if dev.Status.Data[RegStatus] != "success" {
+			continue
+		}
+		checkClusterCNFs(mgrset.DeviceCNF, overlay_name, DeviceResource, dev.Metadata.Name, "DeviceCNF", alerts)
+	}
+}
+
+func checkClusterCNFs(mgr *CNFObjectManager, overlay_name string, resource string, cluster_name string,
+	kind string, alerts map[string]healthAlert) {
+	objs, err := mgr.GetObjects(map[string]string{OverlayResource: overlay_name, resource: cluster_name})
+	if err != nil || len(objs) == 0 {
+		return
+	}
+
+	var status struct {
+		Information []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"information"`
+	}
+	err = json.Unmarshal([]byte(objs[0].(*module.CNFObject).Status), &status)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, info := range status.Information {
+		if info.Status != CNF_NOT_AVAILABLE {
+			continue
+		}
+		object := kind + "/" + cluster_name + "/" + info.Name
+		alerts[overlay_name+"/"+object] = healthAlert{
+			overlay: overlay_name,
+			event:   module.NotifyCNFNotAvailable,
+			object:  object,
+			message: "CNF " + info.Name + " of " + cluster_name + " is not available",
+		}
+	}
+}
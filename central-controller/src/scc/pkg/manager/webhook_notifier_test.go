@@ -0,0 +1,137 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+type receivedPayload struct {
+	header  http.Header
+	payload module.WebhookPayload
+	signed  bool
+}
+
+// webhookStandIn is a local webhook which fails the first requests
+func webhookStandIn(failures int) (*httptest.Server, chan receivedPayload) {
+	received := make(chan receivedPayload, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var p receivedPayload
+		p.header = r.Header
+		p.signed = r.Header.Get(WebhookSignatureHeader) == SignWebhookPayload(testWebhookSecret, body)
+		json.Unmarshal(body, &p.payload)
+		received <- p
+
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return server, received
+}
+
+func createTestWebhook(t *testing.T, url string, events []string) {
+	db.DBconn = &db.NewMockDB{}
+	GetManagerset().Webhook = NewWebhookObjectManager()
+	GetWebhookNotifier().interval = 10 * time.Millisecond
+
+	m := map[string]string{OverlayResource: "overlay1"}
+	_, err := GetManagerset().Webhook.CreateObject(m, &module.WebhookObject{
+		Metadata:      module.ObjectMetaData{Name: "webhook1"},
+		Specification: module.WebhookObjectSpec{Url: url, Secret: testWebhookSecret, Events: events},
+	})
+	if err != nil {
+		t.Fatalf("Create webhook1: %s", err.Error())
+	}
+}
+
+func TestWebhookRetry(t *testing.T) {
+	server, received := webhookStandIn(1)
+	defer server.Close()
+	createTestWebhook(t, server.URL, []string{})
+
+	GetWebhookNotifier().Notify("overlay1", module.NotifyConnectionError, "Connection/conn1", "timeout")
+
+	for i := 0; i < 2; i++ {
+		select {
+		case p := <-received:
+			if !p.signed {
+				t.Errorf("Attempt %d: invalid signature %s", i+1, p.header.Get(WebhookSignatureHeader))
+			}
+			if p.header.Get(WebhookEventHeader) != module.NotifyConnectionError || p.payload.Object != "Connection/conn1" ||
+				p.payload.Overlay != "overlay1" || p.payload.Message != "timeout" {
+				t.Errorf("Attempt %d: unexpected payload %v", i+1, p.payload)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Attempt %d: no payload", i+1)
+		}
+	}
+
+	// the delivery is recorded after the attempt
+	delivered := false
+	for i := 0; i < 50 && !delivered; i++ {
+		time.Sleep(10 * time.Millisecond)
+		deliveries, err := GetWebhookNotifier().GetDeliveries("overlay1", "webhook1")
+		if err != nil {
+			t.Fatalf("Get deliveries: %s", err.Error())
+		}
+		for _, d := range deliveries {
+			delivered = delivered || (d.Delivered && d.Attempts == 2 && d.StatusCode == http.StatusNoContent)
+		}
+	}
+	if !delivered {
+		t.Errorf("Delivery after 2 attempts is not recorded")
+	}
+}
+
+func TestWebhookEventFilter(t *testing.T) {
+	server, received := webhookStandIn(0)
+	defer server.Close()
+	createTestWebhook(t, server.URL, []string{module.NotifyRegistrationFailed})
+
+	GetWebhookNotifier().Notify("overlay1", module.NotifyConnectionError, "Connection/conn1", "timeout")
+	GetWebhookNotifier().Notify("overlay2", module.NotifyRegistrationFailed, "Device/device1", "unauthorized")
+	GetWebhookNotifier().Notify("overlay1", module.NotifyRegistrationFailed, "Device/device1", "unauthorized")
+
+	select {
+	case p := <-received:
+		if p.payload.Event != module.NotifyRegistrationFailed || p.payload.Overlay != "overlay1" {
+			t.Errorf("Unexpected payload %v", p.payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("No payload")
+	}
+
+	select {
+	case p := <-received:
+		t.Errorf("Unexpected payload %v", p.payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
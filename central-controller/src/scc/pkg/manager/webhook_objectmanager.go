@@ -0,0 +1,157 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+type WebhookObjectKey struct {
+	OverlayName string `json:"overlay-name"`
+	WebhookName string `json:"webhook-name"`
+}
+
+// WebhookObjectManager implements the ControllerObjectManager
+type WebhookObjectManager struct {
+	BaseObjectManager
+}
+
+func NewWebhookObjectManager() *WebhookObjectManager {
+	return &WebhookObjectManager{
+		BaseObjectManager{
+			storeName:      StoreName,
+			tagMeta:        "webhook",
+			depResManagers: []ControllerObjectManager{},
+			ownResManagers: []ControllerObjectManager{},
+		},
+	}
+}
+
+func (c *WebhookObjectManager) GetResourceName() string {
+	return WebhookResource
+}
+
+func (c *WebhookObjectManager) IsOperationSupported(oper string) bool {
+	return true
+}
+
+func (c *WebhookObjectManager) CreateEmptyObject() module.ControllerObject {
+	return &module.WebhookObject{}
+}
+
+func (c *WebhookObjectManager) GetStoreKey(m map[string]string, t module.ControllerObject, isCollection bool) (db.Key, error) {
+	overlay_name := m[OverlayResource]
+	key := WebhookObjectKey{
+		OverlayName: overlay_name,
+		WebhookName: "",
+	}
+
+	if isCollection == true {
+		return key, nil
+	}
+
+	to := t.(*module.WebhookObject)
+	meta_name := to.Metadata.Name
+	res_name := m[WebhookResource]
+
+	if res_name != "" {
+		if meta_name != "" && res_name != meta_name {
+			return key, pkgerrors.New("Resource name unmatched metadata name")
+		}
+
+		key.WebhookName = res_name
+	} else {
+		if meta_name == "" {
+			return key, pkgerrors.New("Unable to find resource name")
+		}
+
+		key.WebhookName = meta_name
+	}
+
+	return key, nil
+}
+
+func (c *WebhookObjectManager) ParseObject(r io.Reader) (module.ControllerObject, error) {
+	var v module.WebhookObject
+	err := json.NewDecoder(r).Decode(&v)
+
+	return &v, err
+}
+
+func (c *WebhookObjectManager) CreateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().CreateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *WebhookObjectManager) GetObject(m map[string]string) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObject(c, m)
+
+	return t, err
+}
+
+func (c *WebhookObjectManager) GetObjects(m map[string]string) ([]module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().GetObjects(c, m)
+
+	return t, err
+}
+
+func (c *WebhookObjectManager) IsStoreListed() bool {
+	return true
+}
+
+func (c *WebhookObjectManager) UpdateObject(m map[string]string, t module.ControllerObject) (module.ControllerObject, error) {
+	// DB Operation
+	t, err := GetDBUtils().UpdateObject(c, m, t)
+
+	return t, err
+}
+
+func (c *WebhookObjectManager) DeleteObject(m map[string]string) error {
+	// DB Operation
+	err := GetDBUtils().DeleteObject(c, m)
+	if err == nil {
+		deliveries, err := GetWebhookNotifier().GetDeliveries(m[OverlayResource], m[WebhookResource])
+		if err != nil {
+			log.Println(err)
+		}
+		for _, d := range deliveries {
+			GetWebhookNotifier().removeDelivery(m[OverlayResource], d)
+		}
+	}
+
+	return err
+}
+
+// GetDeliveries returns the deliveries of the webhook, the latest first
+func (c *WebhookObjectManager) GetDeliveries(m map[string]string) ([]module.WebhookDelivery, error) {
+	_, err := c.GetObject(m)
+	if err != nil {
+		return []module.WebhookDelivery{}, pkgerrors.Wrap(err, "Webhook "+m[WebhookResource]+" is not defined")
+	}
+
+	return GetWebhookNotifier().GetDeliveries(m[OverlayResource], m[WebhookResource])
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	IPSEC_TUNNEL     = "ipsec"
+	WIREGUARD_TUNNEL = "wireguard"
+	// first port the hubs listen on, each connection of a hub has its own
+	// interface and port
+	WIREGUARD_PORT      = 51820
+	WIREGUARD_KEEPALIVE = "25"
+)
+
+var wireguard_mux = sync.Mutex{}
+
+type WireguardObjectKey struct {
+	OverlayName string `json:"overlay-name"`
+	End1        string `json:"wireguard-end1-name"`
+	End2        string `json:"wireguard-end2-name"`
+}
+
+// WireguardObject keeps the keys of both ends of a connection and the port
+// the hub listens on, it is only kept in the db
+type WireguardObject struct {
+	Ends        []string `json:"ends"`
+	PrivateKey1 string   `json:"private-key1" encrypted:""`
+	PrivateKey2 string   `json:"private-key2" encrypted:""`
+	Port        int      `json:"port"`
+}
+
+// the object is shared by both ends, so it is stored with the ends sorted
+func wireguardStoreKey(overlay string, end1 string, end2 string) WireguardObjectKey {
+	if end1 > end2 {
+		end1, end2 = end2, end1
+	}
+
+	return WireguardObjectKey{
+		OverlayName: overlay,
+		End1:        end1,
+		End2:        end2,
+	}
+}
+
+// PrivateKey returns the private key of an end of the connection
+func (o *WireguardObject) PrivateKey(end string) string {
+	if len(o.Ends) > 0 && o.Ends[0] == end {
+		return o.PrivateKey1
+	}
+	return o.PrivateKey2
+}
+
+// generateWireguardKey generates a private key the same way as wg genkey
+func generateWireguardKey() (string, error) {
+	b := make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Fail to generate WireGuard key")
+	}
+	b[0] &= 248
+	b[31] = (b[31] & 127) | 64
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// wireguardPublicKey returns the public key of a private key
+func wireguardPublicKey(private_key string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(private_key)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Invalid WireGuard key")
+	}
+
+	pub, err := curve25519.X25519(b, curve25519.Basepoint)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Invalid WireGuard key")
+	}
+
+	return base64.StdEncoding.EncodeToString(pub), nil
+}
+
+func getWireguardObject(overlay string, end1 string, end2 string) (*WireguardObject, error) {
+	value, err := db.DBconn.Find(StoreName, wireguardStoreKey(overlay, end1, end2), "wireguard")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) == 0 {
+		return nil, pkgerrors.New("No Object")
+	}
+
+	var obj WireguardObject
+	err = db.DBconn.Unmarshal(value[0], &obj)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Unmarshaling value")
+	}
+
+	return &obj, nil
+}
+
+// wireguardPorts returns the ports used by the connections of an end
+func wireguardPorts(overlay string, end string) (map[int]bool, error) {
+	ports := make(map[int]bool)
+	for _, key := range []WireguardObjectKey{{overlay, end, ""}, {overlay, "", end}} {
+		values, err := db.DBconn.Find(StoreName, key, "wireguard")
+		if err != nil {
+			return ports, pkgerrors.Wrap(err, "Get WireGuard Objects")
+		}
+
+		for _, value := range values {
+			var obj WireguardObject
+			err = db.DBconn.Unmarshal(value, &obj)
+			if err != nil {
+				return ports, pkgerrors.Wrap(err, "Unmarshaling values")
+			}
+			ports[obj.Port] = true
+		}
+	}
+
+	return ports, nil
+}
+
+// GetOrCreateWireguardObject returns the keys and the port of a connection
+// between a hub and a device, they are generated for a new connection
+func GetOrCreateWireguardObject(overlay string, hub string, device string) (*WireguardObject, error) {
+	wireguard_mux.Lock()
+	defer wireguard_mux.Unlock()
+
+	obj, err := getWireguardObject(overlay, hub, device)
+	if err == nil {
+		return obj, nil
+	}
+
+	ports, err := wireguardPorts(overlay, hub)
+	if err != nil {
+		return nil, err
+	}
+	port := WIREGUARD_PORT
+	for ports[port] {
+		port++
+	}
+
+	k := wireguardStoreKey(overlay, hub, device)
+	obj = &WireguardObject{
+		Ends: []string{k.End1, k.End2},
+		Port: port,
+	}
+	obj.PrivateKey1, err = generateWireguardKey()
+	if err != nil {
+		return nil, err
+	}
+	obj.PrivateKey2, err = generateWireguardKey()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.DBconn.Insert(StoreName, k, nil, "wireguard", obj)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Unable to save WireGuard keys")
+	}
+
+	return obj, nil
+}
+
+func DeleteWireguardObject(overlay string, end1 string, end2 string) {
+	// most connections do not use WireGuard
+	if _, err := getWireguardObject(overlay, end1, end2); err != nil {
+		return
+	}
+
+	err := db.DBconn.Remove(StoreName, wireguardStoreKey(overlay, end1, end2))
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// getTunnelType returns the tunnel type of the hub-to-device connections of
+// an overlay: ipsec (default) or wireguard
+func getTunnelType(overlay string) string {
+	m := make(map[string]string)
+	m[OverlayResource] = overlay
+	o, err := GetManagerset().Overlay.GetObject(m)
+	if err == nil && o.(*module.OverlayObject).Specification.TunnelType != "" {
+		return o.(*module.OverlayObject).Specification.TunnelType
+	}
+
+	return IPSEC_TUNNEL
+}
+
+// wireguardIfName returns the name of the interface to a remote end, it is
+// limited to 15 characters
+func wireguardIfName(ip string) string {
+	if v4 := net.ParseIP(ip).To4(); v4 != nil {
+		return "wg" + hex.EncodeToString(v4)
+	}
+	return "wg" + format_ip_as_suffix(ip)
+}
+
+// tunnelDevice returns the device used by a device to reach the overlay
+// through a hub
+func tunnelDevice(tunnel string, dev_ip string, hub_ip string) string {
+	if tunnel == WIREGUARD_TUNNEL {
+		return wireguardIfName(hub_ip)
+	}
+	return "#" + dev_ip
+}
+
+// wireguardResources returns the resources of a hub-to-device connection
+// using WireGuard: the hub listens on its own port for each device and the
+// device keeps the connection open as it may be behind a NAT
+func wireguardResources(overlay string, hub *module.HubObject, dev *module.DeviceObject, hub_ip string, dev_ip string) ([]resource.ISdewanResource, []resource.ISdewanResource, error) {
+	hub_end := module.CreateEndName(hub.GetType(), hub.Metadata.Name)
+	dev_end := module.CreateEndName(dev.GetType(), dev.Metadata.Name)
+	obj, err := GetOrCreateWireguardObject(overlay, hub_end, dev_end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hub_key := obj.PrivateKey(hub_end)
+	dev_key := obj.PrivateKey(dev_end)
+	hub_pub, err := wireguardPublicKey(hub_key)
+	if err != nil {
+		return nil, nil, err
+	}
+	dev_pub, err := wireguardPublicKey(dev_key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	port := strconv.Itoa(obj.Port)
+	hub_if := wireguardIfName(dev_ip)
+	dev_if := wireguardIfName(hub_ip)
+
+	hub_res := []resource.ISdewanResource{
+		&resource.WireguardInterfaceResource{
+			Name:       hub_if,
+			PrivateKey: hub_key,
+			ListenPort: port,
+		},
+		&resource.WireguardPeerResource{
+			Name:            hub_if,
+			Interface:       hub_if,
+			PublicKey:       dev_pub,
+			AllowedIps:      []string{dev_ip + "/32"},
+			RouteAllowedIps: true,
+		},
+	}
+	dev_res := []resource.ISdewanResource{
+		&resource.WireguardInterfaceResource{
+			Name:       dev_if,
+			PrivateKey: dev_key,
+			Addresses:  []string{dev_ip + "/32"},
+		},
+		&resource.WireguardPeerResource{
+			Name:                dev_if,
+			Interface:           dev_if,
+			PublicKey:           hub_pub,
+			EndpointHost:        hub_ip,
+			EndpointPort:        port,
+			AllowedIps:          []string{WILDCARD_SUBNET + "/0"},
+			PersistentKeepalive: WIREGUARD_KEEPALIVE,
+		},
+	}
+
+	return hub_res, dev_res, nil
+}
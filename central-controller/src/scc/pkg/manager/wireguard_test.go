@@ -0,0 +1,73 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestWireguardKeys(t *testing.T) {
+	// test vector of RFC 7748
+	private, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	public, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	pub, err := wireguardPublicKey(base64.StdEncoding.EncodeToString(private))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub != base64.StdEncoding.EncodeToString(public) {
+		t.Errorf("wireguardPublicKey() = %s", pub)
+	}
+
+	key, err := generateWireguardKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(b) != 32 || b[0]&7 != 0 || b[31]&192 != 64 {
+		t.Errorf("generateWireguardKey() = %s is not a clamped key", key)
+	}
+	if _, err := wireguardPublicKey("not a key"); err == nil {
+		t.Errorf("wireguardPublicKey() accepted an invalid key")
+	}
+}
+
+func TestWireguardIfName(t *testing.T) {
+	if name := wireguardIfName("10.10.10.1"); name != "wg0a0a0a01" {
+		t.Errorf("wireguardIfName() = %s", name)
+	}
+	if d := tunnelDevice(WIREGUARD_TUNNEL, "192.168.0.3", "10.10.10.1"); d != "wg0a0a0a01" {
+		t.Errorf("tunnelDevice() = %s", d)
+	}
+	if d := tunnelDevice(IPSEC_TUNNEL, "192.168.0.3", "10.10.10.1"); d != "#192.168.0.3" {
+		t.Errorf("tunnelDevice() = %s", d)
+	}
+}
+
+func TestWireguardStoreKey(t *testing.T) {
+	k1 := wireguardStoreKey("overlay1", "Hub.hub1", "Device.dev1")
+	k2 := wireguardStoreKey("overlay1", "Device.dev1", "Hub.hub1")
+	if k1 != k2 || k1.End1 != "Device.dev1" {
+		t.Errorf("wireguardStoreKey() = %v, %v", k1, k2)
+	}
+
+	obj := &WireguardObject{Ends: []string{k1.End1, k1.End2}, PrivateKey1: "key1", PrivateKey2: "key2"}
+	if obj.PrivateKey("Hub.hub1") != "key2" || obj.PrivateKey("Device.dev1") != "key1" {
+		t.Errorf("PrivateKey() does not follow the ends")
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// ApplicationObject exposes the pods of an application through the CNF
+type ApplicationObject struct {
+	Metadata      ObjectMetaData        `json:"metadata"`
+	Specification ApplicationObjectSpec `json:"spec"`
+}
+
+// ApplicationObjectSpec contains the parameters
+type ApplicationObjectSpec struct {
+	PodLabels    map[string]string `json:"podLabels" validate:"required,min=1"`
+	AppNamespace string            `json:"appNamespace"`
+	ServicePort  string            `json:"servicePort" validate:"omitempty,numeric"`
+	CNFPort      string            `json:"cnfPort" validate:"omitempty,numeric"`
+}
+
+func (c *ApplicationObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *ApplicationObject) GetType() string {
+	return "Application"
+}
+
+func (c *ApplicationObject) ToResource() resource.ISdewanResource {
+	return &resource.ApplicationResource{
+		Name:         strings.ToLower(c.Metadata.Name),
+		PodLabels:    c.Specification.PodLabels,
+		AppNamespace: c.Specification.AppNamespace,
+		ServicePort:  c.Specification.ServicePort,
+		CNFPort:      c.Specification.CNFPort,
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+// BootstrapRequest is sent by a device to onboard itself with the token
+// given by the operator
+type BootstrapRequest struct {
+	Overlay string `json:"overlay" validate:"required"`
+	Device  string `json:"device" validate:"required"`
+	Token   string `json:"token" validate:"required"`
+	// base64 encoded PEM certificate signing request of the device
+	Csr string `json:"csr" validate:"required,base64"`
+	// base64 encoded kubeconfig of the device
+	KubeConfig string   `json:"kubeConfig" validate:"required,base64"`
+	PublicIps  []string `json:"publicIps" validate:"dive,ip"`
+}
+
+// BootstrapResponse returns the signed device certificate
+type BootstrapResponse struct {
+	// base64 encoded PEM certificate of the device
+	Cert string `json:"cert"`
+	// base64 encoded PEM certificate chain of the overlay
+	CA string `json:"ca"`
+}
@@ -39,6 +39,10 @@ type ConnectionResource struct {
 	ConnObject string `json:"-"`
 	Name       string `json:"-"`
 	Type       string `json:"-"`
+	// serialized resource, kept for the resources which are re-rendered
+	// after the connection is set up (e.g. Ipsec). It holds the keys of
+	// the connection, so it is encrypted like the kubeconfigs
+	Data string `json:"-" encrypted:""`
 }
 
 type ConnectionObject struct {
@@ -118,7 +122,7 @@ func NewConnectionEnd(conn_obj ControllerObject, ip string) ConnectionEnd {
 
 func NewConnectionObject(end1 ConnectionEnd, end2 ConnectionEnd) ConnectionObject {
 	return ConnectionObject{
-		Metadata: ObjectMetaData{CreateConnectionName(end1.Name, end2.Name), "", "", ""},
+		Metadata: ObjectMetaData{Name: CreateConnectionName(end1.Name, end2.Name)},
 		Info: ConnectionInfo{
 			End1:         end1,
 			End2:         end2,
@@ -129,10 +133,10 @@ func NewConnectionObject(end1 ConnectionEnd, end2 ConnectionEnd) ConnectionObjec
 	}
 }
 
-func (c *ConnectionInfo) AddResource(device ControllerObject, resource string, res_type string) {
+func (c *ConnectionInfo) AddResource(device ControllerObject, resource string, res_type string, data string) {
 	dev_str, err := GetObjectBuilder().ToString(device)
 	if err == nil {
-		c.Resources = append(c.Resources, ConnectionResource{dev_str, resource, res_type})
+		c.Resources = append(c.Resources, ConnectionResource{dev_str, resource, res_type, data})
 	} else {
 		log.Println(err)
 	}
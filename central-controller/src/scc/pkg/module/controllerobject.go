@@ -16,6 +16,10 @@
 
 package module
 
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
 // ControllerObject define the basic functionality of ControllerObject
 type ControllerObject interface {
 	GetMetadata() ObjectMetaData
@@ -28,4 +32,15 @@ type ObjectMetaData struct {
 	Description string `json:"description"`
 	UserData1   string `json:"userData1"`
 	UserData2   string `json:"userData2"`
+	// labels select the objects, e.g. region=emea
+	Labels map[string]string `json:"labels,omitempty" validate:"dive,keys,min=1,max=63,excludesall=!=()0x2C,endkeys,max=63,excludesall=()0x2C"`
+	// annotations keep arbitrary data of the tools managing the objects
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DeployableObject is a ControllerObject which is deployed as a single
+// SDEWAN CR to the hub or device it belongs to
+type DeployableObject interface {
+	ControllerObject
+	ToResource() resource.ISdewanResource
 }
@@ -34,6 +34,14 @@ type DeviceObjectSpec struct {
 	CertificateId        string       `json:"certificateId"`
 	KubeConfig           string       `json:"kubeConfig" encrypted:""`
 	GitOpsParam          GitOpsParams `json:"gitOpsParam"`
+	// overrides the authentication method of the overlay: pubkey or psk
+	AuthMode string `json:"authMode" validate:"omitempty,oneof=pubkey psk"`
+	// one-time token presented by the device in zero-touch onboarding,
+	// the kubeConfig is provided by the device itself
+	BootstrapToken string `json:"bootstrapToken" encrypted:"" validate:"omitempty,min=16"`
+	// subnets of the site of the device, advertised to the overlay when it
+	// uses bgp routing
+	Subnets []string `json:"subnets" validate:"dive,cidrv4"`
 }
 
 type GitOpsParams struct {
@@ -53,8 +61,11 @@ type DeviceObjectStatus struct {
 	// DataIps saves the overlay ips assigned for different traffic tunnel
 	DataIps map[string]string
 	// Status Data
-	Data         map[string]string
+	Data map[string]string
+	// DelegatedHub is the primary hub of the internet traffic
 	DelegatedHub string
+	// DelegatedHubs are the hubs of the internet traffic in order of priority
+	DelegatedHubs []string
 }
 
 func (c *DeviceObject) GetMetadata() ObjectMetaData {
@@ -65,6 +76,15 @@ func (c *DeviceObject) GetType() string {
 	return "Device"
 }
 
+// GetDelegatedHubs returns the hubs of the internet traffic, including the
+// primary hub recorded before multiple hubs were supported
+func (c *DeviceObject) GetDelegatedHubs() []string {
+	if len(c.Status.DelegatedHubs) == 0 && c.Status.DelegatedHub != "" {
+		return []string{c.Status.DelegatedHub}
+	}
+	return c.Status.DelegatedHubs
+}
+
 func (c *DeviceObject) IsProxyHub(hub_name string) bool {
 	if c.Status.Mode == 2 {
 		return c.Specification.ProxyHub == hub_name
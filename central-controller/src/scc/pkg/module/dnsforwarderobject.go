@@ -0,0 +1,64 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// DnsForwarderObject forwards the DNS queries of an overlay-internal
+// domain (e.g. served by a hub) from the devices of the overlay
+type DnsForwarderObject struct {
+	Metadata      ObjectMetaData           `json:"metadata"`
+	Specification DnsForwarderObjectSpec   `json:"spec"`
+	Status        DnsForwarderObjectStatus `json:"status"`
+}
+
+// DnsForwarderObjectSpec contains the parameters
+type DnsForwarderObjectSpec struct {
+	Domain  string   `json:"domain" validate:"required,fqdn"`
+	Servers []string `json:"servers" validate:"required,min=1,dive,ip"`
+	Port    string   `json:"port" validate:"omitempty,numeric"`
+	// the devices selected by their labels, all the devices of the overlay
+	// by default
+	DeviceSelector string `json:"deviceSelector"`
+}
+
+// DnsForwarderObjectStatus
+type DnsForwarderObjectStatus struct {
+	// devices the forwarder is deployed to
+	Devices []string `json:"devices"`
+}
+
+func (c *DnsForwarderObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *DnsForwarderObject) GetType() string {
+	return "DnsForwarder"
+}
+
+func (c *DnsForwarderObject) ToResource() *resource.DnsForwarderResource {
+	return &resource.DnsForwarderResource{
+		Name:    strings.ToLower(c.Metadata.Name),
+		Domain:  c.Specification.Domain,
+		Servers: c.Specification.Servers,
+		Port:    c.Specification.Port,
+	}
+}
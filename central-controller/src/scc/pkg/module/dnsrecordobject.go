@@ -0,0 +1,62 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// DnsRecordObject resolves an overlay-internal domain (e.g. a service of
+// a hub) locally in the devices of the overlay
+type DnsRecordObject struct {
+	Metadata      ObjectMetaData        `json:"metadata"`
+	Specification DnsRecordObjectSpec   `json:"spec"`
+	Status        DnsRecordObjectStatus `json:"status"`
+}
+
+// DnsRecordObjectSpec contains the parameters
+type DnsRecordObjectSpec struct {
+	Domain string `json:"domain" validate:"required,fqdn"`
+	Ip     string `json:"ip" validate:"required,ipv4"`
+	// the devices selected by their labels, all the devices of the overlay
+	// by default
+	DeviceSelector string `json:"deviceSelector"`
+}
+
+// DnsRecordObjectStatus
+type DnsRecordObjectStatus struct {
+	// devices the record is deployed to
+	Devices []string `json:"devices"`
+}
+
+func (c *DnsRecordObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *DnsRecordObject) GetType() string {
+	return "DnsRecord"
+}
+
+func (c *DnsRecordObject) ToResource() *resource.DnsRecordResource {
+	return &resource.DnsRecordResource{
+		Name:   strings.ToLower(c.Metadata.Name),
+		Domain: c.Specification.Domain,
+		Ip:     c.Specification.Ip,
+	}
+}
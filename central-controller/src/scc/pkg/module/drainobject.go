@@ -0,0 +1,32 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+// DrainRequest moves the devices connected to a hub to another hub
+type DrainRequest struct {
+	// hub which takes over the devices
+	TargetHub string `json:"targetHub" validate:"required"`
+	// devices to move, all of the connected devices if empty
+	Devices []string `json:"devices"`
+}
+
+// DrainResponse reports the devices moved by a drain
+type DrainResponse struct {
+	Migrated []string `json:"migrated"`
+	// the error of each device which is still connected to the hub
+	Failed map[string]string `json:"failed"`
+}
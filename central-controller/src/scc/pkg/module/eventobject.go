@@ -0,0 +1,45 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventCreated      = "created"
+	EventUpdated      = "updated"
+	EventDeleted      = "deleted"
+	EventStateChanged = "state-changed"
+)
+
+// Event is a change of an object stored by scc. The events are numbered in
+// the order they happen, a client resumes the stream from the sequence
+// number of the last event it received
+type Event struct {
+	Seq     uint64    `json:"seq"`
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Overlay string    `json:"overlay,omitempty"`
+	// store meta of the object, e.g. hub or connection
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// the object after the change with its secrets removed, it is empty
+	// for a deleted object
+	Object json.RawMessage `json:"object,omitempty"`
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// FirewallForwardingObject contains the parameters of a zone forwarding on a hub or device
+type FirewallForwardingObject struct {
+	Metadata      ObjectMetaData               `json:"metadata"`
+	Specification FirewallForwardingObjectSpec `json:"spec"`
+}
+
+// FirewallForwardingObjectSpec contains the parameters
+type FirewallForwardingObjectSpec struct {
+	Src    string `json:"src" validate:"required"`
+	Dest   string `json:"dest" validate:"required"`
+	Family string `json:"family" validate:"omitempty,oneof=ipv4 ipv6 any"`
+}
+
+func (c *FirewallForwardingObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *FirewallForwardingObject) GetType() string {
+	return "FirewallForwarding"
+}
+
+func (c *FirewallForwardingObject) ToResource() resource.ISdewanResource {
+	return &resource.FirewallForwardingResource{
+		Name:   strings.ToLower(c.Metadata.Name),
+		Source: c.Specification.Src,
+		Dest:   c.Specification.Dest,
+		Family: c.Specification.Family,
+	}
+}
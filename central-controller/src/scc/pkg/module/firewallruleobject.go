@@ -0,0 +1,77 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// FirewallRuleObject contains the parameters of a firewall rule on a hub or device
+type FirewallRuleObject struct {
+	Metadata      ObjectMetaData         `json:"metadata"`
+	Specification FirewallRuleObjectSpec `json:"spec"`
+}
+
+// FirewallRuleObjectSpec contains the parameters
+type FirewallRuleObjectSpec struct {
+	Src      string   `json:"src"`
+	SrcIp    string   `json:"srcIp"`
+	SrcMac   string   `json:"srcMac" validate:"omitempty,mac"`
+	SrcPort  string   `json:"srcPort"`
+	Proto    string   `json:"proto"`
+	IcmpType []string `json:"icmpType"`
+	Dest     string   `json:"dest"`
+	DestIp   string   `json:"destIp"`
+	DestPort string   `json:"destPort"`
+	Mark     string   `json:"mark"`
+	Target   string   `json:"target" validate:"omitempty,oneof=ACCEPT REJECT DROP MARK NOTRACK"`
+	SetMark  string   `json:"setMark"`
+	SetXmark string   `json:"setXmark"`
+	Family   string   `json:"family" validate:"omitempty,oneof=ipv4 ipv6 any"`
+	Extra    string   `json:"extra"`
+}
+
+func (c *FirewallRuleObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *FirewallRuleObject) GetType() string {
+	return "FirewallRule"
+}
+
+func (c *FirewallRuleObject) ToResource() resource.ISdewanResource {
+	return &resource.FirewallRuleResource{
+		Name:            strings.ToLower(c.Metadata.Name),
+		Source:          c.Specification.Src,
+		SourceIP:        c.Specification.SrcIp,
+		SourceMac:       c.Specification.SrcMac,
+		SourcePort:      c.Specification.SrcPort,
+		Protocol:        c.Specification.Proto,
+		IcmpType:        c.Specification.IcmpType,
+		Dest:            c.Specification.Dest,
+		DestinationIP:   c.Specification.DestIp,
+		DestinationPort: c.Specification.DestPort,
+		Mark:            c.Specification.Mark,
+		Target:          c.Specification.Target,
+		SetMark:         c.Specification.SetMark,
+		SetXmark:        c.Specification.SetXmark,
+		Family:          c.Specification.Family,
+		Extra:           c.Specification.Extra,
+	}
+}
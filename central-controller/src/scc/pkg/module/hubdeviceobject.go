@@ -22,10 +22,13 @@ type HubDeviceObject struct {
 	Specification HubDeviceObjectSpec `json:"spec"`
 }
 
-//HubDeviceObjectSpec contains the parameters
+// HubDeviceObjectSpec contains the parameters
 type HubDeviceObjectSpec struct {
 	Device        string `json:"device"`
 	IsDelegateHub bool   `json:"isDelegateHub"`
+	// all the registered devices selected by their labels are attached
+	// instead of the device
+	DeviceSelector string `json:"deviceSelector"`
 }
 
 func (c *HubDeviceObject) GetMetadata() ObjectMetaData {
@@ -42,6 +42,21 @@ type HubObjectStatus struct {
 	Data map[string]string
 	// Devices that this hub delegates
 	DelegateDevices []string
+	// device selectors attaching the devices to this hub
+	DeviceSelectors []HubDeviceSelector
+}
+
+// HubDeviceSelector attaches the registered devices selected by their
+// labels to the hub, it is evaluated again when the devices are registered
+// or their labels change
+type HubDeviceSelector struct {
+	Selector      string
+	IsDelegateHub bool
+	// devices attached by the selector
+	Devices []string
+	// devices detached explicitly, they are not attached by the selector
+	// anymore
+	Excluded []string
 }
 
 func (c *HubObject) GetMetadata() ObjectMetaData {
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// Mwan3PolicyObject contains the parameters of a mwan3 policy on a hub or device
+type Mwan3PolicyObject struct {
+	Metadata      ObjectMetaData        `json:"metadata"`
+	Specification Mwan3PolicyObjectSpec `json:"spec"`
+}
+
+// Mwan3PolicyObjectSpec contains the parameters
+type Mwan3PolicyObjectSpec struct {
+	Members []Mwan3PolicyMember `json:"members" validate:"required,min=1,dive"`
+}
+
+type Mwan3PolicyMember struct {
+	Network string `json:"network" validate:"required"`
+	Metric  int    `json:"metric" validate:"min=1,max=256"`
+	Weight  int    `json:"weight" validate:"min=1,max=1000"`
+}
+
+func (c *Mwan3PolicyObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *Mwan3PolicyObject) GetType() string {
+	return "Mwan3Policy"
+}
+
+func (c *Mwan3PolicyObject) ToResource() resource.ISdewanResource {
+	r := &resource.Mwan3PolicyResource{
+		Name:    strings.ToLower(c.Metadata.Name),
+		Members: []resource.Mwan3PolicyMember{},
+	}
+
+	for _, m := range c.Specification.Members {
+		r.Members = append(r.Members, resource.Mwan3PolicyMember{
+			Network: m.Network,
+			Metric:  m.Metric,
+			Weight:  m.Weight,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// Mwan3RuleObject contains the parameters of a mwan3 rule on a hub or device
+type Mwan3RuleObject struct {
+	Metadata      ObjectMetaData      `json:"metadata"`
+	Specification Mwan3RuleObjectSpec `json:"spec"`
+}
+
+// Mwan3RuleObjectSpec contains the parameters
+type Mwan3RuleObjectSpec struct {
+	Policy   string `json:"policy" validate:"required"`
+	SrcIp    string `json:"srcIp"`
+	SrcPort  string `json:"srcPort"`
+	DestIp   string `json:"destIp"`
+	DestPort string `json:"destPort"`
+	Proto    string `json:"proto"`
+	Family   string `json:"family" validate:"omitempty,oneof=ipv4 ipv6 any"`
+	Sticky   string `json:"sticky" validate:"omitempty,oneof=0 1"`
+	Timeout  string `json:"timeout"`
+}
+
+func (c *Mwan3RuleObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *Mwan3RuleObject) GetType() string {
+	return "Mwan3Rule"
+}
+
+func (c *Mwan3RuleObject) ToResource() resource.ISdewanResource {
+	return &resource.Mwan3RuleResource{
+		Name:            strings.ToLower(c.Metadata.Name),
+		Policy:          c.Specification.Policy,
+		SourceIP:        c.Specification.SrcIp,
+		SourcePort:      c.Specification.SrcPort,
+		DestinationIP:   c.Specification.DestIp,
+		DestinationPort: c.Specification.DestPort,
+		Protocol:        c.Specification.Proto,
+		Family:          c.Specification.Family,
+		Sticky:          c.Specification.Sticky,
+		Timeout:         c.Specification.Timeout,
+	}
+}
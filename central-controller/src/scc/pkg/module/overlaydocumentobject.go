@@ -0,0 +1,91 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"encoding/json"
+)
+
+const (
+	OverlayDocumentVersion = "scc/v1"
+
+	// how the secrets (e.g. kubeconfigs) are written in the document
+	SecretsRedacted  = "redacted"
+	SecretsEncrypted = "encrypted"
+
+	// value of a redacted secret, it has to be filled in before import
+	RedactedSecret = "REDACTED"
+
+	// actions of the changes applied to an overlay, objects which can not be
+	// updated are replaced (deleted and created again)
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionReplace = "replace"
+	ActionDelete  = "delete"
+)
+
+// OverlayDocument is the definition of an overlay, the objects are listed
+// in the order they are created by the import
+type OverlayDocument struct {
+	Version string `json:"version" validate:"required,eq=scc/v1"`
+	Secrets string `json:"secrets" validate:"omitempty,oneof=redacted encrypted"`
+	// salt of the key derived from the passphrase of the encrypted secrets
+	Salt    string           `json:"salt,omitempty"`
+	Overlay DocumentObject   `json:"overlay"`
+	Objects []DocumentObject `json:"objects" validate:"dive"`
+}
+
+// DocumentObject is an object of the overlay, the parent is the hub or the
+// device which owns the object. The kind and the parent can be given by the
+// url of the collection of the object instead (e.g. overlays/overlay1/hubs)
+type DocumentObject struct {
+	Kind     string          `json:"kind" validate:"required_without=Anchor"`
+	Parent   string          `json:"parent,omitempty"`
+	Anchor   string          `json:"anchor,omitempty"`
+	Metadata ObjectMetaData  `json:"metadata"`
+	Spec     json.RawMessage `json:"spec"`
+}
+
+// GetKey returns the identity of the object in the document
+func (c *DocumentObject) GetKey() string {
+	if c.Parent != "" {
+		return c.Kind + "/" + c.Parent + "/" + c.Metadata.Name
+	}
+	return c.Kind + "/" + c.Metadata.Name
+}
+
+// ImportResponse reports the objects created by an import
+type ImportResponse struct {
+	Overlay string   `json:"overlay"`
+	Created []string `json:"created"`
+	// the error of each object which is not created
+	Failed map[string]string `json:"failed"`
+}
+
+// ApplyChange is a change of an object made to apply the desired state
+type ApplyChange struct {
+	Action string `json:"action"`
+	Object string `json:"object"`
+}
+
+// ApplyResponse reports the changes made (or to be made in a dry run) to
+// apply the desired state of an overlay
+type ApplyResponse struct {
+	Changes []ApplyChange `json:"changes"`
+	// the error of each object which failed to change
+	Failed map[string]string `json:"failed"`
+}
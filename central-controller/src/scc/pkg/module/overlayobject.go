@@ -24,6 +24,12 @@ type OverlayObject struct {
 
 //OverlayObjectSpec contains the parameters
 type OverlayObjectSpec struct {
+	// authentication method of the connections: pubkey (default) or psk
+	AuthMode string `json:"authMode" validate:"omitempty,oneof=pubkey psk"`
+	// tunnel of the hub-to-device connections: ipsec (default) or wireguard
+	TunnelType string `json:"tunnelType" validate:"omitempty,oneof=ipsec wireguard"`
+	// routing between the hubs and the devices: static (default) or bgp
+	RoutingMode string `json:"routingMode" validate:"omitempty,oneof=static bgp"`
 }
 
 func (c *OverlayObject) GetMetadata() ObjectMetaData {
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+// PreSharedKeyObject is the key of a connection using psk authentication,
+// its name is the peer of the connection (e.g. Hub.hub1)
+type PreSharedKeyObject struct {
+	Metadata      ObjectMetaData         `json:"metadata"`
+	Specification PreSharedKeyObjectSpec `json:"spec"`
+}
+
+// PreSharedKeyObjectSpec contains the parameters
+type PreSharedKeyObjectSpec struct {
+	// a new key is generated if empty
+	Key string `json:"key" encrypted:"" validate:"omitempty,min=16"`
+	// both ends of the connection, only kept in the db
+	Ends []string `json:"ends,omitempty"`
+}
+
+func (c *PreSharedKeyObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *PreSharedKeyObject) GetType() string {
+	return "PreSharedKey"
+}
+
+// GetPeer returns the other end of the connection
+func (c *PreSharedKeyObject) GetPeer(end string) string {
+	for _, e := range c.Specification.Ends {
+		if e != end {
+			return e
+		}
+	}
+
+	return ""
+}
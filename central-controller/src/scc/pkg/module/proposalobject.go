@@ -28,9 +28,60 @@ type ProposalObject struct {
 
 //ProposalObjectSpec contains the parameters
 type ProposalObjectSpec struct {
-	Encryption string `json:"encryption"`
-	Hash       string `json:"hash"`
-	DhGroup    string `json:"dhGroup"`
+	Encryption string `json:"encryption" validate:"required,ipsec_encryption"`
+	Hash       string `json:"hash" validate:"required,ipsec_hash"`
+	DhGroup    string `json:"dhGroup" validate:"required,ipsec_dhgroup"`
+}
+
+// Algorithm keywords supported by strongSwan and accepted by the CNF
+var encryptionAlgorithms = []string{
+	"3des", "cast128", "blowfish128", "blowfish", "blowfish192", "blowfish256", "null",
+	"aes", "aes128", "aes192", "aes256", "aes128ctr", "aes192ctr", "aes256ctr",
+	"aes128ccm8", "aes192ccm8", "aes256ccm8", "aes128ccm64", "aes192ccm64", "aes256ccm64",
+	"aes128ccm12", "aes192ccm12", "aes256ccm12", "aes128ccm96", "aes192ccm96", "aes256ccm96",
+	"aes128ccm16", "aes192ccm16", "aes256ccm16", "aes128ccm128", "aes192ccm128", "aes256ccm128",
+	"aes128gcm8", "aes192gcm8", "aes256gcm8", "aes128gcm64", "aes192gcm64", "aes256gcm64",
+	"aes128gcm12", "aes192gcm12", "aes256gcm12", "aes128gcm96", "aes192gcm96", "aes256gcm96",
+	"aes128gcm16", "aes192gcm16", "aes256gcm16", "aes128gcm128", "aes192gcm128", "aes256gcm128",
+	"camellia128", "camellia192", "camellia256", "camellia",
+	"camellia128ctr", "camellia192ctr", "camellia256ctr",
+	"camellia128ccm8", "camellia192ccm8", "camellia256ccm8", "camellia128ccm64", "camellia192ccm64", "camellia256ccm64",
+	"camellia128ccm12", "camellia192ccm12", "camellia256ccm12", "camellia128ccm96", "camellia192ccm96", "camellia256ccm96",
+	"camellia128ccm16", "camellia192ccm16", "camellia256ccm16", "camellia128ccm128", "camellia192ccm128", "camellia256ccm128",
+	"chacha20poly1305",
+}
+
+var hashAlgorithms = []string{
+	"md5", "sha", "sha1", "aesxcbc", "sha256", "sha2_256", "sha384", "sha2_384",
+	"sha512", "sha2_512", "sha256_96", "sha2_256_96",
+}
+
+var dhGroups = []string{
+	"modp768", "modp1024", "modp1536", "modp2048", "modp3072", "modp4096", "modp6144", "modp8192",
+	"modp1024s160", "modp2048s224", "modp2048s256",
+	"ecp192", "ecp224", "ecp256", "ecp384", "ecp521", "ecp224bp", "ecp256bp", "ecp384bp", "ecp512bp",
+	"curve25519", "x25519", "curve448", "x448",
+}
+
+func contains(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidEncryption(alg string) bool {
+	return contains(encryptionAlgorithms, alg)
+}
+
+func IsValidHash(alg string) bool {
+	return contains(hashAlgorithms, alg)
+}
+
+func IsValidDhGroup(group string) bool {
+	return contains(dhGroups, group)
 }
 
 func (c *ProposalObject) GetMetadata() ObjectMetaData {
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+// ProposalSetObject groups proposals and binds them to connection types
+// and/or devices
+type ProposalSetObject struct {
+	Metadata      ObjectMetaData        `json:"metadata"`
+	Specification ProposalSetObjectSpec `json:"spec"`
+}
+
+// ProposalSetObjectSpec contains the parameters
+type ProposalSetObjectSpec struct {
+	// proposals in order of preference
+	Proposals       []string `json:"proposals" validate:"required,min=1,dive,required"`
+	ConnectionTypes []string `json:"connectionTypes" validate:"dive,oneof=hub-to-hub hub-to-device device-to-device scc-to-device"`
+	Devices         []string `json:"devices"`
+}
+
+func (c *ProposalSetObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *ProposalSetObject) GetType() string {
+	return "ProposalSet"
+}
+
+func (c *ProposalSetObject) HasProposal(name string) bool {
+	return contains(c.Specification.Proposals, name)
+}
+
+func (c *ProposalSetObject) HasConnectionType(conntype string) bool {
+	return contains(c.Specification.ConnectionTypes, conntype)
+}
+
+func (c *ProposalSetObject) HasDevice(name string) bool {
+	return contains(c.Specification.Devices, name)
+}
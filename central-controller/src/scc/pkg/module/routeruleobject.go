@@ -0,0 +1,59 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// RouteRuleObject contains the parameters of a policy routing rule on a hub or device
+type RouteRuleObject struct {
+	Metadata      ObjectMetaData      `json:"metadata"`
+	Specification RouteRuleObjectSpec `json:"spec"`
+}
+
+// RouteRuleObjectSpec contains the parameters
+type RouteRuleObjectSpec struct {
+	Src    string `json:"src" validate:"omitempty,cidr|ip"`
+	Dst    string `json:"dst" validate:"omitempty,cidr|ip"`
+	Not    bool   `json:"not"`
+	Prio   string `json:"prio" validate:"omitempty,numeric"`
+	Fwmark string `json:"fwmark"`
+	Table  string `json:"table"`
+}
+
+func (c *RouteRuleObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *RouteRuleObject) GetType() string {
+	return "RouteRule"
+}
+
+func (c *RouteRuleObject) ToResource() resource.ISdewanResource {
+	return &resource.RouteRuleResource{
+		Name:        strings.ToLower(c.Metadata.Name),
+		Source:      c.Specification.Src,
+		Destination: c.Specification.Dst,
+		Not:         c.Specification.Not,
+		Priority:    c.Specification.Prio,
+		Fwmark:      c.Specification.Fwmark,
+		Table:       c.Specification.Table,
+	}
+}
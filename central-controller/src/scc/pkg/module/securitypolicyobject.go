@@ -0,0 +1,71 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+// SecurityPolicyObject describes which traffic is allowed between two
+// segments of the overlay
+type SecurityPolicyObject struct {
+	Metadata      ObjectMetaData             `json:"metadata"`
+	Specification SecurityPolicyObjectSpec   `json:"spec"`
+	Status        SecurityPolicyObjectStatus `json:"status"`
+}
+
+// SecurityPolicyObjectSpec contains the parameters
+type SecurityPolicyObjectSpec struct {
+	Source      PolicyEndpoint `json:"source"`
+	Destination PolicyEndpoint `json:"destination"`
+	Proto       string         `json:"proto" validate:"omitempty,oneof=tcp udp icmp all"`
+	Port        string         `json:"port"`
+	Action      string         `json:"action" validate:"omitempty,oneof=ACCEPT REJECT DROP"`
+	RuleType    string         `json:"ruleType" validate:"omitempty,oneof=FirewallRule NetworkFirewallRule"`
+	// policies are enforced in ascending order, the first matching one wins
+	Order int `json:"order" validate:"gte=0"`
+}
+
+// PolicyEndpoint selects one side of a security policy. An empty
+// endpoint matches any address
+type PolicyEndpoint struct {
+	Devices []string `json:"devices"`
+	// sites are referenced as <device-name>/<site-name>
+	Sites []string `json:"sites" validate:"dive,contains=/"`
+	Hubs  []string `json:"hubs"`
+	Cidrs []string `json:"cidrs" validate:"dive,cidr|ip"`
+	// the devices and hubs selected by their labels
+	DeviceSelector string `json:"deviceSelector"`
+	HubSelector    string `json:"hubSelector"`
+}
+
+// SecurityPolicyObjectStatus
+type SecurityPolicyObjectStatus struct {
+	// rule type of the deployed rules
+	RuleType string `json:"ruleType"`
+	// rule names deployed to each hub or device (e.g. Device.device1)
+	Rules map[string][]string `json:"rules"`
+}
+
+func (c *SecurityPolicyObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *SecurityPolicyObject) GetType() string {
+	return "SecurityPolicy"
+}
+
+func (c *PolicyEndpoint) IsAny() bool {
+	return len(c.Devices) == 0 && len(c.Sites) == 0 && len(c.Hubs) == 0 && len(c.Cidrs) == 0 &&
+		c.DeviceSelector == "" && c.HubSelector == ""
+}
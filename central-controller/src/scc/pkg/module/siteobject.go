@@ -24,9 +24,12 @@ type SiteObject struct {
 
 //SiteObjectSpec contains the parameters
 type SiteObjectSpec struct {
+	// Hubs are listed in order of priority
 	Hubs   []string `json:"hubs"`
 	Url    string   `json:"url"`
 	Subnet string   `json:"subnet"`
+	// Mode is how the traffic is shared by the hubs, default is active-standby
+	Mode string `json:"mode" validate:"omitempty,oneof=active-standby ecmp"`
 }
 
 func (c *SiteObject) GetMetadata() ObjectMetaData {
@@ -36,3 +39,7 @@ func (c *SiteObject) GetMetadata() ObjectMetaData {
 func (c *SiteObject) GetType() string {
 	return "site"
 }
+
+func (c *SiteObject) IsECMP() bool {
+	return c.Specification.Mode == "ecmp"
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"strings"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource"
+)
+
+// TrafficPolicyObject shapes the traffic of a network of the devices of
+// the overlay with the same classes
+type TrafficPolicyObject struct {
+	Metadata      ObjectMetaData            `json:"metadata"`
+	Specification TrafficPolicyObjectSpec   `json:"spec"`
+	Status        TrafficPolicyObjectStatus `json:"status"`
+}
+
+// TrafficPolicyObjectSpec contains the parameters, the rates are in kbit/s
+type TrafficPolicyObjectSpec struct {
+	// network of the device CNFs, a network has a single policy
+	Network     string         `json:"network" validate:"required"`
+	EgressRate  string         `json:"egressRate" validate:"required,numeric"`
+	IngressRate string         `json:"ingressRate" validate:"omitempty,numeric"`
+	Classes     []TrafficClass `json:"classes" validate:"dive"`
+	// the devices selected by their labels, all the devices of the overlay
+	// by default
+	DeviceSelector string `json:"deviceSelector"`
+}
+
+type TrafficClass struct {
+	Name     string `json:"name" validate:"required,hostname_rfc1123"`
+	Priority string `json:"priority" validate:"omitempty,oneof=0 1 2 3 4 5 6 7"`
+	Rate     string `json:"rate" validate:"required,numeric"`
+	Ceil     string `json:"ceil" validate:"omitempty,numeric"`
+	// DSCP value (0-63) or name (e.g. EF, AF41)
+	Dscp     string `json:"dscp"`
+	Proto    string `json:"proto" validate:"omitempty,oneof=tcp udp icmp"`
+	SrcIp    string `json:"srcIp" validate:"omitempty,cidr|ip"`
+	SrcPort  string `json:"srcPort" validate:"omitempty,numeric"`
+	DestIp   string `json:"destIp" validate:"omitempty,cidr|ip"`
+	DestPort string `json:"destPort" validate:"omitempty,numeric"`
+}
+
+// TrafficPolicyObjectStatus
+type TrafficPolicyObjectStatus struct {
+	// devices the policy is deployed to
+	Devices []string `json:"devices"`
+}
+
+func (c *TrafficPolicyObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *TrafficPolicyObject) GetType() string {
+	return "TrafficPolicy"
+}
+
+func (c *TrafficPolicyObject) ToResource() *resource.TrafficShapingPolicyResource {
+	r := &resource.TrafficShapingPolicyResource{
+		Name:        strings.ToLower(c.Metadata.Name),
+		Network:     c.Specification.Network,
+		EgressRate:  c.Specification.EgressRate,
+		IngressRate: c.Specification.IngressRate,
+	}
+
+	for _, cl := range c.Specification.Classes {
+		r.Classes = append(r.Classes, resource.TrafficClass{
+			Name:     cl.Name,
+			Priority: cl.Priority,
+			Rate:     cl.Rate,
+			Ceil:     cl.Ceil,
+			Dscp:     cl.Dscp,
+			Proto:    cl.Proto,
+			SrcIp:    cl.SrcIp,
+			SrcPort:  cl.SrcPort,
+			DestIp:   cl.DestIp,
+			DestPort: cl.DestPort,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"time"
+)
+
+const (
+	// health events of an overlay notified to the webhooks
+	NotifyRegistrationFailed  = "registration-failed"
+	NotifyConnectionError     = "connection-error"
+	NotifyCertificateExpiring = "certificate-expiring"
+	NotifyCNFNotAvailable     = "cnf-not-available"
+)
+
+// WebhookObject is a subscription to the health events of an overlay
+type WebhookObject struct {
+	Metadata      ObjectMetaData    `json:"metadata"`
+	Specification WebhookObjectSpec `json:"spec"`
+}
+
+// WebhookObjectSpec contains the parameters
+type WebhookObjectSpec struct {
+	Url string `json:"url" validate:"required,url"`
+	// key of the HMAC-SHA256 signature of the payloads
+	Secret string `json:"secret" encrypted:"" validate:"required,min=16"`
+	// events notified to the webhook, all of the events if empty
+	Events []string `json:"events" validate:"dive,oneof=registration-failed connection-error certificate-expiring cnf-not-available"`
+}
+
+func (c *WebhookObject) GetMetadata() ObjectMetaData {
+	return c.Metadata
+}
+
+func (c *WebhookObject) GetType() string {
+	return "Webhook"
+}
+
+// IsSubscribed checks whether the event is notified to the webhook
+func (c *WebhookObject) IsSubscribed(event string) bool {
+	if len(c.Specification.Events) == 0 {
+		return true
+	}
+	for _, e := range c.Specification.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookPayload is the body posted to a webhook, the object is the kind and
+// the name of the object in trouble, e.g. Device/device1
+type WebhookPayload struct {
+	Id      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Event   string    `json:"event"`
+	Overlay string    `json:"overlay"`
+	Object  string    `json:"object"`
+	Message string    `json:"message"`
+}
+
+// WebhookDelivery records the attempts to post a payload to a webhook
+type WebhookDelivery struct {
+	Id          string         `json:"id"`
+	Webhook     string         `json:"webhook"`
+	Payload     WebhookPayload `json:"payload"`
+	Attempts    int            `json:"attempts"`
+	Delivered   bool           `json:"delivered"`
+	StatusCode  int            `json:"statusCode"`
+	LastError   string         `json:"lastError"`
+	LastAttempt time.Time      `json:"lastAttempt"`
+}
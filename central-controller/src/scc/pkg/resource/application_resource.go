@@ -0,0 +1,56 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ApplicationResource struct {
+	Name         string
+	PodLabels    map[string]string
+	AppNamespace string
+	ServicePort  string
+	CNFPort      string
+}
+
+func (c *ApplicationResource) GetName() string {
+	return c.Name
+}
+
+func (c *ApplicationResource) GetType() string {
+	return "Application"
+}
+
+func (c *ApplicationResource) ToYaml(target string) string {
+	spec := crd.SdewanApplicationSpec{
+		AppNamespace: c.AppNamespace,
+		ServicePort:  c.ServicePort,
+		CNFPort:      c.CNFPort,
+	}
+
+	if len(c.PodLabels) > 0 {
+		spec.PodSelector = &metav1.LabelSelector{MatchLabels: c.PodLabels}
+	}
+
+	return toCR("SdewanApplication", c.Name, target, &spec)
+}
+
+func init() {
+	GetResourceBuilder().Register("Application", &ApplicationResource{})
+}
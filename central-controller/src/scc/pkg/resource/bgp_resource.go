@@ -0,0 +1,89 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+// BgpInstanceResource is the BGP routing instance of a CNF, a CNF runs a
+// single instance
+type BgpInstanceResource struct {
+	Name     string
+	As       string
+	RouterId string
+	Networks []string
+	Table    string
+}
+
+func (c *BgpInstanceResource) GetName() string {
+	return c.Name
+}
+
+func (c *BgpInstanceResource) GetType() string {
+	return "BgpInstance"
+}
+
+func (c *BgpInstanceResource) ToYaml(target string) string {
+	return toCR("BgpInstance", c.Name, target, &crd.BgpInstanceSpec{
+		As:       c.As,
+		RouterId: c.RouterId,
+		Networks: c.Networks,
+		Table:    c.Table,
+	})
+}
+
+// BgpNeighborResource is a BGP session of the instance of a CNF
+type BgpNeighborResource struct {
+	Name                 string
+	Instance             string
+	RemoteAddress        string
+	RemoteAs             string
+	SourceAddress        string
+	Password             string
+	HoldTime             string
+	NextHopSelf          bool
+	RouteReflectorClient bool
+	Dev                  string
+}
+
+func (c *BgpNeighborResource) GetName() string {
+	return c.Name
+}
+
+func (c *BgpNeighborResource) GetType() string {
+	return "BgpNeighbor"
+}
+
+func (c *BgpNeighborResource) ToYaml(target string) string {
+	return toCR("BgpNeighbor", c.Name, target, &crd.BgpNeighborSpec{
+		Instance:             c.Instance,
+		RemoteAddress:        c.RemoteAddress,
+		RemoteAs:             c.RemoteAs,
+		SourceAddress:        c.SourceAddress,
+		Password:             c.Password,
+		HoldTime:             c.HoldTime,
+		NextHopSelf:          c.NextHopSelf,
+		RouteReflectorClient: c.RouteReflectorClient,
+		Dev:                  c.Dev,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("BgpInstance", &BgpInstanceResource{})
+	GetResourceBuilder().Register("BgpNeighbor", &BgpNeighborResource{})
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crd
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// bases/ is a copy of platform/crd-ctrlr/src/config/crd/bases and must be
+// refreshed whenever the CRDs are regenerated (see TestSchemasInSync).
+//
+//go:embed bases/*.yaml
+var bases embed.FS
+
+var (
+	schemaOnce sync.Once
+	schemaErr  error
+	// kind -> version -> openAPIV3Schema
+	schemas = make(map[string]map[string]*apiextv1.JSONSchemaProps)
+)
+
+func loadSchemas() error {
+	schemaOnce.Do(func() {
+		files, err := bases.ReadDir("bases")
+		if err != nil {
+			schemaErr = err
+			return
+		}
+
+		for _, f := range files {
+			data, err := bases.ReadFile("bases/" + f.Name())
+			if err != nil {
+				schemaErr = err
+				return
+			}
+
+			var def apiextv1.CustomResourceDefinition
+			err = yaml.Unmarshal(data, &def)
+			if err != nil {
+				schemaErr = pkgerrors.Wrap(err, "Fail to parse CRD "+f.Name())
+				return
+			}
+
+			kind := def.Spec.Names.Kind
+			schemas[kind] = make(map[string]*apiextv1.JSONSchemaProps)
+			for _, v := range def.Spec.Versions {
+				if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
+					schemas[kind][def.Spec.Group+"/"+v.Name] = v.Schema.OpenAPIV3Schema
+				}
+			}
+		}
+	})
+
+	return schemaErr
+}
+
+// Kinds returns all the CRD kinds with a known schema
+func Kinds() []string {
+	if loadSchemas() != nil {
+		return []string{}
+	}
+
+	var kinds []string
+	for k := range schemas {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// IsKnownKind checks whether the given kind is an SDEWAN CRD
+func IsKnownKind(kind string) bool {
+	if loadSchemas() != nil {
+		return false
+	}
+
+	_, ok := schemas[kind]
+	return ok
+}
+
+// Validate checks an object (as decoded from yaml/json) against the
+// OpenAPI schema of its CRD. The object must be an SDEWAN CR.
+func Validate(obj map[string]interface{}) error {
+	err := loadSchemas()
+	if err != nil {
+		return err
+	}
+
+	kind, _ := obj["kind"].(string)
+	apiVersion, _ := obj["apiVersion"].(string)
+	versions, ok := schemas[kind]
+	if !ok {
+		return pkgerrors.New("Unknown kind: " + kind)
+	}
+
+	schema, ok := versions[apiVersion]
+	if !ok {
+		return pkgerrors.New("Unknown apiVersion " + apiVersion + " for kind " + kind)
+	}
+
+	errs := validateValue("", obj, schema)
+	if len(errs) > 0 {
+		return pkgerrors.New(kind + " is invalid: " + strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func validateValue(path string, v interface{}, s *apiextv1.JSONSchemaProps) []string {
+	if v == nil {
+		if s.Nullable {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: must not be null", fieldPath(path))}
+	}
+
+	var errs []string
+	switch s.Type {
+	case "object":
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object", fieldPath(path))}
+		}
+		for _, r := range s.Required {
+			if _, ok := o[r]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: required field is missing", fieldPath(path+"."+r)))
+			}
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p, ok := s.Properties[k]
+			if !ok {
+				if len(s.Properties) > 0 && !preserveUnknown(s) {
+					errs = append(errs, fmt.Sprintf("%s: unknown field", fieldPath(path+"."+k)))
+				}
+				continue
+			}
+			errs = append(errs, validateValue(path+"."+k, o[k], &p)...)
+		}
+	case "array":
+		a, ok := v.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array", fieldPath(path))}
+		}
+		if s.Items != nil && s.Items.Schema != nil {
+			for i, item := range a {
+				errs = append(errs, validateValue(fmt.Sprintf("%s[%d]", path, i), item, s.Items.Schema)...)
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return []string{fmt.Sprintf("%s: expected string", fieldPath(path))}
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != math.Trunc(n) {
+			return []string{fmt.Sprintf("%s: expected integer", fieldPath(path))}
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return []string{fmt.Sprintf("%s: expected number", fieldPath(path))}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []string{fmt.Sprintf("%s: expected boolean", fieldPath(path))}
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			var ev interface{}
+			if yaml.Unmarshal(e.Raw, &ev) == nil && ev == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Sprintf("%s: unsupported value %v", fieldPath(path), v))
+		}
+	}
+
+	return errs
+}
+
+func preserveUnknown(s *apiextv1.JSONSchemaProps) bool {
+	return s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return strings.TrimPrefix(path, ".")
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// location of the CRDs generated by the crd-ctrlr
+const crdCtrlrBases = "../../../../../../platform/crd-ctrlr/src/config/crd/bases"
+
+func TestSchemasInSync(t *testing.T) {
+	if _, err := os.Stat(crdCtrlrBases); err != nil {
+		t.Skip("crd-ctrlr sources are not available")
+	}
+
+	files, err := filepath.Glob(filepath.Join(crdCtrlrBases, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no CRD found in " + crdCtrlrBases)
+	}
+
+	for _, f := range files {
+		expected, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		embedded, err := bases.ReadFile("bases/" + filepath.Base(f))
+		if err != nil {
+			t.Errorf("%s is not embedded, copy it to bases/", filepath.Base(f))
+			continue
+		}
+		if !bytes.Equal(expected, embedded) {
+			t.Errorf("bases/%s is out of date, copy it from %s", filepath.Base(f), crdCtrlrBases)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	spec := func(s map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": ApiVersion,
+			"kind":       "IpsecProposal",
+			"metadata":   map[string]interface{}{"name": "p1"},
+			"spec":       s,
+		}
+	}
+
+	cases := []struct {
+		name  string
+		obj   map[string]interface{}
+		valid bool
+	}{
+		{"valid", spec(map[string]interface{}{"encryption_algorithm": "aes128", "hash_algorithm": "sha256", "dh_group": "modp3072"}), true},
+		{"missing", spec(map[string]interface{}{"encryption_algorithm": "aes128", "hash_algorithm": "sha256"}), false},
+		{"unknown", spec(map[string]interface{}{"encryption_algorithm": "aes128", "hash_algorithm": "sha256", "dh_group": "modp3072", "dhgroup": "x"}), false},
+		{"type", spec(map[string]interface{}{"encryption_algorithm": "aes128", "hash_algorithm": "sha256", "dh_group": float64(14)}), false},
+		{"kind", map[string]interface{}{"apiVersion": ApiVersion, "kind": "Unknown"}, false},
+		{"version", map[string]interface{}{"apiVersion": Group + "/v2", "kind": "IpsecProposal"}, false},
+	}
+
+	for _, c := range cases {
+		err := Validate(c.obj)
+		if c.valid && err != nil {
+			t.Errorf("%s: unexpected error %s", c.name, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+}
+
+func TestKinds(t *testing.T) {
+	for _, k := range []string{"IpsecHost", "IpsecSite", "IpsecProposal", "CNFRoute", "CNFNAT", "FirewallDNAT", "FirewallZone", "CNFHubSite"} {
+		if !IsKnownKind(k) {
+			t.Errorf("%s is not a known kind", k)
+		}
+	}
+}
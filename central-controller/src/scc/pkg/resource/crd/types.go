@@ -0,0 +1,330 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package crd mirrors the spec types of the SDEWAN CRDs defined in
+// platform/crd-ctrlr/src/api/v1alpha1. SCC can not import that module
+// directly (it is built against a different k8s release), so the types
+// below keep the same field names and json tags, and the embedded CRD
+// schemas under bases/ are used to catch any drift before deployment.
+package crd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	Group      = "batch.sdewan.akraino.org"
+	Version    = "v1alpha1"
+	ApiVersion = Group + "/" + Version
+)
+
+// IpsecProposalSpec mirrors v1alpha1.IpsecProposalSpec
+type IpsecProposalSpec struct {
+	Name                string `json:"name,omitempty"`
+	EncryptionAlgorithm string `json:"encryption_algorithm"`
+	HashAlgorithm       string `json:"hash_algorithm"`
+	DhGroup             string `json:"dh_group"`
+}
+
+// Connection mirrors v1alpha1.Connection used by IpsecHost
+type Connection struct {
+	Name           string   `json:"name"`
+	ConnectionType string   `json:"conn_type"`
+	Mode           string   `json:"mode"`
+	LocalSourceIp  string   `json:"local_sourceip,omitempty"`
+	LocalUpDown    string   `json:"local_updown,omitempty"`
+	LocalFirewall  string   `json:"local_firewall,omitempty"`
+	RemoteSubnet   string   `json:"remote_subnet,omitempty"`
+	RemoteSourceIp string   `json:"remote_sourceip,omitempty"`
+	RemoteUpDown   string   `json:"remote_updown,omitempty"`
+	RemoteFirewall string   `json:"remote_firewall,omitempty"`
+	CryptoProposal []string `json:"crypto_proposal,omitempty"`
+	Mark           string   `json:"mark,omitempty"`
+	IfId           string   `json:"if_id,omitempty"`
+}
+
+// IpsecHostSpec mirrors v1alpha1.IpsecHostSpec
+type IpsecHostSpec struct {
+	Name                 string       `json:"name,omitempty"`
+	Type                 string       `json:"type,omitempty"`
+	Remote               string       `json:"remote"`
+	AuthenticationMethod string       `json:"authentication_method"`
+	CryptoProposal       []string     `json:"crypto_proposal"`
+	LocalIdentifier      string       `json:"local_identifier,omitempty"`
+	RemoteIdentifier     string       `json:"remote_identifier,omitempty"`
+	ForceCryptoProposal  string       `json:"force_crypto_proposal,omitempty"`
+	PresharedKey         string       `json:"pre_shared_key,omitempty"`
+	LocalPublicCert      string       `json:"local_public_cert,omitempty"`
+	LocalPrivateCert     string       `json:"local_private_cert,omitempty"`
+	SharedCA             string       `json:"shared_ca,omitempty"`
+	Connections          []Connection `json:"connections"`
+}
+
+// SiteConnection mirrors v1alpha1.SiteConnection used by IpsecSite
+type SiteConnection struct {
+	Name           string   `json:"name"`
+	ConnectionType string   `json:"conn_type"`
+	Mode           string   `json:"mode"`
+	LocalSubnet    string   `json:"local_subnet"`
+	LocalUpDown    string   `json:"local_updown,omitempty"`
+	LocalFirewall  string   `json:"local_firewall,omitempty"`
+	RemoteSubnet   string   `json:"remote_subnet,omitempty"`
+	RemoteSourceIp string   `json:"remote_sourceip,omitempty"`
+	RemoteUpDown   string   `json:"remote_updown,omitempty"`
+	RemoteFirewall string   `json:"remote_firewall,omitempty"`
+	CryptoProposal []string `json:"crypto_proposal,omitempty"`
+	Mark           string   `json:"mark,omitempty"`
+	IfId           string   `json:"if_id,omitempty"`
+}
+
+// IpsecSiteSpec mirrors v1alpha1.IpsecSiteSpec
+type IpsecSiteSpec struct {
+	Name                 string           `json:"name,omitempty"`
+	Type                 string           `json:"type,omitempty"`
+	Remote               string           `json:"remote"`
+	AuthenticationMethod string           `json:"authentication_method"`
+	CryptoProposal       []string         `json:"crypto_proposal"`
+	LocalIdentifier      string           `json:"local_identifier,omitempty"`
+	RemoteIdentifier     string           `json:"remote_identifier,omitempty"`
+	ForceCryptoProposal  string           `json:"force_crypto_proposal,omitempty"`
+	PresharedKey         string           `json:"pre_shared_key,omitempty"`
+	LocalPublicCert      string           `json:"local_public_cert,omitempty"`
+	LocalPrivateCert     string           `json:"local_private_cert,omitempty"`
+	SharedCA             string           `json:"shared_ca,omitempty"`
+	Connections          []SiteConnection `json:"connections"`
+}
+
+// CNFRouteSpec mirrors v1alpha1.CNFRouteSpec
+type CNFRouteSpec struct {
+	Dst   string `json:"dst,omitempty"`
+	Gw    string `json:"gw,omitempty"`
+	Dev   string `json:"dev,omitempty"`
+	Table string `json:"table,omitempty"`
+}
+
+// CNFNATSpec mirrors v1alpha1.CNFNATSpec
+type CNFNATSpec struct {
+	Name        string `json:"name,omitempty"`
+	Src         string `json:"src,omitempty"`
+	SrcIp       string `json:"src_ip,omitempty"`
+	SrcDIp      string `json:"src_dip,omitempty"`
+	SrcPort     string `json:"src_port,omitempty"`
+	SrcDPort    string `json:"src_dport,omitempty"`
+	Proto       string `json:"proto,omitempty"`
+	Dest        string `json:"dest,omitempty"`
+	DestIp      string `json:"dest_ip,omitempty"`
+	DestPort    string `json:"dest_port,omitempty"`
+	Target      string `json:"target,omitempty"`
+	Index       string `json:"index,omitempty"`
+	Probability string `json:"probability,omitempty"`
+}
+
+// FirewallDNATSpec mirrors v1alpha1.FirewallDNATSpec
+type FirewallDNATSpec struct {
+	Name     string `json:"name,omitempty"`
+	Src      string `json:"src,omitempty"`
+	SrcIp    string `json:"src_ip,omitempty"`
+	SrcDIp   string `json:"src_dip,omitempty"`
+	SrcMac   string `json:"src_mac,omitempty"`
+	SrcPort  string `json:"src_port,omitempty"`
+	SrcDPort string `json:"src_dport,omitempty"`
+	Proto    string `json:"proto,omitempty"`
+	Dest     string `json:"dest,omitempty"`
+	DestIp   string `json:"dest_ip,omitempty"`
+	DestPort string `json:"dest_port,omitempty"`
+	Mark     string `json:"mark,omitempty"`
+	Target   string `json:"target,omitempty"`
+	Family   string `json:"family,omitempty"`
+}
+
+// FirewallZoneSpec mirrors v1alpha1.FirewallZoneSpec
+type FirewallZoneSpec struct {
+	Name             string   `json:"name,omitempty"`
+	Network          []string `json:"network"`
+	Masq             string   `json:"masq,omitempty"`
+	MasqSrc          []string `json:"masq_src,omitempty"`
+	MasqDest         []string `json:"masq_dest,omitempty"`
+	MasqAllowInvalid string   `json:"masq_allow_invalid,omitempty"`
+	MtuFix           string   `json:"mtu_fix,omitempty"`
+	Input            string   `json:"input,omitempty"`
+	Forward          string   `json:"forward,omitempty"`
+	Output           string   `json:"output,omitempty"`
+	Family           string   `json:"family,omitempty"`
+	Subnet           []string `json:"subnet,omitempty"`
+	ExtraSrc         string   `json:"extra_src,omitempty"`
+	ExtraDest        string   `json:"etra_dest,omitempty"`
+}
+
+// CNFHubSiteSpec mirrors v1alpha1.CNFHubSiteSpec
+type CNFHubSiteSpec struct {
+	Type        string `json:"type,omitempty"`
+	Site        string `json:"site,omitempty"`
+	Subnet      string `json:"subnet,omitempty"`
+	HubIP       string `json:"hubip,omitempty"`
+	DevicePIP   string `json:"devicepip,omitempty"`
+	Priority    int    `json:"priority,omitempty"`
+	Probability string `json:"probability,omitempty"`
+}
+
+// FirewallRuleSpec mirrors v1alpha1.FirewallRuleSpec
+type FirewallRuleSpec struct {
+	Name     string   `json:"name,omitempty"`
+	Src      string   `json:"src,omitempty"`
+	SrcIp    string   `json:"src_ip,omitempty"`
+	SrcMac   string   `json:"src_mac,omitempty"`
+	SrcPort  string   `json:"src_port,omitempty"`
+	Proto    string   `json:"proto,omitempty"`
+	IcmpType []string `json:"icmp_type,omitempty"`
+	Dest     string   `json:"dest,omitempty"`
+	DestIp   string   `json:"dest_ip,omitempty"`
+	DestPort string   `json:"dest_port,omitempty"`
+	Mark     string   `json:"mark,omitempty"`
+	Target   string   `json:"target,omitempty"`
+	SetMark  string   `json:"set_mark,omitempty"`
+	SetXmark string   `json:"set_xmark,omitempty"`
+	Family   string   `json:"family,omitempty"`
+	Extra    string   `json:"extra,omitempty"`
+}
+
+// NetworkFirewallRuleSpec mirrors v1alpha1.NetworkFirewallRuleSpec
+type NetworkFirewallRuleSpec FirewallRuleSpec
+
+// FirewallForwardingSpec mirrors v1alpha1.FirewallForwardingSpec
+type FirewallForwardingSpec struct {
+	Name   string `json:"name,omitempty"`
+	Src    string `json:"src,omitempty"`
+	Dest   string `json:"dest,omitempty"`
+	Family string `json:"family,omitempty"`
+}
+
+// Mwan3PolicyMember mirrors v1alpha1.Mwan3PolicyMember
+type Mwan3PolicyMember struct {
+	Network string `json:"network"`
+	Metric  int    `json:"metric"`
+	Weight  int    `json:"weight"`
+}
+
+// Mwan3PolicySpec mirrors v1alpha1.Mwan3PolicySpec
+type Mwan3PolicySpec struct {
+	Members []Mwan3PolicyMember `json:"members"`
+}
+
+// Mwan3RuleSpec mirrors v1alpha1.Mwan3RuleSpec
+type Mwan3RuleSpec struct {
+	Policy   string `json:"policy"`
+	SrcIp    string `json:"src_ip"`
+	SrcPort  string `json:"src_port"`
+	DestIp   string `json:"dest_ip"`
+	DestPort string `json:"dest_port"`
+	Proto    string `json:"proto"`
+	Family   string `json:"family"`
+	Sticky   string `json:"sticky"`
+	Timeout  string `json:"timeout"`
+}
+
+// CNFRouteRuleSpec mirrors v1alpha1.CNFRouteRuleSpec
+type CNFRouteRuleSpec struct {
+	Src    string `json:"src,omitempty"`
+	Dst    string `json:"dst,omitempty"`
+	Not    bool   `json:"not,omitempty"`
+	Prio   string `json:"prio,omitempty"`
+	Fwmark string `json:"fwmark,omitempty"`
+	Table  string `json:"table,omitempty"`
+}
+
+// SdewanApplicationSpec mirrors v1alpha1.SdewanApplicationSpec
+type SdewanApplicationSpec struct {
+	PodSelector  *metav1.LabelSelector `json:"podSelector,omitempty"`
+	AppNamespace string                `json:"appNamespace,omitempty"`
+	ServicePort  string                `json:"servicePort,omitempty"`
+	CNFPort      string                `json:"cnfPort,omitempty"`
+}
+
+// WireguardInterfaceSpec mirrors v1alpha1.WireguardInterfaceSpec
+type WireguardInterfaceSpec struct {
+	PrivateKey string   `json:"private_key"`
+	ListenPort string   `json:"listen_port,omitempty"`
+	Addresses  []string `json:"addresses,omitempty"`
+	Mtu        string   `json:"mtu,omitempty"`
+}
+
+// WireguardPeerSpec mirrors v1alpha1.WireguardPeerSpec
+type WireguardPeerSpec struct {
+	Interface           string   `json:"interface"`
+	PublicKey           string   `json:"public_key"`
+	PresharedKey        string   `json:"preshared_key,omitempty"`
+	EndpointHost        string   `json:"endpoint_host,omitempty"`
+	EndpointPort        string   `json:"endpoint_port,omitempty"`
+	AllowedIps          []string `json:"allowed_ips"`
+	PersistentKeepalive string   `json:"persistent_keepalive,omitempty"`
+	RouteAllowedIps     bool     `json:"route_allowed_ips,omitempty"`
+}
+
+// BgpInstanceSpec mirrors v1alpha1.BgpInstanceSpec
+type BgpInstanceSpec struct {
+	As       string   `json:"as"`
+	RouterId string   `json:"router_id"`
+	Networks []string `json:"networks,omitempty"`
+	Table    string   `json:"table,omitempty"`
+}
+
+// BgpNeighborSpec mirrors v1alpha1.BgpNeighborSpec
+type BgpNeighborSpec struct {
+	Instance             string `json:"instance"`
+	RemoteAddress        string `json:"remote_address"`
+	RemoteAs             string `json:"remote_as"`
+	SourceAddress        string `json:"source_address,omitempty"`
+	Password             string `json:"password,omitempty"`
+	HoldTime             string `json:"hold_time,omitempty"`
+	NextHopSelf          bool   `json:"next_hop_self,omitempty"`
+	RouteReflectorClient bool   `json:"route_reflector_client,omitempty"`
+	Dev                  string `json:"dev,omitempty"`
+}
+
+// TrafficClass mirrors v1alpha1.TrafficClass
+type TrafficClass struct {
+	Name     string `json:"name"`
+	Priority string `json:"priority,omitempty"`
+	Rate     string `json:"rate"`
+	Ceil     string `json:"ceil,omitempty"`
+	Dscp     string `json:"dscp,omitempty"`
+	Proto    string `json:"proto,omitempty"`
+	SrcIp    string `json:"src_ip,omitempty"`
+	SrcPort  string `json:"src_port,omitempty"`
+	DestIp   string `json:"dest_ip,omitempty"`
+	DestPort string `json:"dest_port,omitempty"`
+}
+
+// TrafficShapingPolicySpec mirrors v1alpha1.TrafficShapingPolicySpec
+type TrafficShapingPolicySpec struct {
+	Network     string         `json:"network"`
+	EgressRate  string         `json:"egress_rate"`
+	IngressRate string         `json:"ingress_rate,omitempty"`
+	Classes     []TrafficClass `json:"classes,omitempty"`
+}
+
+// DnsForwarderSpec mirrors v1alpha1.DnsForwarderSpec
+type DnsForwarderSpec struct {
+	Domain  string   `json:"domain"`
+	Servers []string `json:"servers"`
+	Port    string   `json:"port,omitempty"`
+}
+
+// DnsRecordSpec mirrors v1alpha1.DnsRecordSpec
+type DnsRecordSpec struct {
+	Domain string `json:"domain"`
+	Ip     string `json:"ip"`
+}
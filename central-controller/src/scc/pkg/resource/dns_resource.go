@@ -0,0 +1,72 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+// DnsForwarderResource forwards the DNS queries of a domain to servers
+type DnsForwarderResource struct {
+	Name    string
+	Domain  string
+	Servers []string
+	Port    string
+}
+
+func (c *DnsForwarderResource) GetName() string {
+	return c.Name
+}
+
+func (c *DnsForwarderResource) GetType() string {
+	return "DnsForwarder"
+}
+
+func (c *DnsForwarderResource) ToYaml(target string) string {
+	return toCR("DnsForwarder", c.Name, target, &crd.DnsForwarderSpec{
+		Domain:  c.Domain,
+		Servers: c.Servers,
+		Port:    c.Port,
+	})
+}
+
+// DnsRecordResource resolves a domain to an ip
+type DnsRecordResource struct {
+	Name   string
+	Domain string
+	Ip     string
+}
+
+func (c *DnsRecordResource) GetName() string {
+	return c.Name
+}
+
+func (c *DnsRecordResource) GetType() string {
+	return "DnsRecord"
+}
+
+func (c *DnsRecordResource) ToYaml(target string) string {
+	return toCR("DnsRecord", c.Name, target, &crd.DnsRecordSpec{
+		Domain: c.Domain,
+		Ip:     c.Ip,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("DnsForwarder", &DnsForwarderResource{})
+	GetResourceBuilder().Register("DnsRecord", &DnsRecordResource{})
+}
@@ -16,7 +16,9 @@
 
 package resource
 
-import ()
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
 
 type FirewallDnatResource struct {
 	Name            string
@@ -38,29 +40,16 @@ func (c *FirewallDnatResource) GetType() string {
 }
 
 func (c *FirewallDnatResource) ToYaml(target string) string {
-	basic := `apiVersion: ` + SdewanApiVersion + `
-kind: FirewallDNAT
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  src: ` + c.Source + `
-  src_dip: ` + c.SourceDestIP + `
-  src_dport: ` + c.SourceDestPort + `
-  dest_ip: ` + c.DestinationIP + `
-  dest_port: ` + c.DestinationPort + `
-  proto: ` + c.Protocol + `
-  target: DNAT `
-
-	if c.SourceIP != "" {
-		basic += `
-  src_ip: ` + c.SourceIP
-	}
-
-	return basic
+	return toCR("FirewallDNAT", c.Name, target, &crd.FirewallDNATSpec{
+		Src:      c.Source,
+		SrcIp:    c.SourceIP,
+		SrcDIp:   c.SourceDestIP,
+		SrcDPort: c.SourceDestPort,
+		Proto:    c.Protocol,
+		DestIp:   c.DestinationIP,
+		DestPort: c.DestinationPort,
+		Target:   "DNAT",
+	})
 }
 
 func init() {
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+type FirewallForwardingResource struct {
+	Name   string
+	Source string
+	Dest   string
+	Family string
+}
+
+func (c *FirewallForwardingResource) GetName() string {
+	return c.Name
+}
+
+func (c *FirewallForwardingResource) GetType() string {
+	return "FirewallForwarding"
+}
+
+func (c *FirewallForwardingResource) ToYaml(target string) string {
+	return toCR("FirewallForwarding", c.Name, target, &crd.FirewallForwardingSpec{
+		Src:    c.Source,
+		Dest:   c.Dest,
+		Family: c.Family,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("FirewallForwarding", &FirewallForwardingResource{})
+}
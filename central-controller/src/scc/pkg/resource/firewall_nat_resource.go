@@ -16,7 +16,9 @@
 
 package resource
 
-import ()
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
 
 type FirewallNatResource struct {
 	Name            string
@@ -42,52 +44,18 @@ func (c *FirewallNatResource) GetType() string {
 }
 
 func (c *FirewallNatResource) ToYaml(target string) string {
-	basic := `apiVersion: ` + SdewanApiVersion + `
-kind: CNFNAT
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  target: ` + c.Target + `
-  src_dip: ` + c.SourceDestIP
-	if c.DestinationIP != "" {
-		basic += `
-  dest_ip: ` + c.DestinationIP
-	}
-
-	if c.DestinationPort != "" {
-		basic += `
-  dest_port: ` + c.DestinationPort
-	}
-	if c.Dest != "" {
-		basic += `
-  dest: "` + c.Dest + `"`
-	}
-	if c.SourceDestPort != "" {
-		basic += `
-  src_dport: ` + c.SourceDestPort
-	}
-	if c.Protocol != "" {
-		basic += `
-  proto: ` + c.Protocol
-	}
-	if c.Source != "" {
-		basic += `
-  src: "` + c.Source + `"`
-	}
-	if c.SourceIP != "" {
-		basic += `
-  src_ip: ` + c.SourceIP
-	}
-	if c.Index != "" {
-		basic += `
-  index: "` + c.Index + `"`
-	}
-
-	return basic
+	return toCR("CNFNAT", c.Name, target, &crd.CNFNATSpec{
+		Src:      c.Source,
+		SrcIp:    c.SourceIP,
+		SrcDIp:   c.SourceDestIP,
+		SrcDPort: c.SourceDestPort,
+		Proto:    c.Protocol,
+		Dest:     c.Dest,
+		DestIp:   c.DestinationIP,
+		DestPort: c.DestinationPort,
+		Target:   c.Target,
+		Index:    c.Index,
+	})
 }
 
 func init() {
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+type FirewallRuleResource struct {
+	Name            string
+	Source          string
+	SourceIP        string
+	SourceMac       string
+	SourcePort      string
+	Protocol        string
+	IcmpType        []string
+	Dest            string
+	DestinationIP   string
+	DestinationPort string
+	Mark            string
+	Target          string
+	SetMark         string
+	SetXmark        string
+	Family          string
+	Extra           string
+}
+
+func (c *FirewallRuleResource) GetName() string {
+	return c.Name
+}
+
+func (c *FirewallRuleResource) GetType() string {
+	return "FirewallRule"
+}
+
+func (c *FirewallRuleResource) ToYaml(target string) string {
+	return toCR("FirewallRule", c.Name, target, &crd.FirewallRuleSpec{
+		Src:      c.Source,
+		SrcIp:    c.SourceIP,
+		SrcMac:   c.SourceMac,
+		SrcPort:  c.SourcePort,
+		Proto:    c.Protocol,
+		IcmpType: c.IcmpType,
+		Dest:     c.Dest,
+		DestIp:   c.DestinationIP,
+		DestPort: c.DestinationPort,
+		Mark:     c.Mark,
+		Target:   c.Target,
+		SetMark:  c.SetMark,
+		SetXmark: c.SetXmark,
+		Family:   c.Family,
+		Extra:    c.Extra,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("FirewallRule", &FirewallRuleResource{})
+}
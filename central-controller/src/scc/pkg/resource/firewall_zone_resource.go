@@ -17,7 +17,7 @@
 package resource
 
 import (
-	"strings"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
 )
 
 type FirewallZoneResource struct {
@@ -39,28 +39,19 @@ func (c *FirewallZoneResource) GetType() string {
 }
 
 func (c *FirewallZoneResource) ToYaml(target string) string {
-	basic := `apiVersion: ` + SdewanApiVersion + `
-kind: FirewallZone
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  network: [` + strings.Join(c.Network, ",") + `]
-  input: ` + c.Input + `
-  output: ` + c.Output + `
-  forward: ` + c.Forward
+	spec := crd.FirewallZoneSpec{
+		Network: emptyIfNil(c.Network),
+		Input:   c.Input,
+		Output:  c.Output,
+		Forward: c.Forward,
+	}
 
 	if c.MASQ != "" && c.MTU_FIX != "" {
-		optional := `
-  masq: ` + c.MASQ + `
-  mtu_fix: ` + c.MTU_FIX
-		basic += optional
+		spec.Masq = c.MASQ
+		spec.MtuFix = c.MTU_FIX
 	}
 
-	return basic
+	return toCR("FirewallZone", c.Name, target, &spec)
 }
 
 func init() {
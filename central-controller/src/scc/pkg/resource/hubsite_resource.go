@@ -16,7 +16,9 @@
 
 package resource
 
-import ()
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
 
 type HubSiteResource struct {
 	Name      string
@@ -25,6 +27,10 @@ type HubSiteResource struct {
 	Subnet    string
 	HubIP     string
 	DevicePIP string
+	// Priority orders the hubs of a device site, 1 is the primary hub
+	Priority int
+	// Probability shares the site traffic with the hubs of lower priority
+	Probability string
 }
 
 func (c *HubSiteResource) GetName() string {
@@ -36,34 +42,15 @@ func (c *HubSiteResource) GetType() string {
 }
 
 func (c *HubSiteResource) ToYaml(target string) string {
-	result := `apiVersion: ` + SdewanApiVersion + `
-kind: CNFHubSite
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  type: ` + c.Type
-	if c.Site != "" {
-		result += `
-  site: '` + c.Site + `'`
-	}
-	if c.Subnet != "" {
-		result += `
-  subnet: '` + c.Subnet + `'`
-	}
-	if c.HubIP != "" {
-		result += `
-  hubip: '` + c.HubIP + `'`
-	}
-	if c.DevicePIP != "" {
-		result += `
-  devicepip: '` + c.DevicePIP + `'`
-	}
-
-	return result
+	return toCR("CNFHubSite", c.Name, target, &crd.CNFHubSiteSpec{
+		Type:        c.Type,
+		Site:        c.Site,
+		Subnet:      c.Subnet,
+		HubIP:       c.HubIP,
+		DevicePIP:   c.DevicePIP,
+		Priority:    c.Priority,
+		Probability: c.Probability,
+	})
 }
 
 func init() {
@@ -17,7 +17,9 @@
 package resource
 
 import (
-	"strings"
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
 )
 
 const (
@@ -67,134 +69,74 @@ func (c *IpsecResource) GetType() string {
 }
 
 func (c *IpsecResource) ToYaml(target string) string {
-	p := strings.Join(c.CryptoProposal, ",")
-	pr := strings.Join(c.Connections.CryptoProposal, ",")
-	var connection = ""
+	if c.AuthenticationMethod != AuthTypePUBKEY && c.AuthenticationMethod != AuthTypePSK {
+		log.Println("Unsupported authentication method for " + c.Name + ": " + c.AuthenticationMethod)
+		return ""
+	}
 
 	if c.Connections.LocalSubnet != "" {
-		base := `apiVersion: ` + SdewanApiVersion + ` 
-kind: IpsecSite
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  type: ` + c.Type + `
-  remote: '` + c.Remote + `'
-  authentication_method: ` + c.AuthenticationMethod + `
-  force_crypto_proposal: "` + c.ForceCryptoProposal + `"
-  crypto_proposal: [` + p + `]`
-
-		connection = `
-  connections:
-  - name: ` + c.Connections.Name + `
-    conn_type: ` + c.Connections.ConnectionType + `
-    mode: ` + c.Connections.Mode + `
-    mark: "` + c.Connections.Mark + `"
-    local_updown: ` + c.Connections.LocalUpDown + `
-    local_subnet: ` + c.Connections.LocalSubnet + `
-    crypto_proposal: [` + pr + `]`
-
-		if c.Connections.RemoteSourceIp != "" {
-			remote_source_ip := `
-    remote_sourceip: '` + c.Connections.RemoteSourceIp + `'`
-			connection += remote_source_ip
-		}
-
-		if c.Connections.RemoteSubnet != "" {
-			remote_subnet := `
-    remote_subnet: '` + c.Connections.RemoteSubnet + `'`
-			connection += remote_subnet
+		spec := crd.IpsecSiteSpec{
+			Type:                 c.Type,
+			Remote:               c.Remote,
+			AuthenticationMethod: c.AuthenticationMethod,
+			ForceCryptoProposal:  c.ForceCryptoProposal,
+			CryptoProposal:       emptyIfNil(c.CryptoProposal),
+			LocalIdentifier:      c.LocalIdentifier,
+			RemoteIdentifier:     c.RemoteIdentifier,
+			Connections: []crd.SiteConnection{{
+				Name:           c.Connections.Name,
+				ConnectionType: c.Connections.ConnectionType,
+				Mode:           c.Connections.Mode,
+				Mark:           c.Connections.Mark,
+				LocalUpDown:    c.Connections.LocalUpDown,
+				LocalSubnet:    c.Connections.LocalSubnet,
+				RemoteSourceIp: c.Connections.RemoteSourceIp,
+				RemoteSubnet:   c.Connections.RemoteSubnet,
+				CryptoProposal: emptyIfNil(c.Connections.CryptoProposal),
+			}},
 		}
 
 		if c.AuthenticationMethod == AuthTypePUBKEY {
-			auth := `
-  local_public_cert: ` + c.PublicCert + `
-  local_private_cert: ` + c.PrivateCert + `
-  shared_ca: ` + c.SharedCA + `
-  local_identifier: ` + c.LocalIdentifier + `
-  remote_identifier: ` + c.RemoteIdentifier
-			return base + auth + connection
-		} else if c.AuthenticationMethod == AuthTypePSK {
-			auth := `
-  pre_shared_key: ` + c.PresharedKey + `
-  local_identifier: ` + c.LocalIdentifier + `
-  remote_identifier: ` + c.RemoteIdentifier
-			return base + auth + connection
+			spec.LocalPublicCert = c.PublicCert
+			spec.LocalPrivateCert = c.PrivateCert
+			spec.SharedCA = c.SharedCA
 		} else {
-			return "Error in authentication method"
+			spec.PresharedKey = c.PresharedKey
 		}
 
+		return toCR("IpsecSite", c.Name, target, &spec)
 	}
 
-	base := `apiVersion: ` + SdewanApiVersion + `
-kind: IpsecHost
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  type: ` + c.Type + `
-  remote: '` + c.Remote + `'
-  authentication_method: ` + c.AuthenticationMethod + `
-  force_crypto_proposal: "` + c.ForceCryptoProposal + `"
-  crypto_proposal: [` + p + `]`
-
-	if c.Connections.LocalSourceIp != "" {
-		connection = `
-  connections:
-  - name: ` + c.Connections.Name + `
-    conn_type: ` + c.Connections.ConnectionType + `
-    mode: ` + c.Connections.Mode + `
-    mark: "` + c.Connections.Mark + `"
-    local_updown: ` + c.Connections.LocalUpDown + `
-    local_sourceip: '` + c.Connections.LocalSourceIp + `'
-    crypto_proposal: [` + pr + `]`
-	} else {
-		connection = `
-  connections: 
-  - name: ` + c.Connections.Name + `
-    conn_type: ` + c.Connections.ConnectionType + `
-    mode: ` + c.Connections.Mode + `
-    mark: "` + c.Connections.Mark + `"
-    local_updown: ` + c.Connections.LocalUpDown + `
-    crypto_proposal: [` + pr + `]`
-	}
-
-	if c.Connections.RemoteSourceIp != "" {
-		remote_source_ip := `
-    remote_sourceip: '` + c.Connections.RemoteSourceIp + `'`
-		connection += remote_source_ip
-	}
-
-	if c.Connections.RemoteSubnet != "" {
-		remote_subnet := `
-    remote_subnet: '` + c.Connections.RemoteSubnet + `'`
-		connection += remote_subnet
+	spec := crd.IpsecHostSpec{
+		Type:                 c.Type,
+		Remote:               c.Remote,
+		AuthenticationMethod: c.AuthenticationMethod,
+		ForceCryptoProposal:  c.ForceCryptoProposal,
+		CryptoProposal:       emptyIfNil(c.CryptoProposal),
+		LocalIdentifier:      c.LocalIdentifier,
+		RemoteIdentifier:     c.RemoteIdentifier,
+		Connections: []crd.Connection{{
+			Name:           c.Connections.Name,
+			ConnectionType: c.Connections.ConnectionType,
+			Mode:           c.Connections.Mode,
+			Mark:           c.Connections.Mark,
+			LocalUpDown:    c.Connections.LocalUpDown,
+			LocalSourceIp:  c.Connections.LocalSourceIp,
+			RemoteSourceIp: c.Connections.RemoteSourceIp,
+			RemoteSubnet:   c.Connections.RemoteSubnet,
+			CryptoProposal: emptyIfNil(c.Connections.CryptoProposal),
+		}},
 	}
 
 	if c.AuthenticationMethod == AuthTypePUBKEY {
-		auth := `
-  local_public_cert: ` + c.PublicCert + `
-  local_private_cert: ` + c.PrivateCert + `
-  shared_ca: ` + c.SharedCA + `
-  local_identifier: ` + c.LocalIdentifier + `
-  remote_identifier: ` + c.RemoteIdentifier
-		return base + auth + connection
-	} else if c.AuthenticationMethod == AuthTypePSK {
-		auth := `
-  pre_shared_key: ` + c.PresharedKey + `
-  local_identifier: ` + c.LocalIdentifier + `
-  remote_identifier: ` + c.RemoteIdentifier
-		return base + auth + connection
+		spec.LocalPublicCert = c.PublicCert
+		spec.LocalPrivateCert = c.PrivateCert
+		spec.SharedCA = c.SharedCA
 	} else {
-		return "Error in authentication method"
+		spec.PresharedKey = c.PresharedKey
 	}
 
+	return toCR("IpsecHost", c.Name, target, &spec)
 }
 
 func init() {
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"log"
+
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+	pkgerrors "github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+type crLabels struct {
+	SdewanPurpose string `json:"sdewanPurpose"`
+	TargetCluster string `json:"targetCluster"`
+}
+
+type crMetadata struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Labels    crLabels `json:"labels"`
+}
+
+type crEnvelope struct {
+	ApiVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   crMetadata  `json:"metadata"`
+	Spec       interface{} `json:"spec"`
+}
+
+// toCR renders an SDEWAN CR of the given kind. The spec is marshalled
+// through its json tags, so values are always quoted and escaped properly.
+func toCR(kind string, name string, target string, spec interface{}) string {
+	cr := crEnvelope{
+		ApiVersion: SdewanApiVersion,
+		Kind:       kind,
+		Metadata: crMetadata{
+			Name:      name,
+			Namespace: "default",
+			Labels: crLabels{
+				SdewanPurpose: SdewanPurpose,
+				TargetCluster: target,
+			},
+		},
+		Spec: spec,
+	}
+
+	data, err := yaml.Marshal(&cr)
+	if err != nil {
+		log.Println("Fail to marshal " + kind + " " + name + ": " + err.Error())
+		return ""
+	}
+
+	return string(data)
+}
+
+// Validate renders the resource for the target and checks the result
+// against the OpenAPI schema of the CRD
+func Validate(res ISdewanResource, target string) error {
+	content := res.ToYaml(target)
+	if content == "" {
+		return pkgerrors.New("Fail to render " + res.GetType() + " " + res.GetName())
+	}
+
+	var obj map[string]interface{}
+	err := yaml.Unmarshal([]byte(content), &obj)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Malformed yaml for "+res.GetType()+" "+res.GetName())
+	}
+
+	// resources not backed by an SDEWAN CRD (e.g. File) are deployed as is
+	kind, _ := obj["kind"].(string)
+	if !crd.IsKnownKind(kind) {
+		return nil
+	}
+
+	return crd.Validate(obj)
+}
+
+func emptyIfNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
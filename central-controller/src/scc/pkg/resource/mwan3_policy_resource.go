@@ -0,0 +1,60 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+type Mwan3PolicyMember struct {
+	Network string
+	Metric  int
+	Weight  int
+}
+
+type Mwan3PolicyResource struct {
+	Name    string
+	Members []Mwan3PolicyMember
+}
+
+func (c *Mwan3PolicyResource) GetName() string {
+	return c.Name
+}
+
+func (c *Mwan3PolicyResource) GetType() string {
+	return "Mwan3Policy"
+}
+
+func (c *Mwan3PolicyResource) ToYaml(target string) string {
+	spec := crd.Mwan3PolicySpec{
+		Members: []crd.Mwan3PolicyMember{},
+	}
+
+	for _, m := range c.Members {
+		spec.Members = append(spec.Members, crd.Mwan3PolicyMember{
+			Network: m.Network,
+			Metric:  m.Metric,
+			Weight:  m.Weight,
+		})
+	}
+
+	return toCR("Mwan3Policy", c.Name, target, &spec)
+}
+
+func init() {
+	GetResourceBuilder().Register("Mwan3Policy", &Mwan3PolicyResource{})
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+type Mwan3RuleResource struct {
+	Name            string
+	Policy          string
+	SourceIP        string
+	SourcePort      string
+	DestinationIP   string
+	DestinationPort string
+	Protocol        string
+	Family          string
+	Sticky          string
+	Timeout         string
+}
+
+func (c *Mwan3RuleResource) GetName() string {
+	return c.Name
+}
+
+func (c *Mwan3RuleResource) GetType() string {
+	return "Mwan3Rule"
+}
+
+func (c *Mwan3RuleResource) ToYaml(target string) string {
+	return toCR("Mwan3Rule", c.Name, target, &crd.Mwan3RuleSpec{
+		Policy:   c.Policy,
+		SrcIp:    c.SourceIP,
+		SrcPort:  c.SourcePort,
+		DestIp:   c.DestinationIP,
+		DestPort: c.DestinationPort,
+		Proto:    c.Protocol,
+		Family:   c.Family,
+		Sticky:   c.Sticky,
+		Timeout:  c.Timeout,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("Mwan3Rule", &Mwan3RuleResource{})
+}
@@ -16,7 +16,9 @@
 
 package resource
 
-import ()
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
 
 type ProposalResource struct {
 	Name       string
@@ -34,18 +36,11 @@ func (c *ProposalResource) GetType() string {
 }
 
 func (c *ProposalResource) ToYaml(target string) string {
-	return `apiVersion: ` + SdewanApiVersion + `
-kind: IpsecProposal
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  encryption_algorithm: ` + c.Encryption + `
-  hash_algorithm: ` + c.Hash + `
-  dh_group: ` + c.DhGroup
+	return toCR("IpsecProposal", c.Name, target, &crd.IpsecProposalSpec{
+		EncryptionAlgorithm: c.Encryption,
+		HashAlgorithm:       c.Hash,
+		DhGroup:             c.DhGroup,
+	})
 }
 
 func init() {
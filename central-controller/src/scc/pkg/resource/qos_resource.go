@@ -0,0 +1,82 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+// TrafficClass is a class of the egress traffic of a TrafficShapingPolicy
+type TrafficClass struct {
+	Name     string
+	Priority string
+	Rate     string
+	Ceil     string
+	Dscp     string
+	Proto    string
+	SrcIp    string
+	SrcPort  string
+	DestIp   string
+	DestPort string
+}
+
+// TrafficShapingPolicyResource shapes the traffic of a network of a CNF, a
+// network has a single policy
+type TrafficShapingPolicyResource struct {
+	Name        string
+	Network     string
+	EgressRate  string
+	IngressRate string
+	Classes     []TrafficClass
+}
+
+func (c *TrafficShapingPolicyResource) GetName() string {
+	return c.Name
+}
+
+func (c *TrafficShapingPolicyResource) GetType() string {
+	return "TrafficShapingPolicy"
+}
+
+func (c *TrafficShapingPolicyResource) ToYaml(target string) string {
+	var classes []crd.TrafficClass
+	for _, cl := range c.Classes {
+		classes = append(classes, crd.TrafficClass{
+			Name:     cl.Name,
+			Priority: cl.Priority,
+			Rate:     cl.Rate,
+			Ceil:     cl.Ceil,
+			Dscp:     cl.Dscp,
+			Proto:    cl.Proto,
+			SrcIp:    cl.SrcIp,
+			SrcPort:  cl.SrcPort,
+			DestIp:   cl.DestIp,
+			DestPort: cl.DestPort,
+		})
+	}
+
+	return toCR("TrafficShapingPolicy", c.Name, target, &crd.TrafficShapingPolicySpec{
+		Network:     c.Network,
+		EgressRate:  c.EgressRate,
+		IngressRate: c.IngressRate,
+		Classes:     classes,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("TrafficShapingPolicy", &TrafficShapingPolicyResource{})
+}
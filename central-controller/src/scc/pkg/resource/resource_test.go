@@ -0,0 +1,175 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+const testPEM = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIRAK: "quoted" 'value'
+-----END CERTIFICATE-----
+`
+
+var goldenCases = []struct {
+	golden string
+	res    ISdewanResource
+}{
+	{"proposal", &ProposalResource{Name: "proposal1", Encryption: "aes128", Hash: "sha256", DhGroup: "modp3072"}},
+	{"ipsec_host_pubkey", &IpsecResource{
+		Name:                 "localto10.10.10.10",
+		Type:                 "VTI-based",
+		Remote:               "%any",
+		AuthenticationMethod: AuthTypePUBKEY,
+		CryptoProposal:       []string{"proposal1", "proposal2"},
+		LocalIdentifier:      "CN=device1",
+		RemoteIdentifier:     "CN=hub1",
+		ForceCryptoProposal:  "true",
+		PublicCert:           testPEM,
+		PrivateCert:          testPEM,
+		SharedCA:             testPEM,
+		Connections: Connection{
+			Name:           "Conn10.10.10.10",
+			ConnectionType: "tunnel",
+			Mode:           "start",
+			Mark:           "30",
+			LocalUpDown:    "/etc/updown_oip",
+			LocalSourceIp:  "%config",
+			RemoteSubnet:   "192.168.0.0/24,10.10.10.10/32",
+			CryptoProposal: []string{"proposal1"},
+		},
+	}},
+	{"ipsec_host_psk", &IpsecResource{
+		Name:                 "localto10.10.10.11",
+		Remote:               "10.10.10.11",
+		AuthenticationMethod: AuthTypePSK,
+		PresharedKey:         "k: #1 \"quoted\"",
+		Connections: Connection{
+			Name:           "Conn10.10.10.11",
+			ConnectionType: "tunnel",
+			Mode:           "start",
+			RemoteSourceIp: "192.168.0.5",
+		},
+	}},
+	{"ipsec_site", &IpsecResource{
+		Name:                 "hub1to10.10.10.12",
+		Type:                 "VTI-based",
+		Remote:               "%any",
+		AuthenticationMethod: AuthTypePUBKEY,
+		CryptoProposal:       []string{"proposal1"},
+		LocalIdentifier:      "CN=hub1",
+		RemoteIdentifier:     "CN=device2",
+		ForceCryptoProposal:  "true",
+		PublicCert:           testPEM,
+		PrivateCert:          testPEM,
+		SharedCA:             testPEM,
+		Connections: Connection{
+			Name:           "Conn10.10.10.12",
+			ConnectionType: "tunnel",
+			Mode:           "start",
+			Mark:           "30",
+			LocalUpDown:    "/etc/updown_oip",
+			LocalSubnet:    "0.0.0.0/0",
+			RemoteSourceIp: "192.168.0.6",
+			CryptoProposal: []string{"proposal1"},
+		},
+	}},
+	{"route", &RouteResource{Name: "route1", Destination: "192.168.0.0/24", Device: "#vti", Table: "cnf"}},
+	{"route_gw", &RouteResource{Name: "route2", Destination: "default", Gateway: "10.10.10.1", Device: "net0", Table: "default"}},
+	{"firewall_nat", &FirewallNatResource{Name: "nat1", Source: "#source", SourceIP: "10.10.10.1", SourceDestIP: "192.168.0.1", SourceDestPort: "80", Dest: "#dest", DestinationIP: "10.10.10.2", DestinationPort: "8080", Protocol: "tcp", Target: "DNAT", Index: "0"}},
+	{"firewall_dnat", &FirewallDnatResource{Name: "dnat1", Source: "wan", SourceIP: "10.10.10.1", SourceDestIP: "192.168.0.1", SourceDestPort: "80", DestinationIP: "10.10.10.2", DestinationPort: "8080", Protocol: "tcp"}},
+	{"firewall_zone", &FirewallZoneResource{Name: "zone1", Network: []string{"vti1", "net0"}, Input: "ACCEPT", Output: "ACCEPT", Forward: "ACCEPT", MASQ: "0", MTU_FIX: "1"}},
+	{"firewall_zone_empty", &FirewallZoneResource{Name: "zone2", Input: "REJECT", Output: "ACCEPT", Forward: "REJECT"}},
+	{"hubsite", &HubSiteResource{Name: "site1", Type: "Device", Site: "device1", Subnet: "192.168.1.0/24", HubIP: "10.10.10.1", DevicePIP: "192.168.0.3"}},
+	{"hubsite_ecmp", &HubSiteResource{Name: "site1hub2", Type: "Device", Subnet: "192.168.1.0/24", DevicePIP: "192.168.0.4", Priority: 2, Probability: "0.5000"}},
+	{"firewall_rule", &FirewallRuleResource{Name: "rule1", Source: "lan", SourceIP: "192.168.1.0/24", Protocol: "icmp", IcmpType: []string{"echo-request"}, Dest: "wan", Target: "ACCEPT", Family: "ipv4"}},
+	{"network_firewall_rule", &NetworkFirewallRuleResource{FirewallRuleResource{Name: "nrule1", Source: "*", SourceIP: "10.10.10.0/24", Dest: "*", DestinationIP: "10.10.20.5", DestinationPort: "22", Protocol: "tcp", Target: "DROP"}}},
+	{"firewall_forwarding", &FirewallForwardingResource{Name: "fwd1", Source: "lan", Dest: "wan"}},
+	{"mwan3_policy", &Mwan3PolicyResource{Name: "policy1", Members: []Mwan3PolicyMember{{Network: "net0", Metric: 1, Weight: 2}, {Network: "net1", Metric: 2, Weight: 1}}}},
+	{"mwan3_rule", &Mwan3RuleResource{Name: "mrule1", Policy: "policy1", SourceIP: "192.168.1.2", SourcePort: "", DestinationIP: "0.0.0.0/0", DestinationPort: "443", Protocol: "tcp", Family: "ipv4", Sticky: "0", Timeout: "600"}},
+	{"route_rule", &RouteRuleResource{Name: "rrule1", Source: "192.168.1.0/24", Priority: "100", Table: "cnf"}},
+	{"wireguard_interface", &WireguardInterfaceResource{Name: "wg0a0a0a01", PrivateKey: "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk=", ListenPort: "51820", Addresses: []string{"10.10.10.1/32"}}},
+	{"wireguard_peer", &WireguardPeerResource{Name: "wg0a0a0a01", Interface: "wg0a0a0a01", PublicKey: "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=", AllowedIps: []string{"192.168.0.3/32"}, RouteAllowedIps: true}},
+	{"bgp_instance", &BgpInstanceResource{Name: "bgp", As: "65000", RouterId: "192.168.0.3", Networks: []string{"192.168.0.3/32"}, Table: "cnf"}},
+	{"bgp_neighbor", &BgpNeighborResource{Name: "bgp0a0a0a01", Instance: "bgp", RemoteAddress: "10.10.10.1", RemoteAs: "65000", SourceAddress: "192.168.0.3", Dev: "#192.168.0.3"}},
+	{"traffic_shaping_policy", &TrafficShapingPolicyResource{Name: "tpvoice", Network: "pnetwork", EgressRate: "100000", Classes: []TrafficClass{{Name: "voice", Priority: "0", Rate: "10000", Dscp: "EF"}, {Name: "bulk", Priority: "6", Rate: "10000", Proto: "tcp", DestPort: "873"}}}},
+	{"dns_forwarder", &DnsForwarderResource{Name: "corp", Domain: "corp.example.com", Servers: []string{"10.10.0.53", "10.10.1.53"}}},
+	{"dns_record", &DnsRecordResource{Name: "wiki", Domain: "wiki.corp.example.com", Ip: "10.10.0.80"}},
+	{"application", &ApplicationResource{Name: "app1", PodLabels: map[string]string{"app": "web"}, AppNamespace: "default", ServicePort: "80", CNFPort: "8080"}},
+}
+
+func TestToYamlGolden(t *testing.T) {
+	for _, c := range goldenCases {
+		t.Run(c.golden, func(t *testing.T) {
+			content := c.res.ToYaml("Device.device1")
+			golden := filepath.Join("testdata", c.golden+".yaml")
+			if *update {
+				err := ioutil.WriteFile(golden, []byte(content), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if content != string(expected) {
+				t.Errorf("%s mismatch, got:\n%s\nexpected:\n%s", c.golden, content, expected)
+			}
+
+			err = Validate(c.res, "Device.device1")
+			if err != nil {
+				t.Errorf("%s does not match the CRD schema: %s", c.golden, err)
+			}
+		})
+	}
+}
+
+func TestToYamlRoundTrip(t *testing.T) {
+	res := goldenCases[1].res.(*IpsecResource)
+	var obj struct {
+		Spec struct {
+			LocalPublicCert string `json:"local_public_cert"`
+		} `json:"spec"`
+	}
+
+	err := yaml.Unmarshal([]byte(res.ToYaml("Hub.hub1")), &obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Spec.LocalPublicCert != testPEM {
+		t.Errorf("certificate was not preserved, got %q", obj.Spec.LocalPublicCert)
+	}
+}
+
+func TestValidateInvalidAuth(t *testing.T) {
+	res := &IpsecResource{Name: "bad", Remote: "%any", AuthenticationMethod: "cert"}
+	if res.ToYaml("Hub.hub1") != "" {
+		t.Errorf("unsupported authentication method should not render")
+	}
+	if Validate(res, "Hub.hub1") == nil {
+		t.Errorf("unsupported authentication method should fail validation")
+	}
+}
@@ -16,6 +16,10 @@
 
 package resource
 
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
 type RouteResource struct {
 	Name        string
 	Destination string
@@ -33,24 +37,12 @@ func (c *RouteResource) GetType() string {
 }
 
 func (c *RouteResource) ToYaml(target string) string {
-	basic := `apiVersion: ` + SdewanApiVersion + `
-kind: CNFRoute
-metadata:
-  name: ` + c.Name + `
-  namespace: default
-  labels:
-    sdewanPurpose: ` + SdewanPurpose + `
-    targetCluster: ` + target + `
-spec:
-  dst: ` + c.Destination + `
-  dev: "` + c.Device + `"
-  table: ` + c.Table
-
-	if c.Gateway != "" {
-		basic += `
-  gw: ` + c.Gateway
-	}
-	return basic
+	return toCR("CNFRoute", c.Name, target, &crd.CNFRouteSpec{
+		Dst:   c.Destination,
+		Gw:    c.Gateway,
+		Dev:   c.Device,
+		Table: c.Table,
+	})
 }
 
 func init() {
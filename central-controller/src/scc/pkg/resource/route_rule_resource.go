@@ -0,0 +1,54 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+type RouteRuleResource struct {
+	Name        string
+	Source      string
+	Destination string
+	Not         bool
+	Priority    string
+	Fwmark      string
+	Table       string
+}
+
+func (c *RouteRuleResource) GetName() string {
+	return c.Name
+}
+
+func (c *RouteRuleResource) GetType() string {
+	return "RouteRule"
+}
+
+func (c *RouteRuleResource) ToYaml(target string) string {
+	return toCR("CNFRouteRule", c.Name, target, &crd.CNFRouteRuleSpec{
+		Src:    c.Source,
+		Dst:    c.Destination,
+		Not:    c.Not,
+		Prio:   c.Priority,
+		Fwmark: c.Fwmark,
+		Table:  c.Table,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("RouteRule", &RouteRuleResource{})
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2020 Intel Corporation, Inc
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/resource/crd"
+)
+
+// WireguardInterfaceResource is a WireGuard network device of a CNF, its
+// name is used as the name of the device
+type WireguardInterfaceResource struct {
+	Name       string
+	PrivateKey string
+	ListenPort string
+	Addresses  []string
+	Mtu        string
+}
+
+func (c *WireguardInterfaceResource) GetName() string {
+	return c.Name
+}
+
+func (c *WireguardInterfaceResource) GetType() string {
+	return "WireguardInterface"
+}
+
+func (c *WireguardInterfaceResource) ToYaml(target string) string {
+	return toCR("WireguardInterface", c.Name, target, &crd.WireguardInterfaceSpec{
+		PrivateKey: c.PrivateKey,
+		ListenPort: c.ListenPort,
+		Addresses:  c.Addresses,
+		Mtu:        c.Mtu,
+	})
+}
+
+// WireguardPeerResource is the remote end of a WireGuard interface
+type WireguardPeerResource struct {
+	Name                string
+	Interface           string
+	PublicKey           string
+	PresharedKey        string
+	EndpointHost        string
+	EndpointPort        string
+	AllowedIps          []string
+	PersistentKeepalive string
+	RouteAllowedIps     bool
+}
+
+func (c *WireguardPeerResource) GetName() string {
+	return c.Name
+}
+
+func (c *WireguardPeerResource) GetType() string {
+	return "WireguardPeer"
+}
+
+func (c *WireguardPeerResource) ToYaml(target string) string {
+	return toCR("WireguardPeer", c.Name, target, &crd.WireguardPeerSpec{
+		Interface:           c.Interface,
+		PublicKey:           c.PublicKey,
+		PresharedKey:        c.PresharedKey,
+		EndpointHost:        c.EndpointHost,
+		EndpointPort:        c.EndpointPort,
+		AllowedIps:          emptyIfNil(c.AllowedIps),
+		PersistentKeepalive: c.PersistentKeepalive,
+		RouteAllowedIps:     c.RouteAllowedIps,
+	})
+}
+
+func init() {
+	GetResourceBuilder().Register("WireguardInterface", &WireguardInterfaceResource{})
+	GetResourceBuilder().Register("WireguardPeer", &WireguardPeerResource{})
+}
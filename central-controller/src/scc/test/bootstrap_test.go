@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+var BootstrapUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.DeviceCollection
+	BootstrapUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.BootstrapAction
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	var device_object = module.DeviceObject{
+		Metadata:      module.ObjectMetaData{Name: "device1"},
+		Specification: module.DeviceObjectSpec{BootstrapToken: "3f1c9a7e0b2d4c6f8a5e"}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+	createControllerObject(BaseUrl, &device_object, &module.DeviceObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(BaseUrl, "device1")
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestBootstrap(t *testing.T) {
+	kubeconfig := base64.StdEncoding.EncodeToString([]byte("apiVersion: v1"))
+	csr := base64.StdEncoding.EncodeToString([]byte("csr"))
+
+	tcases := []struct {
+		name            string
+		req             module.BootstrapRequest
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name:            "NoCsr",
+			req:             module.BootstrapRequest{Overlay: "overlay1", Device: "device1", Token: "3f1c9a7e0b2d4c6f8a5e", KubeConfig: kubeconfig},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name:            "WrongToken",
+			req:             module.BootstrapRequest{Overlay: "overlay1", Device: "device1", Token: "0000000000000000", Csr: csr, KubeConfig: kubeconfig},
+			expectedErr:     true,
+			expectedErrCode: 401,
+		},
+		{
+			name:            "UnknownDevice",
+			req:             module.BootstrapRequest{Overlay: "overlay1", Device: "device2", Token: "3f1c9a7e0b2d4c6f8a5e", Csr: csr, KubeConfig: kubeconfig},
+			expectedErr:     true,
+			expectedErrCode: 401,
+		},
+		{
+			name:            "WrongCsr",
+			req:             module.BootstrapRequest{Overlay: "overlay1", Device: "device1", Token: "3f1c9a7e0b2d4c6f8a5e", Csr: csr, KubeConfig: kubeconfig},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		body, _ := json.Marshal(tcase.req)
+		_, err := callRest("POST", BootstrapUrl, string(body))
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestRegisterBootstrapDevice(t *testing.T) {
+	ret, err := getControllerObject(BaseUrl, "device1", &module.DeviceObject{})
+	if err != nil {
+		printError(err)
+		t.Errorf("Test Case 'RegisterBootstrapDevice' failed: get object")
+		return
+	}
+
+	dev := ret.(*module.DeviceObject)
+	if dev.Status.Data[manager.RegStatus] != "bootstrap" || dev.Specification.BootstrapToken != "" {
+		t.Errorf("Test Case 'RegisterBootstrapDevice' failed: device is not waiting for bootstrap")
+	}
+
+	// the device registers itself by the bootstrap api
+	_, err = callRest("POST", BaseUrl+"/device1/"+manager.RegisterAction, "")
+	handleError(t, err, "RegisterBootstrapDevice", true, 409)
+}
@@ -20,13 +20,13 @@ func TestMain(m *testing.M) {
 	BaseUrl = OverlayUrl + "/overlay1/" + manager.CertCollection
 
 	var overlay_object = module.OverlayObject{
-		Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
 		Specification: module.OverlayObjectSpec{}}
 
 	var cert_object1 = module.CertificateObject{
-		Metadata: module.ObjectMetaData{"device1", "", "", ""}}
+		Metadata: module.ObjectMetaData{Name: "device1"}}
 	var cert_object2 = module.CertificateObject{
-		Metadata: module.ObjectMetaData{"device2", "", "", ""}}
+		Metadata: module.ObjectMetaData{Name: "device2"}}
 
 	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
 	createControllerObject(BaseUrl, &cert_object1, &module.CertificateObject{})
@@ -102,7 +102,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "EmptyName",
 			obj: module.CertificateObject{
-				Metadata: module.ObjectMetaData{"", "object 1", "", ""}},
+				Metadata: module.ObjectMetaData{Description: "object 1"}},
 			url:             BaseUrl,
 			expectedErr:     true,
 			expectedErrCode: 422,
@@ -110,7 +110,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "WrongOverlayName",
 			obj: module.CertificateObject{
-				Metadata: module.ObjectMetaData{"device3", "", "", ""}},
+				Metadata: module.ObjectMetaData{Name: "device3"}},
 			url:             OverlayUrl + "/foooverlay/" + manager.CertCollection,
 			expectedErr:     true,
 			expectedErrCode: 500,
@@ -118,7 +118,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "DumplicateName",
 			obj: module.CertificateObject{
-				Metadata: module.ObjectMetaData{"device1", "", "", ""}},
+				Metadata: module.ObjectMetaData{Name: "device1"}},
 			url:             BaseUrl,
 			expectedErr:     true,
 			expectedErrCode: 409,
@@ -156,7 +156,7 @@ func TestHappyPath(t *testing.T) {
 	cert_name := "my-device"
 
 	obj := module.CertificateObject{
-		Metadata: module.ObjectMetaData{cert_name, "", "", ""}}
+		Metadata: module.ObjectMetaData{Name: cert_name}}
 
 	_, err := createControllerObject(BaseUrl, &obj, &module.CertificateObject{})
 	if err != nil {
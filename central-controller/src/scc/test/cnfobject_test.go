@@ -18,7 +18,7 @@ func TestMain(m *testing.M) {
 	BaseUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
 
 	var object1 = module.OverlayObject{
-		Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
 		Specification: module.OverlayObjectSpec{}}
 
 	createControllerObject(BaseUrl, &object1, &module.OverlayObject{})
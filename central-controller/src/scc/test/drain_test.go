@@ -0,0 +1,63 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.HubCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestDrainHub(t *testing.T) {
+	tcases := []struct {
+		name            string
+		hub             string
+		req             module.DrainRequest
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name:            "NoTargetHub",
+			hub:             "hub1",
+			req:             module.DrainRequest{},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name:            "UnknownHub",
+			hub:             "hub1",
+			req:             module.DrainRequest{TargetHub: "hub2"},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		body, _ := json.Marshal(tcase.req)
+		_, err := callRest("POST", BaseUrl+"/"+tcase.hub+"/"+manager.DrainAction, string(body))
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
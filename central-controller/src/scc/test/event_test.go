@@ -0,0 +1,74 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var OverlayUrl string
+var EventUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	EventUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.EventCollection
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestStreamEvents(t *testing.T) {
+	resp, err := http.Get(EventUrl + "?overlay=overlay1")
+	if err != nil {
+		t.Fatalf("Stream events: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Stream events: unexpected response %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+	_, err = createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+	if err != nil {
+		t.Fatalf("Create overlay1: %s", err.Error())
+	}
+
+	events := make(chan module.Event)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var e module.Event
+			line := scanner.Text()
+			if strings.HasPrefix(line, "data: ") && json.Unmarshal([]byte(line[6:]), &e) == nil {
+				events <- e
+			}
+		}
+		close(events)
+	}()
+
+	select {
+	case e, ok := <-events:
+		if !ok || e.Type != module.EventCreated || e.Overlay != "overlay1" || e.Name != "overlay1" {
+			t.Errorf("Stream events: unexpected event %v", e)
+		}
+	case <-time.After(10 * time.Second):
+		t.Errorf("Stream events: no event for overlay1")
+	}
+
+	_, err = callRest("GET", EventUrl+"?since=last", "")
+	handleError(t, err, "InvalidSince", true, 400)
+}
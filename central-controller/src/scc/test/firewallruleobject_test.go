@@ -0,0 +1,104 @@
+package test
+
+import (
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.HubCollection + "/foohub/" + manager.FirewallRuleCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestCreateObject(t *testing.T) {
+	tcases := []struct {
+		name            string
+		url             string
+		obj             module.FirewallRuleObject
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name: "EmptyName",
+			obj: module.FirewallRuleObject{
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
+				Specification: module.FirewallRuleObjectSpec{Src: "lan", Dest: "wan", Target: "ACCEPT"}},
+			url:             BaseUrl,
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongTarget",
+			obj: module.FirewallRuleObject{
+				Metadata:      module.ObjectMetaData{Name: "rule1"},
+				Specification: module.FirewallRuleObjectSpec{Src: "lan", Dest: "wan", Target: "ALLOW"}},
+			url:             BaseUrl,
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongHubName",
+			obj: module.FirewallRuleObject{
+				Metadata:      module.ObjectMetaData{Name: "rule1"},
+				Specification: module.FirewallRuleObjectSpec{Src: "lan", Dest: "wan", Target: "ACCEPT"}},
+			url:             BaseUrl,
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+		{
+			name: "WrongDeviceName",
+			obj: module.FirewallRuleObject{
+				Metadata:      module.ObjectMetaData{Name: "rule1"},
+				Specification: module.FirewallRuleObjectSpec{Src: "lan", Dest: "wan", Target: "ACCEPT"}},
+			url:             OverlayUrl + "/overlay1/" + manager.DeviceCollection + "/foodevice/" + manager.FirewallRuleCollection,
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := createControllerObject(tcase.url, &tcase.obj, &module.FirewallRuleObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestGetObject(t *testing.T) {
+	tcases := []struct {
+		name            string
+		object_name     string
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name:            "GetFoolName",
+			object_name:     "foo_name",
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := getControllerObject(BaseUrl, tcase.object_name, &module.FirewallRuleObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
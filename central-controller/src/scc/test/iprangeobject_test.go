@@ -20,14 +20,14 @@ func TestMain(m *testing.M) {
 	BaseUrl = OverlayUrl + "/overlay1/" + manager.IPRangeCollection
 
 	var overlay_object = module.OverlayObject{
-		Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
 		Specification: module.OverlayObjectSpec{}}
 
 	var iprange_object1 = module.IPRangeObject{
-		Metadata:      module.ObjectMetaData{"ipr1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "ipr1"},
 		Specification: module.IPRangeObjectSpec{"192.168.0.2", 10, 12}}
 	var iprange_object2 = module.IPRangeObject{
-		Metadata:      module.ObjectMetaData{"ipr2", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "ipr2"},
 		Specification: module.IPRangeObjectSpec{"192.168.1.3", 32, 36}}
 
 	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
@@ -100,7 +100,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "EmptyName",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"", "object 1", "", ""},
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
 				Specification: module.IPRangeObjectSpec{}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -109,7 +109,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "DumplicateName",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"ipr1", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "ipr1"},
 				Specification: module.IPRangeObjectSpec{"192.168.2.3", 10, 15}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -118,7 +118,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "WrongOverlayName",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.2.3", 10, 15}},
 			url:             OverlayUrl + "/foooverlay/" + manager.IPRangeCollection,
 			expectedErr:     true,
@@ -127,7 +127,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "WrongSubnet",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.2.3.0", 1, 15}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -136,7 +136,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "WrongMinIP",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.2.3", 0, 15}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -145,7 +145,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "WrongMaxIP",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.1.3", 1, 300}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -154,7 +154,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "WrongMinMaxIP",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.2.3", 20, 15}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -163,7 +163,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "ConflictRange1",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.0.3", 11, 15}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -172,7 +172,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "ConflictRange2",
 			obj: module.IPRangeObject{
-				Metadata:      module.ObjectMetaData{"my-ipr", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "my-ipr"},
 				Specification: module.IPRangeObjectSpec{"192.168.1.3", 30, 40}},
 			url:             BaseUrl,
 			expectedErr:     true,
@@ -211,7 +211,7 @@ func TestHappyPath(t *testing.T) {
 	ipr_name := "my-ipr"
 
 	obj := module.IPRangeObject{
-		Metadata:      module.ObjectMetaData{ipr_name, "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: ipr_name},
 		Specification: module.IPRangeObjectSpec{"192.168.2.3", 10, 15}}
 
 	ret_obj, err := createControllerObject(BaseUrl, &obj, &module.IPRangeObject{})
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.ProposalCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	var proposal_object = module.ProposalObject{
+		Metadata:      module.ObjectMetaData{Name: "proposal1"},
+		Specification: module.ProposalObjectSpec{Encryption: "aes256", Hash: "sha256", DhGroup: "modp4096"}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+	createControllerObject(BaseUrl, &proposal_object, &module.ProposalObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay2")
+	deleteControllerObject(BaseUrl, "proposal1")
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestExportOverlay(t *testing.T) {
+	res, err := callRest("GET", OverlayUrl+"/overlay1/"+manager.ExportAction, "")
+	if err != nil {
+		t.Fatalf("Export overlay1: %s", err.Error())
+	}
+
+	var doc module.OverlayDocument
+	err = json.Unmarshal([]byte(res), &doc)
+	if err != nil {
+		t.Fatalf("Export overlay1: %s", err.Error())
+	}
+	if doc.Version != module.OverlayDocumentVersion || doc.Secrets != module.SecretsRedacted {
+		t.Errorf("Export overlay1: unexpected version %s or secrets %s", doc.Version, doc.Secrets)
+	}
+	if len(doc.Objects) != 1 || doc.Objects[0].GetKey() != "Proposal/proposal1" {
+		t.Errorf("Export overlay1: unexpected objects %v", doc.Objects)
+	}
+
+	_, err = callRest("GET", OverlayUrl+"/overlay3/"+manager.ExportAction, "")
+	handleError(t, err, "UnknownOverlay", true, 500)
+}
+
+func TestImportOverlay(t *testing.T) {
+	proposal, _ := json.Marshal(module.ProposalObjectSpec{Encryption: "aes256", Hash: "sha256", DhGroup: "modp4096"})
+	tcases := []struct {
+		name            string
+		overlay         string
+		doc             module.OverlayDocument
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name:            "NoVersion",
+			doc:             module.OverlayDocument{Overlay: module.DocumentObject{Kind: "Overlay", Metadata: module.ObjectMetaData{Name: "overlay2"}}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name:            "ExistingOverlay",
+			doc:             module.OverlayDocument{Version: module.OverlayDocumentVersion, Overlay: module.DocumentObject{Kind: "Overlay", Metadata: module.ObjectMetaData{Name: "overlay1"}}},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+		{
+			name:            "NoPassphrase",
+			doc:             module.OverlayDocument{Version: module.OverlayDocumentVersion, Secrets: module.SecretsEncrypted, Overlay: module.DocumentObject{Kind: "Overlay", Metadata: module.ObjectMetaData{Name: "overlay2"}}},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+		{
+			name:    "Rename",
+			overlay: "overlay2",
+			doc: module.OverlayDocument{Version: module.OverlayDocumentVersion, Overlay: module.DocumentObject{Kind: "Overlay", Metadata: module.ObjectMetaData{Name: "overlay1"}},
+				Objects: []module.DocumentObject{{Kind: "Proposal", Metadata: module.ObjectMetaData{Name: "proposal1"}, Spec: proposal}}},
+			expectedErr: false,
+		},
+	}
+
+	for _, tcase := range tcases {
+		body, _ := json.Marshal(tcase.doc)
+		url := OverlayUrl + "/" + manager.ImportAction
+		if tcase.overlay != "" {
+			url = url + "?name=" + tcase.overlay
+		}
+		_, err := callRest("POST", url, string(body))
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestApplyOverlay(t *testing.T) {
+	proposal, _ := json.Marshal(module.ProposalObjectSpec{Encryption: "aes256", Hash: "sha256", DhGroup: "modp4096"})
+	doc := module.OverlayDocument{Version: module.OverlayDocumentVersion,
+		Objects: []module.DocumentObject{{Anchor: "overlays/overlay1/proposals", Metadata: module.ObjectMetaData{Name: "proposal2"}, Spec: proposal}}}
+	body, _ := json.Marshal(doc)
+	url := OverlayUrl + "/overlay1/" + manager.ApplyAction
+
+	res, err := callRest("POST", url+"?prune=true&dryRun=true", string(body))
+	if err != nil {
+		t.Fatalf("Apply overlay1: %s", err.Error())
+	}
+
+	var ret module.ApplyResponse
+	err = json.Unmarshal([]byte(res), &ret)
+	if err != nil {
+		t.Fatalf("Apply overlay1: %s", err.Error())
+	}
+	expected := []module.ApplyChange{
+		{Action: module.ActionCreate, Object: "Proposal/proposal2"},
+		{Action: module.ActionDelete, Object: "Proposal/proposal1"},
+	}
+	if len(ret.Changes) != len(expected) || ret.Changes[0] != expected[0] || ret.Changes[1] != expected[1] {
+		t.Errorf("Apply overlay1: unexpected changes %v", ret.Changes)
+	}
+
+	_, err = callRest("POST", url+"?prune=maybe", string(body))
+	handleError(t, err, "InvalidPrune", true, 400)
+
+	_, err = callRest("POST", OverlayUrl+"/overlay3/"+manager.ApplyAction+"?dryRun=true", string(body))
+	handleError(t, err, "WrongAnchor", true, 500)
+}
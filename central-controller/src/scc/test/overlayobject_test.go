@@ -18,10 +18,10 @@ func TestMain(m *testing.M) {
 	BaseUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
 
 	var object1 = module.OverlayObject{
-		Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
 		Specification: module.OverlayObjectSpec{}}
 	var object2 = module.OverlayObject{
-		Metadata:      module.ObjectMetaData{"overlay2", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "overlay2"},
 		Specification: module.OverlayObjectSpec{}}
 
 	createControllerObject(BaseUrl, &object1, &module.OverlayObject{})
@@ -91,7 +91,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "EmptyName",
 			obj: module.OverlayObject{
-				Metadata:      module.ObjectMetaData{"", "object 1", "", ""},
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
 				Specification: module.OverlayObjectSpec{}},
 			expectedErr:     true,
 			expectedErrCode: 422,
@@ -99,7 +99,7 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "DumplicateName",
 			obj: module.OverlayObject{
-				Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "overlay1"},
 				Specification: module.OverlayObjectSpec{}},
 			expectedErr:     true,
 			expectedErrCode: 409,
@@ -124,7 +124,7 @@ func TestUpdateObject(t *testing.T) {
 			name:        "EmptyName",
 			object_name: "overlay1",
 			obj: module.OverlayObject{
-				Metadata:      module.ObjectMetaData{"", "object 1", "", ""},
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
 				Specification: module.OverlayObjectSpec{}},
 			expectedErr:     true,
 			expectedErrCode: 422,
@@ -133,7 +133,7 @@ func TestUpdateObject(t *testing.T) {
 			name:        "MisMatchName",
 			object_name: "overlay2",
 			obj: module.OverlayObject{
-				Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "overlay1"},
 				Specification: module.OverlayObjectSpec{}},
 			expectedErr:     true,
 			expectedErrCode: 500,
@@ -169,11 +169,11 @@ func TestHappyPath(t *testing.T) {
 	overlay_name := "my-overlay"
 
 	obj := module.OverlayObject{
-		Metadata:      module.ObjectMetaData{overlay_name, "object 1", "", ""},
+		Metadata:      module.ObjectMetaData{Name: overlay_name, Description: "object 1"},
 		Specification: module.OverlayObjectSpec{}}
 
 	obj_update := module.OverlayObject{
-		Metadata:      module.ObjectMetaData{overlay_name, "object 2", "", ""},
+		Metadata:      module.ObjectMetaData{Name: overlay_name, Description: "object 2"},
 		Specification: module.OverlayObjectSpec{}}
 
 	ret_obj, err := createControllerObject(BaseUrl, &obj, &module.OverlayObject{})
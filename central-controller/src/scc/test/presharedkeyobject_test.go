@@ -0,0 +1,85 @@
+package test
+
+import (
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.DeviceCollection + "/device1/" + manager.PreSharedKeyCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{AuthMode: "psk"}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestCreateOverlay(t *testing.T) {
+	tcases := []struct {
+		name            string
+		obj             module.OverlayObject
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name: "WrongAuthMode",
+			obj: module.OverlayObject{
+				Metadata:      module.ObjectMetaData{Name: "overlay2"},
+				Specification: module.OverlayObjectSpec{AuthMode: "password"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := createControllerObject(OverlayUrl, &tcase.obj, &module.OverlayObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestUpdateObject(t *testing.T) {
+	tcases := []struct {
+		name            string
+		obj             module.PreSharedKeyObject
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name: "ShortKey",
+			obj: module.PreSharedKeyObject{
+				Metadata:      module.ObjectMetaData{Name: "Hub.hub1"},
+				Specification: module.PreSharedKeyObjectSpec{Key: "short"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "NoConnection",
+			obj: module.PreSharedKeyObject{
+				Metadata:      module.ObjectMetaData{Name: "Hub.hub1"},
+				Specification: module.PreSharedKeyObjectSpec{}},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := updateControllerObject(BaseUrl, tcase.obj.Metadata.Name, &tcase.obj, &module.PreSharedKeyObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
@@ -21,22 +21,22 @@ func TestMain(m *testing.M) {
 	BaseUrl = OverlayUrl + "/overlay1/" + manager.ProposalCollection
 
 	var overlay_object = module.OverlayObject{
-		Metadata:      module.ObjectMetaData{"overlay1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
 		Specification: module.OverlayObjectSpec{}}
 
 	var iprange_object1 = module.IPRangeObject{
-		Metadata:      module.ObjectMetaData{"ipr1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "ipr1"},
 		Specification: module.IPRangeObjectSpec{"192.168.0.2", 10, 20}}
 	var iprange_object2 = module.IPRangeObject{
-		Metadata:      module.ObjectMetaData{"ipr2", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "ipr2"},
 		Specification: module.IPRangeObjectSpec{"192.168.2.2", 18, 20}}
 
 	var proposal_object1 = module.ProposalObject{
-		Metadata:      module.ObjectMetaData{"proposal1", "", "", ""},
+		Metadata:      module.ObjectMetaData{Name: "proposal1"},
 		Specification: module.ProposalObjectSpec{"aes256", "sha256", "modp4096"}}
 	var proposal_object2 = module.ProposalObject{
-		Metadata:      module.ObjectMetaData{"proposal2", "", "", ""},
-		Specification: module.ProposalObjectSpec{"aes512", "sha512", "modp4096"}}
+		Metadata:      module.ObjectMetaData{Name: "proposal2"},
+		Specification: module.ProposalObjectSpec{"aes256gcm16", "sha512", "ecp384"}}
 
 	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
 	createControllerObject(IPBaseUrl, &iprange_object1, &module.IPRangeObject{})
@@ -112,17 +112,44 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "EmptyName",
 			obj: module.ProposalObject{
-				Metadata:      module.ObjectMetaData{"", "object 1", "", ""},
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
 				Specification: module.ProposalObjectSpec{}},
 			url:             BaseUrl,
 			expectedErr:     true,
 			expectedErrCode: 422,
 		},
 		{
-			name: "WrongOverlayName",
+			name: "WrongEncryption",
 			obj: module.ProposalObject{
-				Metadata:      module.ObjectMetaData{"proposal1", "", "", ""},
+				Metadata:      module.ObjectMetaData{Name: "proposal3"},
 				Specification: module.ProposalObjectSpec{"aes512", "sha512", "modp4096"}},
+			url:             BaseUrl,
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongHash",
+			obj: module.ProposalObject{
+				Metadata:      module.ObjectMetaData{Name: "proposal3"},
+				Specification: module.ProposalObjectSpec{"aes256", "sha3", "modp4096"}},
+			url:             BaseUrl,
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongDhGroup",
+			obj: module.ProposalObject{
+				Metadata:      module.ObjectMetaData{Name: "proposal3"},
+				Specification: module.ProposalObjectSpec{"aes256", "sha256", "group19"}},
+			url:             BaseUrl,
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongOverlayName",
+			obj: module.ProposalObject{
+				Metadata:      module.ObjectMetaData{Name: "proposal1"},
+				Specification: module.ProposalObjectSpec{"aes256gcm16", "sha512", "ecp384"}},
 			url:             OverlayUrl + "/foooverlay/" + manager.ProposalCollection,
 			expectedErr:     true,
 			expectedErrCode: 500,
@@ -130,8 +157,8 @@ func TestCreateObject(t *testing.T) {
 		{
 			name: "DumplicateName",
 			obj: module.ProposalObject{
-				Metadata:      module.ObjectMetaData{"proposal1", "", "", ""},
-				Specification: module.ProposalObjectSpec{"aes512", "sha512", "modp4096"}},
+				Metadata:      module.ObjectMetaData{Name: "proposal1"},
+				Specification: module.ProposalObjectSpec{"aes256gcm16", "sha512", "ecp384"}},
 			url:             BaseUrl,
 			expectedErr:     true,
 			expectedErrCode: 409,
@@ -156,8 +183,8 @@ func TestUpdateObject(t *testing.T) {
 			name:        "EmptyName",
 			object_name: "proposal1",
 			obj: module.ProposalObject{
-				Metadata:      module.ObjectMetaData{"", "object 1", "", ""},
-				Specification: module.ProposalObjectSpec{"aes512", "sha512", "modp4096"}},
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
+				Specification: module.ProposalObjectSpec{"aes256gcm16", "sha512", "ecp384"}},
 			expectedErr:     true,
 			expectedErrCode: 422,
 		},
@@ -165,8 +192,8 @@ func TestUpdateObject(t *testing.T) {
 			name:        "MisMatchName",
 			object_name: "proposal2",
 			obj: module.ProposalObject{
-				Metadata:      module.ObjectMetaData{"proposal1", "", "", ""},
-				Specification: module.ProposalObjectSpec{"aes512", "sha512", "modp4096"}},
+				Metadata:      module.ObjectMetaData{Name: "proposal1"},
+				Specification: module.ProposalObjectSpec{"aes256gcm16", "sha512", "ecp384"}},
 			expectedErr:     true,
 			expectedErrCode: 500,
 		},
@@ -201,12 +228,12 @@ func TestHappyPath(t *testing.T) {
 	proposal_name := "my-proposal"
 
 	obj := module.ProposalObject{
-		Metadata:      module.ObjectMetaData{proposal_name, "object 1", "", ""},
+		Metadata:      module.ObjectMetaData{Name: proposal_name, Description: "object 1"},
 		Specification: module.ProposalObjectSpec{"aes256", "sha256", "modp4096"}}
 
 	obj_update := module.ProposalObject{
-		Metadata:      module.ObjectMetaData{proposal_name, "object 1", "", ""},
-		Specification: module.ProposalObjectSpec{"aes512", "sha512", "modp4096"}}
+		Metadata:      module.ObjectMetaData{Name: proposal_name, Description: "object 1"},
+		Specification: module.ProposalObjectSpec{"aes256gcm16", "sha512", "ecp384"}}
 
 	ret_obj, err := createControllerObject(BaseUrl, &obj, &module.ProposalObject{})
 	if err != nil {
@@ -227,7 +254,7 @@ func TestHappyPath(t *testing.T) {
 		return
 	}
 
-	if ret_obj.(*module.ProposalObject).Specification.Encryption != "aes512" {
+	if ret_obj.(*module.ProposalObject).Specification.Encryption != "aes256gcm16" {
 		t.Errorf("Test Case 'Happy Path' failed: update object")
 		return
 	}
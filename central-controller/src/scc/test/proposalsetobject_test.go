@@ -0,0 +1,127 @@
+package test
+
+import (
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+var ProposalUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	ProposalUrl = OverlayUrl + "/overlay1/" + manager.ProposalCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.ProposalSetCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	var proposal_object1 = module.ProposalObject{
+		Metadata:      module.ObjectMetaData{Name: "gcm"},
+		Specification: module.ProposalObjectSpec{"aes256gcm16", "sha384", "ecp384"}}
+	var proposal_object2 = module.ProposalObject{
+		Metadata:      module.ObjectMetaData{Name: "legacy"},
+		Specification: module.ProposalObjectSpec{"aes128", "sha1", "modp2048"}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+	createControllerObject(ProposalUrl, &proposal_object1, &module.ProposalObject{})
+	createControllerObject(ProposalUrl, &proposal_object2, &module.ProposalObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(ProposalUrl, "gcm")
+	deleteControllerObject(ProposalUrl, "legacy")
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestCreateObject(t *testing.T) {
+	tcases := []struct {
+		name            string
+		obj             module.ProposalSetObject
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name: "NoProposal",
+			obj: module.ProposalSetObject{
+				Metadata:      module.ObjectMetaData{Name: "set1"},
+				Specification: module.ProposalSetObjectSpec{ConnectionTypes: []string{"hub-to-hub"}}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongConnectionType",
+			obj: module.ProposalSetObject{
+				Metadata:      module.ObjectMetaData{Name: "set1"},
+				Specification: module.ProposalSetObjectSpec{Proposals: []string{"gcm"}, ConnectionTypes: []string{"hub-to-cloud"}}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "UnknownProposal",
+			obj: module.ProposalSetObject{
+				Metadata:      module.ObjectMetaData{Name: "set1"},
+				Specification: module.ProposalSetObjectSpec{Proposals: []string{"foo"}, ConnectionTypes: []string{"hub-to-hub"}}},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := createControllerObject(BaseUrl, &tcase.obj, &module.ProposalSetObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestHappyPath(t *testing.T) {
+	inter_hub := module.ProposalSetObject{
+		Metadata:      module.ObjectMetaData{Name: "inter-hub"},
+		Specification: module.ProposalSetObjectSpec{Proposals: []string{"gcm"}, ConnectionTypes: []string{"hub-to-hub"}}}
+	branch := module.ProposalSetObject{
+		Metadata:      module.ObjectMetaData{Name: "branch"},
+		Specification: module.ProposalSetObjectSpec{Proposals: []string{"legacy", "gcm"}, Devices: []string{"device1"}}}
+
+	for _, obj := range []module.ProposalSetObject{inter_hub, branch} {
+		_, err := createControllerObject(BaseUrl, &obj, &module.ProposalSetObject{})
+		if err != nil {
+			printError(err)
+			t.Errorf("Test Case 'Happy Path' failed: create object")
+			return
+		}
+	}
+
+	// proposals in use can not be deleted
+	_, err := deleteControllerObject(ProposalUrl, "legacy")
+	if err == nil {
+		t.Errorf("Test Case 'Happy Path' failed: delete proposal in use")
+	}
+
+	branch.Specification.Proposals = []string{"gcm"}
+	ret_obj, err := updateControllerObject(BaseUrl, "branch", &branch, &module.ProposalSetObject{})
+	if err != nil {
+		printError(err)
+		t.Errorf("Test Case 'Happy Path' failed: update object")
+		return
+	}
+
+	if len(ret_obj.(*module.ProposalSetObject).Specification.Proposals) != 1 {
+		t.Errorf("Test Case 'Happy Path' failed: update object")
+	}
+
+	for _, name := range []string{"inter-hub", "branch"} {
+		_, err = deleteControllerObject(BaseUrl, name)
+		if err != nil {
+			printError(err)
+			t.Errorf("Test Case 'Happy Path' failed: delete object")
+		}
+	}
+}
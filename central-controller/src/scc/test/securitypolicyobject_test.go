@@ -0,0 +1,141 @@
+package test
+
+import (
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.SecurityPolicyCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestCreateObject(t *testing.T) {
+	tcases := []struct {
+		name            string
+		obj             module.SecurityPolicyObject
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name: "EmptyName",
+			obj: module.SecurityPolicyObject{
+				Metadata:      module.ObjectMetaData{Description: "object 1"},
+				Specification: module.SecurityPolicyObjectSpec{Action: "ACCEPT"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongAction",
+			obj: module.SecurityPolicyObject{
+				Metadata:      module.ObjectMetaData{Name: "policy1"},
+				Specification: module.SecurityPolicyObjectSpec{Action: "ALLOW"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongProto",
+			obj: module.SecurityPolicyObject{
+				Metadata:      module.ObjectMetaData{Name: "policy1"},
+				Specification: module.SecurityPolicyObjectSpec{Proto: "sctp"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongCidr",
+			obj: module.SecurityPolicyObject{
+				Metadata: module.ObjectMetaData{Name: "policy1"},
+				Specification: module.SecurityPolicyObjectSpec{
+					Source: module.PolicyEndpoint{Cidrs: []string{"10.10.10.0/33"}}}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongSite",
+			obj: module.SecurityPolicyObject{
+				Metadata: module.ObjectMetaData{Name: "policy1"},
+				Specification: module.SecurityPolicyObjectSpec{
+					Destination: module.PolicyEndpoint{Sites: []string{"site1"}}}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "PortWithoutProto",
+			obj: module.SecurityPolicyObject{
+				Metadata:      module.ObjectMetaData{Name: "policy1"},
+				Specification: module.SecurityPolicyObjectSpec{Proto: "icmp", Port: "22"}},
+			expectedErr:     true,
+			expectedErrCode: 500,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := createControllerObject(BaseUrl, &tcase.obj, &module.SecurityPolicyObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestHappyPath(t *testing.T) {
+	policy_name := "my-policy"
+
+	obj := module.SecurityPolicyObject{
+		Metadata: module.ObjectMetaData{Name: policy_name, Description: "object 1"},
+		Specification: module.SecurityPolicyObjectSpec{
+			Source:      module.PolicyEndpoint{Cidrs: []string{"10.10.10.0/24"}},
+			Destination: module.PolicyEndpoint{Cidrs: []string{"10.10.20.5"}},
+			Proto:       "tcp",
+			Port:        "22",
+			Action:      "DROP"}}
+
+	ret_obj, err := createControllerObject(BaseUrl, &obj, &module.SecurityPolicyObject{})
+	if err != nil {
+		printError(err)
+		t.Errorf("Test Case 'Happy Path' failed: create object")
+		return
+	}
+
+	if ret_obj.(*module.SecurityPolicyObject).Status.RuleType != "FirewallRule" {
+		t.Errorf("Test Case 'Happy Path' failed: create object")
+		return
+	}
+
+	obj.Specification.RuleType = "NetworkFirewallRule"
+	ret_obj, err = updateControllerObject(BaseUrl, policy_name, &obj, &module.SecurityPolicyObject{})
+	if err != nil {
+		printError(err)
+		t.Errorf("Test Case 'Happy Path' failed: update object")
+		return
+	}
+
+	if ret_obj.(*module.SecurityPolicyObject).Status.RuleType != "NetworkFirewallRule" {
+		t.Errorf("Test Case 'Happy Path' failed: update object")
+		return
+	}
+
+	_, err = deleteControllerObject(BaseUrl, policy_name)
+	if err != nil {
+		printError(err)
+		t.Errorf("Test Case 'Happy Path' failed: delete object")
+		return
+	}
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"flag"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/manager"
+	"github.com/akraino-edge-stack/icn-sdwan/central-controller/src/scc/pkg/module"
+	"os"
+	"testing"
+)
+
+var OverlayUrl string
+var BaseUrl string
+
+func TestMain(m *testing.M) {
+	servIp := flag.String("ip", "127.0.0.1", "SDEWAN Central Controller IP Address")
+	flag.Parse()
+	OverlayUrl = "http://" + *servIp + ":9015/scc/v1/" + manager.OverlayCollection
+	BaseUrl = OverlayUrl + "/overlay1/" + manager.WebhookCollection
+
+	var overlay_object = module.OverlayObject{
+		Metadata:      module.ObjectMetaData{Name: "overlay1"},
+		Specification: module.OverlayObjectSpec{}}
+
+	createControllerObject(OverlayUrl, &overlay_object, &module.OverlayObject{})
+
+	var ret = m.Run()
+
+	deleteControllerObject(OverlayUrl, "overlay1")
+
+	os.Exit(ret)
+}
+
+func TestCreateWebhookObjectWithWrongParameter(t *testing.T) {
+	tcases := []struct {
+		name            string
+		obj             module.WebhookObject
+		expectedErr     bool
+		expectedErrCode int
+	}{
+		{
+			name: "WrongUrl",
+			obj: module.WebhookObject{
+				Metadata:      module.ObjectMetaData{Name: "webhook1"},
+				Specification: module.WebhookObjectSpec{Url: "portal", Secret: "0123456789abcdef"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "ShortSecret",
+			obj: module.WebhookObject{
+				Metadata:      module.ObjectMetaData{Name: "webhook1"},
+				Specification: module.WebhookObjectSpec{Url: "http://127.0.0.1:8080/events", Secret: "secret"}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+		{
+			name: "WrongEvent",
+			obj: module.WebhookObject{
+				Metadata: module.ObjectMetaData{Name: "webhook1"},
+				Specification: module.WebhookObjectSpec{Url: "http://127.0.0.1:8080/events", Secret: "0123456789abcdef",
+					Events: []string{"device-deleted"}}},
+			expectedErr:     true,
+			expectedErrCode: 422,
+		},
+	}
+
+	for _, tcase := range tcases {
+		_, err := createControllerObject(BaseUrl, &tcase.obj, &module.WebhookObject{})
+		handleError(t, err, tcase.name, tcase.expectedErr, tcase.expectedErrCode)
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	obj := module.WebhookObject{
+		Metadata: module.ObjectMetaData{Name: "webhook1"},
+		Specification: module.WebhookObjectSpec{Url: "http://127.0.0.1:8080/events", Secret: "0123456789abcdef",
+			Events: []string{module.NotifyConnectionError}}}
+
+	_, err := createControllerObject(BaseUrl, &obj, &module.WebhookObject{})
+	if err != nil {
+		printError(err)
+		t.Fatalf("Create webhook1 failed")
+	}
+
+	res, err := callRest("GET", BaseUrl+"/webhook1/"+manager.DeliveryCollection, "")
+	if err != nil || res != "[]\n" {
+		t.Errorf("Get deliveries of webhook1: unexpected %s %v", res, err)
+	}
+
+	_, err = callRest("GET", BaseUrl+"/webhook2/"+manager.DeliveryCollection, "")
+	handleError(t, err, "UnknownWebhook", true, 500)
+
+	_, err = deleteControllerObject(BaseUrl, "webhook1")
+	if err != nil {
+		printError(err)
+		t.Errorf("Delete webhook1 failed")
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var prune bool
+var dryRun bool
+
 // applyCmd represents the apply command
 var applyCmd = &cobra.Command{
 	Use:   "apply",
@@ -20,7 +23,13 @@ var applyCmd = &cobra.Command{
 		} else {
 			c = NewRestClient()
 		}
-		if len(inputFiles) > 0 {
+		if len(inputFiles) > 0 && prune {
+			resources := readResources()
+			err := c.RestClientApplyOverlay(resources)
+			if err != nil && err.Error() != "Server Error" {
+				fmt.Println("Apply: Error: ", err)
+			}
+		} else if len(inputFiles) > 0 {
 			resources := readResources()
 			for _, res := range resources {
 				if res.file != "" {
@@ -56,4 +65,7 @@ func init() {
 	applyCmd.Flags().StringSliceVarP(&inputFiles, "filename", "f", []string{}, "Filename of the input file")
 	applyCmd.Flags().StringSliceVarP(&valuesFiles, "values", "v", []string{}, "Template Values to go with the input template file")
 	applyCmd.Flags().StringSliceVarP(&token, "token", "t", []string{}, "Token for EWO API")
+	applyCmd.Flags().BoolVar(&prune, "prune", false, "Apply the file as the whole overlay, the resources not in the file are deleted")
+	applyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show the changes of apply --prune")
+	applyCmd.Flags().StringVarP(&passphrase, "passphrase", "p", "", "Passphrase of the encrypted secrets of apply --prune")
 }
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2020 Intel Corporation
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+)
+
+var outputFile string
+var passphrase string
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the definition of an overlay to a file",
+	Run: func(cmd *cobra.Command, args []string) {
+		var c RestyClient
+		if len(token) > 0 {
+			c = NewRestClientToken(token[0])
+		} else {
+			c = NewRestClient()
+		}
+		if len(args) < 1 {
+			fmt.Println("Error: No args ")
+			return
+		}
+		body, err := c.RestClientExport(args[0], passphrase)
+		if err != nil {
+			return
+		}
+		var out bytes.Buffer
+		if json.Indent(&out, body, "", "  ") == nil {
+			body = out.Bytes()
+		}
+		if outputFile == "" {
+			fmt.Println(string(body))
+			return
+		}
+		err = ioutil.WriteFile(outputFile, body, 0600)
+		if err != nil {
+			fmt.Println("Error writing file", "error", err, "filename", outputFile)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Filename of the exported overlay")
+	exportCmd.Flags().StringVarP(&passphrase, "passphrase", "p", "", "Passphrase to encrypt the secrets, they are redacted if empty")
+	exportCmd.Flags().StringSliceVarP(&token, "token", "t", []string{}, "Token for EWO API")
+}
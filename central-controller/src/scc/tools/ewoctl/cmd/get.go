@@ -9,6 +9,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var watch bool
+var listLimit int
+var listContinue string
+var listFilters []string
+var listSelector string
+var listSort string
+var listFields string
+
 // getCmd represents the get command
 var getCmd = &cobra.Command{
 	Use:   "get",
@@ -20,7 +28,16 @@ var getCmd = &cobra.Command{
 		} else {
 			c = NewRestClient()
 		}
-		if len(inputFiles) > 0 {
+		if watch {
+			anchor := ""
+			if len(args) >= 1 {
+				anchor = args[0]
+			}
+			err := c.RestClientWatch(anchor)
+			if err != nil && err.Error() != "Server Error" {
+				fmt.Println("Watch: Error: ", err)
+			}
+		} else if len(inputFiles) > 0 {
 			resources := readResources()
 			for _, res := range resources {
 				c.RestClientGet(res.anchor, res.body)
@@ -38,4 +55,11 @@ func init() {
 	getCmd.Flags().StringSliceVarP(&inputFiles, "filename", "f", []string{}, "Filename of the input file")
 	getCmd.Flags().StringSliceVarP(&valuesFiles, "values", "v", []string{}, "Template Values to go with the input template file")
 	getCmd.Flags().StringSliceVarP(&token, "token", "t", []string{}, "Token for EWO API")
+	getCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Watch the changes of the resources in the overlay of the anchor, or in all overlays")
+	getCmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum number of the resources listed")
+	getCmd.Flags().StringVar(&listContinue, "continue", "", "Continue token of the next page of the list")
+	getCmd.Flags().StringSliceVar(&listFilters, "filter", []string{}, "Filter of the resources listed, as field=value or field!=value")
+	getCmd.Flags().StringVarP(&listSelector, "selector", "l", "", "Label selector of the resources listed, e.g. region=emea,tier!=gold")
+	getCmd.Flags().StringVar(&listSort, "sort", "", "Field to sort the resources listed by, descending if it starts with -")
+	getCmd.Flags().StringVar(&listFields, "fields", "", "Comma separated fields of the resources listed")
 }
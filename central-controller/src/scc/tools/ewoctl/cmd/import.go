@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2020 Intel Corporation
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var overlayName string
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create an overlay from the exported file",
+	Run: func(cmd *cobra.Command, args []string) {
+		var c RestyClient
+		if len(token) > 0 {
+			c = NewRestClientToken(token[0])
+		} else {
+			c = NewRestClient()
+		}
+		if len(inputFiles) == 0 {
+			fmt.Println("Error: No input file ")
+			return
+		}
+		body, err := ioutil.ReadFile(inputFiles[0])
+		if err != nil {
+			fmt.Println("Error reading file", "error", err, "filename", inputFiles[0])
+			return
+		}
+		// the exported file may have been edited as yaml
+		if !json.Valid(body) {
+			var doc interface{}
+			if err = yaml.Unmarshal(body, &doc); err == nil {
+				body, err = json.Marshal(doc)
+			}
+			if err != nil {
+				fmt.Println("Invalid input file! Exiting..", err)
+				return
+			}
+		}
+		c.RestClientImport(body, overlayName, passphrase)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringSliceVarP(&inputFiles, "filename", "f", []string{}, "Filename of the exported overlay")
+	importCmd.Flags().StringVarP(&overlayName, "name", "n", "", "Name of the new overlay, the exported name if empty")
+	importCmd.Flags().StringVarP(&passphrase, "passphrase", "p", "", "Passphrase of the encrypted secrets")
+	importCmd.Flags().StringSliceVarP(&token, "token", "t", []string{}, "Token for EWO API")
+}
@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
@@ -14,9 +15,11 @@ import (
 	neturl "net/url"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"text/template"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 //	"github.com/mitchellh/mapstructure"
@@ -28,15 +31,23 @@ var inputFiles []string
 var valuesFiles []string
 var token []string
 
+// header carrying the passphrase of the secrets in the overlay document
+const passphraseHeader = "X-Passphrase"
+
+// interval before the event stream is resumed
+const watchRetryInterval = 5 * time.Second
+
 type ResourceContext struct {
 	Anchor string `json:"anchor" yaml:"anchor"`
 }
 
 type Metadata struct {
-	Name        string `yaml:"name" json:"name"`
-	Description string `yaml:"description,omitempty" json:"description,omitempty"`
-	UserData1   string `yaml:"userData1,omitempty" json:"userData1,omitempty"`
-	UserData2   string `yaml:"userData2,omitempty" json:"userData2,omitempty"`
+	Name        string            `yaml:"name" json:"name"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	UserData1   string            `yaml:"userData1,omitempty" json:"userData1,omitempty"`
+	UserData2   string            `yaml:"userData2,omitempty" json:"userData2,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
 }
 
 type ewoRes struct {
@@ -462,13 +473,37 @@ func (r RestyClient) RestClientGetAnchor(anchor string) error {
 	if err != nil {
 		return err
 	}
-	resp, err := r.client.R().
-		Get(url)
+	req := r.client.R()
+	if listLimit > 0 {
+		req.SetQueryParam("limit", strconv.Itoa(listLimit))
+	}
+	if listContinue != "" {
+		req.SetQueryParam("continue", listContinue)
+	}
+	for _, f := range listFilters {
+		req.QueryParam.Add("filter", f)
+	}
+	if listSelector != "" {
+		req.SetQueryParam("labelSelector", listSelector)
+	}
+	if listSort != "" {
+		req.SetQueryParam("sort", listSort)
+	}
+	if listFields != "" {
+		req.SetQueryParam("fields", listFields)
+	}
+	resp, err := req.Get(url)
 	if err != nil {
 		fmt.Println(err)
 		return err
 	}
 	printOutput(url, "GET", resp)
+	if total := resp.Header().Get("X-Total-Count"); total != "" {
+		fmt.Println("Total:", total)
+	}
+	if next := resp.Header().Get("X-Continue"); next != "" {
+		fmt.Println("Continue:", next)
+	}
 	return nil
 }
 
@@ -524,6 +559,225 @@ func (r RestyClient) RestClientDelete(anchor string, body []byte) error {
 	return r.RestClientDeleteAnchor(c)
 }
 
+// RestClientExport gets the definition of the overlay, the secrets are
+// encrypted with the passphrase if given
+func (r RestyClient) RestClientExport(overlay string, passphrase string) ([]byte, error) {
+	url, err := GetURL("overlays/" + overlay + "/export")
+	if err != nil {
+		return nil, err
+	}
+	req := r.client.R()
+	if passphrase != "" {
+		req = req.SetHeader(passphraseHeader, passphrase)
+	}
+	resp, err := req.Get(url)
+	if err != nil {
+		fmt.Println(err)
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		printOutput(url, "GET", resp)
+		return nil, pkgerrors.Errorf("Server Error")
+	}
+	return resp.Body(), nil
+}
+
+// RestClientImport creates an overlay from its definition
+func (r RestyClient) RestClientImport(body []byte, name string, passphrase string) error {
+	url, err := GetURL("overlays/import")
+	if err != nil {
+		return err
+	}
+	req := r.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(body)
+	if name != "" {
+		req = req.SetQueryParam("name", name)
+	}
+	if passphrase != "" {
+		req = req.SetHeader(passphraseHeader, passphrase)
+	}
+	resp, err := req.Post(url)
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	printOutput(url, "POST", resp)
+	if resp.StatusCode() >= 200 && resp.StatusCode() <= 299 {
+		return nil
+	}
+	return pkgerrors.Errorf("Server Error")
+}
+
+// overlayDocument is the desired state of an overlay
+type overlayDocument struct {
+	Version string           `json:"version"`
+	Overlay documentObject   `json:"overlay"`
+	Objects []documentObject `json:"objects"`
+}
+
+type documentObject struct {
+	Anchor string                 `json:"anchor,omitempty"`
+	Meta   Metadata               `json:"metadata"`
+	Spec   map[string]interface{} `json:"spec,omitempty"`
+}
+
+// RestClientApplyOverlay shows and makes the changes to the overlay so that
+// it matches the resources, the resources not in the file are deleted
+func (r RestyClient) RestClientApplyOverlay(resources []Resources) error {
+	doc := overlayDocument{Version: "scc/v1", Objects: []documentObject{}}
+	overlay := ""
+	for _, res := range resources {
+		if res.file != "" || len(res.files) > 0 {
+			return pkgerrors.Errorf("Files are not supported: %s", res.anchor)
+		}
+		var e ewoBody
+		err := json.Unmarshal(res.body, &e)
+		if err != nil {
+			return err
+		}
+
+		name := e.Meta.Name
+		s := strings.Split(strings.Trim(res.anchor, "/"), "/")
+		if len(s) > 1 {
+			name = s[1]
+		}
+		if overlay != "" && name != overlay {
+			return pkgerrors.Errorf("Resources of more than one overlay: %s, %s", overlay, name)
+		}
+		overlay = name
+
+		if len(s) == 1 {
+			doc.Overlay = documentObject{Meta: e.Meta, Spec: e.Spec}
+		} else {
+			doc.Objects = append(doc.Objects, documentObject{Anchor: res.anchor, Meta: e.Meta, Spec: e.Spec})
+		}
+	}
+	if overlay == "" {
+		return pkgerrors.Errorf("No resources")
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	url, err := GetURL("overlays/" + overlay + "/apply")
+	if err != nil {
+		return err
+	}
+
+	// show the changes before they are made
+	runs := []string{"true"}
+	if !dryRun {
+		runs = append(runs, "false")
+	}
+	for _, run := range runs {
+		req := r.client.R().
+			SetHeader("Content-Type", "application/json").
+			SetQueryParam("prune", "true").
+			SetQueryParam("dryRun", run).
+			SetBody(body)
+		if passphrase != "" {
+			req = req.SetHeader(passphraseHeader, passphrase)
+		}
+		resp, err := req.Post(url)
+		if err != nil {
+			fmt.Println(err)
+			return err
+		}
+		printOutput(url, "POST", resp)
+		if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+			return pkgerrors.Errorf("Server Error")
+		}
+	}
+	return nil
+}
+
+// event streamed by scc for a change of an object
+type ewoEvent struct {
+	Seq     uint64                 `json:"seq"`
+	Time    string                 `json:"time"`
+	Type    string                 `json:"type"`
+	Overlay string                 `json:"overlay"`
+	Kind    string                 `json:"kind"`
+	Name    string                 `json:"name"`
+	Object  map[string]interface{} `json:"object"`
+}
+
+// RestClientWatch prints the changes of the objects in the overlay of the
+// anchor (or in all the overlays) until it is interrupted. The stream is
+// resumed from the last event when the connection is lost
+func (r RestyClient) RestClientWatch(anchor string) error {
+	overlay := ""
+	s := strings.Split(strings.Trim(anchor, "/"), "/")
+	if len(s) > 1 && s[0] == "overlays" {
+		overlay = s[1]
+	}
+
+	url, err := GetURL("events")
+	if err != nil {
+		return err
+	}
+
+	lastId := ""
+	for {
+		req := r.client.R().SetDoNotParseResponse(true)
+		if overlay != "" {
+			req = req.SetQueryParam("overlay", overlay)
+		}
+		if lastId != "" {
+			req = req.SetHeader("Last-Event-ID", lastId)
+		}
+		resp, err := req.Get(url)
+		if err != nil {
+			fmt.Println(err)
+			time.Sleep(watchRetryInterval)
+			continue
+		}
+		if resp.StatusCode() != 200 {
+			body, _ := ioutil.ReadAll(resp.RawBody())
+			resp.RawBody().Close()
+			fmt.Println("GET --> URL:", url)
+			fmt.Println("Response Code:", resp.StatusCode())
+			fmt.Println("Response:", string(body))
+			return pkgerrors.Errorf("Server Error")
+		}
+
+		scanner := bufio.NewScanner(resp.RawBody())
+		scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				lastId = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				var e ewoEvent
+				err = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e)
+				if err != nil {
+					fmt.Println(err)
+					continue
+				}
+				printEvent(e)
+			}
+		}
+		resp.RawBody().Close()
+		time.Sleep(watchRetryInterval)
+	}
+}
+
+func printEvent(e ewoEvent) {
+	state := ""
+	if info, ok := e.Object["information"].(map[string]interface{}); ok {
+		state = fmt.Sprintf(" %v %v", info["state"], info["message"])
+	} else if status, ok := e.Object["status"].(map[string]interface{}); ok {
+		// registration status of a device
+		if data, ok := status["Data"].(map[string]interface{}); ok && data["RegStatus"] != nil {
+			state = fmt.Sprintf(" %v", data["RegStatus"])
+		}
+	}
+	fmt.Printf("%d %s %s %s %s/%s%s\n", e.Seq, e.Time, e.Type, e.Overlay, e.Kind, e.Name, strings.TrimRight(state, " "))
+}
+
 // GetURL reads the configuration file to get URL
 func GetURL(anchor string) (string, error) {
 	var baseUrl string
@@ -13,15 +13,21 @@ RUN mkdir /var/lock && \
     uci set uhttpd.main.interpreter='.lua=/usr/bin/lua' && \
     uci commit uhttpd && \
     opkg install shadow-useradd shadow-groupadd shadow-usermod && \
-    opkg install mwan3 jq bash conntrack && \
+    opkg install mwan3 jq bash conntrack iptables-mod-ipopt && \
     opkg install strongswan-default luasocket strongswan-mod-af-alg && \
+    opkg install wireguard-tools && \
+    opkg install bird2 bird2c && \
+    opkg install tc-full && \
     opkg install luci-app-mwan3; exit 0
 
 COPY strongswan.conf /etc/strongswan.conf
 COPY cnfroute /etc/cnfroute
+COPY cnfnat /etc/cnfnat
 COPY system /etc/config/system
 COPY ipsec /etc/config/ipsec
 COPY ipsec_exec /etc/init.d/ipsec
+COPY wireguard_exec /etc/init.d/wireguard-cnf
+COPY qos_exec /etc/init.d/qos-cnf
 COPY updown /etc/updown
 COPY updown_oip /etc/updown_oip
 COPY sdewan.user /etc/sdewan.user
@@ -33,6 +39,7 @@ COPY default_firewall /etc/config/firewall
 COPY rest_v1 /usr/lib/lua/luci/controller/rest_v1
 COPY 10-default.conf /etc/sysctl.d/10-default.conf
 
+RUN /etc/init.d/wireguard-cnf enable && /etc/init.d/qos-cnf enable
 RUN echo '%sudo ALL=(ALL) NOPASSWD:ALL' >> /etc/sudoers
 RUN groupadd --system sudo && useradd wrt
 RUN usermod -a -G sudo wrt
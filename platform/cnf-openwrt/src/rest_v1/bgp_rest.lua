@@ -0,0 +1,329 @@
+--- SPDX-License-Identifier: Apache-2.0
+--- Copyright (c) 2021 Intel Corporation
+
+module("luci.controller.rest_v1.bgp_rest", package.seeall)
+
+local uci = require "luci.model.uci"
+
+json = require "luci.jsonc"
+io = require "io"
+sys = require "luci.sys"
+utils = require "luci.controller.rest_v1.utils"
+ifutil = require "luci.controller.rest_v1.ifutil"
+
+uci_conf = "bgp-cnf"
+bird_conf = "/etc/bird.conf"
+
+instance_validator = {
+    create_section_name=false,
+    {name="name"},
+    {name="as", required=true, validator=function(value) return utils.is_integer_and_in_range(value, 0, 4294967295) end, message="Invalid AS number"},
+    {name="router_id", required=true, validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid router id"},
+    {name="networks", is_list=true, item_validator=function(value) return utils.is_valid_ip(value) end, message="Invalid network"},
+    {name="table", validator=function(value) return utils.in_array(value, {"default", "cnf"}) end, message="Bad route table"},
+}
+
+neighbor_validator = {
+    create_section_name=false,
+    object_validator=function(value) return check_neighbor(value) end,
+    {name="name"},
+    {name="instance", required=true, validator=function(value) return is_instance_defined(value) end, message="Invalid instance", code="428"},
+    {name="remote_address", required=true, validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid remote address"},
+    {name="remote_as", required=true, validator=function(value) return utils.is_integer_and_in_range(value, 0, 4294967295) end, message="Invalid remote AS number"},
+    {name="source_address", validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid source address"},
+    {name="password", validator=function(value) return string.find(value, "\"") == nil end, message="Invalid password"},
+    {name="hold_time", validator=function(value) return utils.is_integer_and_in_range(value, 2, 65536) end, message="Invalid hold time"},
+    {name="next_hop_self",
+        load_func=function(value) if value["next_hop_self"] == "true" then return true else return false end end,
+        save_func=function(value) if value["next_hop_self"] == true then return true, "true" else return true, "false" end end},
+    {name="route_reflector_client",
+        load_func=function(value) if value["route_reflector_client"] == "true" then return true else return false end end,
+        save_func=function(value) if value["route_reflector_client"] == true then return true, "true" else return true, "false" end end},
+    {name="dev"},
+    {name="dev_val"},
+}
+
+bgp_processor = {
+    instance={create="create_instance", update="update_instance", delete="delete_instance", validator=instance_validator},
+    neighbor={create="create_neighbor", delete="delete_neighbor", validator=neighbor_validator},
+    configuration=uci_conf
+}
+
+function index()
+    ver = "v1"
+    configuration = "bgp"
+    entry({"sdewan", configuration, ver, "instances"}, call("handle_request")).leaf = true
+    entry({"sdewan", configuration, ver, "neighbors"}, call("handle_request")).leaf = true
+end
+
+-- Request Handler
+function handle_request()
+    local conf = io.open("/etc/config/" .. uci_conf, "r")
+    if conf == nil then
+        conf = io.open("/etc/config/" .. uci_conf, "w")
+    end
+    conf:close()
+
+    local handler = utils.handles_table[utils.get_req_method()]
+    if handler == nil then
+        utils.response_error(405, "Method Not Allowed")
+    else
+        return utils[handler](_M, bgp_processor)
+    end
+end
+
+function is_instance_defined(name)
+    local instance = utils.get_object(_M, bgp_processor, "instance", name)
+    if instance == nil then
+        return false, "Instance[" .. name .. "] is not defined"
+    end
+
+    return true, name
+end
+
+-- the routes learned from the neighbor go through dev, #ip stands for the
+-- interface of the ip as for the routes
+function check_neighbor(value)
+    local dev = value["dev"]
+    if dev == nil or dev == "" then
+        value["dev_val"] = ""
+        return true, value
+    end
+
+    local dev_val = dev
+    if utils.start_with(dev, "#") then
+        dev_val = ifutil.get_name_by_ip(string.sub(dev, 2, string.len(dev)))
+    end
+
+    if dev_val == nil or (not ifutil.is_interface_available(dev_val)) then
+        return false, "428:Field[dev] checked failed: Invalid interface"
+    end
+
+    value["dev_val"] = dev_val
+    return true, value
+end
+
+function get_sections(section_type)
+    local objs = {}
+    uci:foreach(uci_conf, section_type,
+        function(section)
+            objs[#objs+1] = utils.get_object(_M, bgp_processor, section_type, section["name"])
+        end
+    )
+
+    return objs
+end
+
+function get_neighbors(instance)
+    local neighbors = {}
+    local objs = get_sections("neighbor")
+    for i=1, #objs do
+        if objs[i]["instance"] == instance then
+            neighbors[#neighbors+1] = objs[i]
+        end
+    end
+
+    return neighbors
+end
+
+-- bird protocol names are symbols
+function protocol_name(name)
+    return "bgp_" .. string.gsub(name, "[^%w_]", "_")
+end
+
+function to_list(value)
+    if value == nil then
+        return {}
+    end
+    if type(value) ~= "table" then
+        return {value}
+    end
+    return value
+end
+
+-- generate the bird configuration of the instance and its neighbors
+function bird_config(instance)
+    local lines = {
+        "# generated by the sdewan bgp module",
+        "router id " .. instance["router_id"] .. ";",
+        "",
+        "protocol device {",
+        "}",
+        "",
+    }
+
+    -- the routes of the main table are learned to resolve the next hops,
+    -- only the BGP routes are exported to the kernel
+    local main_export = "where source = RTS_BGP"
+    if instance["table"] == "cnf" then
+        main_export = "none"
+    end
+    lines[#lines+1] = "protocol kernel kernel_main {"
+    lines[#lines+1] = "    ipv4 { import all; export " .. main_export .. "; };"
+    lines[#lines+1] = "    learn;"
+    lines[#lines+1] = "}"
+    lines[#lines+1] = ""
+    if instance["table"] == "cnf" then
+        lines[#lines+1] = "protocol kernel kernel_cnf {"
+        lines[#lines+1] = "    kernel table 40;"
+        lines[#lines+1] = "    ipv4 { import none; export where source = RTS_BGP; };"
+        lines[#lines+1] = "}"
+        lines[#lines+1] = ""
+    end
+
+    -- the networks are advertised through blackhole static routes which
+    -- are not exported to the kernel
+    local networks = to_list(instance["networks"])
+    lines[#lines+1] = "protocol static networks {"
+    lines[#lines+1] = "    ipv4;"
+    for i=1, #networks do
+        lines[#lines+1] = "    route " .. networks[i] .. " blackhole;"
+    end
+    lines[#lines+1] = "}"
+
+    local neighbors = get_neighbors(instance["name"])
+    for i=1, #neighbors do
+        local n = neighbors[i]
+        lines[#lines+1] = ""
+        lines[#lines+1] = "protocol bgp " .. protocol_name(n["name"]) .. " {"
+        local src = ""
+        if n["source_address"] ~= nil and n["source_address"] ~= "" then
+            src = " source address " .. n["source_address"]
+        end
+        lines[#lines+1] = "    local" .. src .. " as " .. instance["as"] .. ";"
+        lines[#lines+1] = "    neighbor " .. n["remote_address"] .. " as " .. n["remote_as"] .. ";"
+        if n["password"] ~= nil and n["password"] ~= "" then
+            lines[#lines+1] = "    password \"" .. n["password"] .. "\";"
+        end
+        if n["hold_time"] ~= nil and n["hold_time"] ~= "" then
+            lines[#lines+1] = "    hold time " .. n["hold_time"] .. ";"
+        end
+        if tostring(n["route_reflector_client"]) == "true" then
+            lines[#lines+1] = "    rr client;"
+        end
+        lines[#lines+1] = "    ipv4 {"
+        if n["dev_val"] ~= nil and n["dev_val"] ~= "" then
+            lines[#lines+1] = "        import filter { ifname = \"" .. n["dev_val"] .. "\"; accept; };"
+        else
+            lines[#lines+1] = "        import all;"
+        end
+        lines[#lines+1] = "        export where source ~ [RTS_STATIC, RTS_BGP];"
+        if tostring(n["next_hop_self"]) == "true" then
+            lines[#lines+1] = "        next hop self;"
+        end
+        lines[#lines+1] = "    };"
+        lines[#lines+1] = "}"
+    end
+
+    return table.concat(lines, "\n") .. "\n"
+end
+
+-- write the bird configuration and (re)load it, bird is stopped when there
+-- is no instance
+function apply_config()
+    local instances = get_sections("instance")
+    local running = (sys.call("pidof bird >/dev/null 2>&1") == 0)
+    if #instances == 0 then
+        if running then
+            utils.log("birdc down")
+            os.execute("birdc down")
+        end
+        os.remove(bird_conf)
+        return
+    end
+
+    local file = io.open(bird_conf, "w")
+    if file == nil then
+        utils.log("failed to write " .. bird_conf)
+        return
+    end
+    file:write(bird_config(instances[1]))
+    file:close()
+
+    local comm = "bird -c " .. bird_conf
+    if running then
+        comm = "birdc configure"
+    end
+    utils.log(comm)
+    os.execute(comm)
+end
+
+function commit()
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+    apply_config()
+end
+
+-- create an instance, bird runs a single instance
+function create_instance(instance)
+    if #get_sections("instance") > 0 then
+        return false, 400, "only one instance is supported"
+    end
+
+    local res, code, msg = utils.create_uci_section(uci_conf, instance_validator, "instance", instance)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    commit()
+    return true
+end
+
+-- update an instance, its neighbors are kept
+function update_instance(instance)
+    local old = utils.get_object(_M, bgp_processor, "instance", instance.name)
+    if old == nil then
+        return false, 404, "instance " .. instance.name .. " is not defined"
+    end
+
+    utils.delete_uci_section(uci_conf, instance_validator, old, "instance")
+    local res, code, msg = utils.create_uci_section(uci_conf, instance_validator, "instance", instance)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    commit()
+    return true
+end
+
+-- delete an instance
+function delete_instance(name)
+    local instance = utils.get_object(_M, bgp_processor, "instance", name)
+    if instance == nil then
+        return false, 404, "instance " .. name .. " is not defined"
+    end
+
+    if #get_neighbors(name) > 0 then
+        return false, 400, "instance " .. name .. " is used by neighbors"
+    end
+
+    utils.delete_uci_section(uci_conf, instance_validator, instance, "instance")
+    commit()
+    return true
+end
+
+-- create a neighbor
+function create_neighbor(neighbor)
+    local res, code, msg = utils.create_uci_section(uci_conf, neighbor_validator, "neighbor", neighbor)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    commit()
+    return true
+end
+
+-- delete a neighbor
+function delete_neighbor(name)
+    local neighbor = utils.get_object(_M, bgp_processor, "neighbor", name)
+    if neighbor == nil then
+        return false, 404, "neighbor " .. name .. " is not defined"
+    end
+
+    utils.delete_uci_section(uci_conf, neighbor_validator, neighbor, "neighbor")
+    commit()
+    return true
+end
@@ -0,0 +1,326 @@
+--- SPDX-License-Identifier: Apache-2.0
+--- Copyright (c) 2021 Intel Corporation
+
+module("luci.controller.rest_v1.dhcp_rest", package.seeall)
+
+local uci = require "luci.model.uci"
+
+json = require "luci.jsonc"
+io = require "io"
+sys = require "luci.sys"
+utils = require "luci.controller.rest_v1.utils"
+ifutil = require "luci.controller.rest_v1.ifutil"
+
+uci_conf = "dhcp-cnf"
+-- read by dnsmasq besides the configuration generated from /etc/config/dhcp
+dnsmasq_conf_dir = "/tmp/dnsmasq.d"
+dnsmasq_conf = dnsmasq_conf_dir .. "/sdewan.conf"
+
+pool_validator = {
+    create_section_name=false,
+    object_validator=function(value) return check_pool(value) end,
+    {name="name"},
+    {name="interface", required=true, validator=function(value) return is_interface_available(value) end, message="Invalid interface", code="428"},
+    {name="start", required=true, validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid start"},
+    {name="end", required=true, validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid end"},
+    {name="netmask", validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid netmask"},
+    {name="lease_time", validator=function(value) return is_valid_lease_time(value) end, message="Invalid lease_time"},
+    {name="gateway", validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid gateway"},
+    {name="dns_servers", is_list=true, item_validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid dns_servers"},
+    {name="domain", validator=function(value) return is_valid_domain(value) end, message="Invalid domain"},
+}
+
+lease_validator = {
+    create_section_name=false,
+    object_validator=function(value) return check_lease(value) end,
+    {name="name"},
+    {name="mac", required=true, validator=function(value) return utils.is_valid_mac(value) end, message="Invalid mac"},
+    {name="ip", required=true, validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid ip"},
+    {name="hostname", validator=function(value) return is_valid_hostname(value) end, message="Invalid hostname"},
+    {name="lease_time", validator=function(value) return is_valid_lease_time(value) end, message="Invalid lease_time"},
+}
+
+forwarder_validator = {
+    create_section_name=false,
+    {name="name"},
+    {name="domain", required=true, validator=function(value) return is_valid_domain(value) end, message="Invalid domain"},
+    {name="servers", required=true, is_list=true, item_validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid servers"},
+    {name="port", validator=function(value) return utils.is_integer_and_in_range(value, 0, 65536) end, message="Invalid port"},
+}
+
+record_validator = {
+    create_section_name=false,
+    {name="name"},
+    {name="domain", required=true, validator=function(value) return is_valid_domain(value) end, message="Invalid domain"},
+    {name="ip", required=true, validator=function(value) return utils.is_valid_ip_address(value) end, message="Invalid ip"},
+}
+
+dhcp_processor = {
+    pool={create="create_pool", delete="delete_pool", validator=pool_validator},
+    lease={create="create_lease", delete="delete_lease", validator=lease_validator},
+    forwarder={create="create_forwarder", delete="delete_forwarder", validator=forwarder_validator},
+    record={create="create_record", delete="delete_record", validator=record_validator},
+    configuration=uci_conf
+}
+
+function index()
+    ver = "v1"
+    configuration = "dhcp"
+    entry({"sdewan", configuration, ver, "pools"}, call("handle_request")).leaf = true
+    entry({"sdewan", configuration, ver, "leases"}, call("handle_request")).leaf = true
+    entry({"sdewan", configuration, ver, "forwarders"}, call("handle_request")).leaf = true
+    entry({"sdewan", configuration, ver, "records"}, call("handle_request")).leaf = true
+end
+
+-- Request Handler
+function handle_request()
+    local conf = io.open("/etc/config/" .. uci_conf, "r")
+    if conf == nil then
+        conf = io.open("/etc/config/" .. uci_conf, "w")
+    end
+    conf:close()
+
+    local handler = utils.handles_table[utils.get_req_method()]
+    if handler == nil then
+        utils.response_error(405, "Method Not Allowed")
+    else
+        return utils[handler](_M, dhcp_processor)
+    end
+end
+
+function is_interface_available(value)
+    if not ifutil.is_interface_available(value) then
+        return false, "Interface[" .. value .. "] is not available"
+    end
+
+    return true, value
+end
+
+-- lease time in seconds or with a unit (e.g. 12h), or infinite
+function is_valid_lease_time(value)
+    if value == "infinite" or utils.is_match(value, "^%d+[smhdw]?$") then
+        return true, value
+    end
+
+    return false
+end
+
+function is_valid_domain(value)
+    if utils.is_match(value, "^[%w%-%.]+$") and not utils.start_with(value, ".") then
+        return true, value
+    end
+
+    return false
+end
+
+function is_valid_hostname(value)
+    if utils.is_match(value, "^[%w%-]+$") then
+        return true, value
+    end
+
+    return false
+end
+
+function ip_to_number(ip)
+    local a, b, c, d = string.match(ip, "^(%d+)%.(%d+)%.(%d+)%.(%d+)$")
+    return ((tonumber(a) * 256 + tonumber(b)) * 256 + tonumber(c)) * 256 + tonumber(d)
+end
+
+function check_pool(value)
+    if ip_to_number(value["start"]) > ip_to_number(value["end"]) then
+        return false, "Field[end] checked failed: end is lower than start"
+    end
+
+    return true, value
+end
+
+-- a mac or an ip has a single static lease
+function check_lease(value)
+    local leases = get_sections("lease")
+    for i=1, #leases do
+        if leases[i]["name"] ~= value["name"] then
+            if leases[i]["mac"] == value["mac"] then
+                return false, "Field[mac] checked failed: Mac[" .. value["mac"] .. "] is used by lease " .. leases[i]["name"]
+            end
+            if leases[i]["ip"] == value["ip"] then
+                return false, "Field[ip] checked failed: Ip[" .. value["ip"] .. "] is used by lease " .. leases[i]["name"]
+            end
+        end
+    end
+
+    return true, value
+end
+
+function get_sections(section_type)
+    local objs = {}
+    uci:foreach(uci_conf, section_type,
+        function(section)
+            objs[#objs+1] = utils.get_object(_M, dhcp_processor, section_type, section["name"])
+        end
+    )
+
+    return objs
+end
+
+-- dnsmasq tags are symbols
+function tag_name(name)
+    return "sdewan_" .. string.gsub(name, "[^%w_]", "_")
+end
+
+function to_list(value)
+    if value == nil then
+        return {}
+    end
+    if type(value) ~= "table" then
+        return {value}
+    end
+    return value
+end
+
+function is_set(value)
+    return value ~= nil and value ~= ""
+end
+
+-- generate the dnsmasq configuration of the pools, leases, forwarders and records,
+-- dnsmasq sets the tag of the interface a request arrives on so that a pool
+-- only serves its interface
+function dnsmasq_config()
+    local lines = {
+        "# generated by the sdewan dhcp module",
+    }
+
+    local pools = get_sections("pool")
+    for i=1, #pools do
+        local p = pools[i]
+        local tag = tag_name(p["name"])
+        local range = "dhcp-range=tag:" .. p["interface"] .. ",set:" .. tag .. "," .. p["start"] .. "," .. p["end"]
+        if is_set(p["netmask"]) then
+            range = range .. "," .. p["netmask"]
+        end
+        if is_set(p["lease_time"]) then
+            range = range .. "," .. p["lease_time"]
+        end
+        lines[#lines+1] = range
+        if is_set(p["gateway"]) then
+            lines[#lines+1] = "dhcp-option=tag:" .. tag .. ",option:router," .. p["gateway"]
+        end
+        local servers = to_list(p["dns_servers"])
+        if #servers > 0 then
+            lines[#lines+1] = "dhcp-option=tag:" .. tag .. ",option:dns-server," .. table.concat(servers, ",")
+        end
+        if is_set(p["domain"]) then
+            lines[#lines+1] = "dhcp-option=tag:" .. tag .. ",option:domain-name," .. p["domain"]
+        end
+    end
+
+    local leases = get_sections("lease")
+    for i=1, #leases do
+        local l = leases[i]
+        local host = "dhcp-host=" .. l["mac"] .. "," .. l["ip"]
+        if is_set(l["hostname"]) then
+            host = host .. "," .. l["hostname"]
+        end
+        if is_set(l["lease_time"]) then
+            host = host .. "," .. l["lease_time"]
+        end
+        lines[#lines+1] = host
+    end
+
+    local forwarders = get_sections("forwarder")
+    for i=1, #forwarders do
+        local f = forwarders[i]
+        local servers = to_list(f["servers"])
+        for j=1, #servers do
+            local server = servers[j]
+            if is_set(f["port"]) then
+                server = server .. "#" .. f["port"]
+            end
+            lines[#lines+1] = "server=/" .. f["domain"] .. "/" .. server
+        end
+    end
+
+    local records = get_sections("record")
+    for i=1, #records do
+        lines[#lines+1] = "address=/" .. records[i]["domain"] .. "/" .. records[i]["ip"]
+    end
+
+    return table.concat(lines, "\n") .. "\n"
+end
+
+-- write the dnsmasq configuration and restart dnsmasq
+function apply_config()
+    os.execute("mkdir -p " .. dnsmasq_conf_dir)
+    local file = io.open(dnsmasq_conf, "w")
+    if file == nil then
+        utils.log("failed to write " .. dnsmasq_conf)
+        return
+    end
+    file:write(dnsmasq_config())
+    file:close()
+
+    local comm = "/etc/init.d/dnsmasq restart"
+    utils.log(comm)
+    os.execute(comm)
+end
+
+function commit()
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+    apply_config()
+end
+
+-- create a pool, lease, forwarder or record
+function create_section(section_type, obj)
+    local res, code, msg = utils.create_uci_section(uci_conf, dhcp_processor[section_type].validator, section_type, obj)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    commit()
+    return true
+end
+
+-- delete a pool, lease, forwarder or record
+function delete_section(section_type, name)
+    local obj = utils.get_object(_M, dhcp_processor, section_type, name)
+    if obj == nil then
+        return false, 404, section_type .. " " .. name .. " is not defined"
+    end
+
+    utils.delete_uci_section(uci_conf, dhcp_processor[section_type].validator, obj, section_type)
+    commit()
+    return true
+end
+
+function create_pool(pool)
+    return create_section("pool", pool)
+end
+
+function delete_pool(name)
+    return delete_section("pool", name)
+end
+
+function create_lease(lease)
+    return create_section("lease", lease)
+end
+
+function delete_lease(name)
+    return delete_section("lease", name)
+end
+
+function create_forwarder(forwarder)
+    return create_section("forwarder", forwarder)
+end
+
+function delete_forwarder(name)
+    return delete_section("forwarder", name)
+end
+
+function create_record(record)
+    return create_section("record", record)
+end
+
+function delete_record(name)
+    return delete_section("record", name)
+end
@@ -53,13 +53,13 @@ rule_validator = {
     create_section_name=false,
     object_validator=function(value) return check_rule(value) end,
     {name="name"},
-    {name="src", validator=function(value) return is_zone_available(value) end, message="invalid src"},
+    {name="src", validator=function(value) return is_rule_zone_available(value) end, message="invalid src"},
     {name="src_ip", validator=function(value) return utils.is_valid_ip(value) end, message="invalid src_ip"},
     {name="src_mac", validator=function(value) return utils.is_valid_mac(value) end, message="invalid src_mac"},
     {name="src_port", validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="invalid src_port"},
     {name="proto", validator=function(value) return utils.in_array(value, {"tcp", "udp", "tcpudp", "udplite", "icmp", "esp", "ah", "sctp", "all"}) end, message="invalid proto"},
     {name="icmp_type", is_list=true, item_validator=function(value) return check_icmp_type(value) end, message="invalid icmp_type"},
-    {name="dest", validator=function(value) return is_zone_available(value) end, message="invalid dest"},
+    {name="dest", validator=function(value) return is_rule_zone_available(value) end, message="invalid dest"},
     {name="dest_ip", validator=function(value) return utils.is_valid_ip(value) end, message="invalid dest_ip"},
     {name="dest_port", validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="invalid dest_port"},
     {name="mark"},
@@ -221,6 +221,15 @@ function is_zone_available(name)
     return true, name
 end
 
+-- rules also accept * which matches any zone
+function is_rule_zone_available(name)
+    if name == "*" then
+        return true, name
+    end
+
+    return is_zone_available(name)
+end
+
 -- delete a zone
 function delete_zone(name, check_used)
     -- check whether zone is defined
@@ -15,6 +15,10 @@ function index()
     entry({"sdewan", "route", ver}, call("help")).dependent = false
     entry({"sdewan", "rule", ver}, call("help")).dependent = false
     entry({"sdewan", "nat", ver}, call("help")).dependent = false
+    entry({"sdewan", "wireguard", ver}, call("help")).dependent = false
+    entry({"sdewan", "bgp", ver}, call("help")).dependent = false
+    entry({"sdewan", "qos", ver}, call("help")).dependent = false
+    entry({"sdewan", "dhcp", ver}, call("help")).dependent = false
 
 end
 
@@ -13,6 +13,11 @@ mime = require "mime"
 
 uci_conf = "ipsec"
 
+-- a device onboarded with a bootstrap token keeps its private key, which is
+-- installed at local_key_path, and refers to it by local_key_ref
+local_key_ref = "#local"
+local_key_path = "/etc/ipsec.d/private/local.pem"
+
 encrypto_alg={"3des", "cast128", "blowfish128", "blowfish", "blowfish192", "blowfish256", "null", "aes", "aes128", "aes192", "aes256", "aes128ctr", "aes192ctr", "aes256ctr", "aes128ccm8", "aes192ccm8", "aes256ccm8", "aes128ccm64", "aes192ccm64", "aes256ccm64", "aes128ccm12", "aes192ccm12", "aes256ccm12", "aes128ccm96", "aes192ccm96", "aes256ccm96", "aes128ccm16", "aes192ccm16", "aes256ccm16", "aes128ccm128", "aes192ccm128", "aes256ccm128", "aes128gcm8", "aes192gcm8", "aes256gcm8", "aes128gcm64", "aes192gcm64", "aes256gcm64", "aes128gcm12", "aes192gcm12", "aes256gcm12", "aes128gcm96", "aes192gcm96", "aes256gcm96", "aes128gcm16", "aes192gcm16", "aes256gcm16", "aes128gcm128", "aes192gcm128", "aes256gcm128", "camellia128", "camellia192", "camellia256", "camellia", "camellia128ctr", "camellia192ctr", "camellia256ctr", "camellia128ccm8", "camellia192ccm8", "camellia256ccm8", "camellia128ccm64", "camellia192ccm64", "camellia256ccm64", "camellia128ccm12", "camellia192ccm12", "camellia256ccm12", "camellia128ccm96", "camellia192ccm96", "camellia256ccm96", "camellia128ccm16", "camellia192ccm16", "camellia256ccm16", "camellia128ccm128", "camellia192ccm128", "camellia256ccm128", "chacha20poly1305"}
 
 hash_alg={"md5", "sha", "sha1", "aesxcbc", "sha256", "sha2_256", "sha384", "sha2_384", "sha512", "sha2_512", "sha256_96", "sha2_256_96"}
@@ -147,6 +152,14 @@ function save_cert(content, info_type)
     local index = 0
     local name = content["name"]
     local path_list
+    if info_type == "local_private_cert" and content[info_type] == local_key_ref then
+        local file = io.open(local_key_path, "rb")
+        if file == nil then
+            return false, "local private key is not installed at " .. local_key_path
+        end
+        file:close()
+        return true, local_key_path
+    end
     for ctx in string.gmatch(content[info_type], "([^___^]+)") do
 	local path = ""
 	if info_type == "local_private_cert" then
@@ -175,6 +188,9 @@ function load_cert(paths)
     if paths == nil then
 	    return nil
     end
+    if paths == local_key_path then
+        return local_key_ref
+    end
     local certs
     local index = 0
     for path in string.gmatch(paths, "([^,^]+)") do
@@ -202,7 +218,7 @@ end
 function delete_cert(paths)
     if paths ~= nil then
         for path in string.gmatch(paths, "([^,^]+)") do
-            if path ~= nil then
+            if path ~= nil and path ~= local_key_path then
                 os.remove(path)
             end
         end
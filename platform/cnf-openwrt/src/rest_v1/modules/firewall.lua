@@ -0,0 +1,35 @@
+--- SPDX-License-Identifier: Apache-2.0
+--- Copyright (c) 2021 Intel Corporation
+
+module("luci.controller.rest_v1.modules.firewall", package.seeall)
+
+util = require "luci.util"
+
+function register()
+    return "firewall", _M["get_firewall_info"]
+end
+
+-- fw3 creates the delegate chains and the chains of each zone when it loads
+-- the firewall, so the firewall is not loaded if they are missing
+function get_firewall_info()
+    local ret = {}
+    local zones = {}
+    local loaded = false
+    for line in util.execi("iptables -w -S 2>/dev/null") do
+        if line == "-N delegate_input" then
+            loaded = true
+        end
+        local zone = string.match(line, "^%-N zone_(.+)_input$")
+        if zone ~= nil then
+            zones[#zones+1] = zone
+        end
+    end
+
+    if loaded then
+        ret["state"] = "loaded"
+    else
+        ret["state"] = "not loaded"
+    end
+    ret["zones"] = zones
+    return ret
+end
@@ -28,6 +28,7 @@ nat_validator = {
     {name="dest_port", validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="invalid dest_port"},
     {name="target", validator=function(value) return utils.in_array(value, {"DNAT", "SNAT", "MASQUERADE"}) end, message="invalid target"},
     {name="index", validator=function(value) return utils.is_integer_and_in_range(value, -1) end, message="invalid index"},
+    {name="probability", validator=function(value) return is_valid_probability(value) end, message="invalid probability"},
 }
 
 nat_processor = {
@@ -41,6 +42,11 @@ function index()
     entry({"sdewan", configuration, ver, "nats"}, call("handle_request")).leaf = true
 end
 
+function is_valid_probability(value)
+    local p = tonumber(value)
+    return p ~= nil and p > 0 and p <= 1
+end
+
 function check_nat(value)
     local target = value["target"]
     if target == "SNAT" then
@@ -114,6 +120,7 @@ function nat_command(nat, op)
     if index == nil or index == "" then
         index = "0"
     end
+    local probability = nat["probability"]
 
     local comm = "iptables -t nat"
     if op == "create" then
@@ -155,6 +162,9 @@ function nat_command(nat, op)
     if src_port ~= nil and src_port ~= "" then
         comm = comm .. " --sport " .. src_port
     end
+    if probability ~= nil and probability ~= "" then
+        comm = comm .. " -m statistic --mode random --probability " .. probability
+    end
 
     if target == "SNAT" then
         if dest_ip ~= nil and dest_ip ~= "" then
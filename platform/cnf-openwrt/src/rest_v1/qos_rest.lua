@@ -0,0 +1,261 @@
+--- SPDX-License-Identifier: Apache-2.0
+--- Copyright (c) 2021 Intel Corporation
+
+module("luci.controller.rest_v1.qos_rest", package.seeall)
+
+local uci = require "luci.model.uci"
+
+json = require "luci.jsonc"
+io = require "io"
+sys = require "luci.sys"
+utils = require "luci.controller.rest_v1.utils"
+ifutil = require "luci.controller.rest_v1.ifutil"
+
+uci_conf = "qos-cnf"
+
+-- DSCP names accepted besides the numeric values
+dscp_values = {
+    CS0=0, CS1=8, CS2=16, CS3=24, CS4=32, CS5=40, CS6=48, CS7=56,
+    AF11=10, AF12=12, AF13=14, AF21=18, AF22=20, AF23=22,
+    AF31=26, AF32=28, AF33=30, AF41=34, AF42=36, AF43=38,
+    EF=46,
+}
+
+proto_values = {tcp=6, udp=17, icmp=1}
+
+-- the names of the classes are unique in the CNF, the clients qualify them
+-- with the name of the policy
+class_validator = {
+    create_section_name=false,
+    config_type="class",
+    {name="name"},
+    {name="priority", validator=function(value) return utils.is_integer_and_in_range(value, -1, 8) end, message="Invalid priority"},
+    {name="rate", required=true, validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="Invalid rate"},
+    {name="ceil", validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="Invalid ceil"},
+    {name="dscp", validator=function(value) return is_valid_dscp(value) end, message="Invalid dscp"},
+    {name="proto", validator=function(value) return utils.in_array(value, {"tcp", "udp", "icmp"}) end, message="Invalid proto"},
+    {name="src_ip", validator=function(value) return utils.is_valid_ip(value) end, message="Invalid src_ip"},
+    {name="src_port", validator=function(value) return utils.is_integer_and_in_range(value, 0, 65536) end, message="Invalid src_port"},
+    {name="dest_ip", validator=function(value) return utils.is_valid_ip(value) end, message="Invalid dest_ip"},
+    {name="dest_port", validator=function(value) return utils.is_integer_and_in_range(value, 0, 65536) end, message="Invalid dest_port"},
+}
+
+policy_validator = {
+    create_section_name=false,
+    object_validator=function(value) return check_policy(value) end,
+    {name="name"},
+    {name="interface", required=true, validator=function(value) return is_interface_available(value) end, message="Invalid interface", code="428"},
+    {name="egress_rate", required=true, validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="Invalid egress_rate"},
+    {name="ingress_rate", validator=function(value) return utils.is_integer_and_in_range(value, 0) end, message="Invalid ingress_rate"},
+    {name="classes", target="class", item_validator=class_validator, message="Invalid class"},
+}
+
+qos_processor = {
+    policy={create="create_policy", delete="delete_policy", validator=policy_validator},
+    configuration=uci_conf
+}
+
+function index()
+    ver = "v1"
+    configuration = "qos"
+    entry({"sdewan", configuration, ver, "policies"}, call("handle_request")).leaf = true
+end
+
+-- Request Handler
+function handle_request()
+    local conf = io.open("/etc/config/" .. uci_conf, "r")
+    if conf == nil then
+        conf = io.open("/etc/config/" .. uci_conf, "w")
+    end
+    conf:close()
+
+    local handler = utils.handles_table[utils.get_req_method()]
+    if handler == nil then
+        utils.response_error(405, "Method Not Allowed")
+    else
+        return utils[handler](_M, qos_processor)
+    end
+end
+
+function is_interface_available(value)
+    if not ifutil.is_interface_available(value) then
+        return false, "Interface[" .. value .. "] is not available"
+    end
+
+    return true, value
+end
+
+function is_valid_dscp(value)
+    if dscp_values[value] ~= nil or utils.is_integer_and_in_range(value, -1, 64) then
+        return true, value
+    end
+
+    return false
+end
+
+function get_policies()
+    local policies = {}
+    uci:foreach(uci_conf, "policy",
+        function(section)
+            policies[#policies+1] = utils.get_object(_M, qos_processor, "policy", section["name"])
+        end
+    )
+
+    return policies
+end
+
+-- an interface has a single policy and the classes share its egress rate
+function check_policy(value)
+    local policies = get_policies()
+    for i=1, #policies do
+        if policies[i]["interface"] == value["interface"] and policies[i]["name"] ~= value["name"] then
+            return false, "Field[interface] checked failed: Interface[" .. value["interface"] .. "] is used by policy " .. policies[i]["name"]
+        end
+    end
+
+    local egress_rate = tonumber(value["egress_rate"])
+    local total = 0
+    local classes = value["classes"] or {}
+    for i=1, #classes do
+        total = total + tonumber(classes[i]["rate"])
+        if classes[i]["ceil"] ~= nil and tonumber(classes[i]["ceil"]) < tonumber(classes[i]["rate"]) then
+            return false, "Field[classes] checked failed: ceil of class " .. classes[i]["name"] .. " is lower than its rate"
+        end
+    end
+    if total > egress_rate then
+        return false, "Field[classes] checked failed: the rates of the classes exceed egress_rate"
+    end
+
+    return true, value
+end
+
+-- u32 matches of a class
+function class_matches(class)
+    local matches = ""
+    if class["dscp"] ~= nil and class["dscp"] ~= "" then
+        local dscp = dscp_values[class["dscp"]] or tonumber(class["dscp"])
+        matches = matches .. " match ip dsfield " .. string.format("0x%02x", dscp * 4) .. " 0xfc"
+    end
+    if class["proto"] ~= nil and class["proto"] ~= "" then
+        matches = matches .. " match ip protocol " .. proto_values[class["proto"]] .. " 0xff"
+    end
+    if class["src_ip"] ~= nil and class["src_ip"] ~= "" then
+        matches = matches .. " match ip src " .. class["src_ip"]
+    end
+    if class["src_port"] ~= nil and class["src_port"] ~= "" then
+        matches = matches .. " match ip sport " .. class["src_port"] .. " 0xffff"
+    end
+    if class["dest_ip"] ~= nil and class["dest_ip"] ~= "" then
+        matches = matches .. " match ip dst " .. class["dest_ip"]
+    end
+    if class["dest_port"] ~= nil and class["dest_port"] ~= "" then
+        matches = matches .. " match ip dport " .. class["dest_port"] .. " 0xffff"
+    end
+
+    return matches
+end
+
+-- generate the tc commands of a policy: an htb class with a fq_codel queue
+-- for each class of the policy, the unmatched traffic goes to a default class
+-- with the lowest priority and the ingress traffic is policed
+function policy_commands(policy, op)
+    local dev = policy["interface"]
+    local comms = {
+        "tc qdisc del dev " .. dev .. " root",
+        "tc qdisc del dev " .. dev .. " ingress",
+    }
+    if op ~= "create" then
+        return comms
+    end
+
+    local egress_rate = policy["egress_rate"]
+    comms[#comms+1] = "tc qdisc add dev " .. dev .. " root handle 1: htb default 9999"
+    comms[#comms+1] = "tc class add dev " .. dev .. " parent 1: classid 1:1 htb rate " .. egress_rate .. "kbit ceil " .. egress_rate .. "kbit"
+
+    local left = tonumber(egress_rate)
+    local classes = policy["classes"] or {}
+    for i=1, #classes do
+        local class = classes[i]
+        local classid = "1:" .. tostring(9 + i)
+        local ceil = class["ceil"]
+        if ceil == nil or ceil == "" then
+            ceil = egress_rate
+        end
+        local prio = class["priority"]
+        if prio == nil or prio == "" then
+            prio = "3"
+        end
+        left = left - tonumber(class["rate"])
+        comms[#comms+1] = "tc class add dev " .. dev .. " parent 1:1 classid " .. classid .. " htb rate " .. class["rate"] .. "kbit ceil " .. ceil .. "kbit prio " .. prio
+        comms[#comms+1] = "tc qdisc add dev " .. dev .. " parent " .. classid .. " fq_codel"
+        local matches = class_matches(class)
+        if matches ~= "" then
+            comms[#comms+1] = "tc filter add dev " .. dev .. " parent 1: protocol ip prio " .. tostring(i) .. " u32" .. matches .. " flowid " .. classid
+        end
+    end
+
+    if left < 1 then
+        left = 1
+    end
+    comms[#comms+1] = "tc class add dev " .. dev .. " parent 1:1 classid 1:9999 htb rate " .. tostring(left) .. "kbit ceil " .. egress_rate .. "kbit prio 7"
+    comms[#comms+1] = "tc qdisc add dev " .. dev .. " parent 1:9999 fq_codel"
+
+    local ingress_rate = policy["ingress_rate"]
+    if ingress_rate ~= nil and ingress_rate ~= "" then
+        comms[#comms+1] = "tc qdisc add dev " .. dev .. " handle ffff: ingress"
+        comms[#comms+1] = "tc filter add dev " .. dev .. " parent ffff: protocol all u32 match u32 0 0 police rate " .. ingress_rate .. "kbit burst 64k drop flowid :1"
+    end
+
+    return comms
+end
+
+function execute(comms)
+    for i=1, #comms do
+        utils.log(comms[i])
+        os.execute(comms[i] .. " 2>/dev/null")
+    end
+end
+
+-- apply the saved policies again, it is called by the init script as the
+-- tc state is lost when the CNF restarts
+function apply_policies()
+    local policies = get_policies()
+    for i=1, #policies do
+        execute(policy_commands(policies[i], "create"))
+    end
+end
+
+-- create a policy
+function create_policy(policy)
+    local res, code, msg = utils.create_uci_section(uci_conf, policy_validator, "policy", policy)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    execute(policy_commands(policy, "create"))
+
+    -- commit change
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+
+    return true
+end
+
+-- delete a policy
+function delete_policy(name)
+    local policy = utils.get_object(_M, qos_processor, "policy", name)
+    if policy == nil then
+        return false, 404, "policy " .. name .. " is not defined"
+    end
+
+    execute(policy_commands(policy, "delete"))
+
+    utils.delete_uci_section(uci_conf, policy_validator, policy, "policy")
+
+    -- commit change
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+
+    return true
+end
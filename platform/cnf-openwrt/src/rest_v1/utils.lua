@@ -83,6 +83,22 @@ function is_valid_ip(s)
     return false
 end
 
+-- check RFC 1123 host name
+function is_valid_hostname(s)
+    if string.len(s) > 253 then
+        return false
+    end
+
+    for label in string.gmatch(s .. ".", "([^%.]*)%.") do
+        if string.len(label) < 1 or string.len(label) > 63 or not is_match(label, "[%w%-]+")
+            or string.sub(label, 1, 1) == "-" or string.sub(label, -1) == "-" then
+            return false
+        end
+    end
+
+    return true, s
+end
+
 function is_valid_mac(s)
     local array = {}
     local reg = string.format("([^%s]+)", ":")
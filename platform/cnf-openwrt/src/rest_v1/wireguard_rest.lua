@@ -0,0 +1,310 @@
+--- SPDX-License-Identifier: Apache-2.0
+--- Copyright (c) 2021 Intel Corporation
+
+module("luci.controller.rest_v1.wireguard_rest", package.seeall)
+
+local uci = require "luci.model.uci"
+
+json = require "luci.jsonc"
+io = require "io"
+sys = require "luci.sys"
+utils = require "luci.controller.rest_v1.utils"
+ifutil = require "luci.controller.rest_v1.ifutil"
+
+uci_conf = "wireguard-cnf"
+key_dir = "/etc/wireguard/"
+
+interface_validator = {
+    create_section_name=false,
+    {name="name", validator=function(value) return is_valid_ifname(value) end, message="Invalid interface name"},
+    {name="private_key", required=true, validator=function(value) return is_valid_key(value) end, message="Invalid private key"},
+    {name="listen_port", validator=function(value) return utils.is_integer_and_in_range(value, 0, 65536) end, message="Invalid listen port"},
+    {name="addresses", is_list=true, item_validator=function(value) return utils.is_valid_ip(value) end, message="Invalid address"},
+    {name="mtu", validator=function(value) return utils.is_integer_and_in_range(value, 1279, 65536) end, message="Invalid mtu"},
+}
+
+peer_validator = {
+    create_section_name=false,
+    {name="name", validator=function(value) return is_valid_ifname(value) end, message="Invalid peer name"},
+    {name="interface", required=true, validator=function(value) return is_interface_defined(value) end, message="Invalid interface", code="428"},
+    {name="public_key", required=true, validator=function(value) return is_valid_key(value) end, message="Invalid public key"},
+    {name="preshared_key", validator=function(value) return is_valid_key(value) end, message="Invalid preshared key"},
+    {name="endpoint_host", validator=function(value) return is_valid_host(value) end, message="Invalid endpoint host"},
+    {name="endpoint_port", validator=function(value) return utils.is_integer_and_in_range(value, 0, 65536) end, message="Invalid endpoint port"},
+    {name="allowed_ips", is_list=true, required=true, item_validator=function(value) return utils.is_valid_ip(value) end, message="Invalid allowed ip"},
+    {name="persistent_keepalive", validator=function(value) return utils.is_integer_and_in_range(value, -1, 65536) end, message="Invalid persistent keepalive"},
+    {name="route_allowed_ips",
+        load_func=function(value) if value["route_allowed_ips"] == "true" then return true else return false end end,
+        save_func=function(value) if value["route_allowed_ips"] == true then return true, "true" else return true, "false" end end},
+}
+
+wireguard_processor = {
+    interface={create="create_interface", update="update_interface", delete="delete_interface", validator=interface_validator},
+    peer={create="create_peer", delete="delete_peer", validator=peer_validator},
+    configuration=uci_conf
+}
+
+function index()
+    ver = "v1"
+    configuration = "wireguard"
+    entry({"sdewan", configuration, ver, "interfaces"}, call("handle_request")).leaf = true
+    entry({"sdewan", configuration, ver, "peers"}, call("handle_request")).leaf = true
+end
+
+-- Request Handler
+function handle_request()
+    local conf = io.open("/etc/config/" .. uci_conf, "r")
+    if conf == nil then
+        conf = io.open("/etc/config/" .. uci_conf, "w")
+    end
+    conf:close()
+
+    local handler = utils.handles_table[utils.get_req_method()]
+    if handler == nil then
+        utils.response_error(405, "Method Not Allowed")
+    else
+        return utils[handler](_M, wireguard_processor)
+    end
+end
+
+-- the interface name is used as the name of the network device and the
+-- peer name as the name of the preshared key file
+function is_valid_ifname(value)
+    if string.len(value) > 15 or not utils.is_match(value, "[%w_%-%.]+") then
+        return false, "at most 15 characters of letters, digits, '_', '-' or '.'"
+    end
+
+    return true, value
+end
+
+-- keys are the base64 encoding of 32 bytes
+function is_valid_key(value)
+    if string.len(value) ~= 44 or not utils.is_match(value, "[%w%+/]+=") then
+        return false, "not a base64 encoded 32 bytes key"
+    end
+
+    return true, value
+end
+
+-- the endpoint host is an ip address or a host name resolved by wg
+function is_valid_host(value)
+    if utils.is_valid_ip_address(value) or utils.is_valid_hostname(value) then
+        return true, value
+    end
+
+    return false, "not an ip address or a host name"
+end
+
+function is_interface_defined(name)
+    local interface = utils.get_object(_M, wireguard_processor, "interface", name)
+    if interface == nil then
+        return false, "Interface[" .. name .. "] is not defined"
+    end
+
+    return true, name
+end
+
+-- keys are passed to wg in files
+function save_key(name, key)
+    os.execute("mkdir -p " .. key_dir)
+    local path = key_dir .. name
+    local file = io.open(path, "w")
+    if file == nil then
+        return nil
+    end
+    file:write(key)
+    file:close()
+    os.execute("chmod 600 " .. path)
+
+    return path
+end
+
+function get_peers(interface)
+    local peers = {}
+    uci:foreach(uci_conf, "peer",
+        function(section)
+            if section["interface"] == interface then
+                peers[#peers+1] = utils.get_object(_M, wireguard_processor, "peer", section["name"])
+            end
+        end
+    )
+
+    return peers
+end
+
+-- generate commands for interface
+function interface_commands(interface, op)
+    local name = interface["name"]
+    if op ~= "create" then
+        return {"ip link del dev " .. name}
+    end
+
+    local comms = {"ip link add dev " .. name .. " type wireguard"}
+    local comm = "wg set " .. name .. " private-key " .. save_key(name .. ".key", interface["private_key"])
+    if interface["listen_port"] ~= nil and interface["listen_port"] ~= "" then
+        comm = comm .. " listen-port " .. interface["listen_port"]
+    end
+    comms[#comms+1] = comm
+
+    local addresses = interface["addresses"]
+    if addresses ~= nil then
+        for i=1, #addresses do
+            comms[#comms+1] = "ip address add " .. addresses[i] .. " dev " .. name
+        end
+    end
+    if interface["mtu"] ~= nil and interface["mtu"] ~= "" then
+        comms[#comms+1] = "ip link set dev " .. name .. " mtu " .. interface["mtu"]
+    end
+    comms[#comms+1] = "ip link set dev " .. name .. " up"
+    -- apply the routes waiting for the device
+    comms[#comms+1] = "bash /etc/cnfroute check " .. name
+
+    return comms
+end
+
+-- generate commands for peer
+function peer_commands(peer, op)
+    local interface = peer["interface"]
+    local comm = "wg set " .. interface .. " peer " .. peer["public_key"]
+    local allowed_ips = peer["allowed_ips"]
+    if type(allowed_ips) ~= "table" then
+        allowed_ips = {allowed_ips}
+    end
+
+    if op ~= "create" then
+        local comms = {comm .. " remove"}
+        if tostring(peer["route_allowed_ips"]) == "true" then
+            for i=1, #allowed_ips do
+                comms[#comms+1] = "ip route del " .. allowed_ips[i] .. " dev " .. interface
+            end
+        end
+        return comms
+    end
+
+    if peer["preshared_key"] ~= nil and peer["preshared_key"] ~= "" then
+        comm = comm .. " preshared-key " .. save_key(peer["name"] .. ".psk", peer["preshared_key"])
+    end
+    if peer["endpoint_host"] ~= nil and peer["endpoint_host"] ~= "" then
+        local port = peer["endpoint_port"]
+        if port == nil or port == "" then
+            port = "51820"
+        end
+        comm = comm .. " endpoint " .. peer["endpoint_host"] .. ":" .. port
+    end
+    if peer["persistent_keepalive"] ~= nil and peer["persistent_keepalive"] ~= "" then
+        comm = comm .. " persistent-keepalive " .. peer["persistent_keepalive"]
+    end
+    comm = comm .. " allowed-ips " .. table.concat(allowed_ips, ",")
+
+    local comms = {comm}
+    if tostring(peer["route_allowed_ips"]) == "true" then
+        for i=1, #allowed_ips do
+            comms[#comms+1] = "ip route replace " .. allowed_ips[i] .. " dev " .. interface
+        end
+    end
+
+    return comms
+end
+
+function execute(comms)
+    for i=1, #comms do
+        utils.log(comms[i])
+        os.execute(comms[i])
+    end
+end
+
+-- create an interface
+function create_interface(interface)
+    local res, code, msg = utils.create_uci_section(uci_conf, interface_validator, "interface", interface)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    execute(interface_commands(interface, "create"))
+
+    -- commit change
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+
+    return true
+end
+
+-- delete an interface
+function delete_interface(name, check_used)
+    local interface = utils.get_object(_M, wireguard_processor, "interface", name)
+    if interface == nil then
+        return false, 404, "interface " .. name .. " is not defined"
+    end
+
+    if check_used ~= false and #get_peers(name) > 0 then
+        return false, 400, "interface " .. name .. " is used by peers"
+    end
+
+    execute(interface_commands(interface, "delete"))
+    os.remove(key_dir .. name .. ".key")
+
+    utils.delete_uci_section(uci_conf, interface_validator, interface, "interface")
+
+    -- commit change
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+
+    return true
+end
+
+-- update an interface, the peers are set again on the new device
+function update_interface(interface)
+    local name = interface.name
+    local peers = get_peers(name)
+    local res, code, msg = delete_interface(name, false)
+    if res == false and code ~= 404 then
+        return false, code, msg
+    end
+
+    res, code, msg = create_interface(interface)
+    if res == false then
+        return false, code, msg
+    end
+    for i=1, #peers do
+        execute(peer_commands(peers[i], "create"))
+    end
+
+    return true
+end
+
+-- create a peer
+function create_peer(peer)
+    local res, code, msg = utils.create_uci_section(uci_conf, peer_validator, "peer", peer)
+    if res == false then
+        uci:revert(uci_conf)
+        return res, code, msg
+    end
+
+    execute(peer_commands(peer, "create"))
+
+    -- commit change
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+
+    return true
+end
+
+-- delete a peer
+function delete_peer(name)
+    local peer = utils.get_object(_M, wireguard_processor, "peer", name)
+    if peer == nil then
+        return false, 404, "peer " .. name .. " is not defined"
+    end
+
+    execute(peer_commands(peer, "delete"))
+    os.remove(key_dir .. name .. ".psk")
+
+    utils.delete_uci_section(uci_conf, peer_validator, peer, "peer")
+
+    -- commit change
+    uci:save(uci_conf)
+    uci:commit(uci_conf)
+
+    return true
+end
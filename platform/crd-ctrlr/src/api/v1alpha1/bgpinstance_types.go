@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BgpInstanceSpec defines the desired state of BgpInstance
+type BgpInstanceSpec struct {
+	// local AS number
+	As string `json:"as"`
+	// IPv4 address identifying the router
+	RouterId string `json:"router_id"`
+	// IPv4 networks (CIDR) advertised to the neighbors
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+	// route table of the learned routes, default or cnf
+	// +optional
+	Table string `json:"table,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BgpInstance is the Schema for the bgpinstances API
+type BgpInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BgpInstanceSpec `json:"spec,omitempty"`
+	Status SdewanStatus    `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BgpInstanceList contains a list of BgpInstance
+type BgpInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BgpInstance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BgpInstance{}, &BgpInstanceList{})
+}
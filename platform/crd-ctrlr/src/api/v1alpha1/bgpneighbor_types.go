@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BgpNeighborSpec defines the desired state of BgpNeighbor
+type BgpNeighborSpec struct {
+	// name of the BgpInstance of the neighbor
+	Instance string `json:"instance"`
+	// IPv4 address of the neighbor
+	RemoteAddress string `json:"remote_address"`
+	// AS number of the neighbor, the session is iBGP when it is the AS of the instance
+	RemoteAs string `json:"remote_as"`
+	// +optional
+	SourceAddress string `json:"source_address,omitempty"`
+	// +optional
+	Password string `json:"password,omitempty"`
+	// +optional
+	HoldTime string `json:"hold_time,omitempty"`
+	// advertise the routes with the local address as next hop
+	// +optional
+	NextHopSelf bool `json:"next_hop_self,omitempty"`
+	// reflect the iBGP routes to the neighbor
+	// +optional
+	RouteReflectorClient bool `json:"route_reflector_client,omitempty"`
+	// only accept the routes through the interface, #ip for the interface of ip
+	// +optional
+	Dev string `json:"dev,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BgpNeighbor is the Schema for the bgpneighbors API
+type BgpNeighbor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BgpNeighborSpec `json:"spec,omitempty"`
+	Status SdewanStatus    `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BgpNeighborList contains a list of BgpNeighbor
+type BgpNeighborList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BgpNeighbor `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BgpNeighbor{}, &BgpNeighborList{})
+}
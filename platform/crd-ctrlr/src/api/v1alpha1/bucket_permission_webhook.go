@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sync"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,7 +25,18 @@ import (
 // log is for logging in this package.
 var bucketlog = logf.Log.WithName("sdewan-bucket-permission")
 
+// BindingSubjectsIndex is the field index of the RoleBindings and
+// ClusterRoleBindings by subject, see BindingSubjectKeys
+const BindingSubjectsIndex = ".subjects"
+
 func SetupBucketPermissionWebhookWithManager(mgr ctrl.Manager) error {
+	// the bindings and roles are looked up for every request, start their
+	// informers with the manager rather than on the first request
+	for _, obj := range []client.Object{&rbacv1.Role{}, &rbacv1.ClusterRole{}, &rbacv1.RoleBinding{}, &rbacv1.ClusterRoleBinding{}} {
+		if _, err := mgr.GetCache().GetInformer(context.Background(), obj); err != nil {
+			return err
+		}
+	}
 	mgr.GetWebhookServer().Register(
 		"/validate-sdewan-bucket-permission",
 		&webhook.Admission{Handler: &bucketPermissionValidator{Client: mgr.GetClient()}})
@@ -65,12 +78,20 @@ func wildMatchArray(p []rune, pindex int, v []rune, vindex int) bool {
 	return true
 }
 
-// +kubebuilder:webhook:path=/validate-sdewan-bucket-permission,mutating=false,failurePolicy=fail,groups="batch.sdewan.akraino.org",resources=mwan3policies;mwan3rules;networkfirewallrules;firewallzones;firewallforwardings;firewallrules;firewallsnats;firewalldnats;cnfnats;cnfroutes;cnfrouterules;cnfservices;cnflocalservices;cnfhubsites;cnfstatuses;sdewanapplication;ipsecproposals;ipsechosts;ipsecsites,verbs=create;update;delete,versions=v1alpha1,name=validate-sdewan-bucket.akraino.org,admissionReviewVersions=v1,sideEffects=none
+// +kubebuilder:webhook:path=/validate-sdewan-bucket-permission,mutating=false,failurePolicy=fail,groups="batch.sdewan.akraino.org",resources=mwan3policies;mwan3rules;networkfirewallrules;firewallzones;firewallforwardings;firewallrules;firewallsnats;firewalldnats;cnfnats;cnfroutes;cnfrouterules;cnfservices;cnflocalservices;cnfhubsites;cnfstatuses;sdewanapplication;ipsecproposals;ipsechosts;ipsecsites;wireguardinterfaces;wireguardpeers;bgpinstances;bgpneighbors;trafficshapingpolicies;dhcppools;dhcpstaticleases;dnsforwarders;dnsrecords;cnfstatusactions,verbs=create;update;delete,versions=v1alpha1,name=validate-sdewan-bucket.akraino.org,admissionReviewVersions=v1,sideEffects=none
 
 // bucketPermissionValidator validates Pods
 type bucketPermissionValidator struct {
 	Client  client.Client
 	decoder *admission.Decoder
+	// parsed sdewan-bucket-type-permission annotations of the roles
+	lock        sync.Mutex
+	permissions map[string]cachedBucketPermission
+}
+
+type cachedBucketPermission struct {
+	version string
+	perm    BucketPermission
 }
 
 // map key is the resource type, values is the permissions. Sample bucket permission:
@@ -97,7 +118,7 @@ func (v *bucketPermissionValidator) Handle(ctx context.Context, req admission.Re
 		}
 	}
 	if authenticated && clusterAdmin {
-		return admission.Allowed("Allowd as cluster admin")
+		return admission.Allowed("Allowed as cluster admin")
 	}
 	var meta metav1.ObjectMeta
 	var err error
@@ -131,6 +152,26 @@ func (v *bucketPermissionValidator) Handle(ctx context.Context, req admission.Re
 		obj = &IpsecHost{}
 	case "IpsecSite":
 		obj = &IpsecSite{}
+	case "WireguardInterface":
+		obj = &WireguardInterface{}
+	case "WireguardPeer":
+		obj = &WireguardPeer{}
+	case "BgpInstance":
+		obj = &BgpInstance{}
+	case "BgpNeighbor":
+		obj = &BgpNeighbor{}
+	case "TrafficShapingPolicy":
+		obj = &TrafficShapingPolicy{}
+	case "DhcpPool":
+		obj = &DhcpPool{}
+	case "DhcpStaticLease":
+		obj = &DhcpStaticLease{}
+	case "DnsForwarder":
+		obj = &DnsForwarder{}
+	case "DnsRecord":
+		obj = &DnsRecord{}
+	case "CNFStatusAction":
+		obj = &CNFStatusAction{}
 	case "CNFService":
 		obj = &CNFService{}
 	case "CNFStatus":
@@ -164,76 +205,163 @@ func (v *bucketPermissionValidator) Handle(ctx context.Context, req admission.Re
 		return admission.Allowed("")
 	}
 
-	// Validate role within target namespace
-	roleBindings := &rbacv1.RoleBindingList{}
-	err = v.Client.List(ctx, roleBindings, client.MatchingFields{".subjects": req.UserInfo.Username})
-	if err != nil {
-		bucketlog.Error(err, "Failed to get rolebinding list")
-	} else {
-		for _, rolebinding := range roleBindings.Items {
-			role := &rbacv1.Role{}
-			err = v.Client.Get(ctx, types.NamespacedName{Namespace: rolebinding.Namespace, Name: rolebinding.RoleRef.Name}, role)
-			if err != nil {
-				bucketlog.Error(err, "Failed to get role from rolebinding")
-				continue
-			}
-			if role.Annotations["sdewan-bucket-type-permission"] == "" {
-				continue
-			}
-			var perm BucketPermission = make(map[string][]string)
-			err := json.Unmarshal([]byte(role.Annotations["sdewan-bucket-type-permission"]), &perm)
-			if err != nil {
-				bucketlog.Error(err, "Failed to parse bucket permission annotation")
-				continue
-			}
-			if role.Namespace == meta.Namespace {
-				for res, resPerm := range perm {
-					if wildMatch(res, req.Resource.Resource) {
-						for _, p := range resPerm {
-							if wildMatch(p, bucketType) {
-								return admission.Allowed("")
-							}
-						}
-					}
+	perms, admin := v.grantedPermissions(ctx, req.UserInfo, meta.Namespace)
+	if admin {
+		return admission.Allowed("Allowed as cluster admin")
+	}
+	for _, perm := range perms {
+		if perm.allows(req.Resource.Resource, bucketType) {
+			return admission.Allowed("")
+		}
+	}
+
+	return admission.Denied(fmt.Sprintf(
+		"User(%v) doesn't have the sdewan-bucket-type-permission for %s of bucket type %s in namespace %s, "+
+			"a Role or ClusterRole with the annotation sdewan-bucket-type-permission: '{\"%s\": [\"%s\"]}' "+
+			"should be bound to the user, its groups or its ServiceAccount",
+		req.UserInfo.Username, req.Resource.Resource, bucketType, meta.Namespace,
+		req.Resource.Resource, bucketType))
+}
+
+// allows checks whether the permission covers the resource type and the bucket type
+func (perm BucketPermission) allows(resource string, bucketType string) bool {
+	for res, resPerm := range perm {
+		if wildMatch(res, resource) {
+			for _, p := range resPerm {
+				if wildMatch(p, bucketType) {
+					return true
 				}
 			}
 		}
 	}
-	// Validate clusterrole
-	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
-	err = v.Client.List(ctx, clusterRoleBindings, client.MatchingFields{".subjects": req.UserInfo.Username})
-	if err != nil {
-		bucketlog.Error(err, "Failed to get clusterrolebinding list")
-	} else {
+	return false
+}
+
+// grantedPermissions returns the bucket permissions granted in the namespace
+// by the Roles and ClusterRoles bound to the user, to one of its groups or to
+// its ServiceAccount. admin is set if a ClusterRoleBinding grants a cluster
+// admin role. The bindings and roles are read from the informer cache
+func (v *bucketPermissionValidator) grantedPermissions(ctx context.Context, user authenticationv1.UserInfo, namespace string) ([]BucketPermission, bool) {
+	perms := []BucketPermission{}
+	seen := make(map[string]bool)
+	for _, key := range UserSubjectKeys(user) {
+		roleBindings := &rbacv1.RoleBindingList{}
+		err := v.Client.List(ctx, roleBindings, client.InNamespace(namespace), client.MatchingFields{BindingSubjectsIndex: key})
+		if err != nil {
+			bucketlog.Error(err, "Failed to get rolebinding list")
+		} else {
+			for _, rolebinding := range roleBindings.Items {
+				if seen["RoleBinding/"+rolebinding.Namespace+"/"+rolebinding.Name] {
+					continue
+				}
+				seen["RoleBinding/"+rolebinding.Namespace+"/"+rolebinding.Name] = true
+				var role client.Object = &rbacv1.Role{}
+				name := types.NamespacedName{Namespace: rolebinding.Namespace, Name: rolebinding.RoleRef.Name}
+				if rolebinding.RoleRef.Kind == "ClusterRole" {
+					role = &rbacv1.ClusterRole{}
+					name.Namespace = ""
+				}
+				if perm := v.getPermission(ctx, name, role); perm != nil {
+					perms = append(perms, perm)
+				}
+			}
+		}
+
+		clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+		err = v.Client.List(ctx, clusterRoleBindings, client.MatchingFields{BindingSubjectsIndex: key})
+		if err != nil {
+			bucketlog.Error(err, "Failed to get clusterrolebinding list")
+			continue
+		}
 		for _, clusterrolebinding := range clusterRoleBindings.Items {
-			clusterrole := &rbacv1.ClusterRole{}
-			err = v.Client.Get(ctx, types.NamespacedName{Name: clusterrolebinding.RoleRef.Name}, clusterrole)
-			if err != nil {
-				bucketlog.Error(err, "Failed to get clusterrole from clusterrolebinding")
+			if seen["ClusterRoleBinding/"+clusterrolebinding.Name] {
 				continue
 			}
-			if clusterrole.Annotations["sdewan-bucket-type-permission"] == "" {
-				continue
+			seen["ClusterRoleBinding/"+clusterrolebinding.Name] = true
+			clusterrole := &rbacv1.ClusterRole{}
+			if perm := v.getPermission(ctx, types.NamespacedName{Name: clusterrolebinding.RoleRef.Name}, clusterrole); perm != nil {
+				perms = append(perms, perm)
 			}
-			var perm BucketPermission = make(map[string][]string)
-			err := json.Unmarshal([]byte(clusterrole.Annotations["sdewan-bucket-type-permission"]), &perm)
-			if err != nil {
-				bucketlog.Error(err, "Failed to parse bucket permission annotation")
-				continue
+			if isClusterAdminRole(clusterrole) {
+				return perms, true
 			}
-			for res, resPerm := range perm {
-				if wildMatch(res, req.Resource.Resource) {
-					for _, p := range resPerm {
-						if wildMatch(p, bucketType) {
-							return admission.Allowed("")
-						}
-					}
-				}
+		}
+	}
+	return perms, false
+}
+
+// getPermission gets the role and returns its bucket permission, the parsed
+// annotation is cached by resource version
+func (v *bucketPermissionValidator) getPermission(ctx context.Context, name types.NamespacedName, role client.Object) BucketPermission {
+	err := v.Client.Get(ctx, name, role)
+	if err != nil {
+		bucketlog.Error(err, "Failed to get role from rolebinding", "role", name)
+		return nil
+	}
+	annotation := role.GetAnnotations()["sdewan-bucket-type-permission"]
+	if annotation == "" {
+		return nil
+	}
+
+	key := fmt.Sprintf("%T/%s", role, name)
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if cached, ok := v.permissions[key]; ok && cached.version == role.GetResourceVersion() {
+		return cached.perm
+	}
+
+	var perm BucketPermission = make(map[string][]string)
+	err = json.Unmarshal([]byte(annotation), &perm)
+	if err != nil {
+		bucketlog.Error(err, "Failed to parse bucket permission annotation", "role", name)
+		perm = nil
+	}
+	if v.permissions == nil {
+		v.permissions = make(map[string]cachedBucketPermission)
+	}
+	v.permissions[key] = cachedBucketPermission{version: role.GetResourceVersion(), perm: perm}
+	return perm
+}
+
+// isClusterAdminRole checks whether the role grants every verb on every
+// resource, like the cluster-admin ClusterRole
+func isClusterAdminRole(role *rbacv1.ClusterRole) bool {
+	for _, rule := range role.Rules {
+		if contains(rule.APIGroups, "*") && contains(rule.Resources, "*") && contains(rule.Verbs, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// BindingSubjectKeys returns the keys of the subjects of a binding for the
+// BindingSubjectsIndex. A ServiceAccount is keyed as the user it
+// authenticates as
+func BindingSubjectKeys(namespace string, subjects []rbacv1.Subject) []string {
+	var keys []string
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			ns := subject.Namespace
+			if ns == "" {
+				ns = namespace
 			}
+			keys = append(keys, rbacv1.UserKind+":"+fmt.Sprintf("system:serviceaccount:%s:%s", ns, subject.Name))
+		case rbacv1.UserKind, rbacv1.GroupKind:
+			keys = append(keys, subject.Kind+":"+subject.Name)
 		}
 	}
+	return keys
+}
 
-	return admission.Denied(fmt.Sprintf("User(%v) don't have the permission", req.UserInfo.Username))
+// UserSubjectKeys returns the BindingSubjectsIndex keys which match the user
+// of a request: its name and its groups
+func UserSubjectKeys(user authenticationv1.UserInfo) []string {
+	keys := []string{rbacv1.UserKind + ":" + user.Username}
+	for _, g := range user.Groups {
+		keys = append(keys, rbacv1.GroupKind+":"+g)
+	}
+	return keys
 }
 
 // bucketPermissionValidator implements admission.DecoderInjector.
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestBindingSubjectKeys(t *testing.T) {
+	subjects := []rbacv1.Subject{
+		{Kind: rbacv1.UserKind, Name: "alice"},
+		{Kind: rbacv1.GroupKind, Name: "sdewan-admins"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "gitops", Namespace: "flux-system"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "default"},
+	}
+	expected := []string{
+		"User:alice",
+		"Group:sdewan-admins",
+		"User:system:serviceaccount:flux-system:gitops",
+		"User:system:serviceaccount:sdewan-system:default",
+	}
+	if keys := BindingSubjectKeys("sdewan-system", subjects); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("BindingSubjectKeys() = %v, expected %v", keys, expected)
+	}
+}
+
+func TestUserSubjectKeys(t *testing.T) {
+	user := authenticationv1.UserInfo{
+		Username: "system:serviceaccount:flux-system:gitops",
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:flux-system", "system:authenticated"},
+	}
+	keys := UserSubjectKeys(user)
+
+	// a binding to the ServiceAccount or to its namespace group matches
+	for _, subject := range []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Name: "gitops", Namespace: "flux-system"},
+		{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:flux-system"},
+		{Kind: rbacv1.UserKind, Name: "system:serviceaccount:flux-system:gitops"},
+	} {
+		key := BindingSubjectKeys("default", []rbacv1.Subject{subject})[0]
+		if !contains(keys, key) {
+			t.Errorf("Subject %v is not matched by %v", subject, keys)
+		}
+	}
+	// a group of the same name as the user does not match
+	if contains(keys, "Group:"+user.Username) {
+		t.Errorf("Unexpected group key in %v", keys)
+	}
+}
+
+func TestBucketPermissionAllows(t *testing.T) {
+	perm := BucketPermission{
+		"mwan3*":         {"app-intent"},
+		"firewallzones":  {"k8s-*"},
+		"cnfrouterules":  {},
+		"firewallrules?": {"*"},
+	}
+	for _, c := range []struct {
+		resource   string
+		bucketType string
+		allowed    bool
+	}{
+		{"mwan3rules", "app-intent", true},
+		{"mwan3policies", "k8s-service", false},
+		{"firewallzones", "k8s-service", true},
+		{"cnfrouterules", "app-intent", false},
+		{"firewallrules", "app-intent", false},
+		{"ipsecsites", "app-intent", false},
+	} {
+		if allowed := perm.allows(c.resource, c.bucketType); allowed != c.allowed {
+			t.Errorf("allows(%s, %s) = %v, expected %v", c.resource, c.bucketType, allowed, c.allowed)
+		}
+	}
+}
+
+func TestIsClusterAdminRole(t *testing.T) {
+	admin := &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+	}}
+	if !isClusterAdminRole(admin) {
+		t.Errorf("Expected cluster admin role")
+	}
+
+	editor := &rbacv1.ClusterRole{Rules: []rbacv1.PolicyRule{
+		{APIGroups: []string{"batch.sdewan.akraino.org"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+	}}
+	if isClusterAdminRole(editor) {
+		t.Errorf("Unexpected cluster admin role")
+	}
+}
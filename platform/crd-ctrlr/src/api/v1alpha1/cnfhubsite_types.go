@@ -24,6 +24,10 @@ type CNFHubSiteStatus struct {
 	// +optional
 	DevicePIP string `json:"devicepip,omitempty"`
 	// +optional
+	Priority int `json:"priority,omitempty"`
+	// +optional
+	Probability string `json:"probability,omitempty"`
+	// +optional
 	Message string `json:"message,omitempty"`
 }
 
@@ -31,7 +35,9 @@ func (c *CNFHubSiteStatus) IsEqual(s *CNFHubSiteStatus) bool {
 	if c.Type != s.Type ||
 		c.Subnet != s.Subnet ||
 		c.HubIP != s.HubIP ||
-		c.DevicePIP != s.DevicePIP {
+		c.DevicePIP != s.DevicePIP ||
+		c.Priority != s.Priority ||
+		c.Probability != s.Probability {
 		return false
 	}
 
@@ -55,6 +61,12 @@ type CNFHubSiteSpec struct {
 	Subnet    string `json:"subnet,omitempty"`
 	HubIP     string `json:"hubip,omitempty"`
 	DevicePIP string `json:"devicepip,omitempty"`
+	// Priority of the hub for a Device site, the SNAT rules of the
+	// primary hub (1) are inserted first, the others are appended
+	Priority int `json:"priority,omitempty"`
+	// Probability of the flows to the site which are sent to this hub,
+	// the remaining flows fall through to the hubs of lower priority
+	Probability string `json:"probability,omitempty"`
 }
 
 // +kubebuilder:object:root=true
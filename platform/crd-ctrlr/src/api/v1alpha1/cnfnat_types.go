@@ -25,10 +25,18 @@ type CNFNATSpec struct {
 	DestPort string `json:"dest_port,omitempty"`
 	Target   string `json:"target,omitempty"`
 	Index    string `json:"index,omitempty"`
+	// Probability makes the rule match a random share of the new flows
+	Probability string `json:"probability,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // CNFNAT is the Schema for the cnfnats API
 type CNFNAT struct {
@@ -27,6 +27,12 @@ type CNFRouteRuleSpec struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // CNFRouteRule is the Schema for the cnfrouterules API
 type CNFRouteRule struct {
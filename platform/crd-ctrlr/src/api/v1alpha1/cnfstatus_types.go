@@ -35,6 +35,22 @@ type CNFStatusStatus struct {
 	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
 	// +optional
 	Information []CNFStatusInformation `json:"information,omitempty"`
+	// the last actions taken by the CNFStatusActions, the latest last
+	// +optional
+	Actions []CNFStatusActionRecord `json:"actions,omitempty"`
+}
+
+// CNFStatusActionRecord records an action taken on a CNF
+type CNFStatusActionRecord struct {
+	// namespace/name of the CNFStatusAction
+	Name   string      `json:"name"`
+	Pod    string      `json:"pod"`
+	Module string      `json:"module"`
+	Action string      `json:"action"`
+	Time   metav1.Time `json:"time"`
+	// error of the action, empty if it succeeded
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Actions taken on a CNF when the status of one of its modules matches a
+// condition
+const (
+	// restart a service of the CNF
+	RestartServiceAction = "RestartService"
+	// re-apply the CRs of the module to the CNF
+	ReapplyCRsAction = "ReapplyCRs"
+	// raise a Kubernetes Event on the CNF pod
+	EventAction = "Event"
+)
+
+// default minimum interval between two actions on a CNF pod
+const defaultActionInterval = 5 * time.Minute
+
+// the service of each module restarted by RestartService by default
+var moduleServices = map[string]string{
+	"ipsec":    "ipsec",
+	"wan":      "mwan3",
+	"firewall": "firewall",
+}
+
+// CNFStatusActionSpec defines the desired state of CNFStatusAction
+type CNFStatusActionSpec struct {
+	// module of the CNF status, e.g. ipsec, wan (mwan3) or firewall
+	Module string `json:"module"`
+	// JSONPath on the status of the module, e.g. {.InitConnection}
+	Condition string `json:"condition"`
+	// the action is taken when a result of the condition equals the value,
+	// or when the condition has a non-empty result if the value is not set
+	// +optional
+	Value string `json:"value,omitempty"`
+	// +kubebuilder:validation:Enum=RestartService;ReapplyCRs;Event
+	Action string `json:"action"`
+	// service restarted by RestartService (mwan3, firewall or ipsec), the
+	// service of the module by default
+	// +optional
+	Service string `json:"service,omitempty"`
+	// minimum interval between two actions on a CNF pod (e.g. 10m), 5m by
+	// default
+	// +optional
+	MinInterval string `json:"min_interval,omitempty"`
+}
+
+// GetService returns the service restarted by RestartService
+func (s *CNFStatusActionSpec) GetService() string {
+	if s.Service != "" {
+		return s.Service
+	}
+	return moduleServices[s.Module]
+}
+
+// GetMinInterval returns the minimum interval between two actions on a CNF
+// pod
+func (s *CNFStatusActionSpec) GetMinInterval() time.Duration {
+	d, err := time.ParseDuration(s.MinInterval)
+	if err != nil || d <= 0 {
+		return defaultActionInterval
+	}
+	return d
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Module",type=string,JSONPath=`.spec.module`
+// +kubebuilder:printcolumn:name="Condition",type=string,JSONPath=`.spec.condition`
+// +kubebuilder:printcolumn:name="Value",type=string,JSONPath=`.spec.value`
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CNFStatusAction is the Schema for the cnfstatusactions API, the action
+// applies to the CNFs of its namespace, or to the CNF of its sdewanPurpose
+// label if set
+type CNFStatusAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CNFStatusActionSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CNFStatusActionList contains a list of CNFStatusAction
+type CNFStatusActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CNFStatusAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CNFStatusAction{}, &CNFStatusActionList{})
+}
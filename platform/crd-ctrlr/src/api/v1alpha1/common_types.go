@@ -25,4 +25,28 @@ type SdewanStatus struct {
 	State       SdewanState  `json:"state"`
 	// +optional
 	Message string `json:"message,omitempty"`
+	// hash of the object as reported by the CNF, empty if the pods report
+	// different objects
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+	// the pods of the CNF the object is applied to
+	// +optional
+	Pods []SdewanPodStatus `json:"pods,omitempty"`
+	// pods in sync out of the pods of the CNF
+	// +optional
+	PodsInSync string `json:"podsInSync,omitempty"`
+	// summary of the errors of the pods
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// status of the object in a pod of the CNF
+type SdewanPodStatus struct {
+	Name string `json:"name"`
+	// hash of the object as reported by the CNF of the pod
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+	// last error applying the object to the pod
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+// v1alpha1 is the storage version, the other versions are converted to and
+// from it by the conversion webhook
+
+func (*Mwan3Rule) Hub()           {}
+func (*FirewallZone) Hub()        {}
+func (*FirewallRule) Hub()        {}
+func (*NetworkFirewallRule) Hub() {}
+func (*FirewallSNAT) Hub()        {}
+func (*FirewallDNAT) Hub()        {}
+func (*CNFNAT) Hub()              {}
+func (*CNFRouteRule) Hub()        {}
+func (*IpsecHost) Hub()           {}
+func (*IpsecSite) Hub()           {}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DhcpPoolSpec defines the desired state of DhcpPool
+type DhcpPoolSpec struct {
+	// network of the CNF the pool serves
+	Network string `json:"network"`
+	// first and last IPv4 addresses of the pool
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// +optional
+	Netmask string `json:"netmask,omitempty"`
+	// lease time in seconds, with a unit (e.g. 12h) or infinite, 1h by default
+	// +optional
+	LeaseTime string `json:"lease_time,omitempty"`
+	// default gateway given to the clients
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// DNS servers given to the clients, the CNF by default
+	// +optional
+	DnsServers []string `json:"dns_servers,omitempty"`
+	// +optional
+	Domain string `json:"domain,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DhcpPool is the Schema for the dhcppools API
+type DhcpPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DhcpPoolSpec `json:"spec,omitempty"`
+	Status SdewanStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DhcpPoolList contains a list of DhcpPool
+type DhcpPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DhcpPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DhcpPool{}, &DhcpPoolList{})
+}
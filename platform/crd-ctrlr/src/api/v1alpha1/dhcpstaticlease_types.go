@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DhcpStaticLeaseSpec defines the desired state of DhcpStaticLease
+type DhcpStaticLeaseSpec struct {
+	Mac string `json:"mac"`
+	// IPv4 address given to the mac
+	Ip string `json:"ip"`
+	// the CNF resolves the hostname to the ip
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// +optional
+	LeaseTime string `json:"lease_time,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DhcpStaticLease is the Schema for the dhcpstaticleases API
+type DhcpStaticLease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DhcpStaticLeaseSpec `json:"spec,omitempty"`
+	Status SdewanStatus        `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DhcpStaticLeaseList contains a list of DhcpStaticLease
+type DhcpStaticLeaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DhcpStaticLease `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DhcpStaticLease{}, &DhcpStaticLeaseList{})
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DnsForwarderSpec defines the desired state of DnsForwarder
+type DnsForwarderSpec struct {
+	// the queries of the domain and its subdomains are forwarded to the servers
+	Domain string `json:"domain"`
+	// IPv4 addresses of the DNS servers
+	Servers []string `json:"servers"`
+	// +optional
+	Port string `json:"port,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DnsForwarder is the Schema for the dnsforwarders API
+type DnsForwarder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DnsForwarderSpec `json:"spec,omitempty"`
+	Status SdewanStatus     `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DnsForwarderList contains a list of DnsForwarder
+type DnsForwarderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DnsForwarder `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DnsForwarder{}, &DnsForwarderList{})
+}
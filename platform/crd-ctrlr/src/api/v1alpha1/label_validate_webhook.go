@@ -29,7 +29,7 @@ func SetupLabelValidateWebhookWithManager(mgr ctrl.Manager) error {
 	return nil
 }
 
-// +kubebuilder:webhook:path=/validate-label,mutating=false,failurePolicy=fail,groups=apps;batch.sdewan.akraino.org,resources=deployments;mwan3policies;mwan3rules;networkfirewallrules;firewallzones;firewallforwardings;firewallrules;firewallsnats;firewalldnats;cnfnats;cnfservices;cnfroutes;cnfrouterules;cnflocalservices;cnfhubsites;cnfstatuses;sdewanapplication;ipsecproposals;ipsechosts;ipsecsites,verbs=update,versions=v1;v1alpha1,name=validate-label.akraino.org,admissionReviewVersions=v1,sideEffects=none
+// +kubebuilder:webhook:path=/validate-label,mutating=false,failurePolicy=fail,groups=apps;batch.sdewan.akraino.org,resources=deployments;mwan3policies;mwan3rules;networkfirewallrules;firewallzones;firewallforwardings;firewallrules;firewallsnats;firewalldnats;cnfnats;cnfservices;cnfroutes;cnfrouterules;cnflocalservices;cnfhubsites;cnfstatuses;sdewanapplication;ipsecproposals;ipsechosts;ipsecsites;wireguardinterfaces;wireguardpeers;bgpinstances;bgpneighbors;trafficshapingpolicies;dhcppools;dhcpstaticleases;dnsforwarders;dnsrecords;cnfstatusactions,verbs=update,versions=v1;v1alpha1,name=validate-label.akraino.org,admissionReviewVersions=v1,sideEffects=none
 
 type labelValidator struct {
 	Client  client.Client
@@ -69,6 +69,26 @@ func (v *labelValidator) Handle(ctx context.Context, req admission.Request) admi
 		obj = &IpsecHost{}
 	case "IpsecSite":
 		obj = &IpsecSite{}
+	case "WireguardInterface":
+		obj = &WireguardInterface{}
+	case "WireguardPeer":
+		obj = &WireguardPeer{}
+	case "BgpInstance":
+		obj = &BgpInstance{}
+	case "BgpNeighbor":
+		obj = &BgpNeighbor{}
+	case "TrafficShapingPolicy":
+		obj = &TrafficShapingPolicy{}
+	case "DhcpPool":
+		obj = &DhcpPool{}
+	case "DhcpStaticLease":
+		obj = &DhcpStaticLease{}
+	case "DnsForwarder":
+		obj = &DnsForwarder{}
+	case "DnsRecord":
+		obj = &DnsRecord{}
+	case "CNFStatusAction":
+		obj = &CNFStatusAction{}
 	case "CNFService":
 		obj = &CNFService{}
 	case "CNFLocalService":
@@ -0,0 +1,872 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/jsonpath"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var speclog = logf.Log.WithName("sdewan-spec-validator")
+
+// values accepted by the CNF, they follow the validators of its REST API
+var (
+	firewallProtos    = []string{"tcp", "udp", "tcpudp", "udplite", "icmp", "esp", "ah", "sctp", "all"}
+	firewallFamilies  = []string{"ipv4", "ipv6", "any"}
+	firewallTargets   = []string{"ACCEPT", "REJECT", "DROP", "MARK", "NOTRACK"}
+	firewallPolicies  = []string{"ACCEPT", "REJECT", "DROP"}
+	firewallSwitches  = []string{"0", "1"}
+	mwan3Protos       = []string{"tcp", "udp", "icmp", "all"}
+	mwan3Families     = []string{"ipv4", "ipv6", "all"}
+	natProtos         = firewallProtos
+	natTargets        = []string{"DNAT", "SNAT", "MASQUERADE"}
+	routeRuleTables   = []string{"main", "local", "default"}
+	ipsecAuthMethods  = []string{"psk", "pubkey"}
+	ipsecConnTypes    = []string{"tunnel", "transport"}
+	ipsecConnModes    = []string{"start", "add", "route"}
+	ipsecYesNo        = []string{"yes", "no"}
+	bgpTables         = []string{"default", "cnf"}
+	qosProtos         = []string{"tcp", "udp", "icmp"}
+	cnfServices       = []string{"mwan3", "firewall", "ipsec"}
+	statusActions     = []string{RestartServiceAction, ReapplyCRsAction, EventAction}
+	dscpNames         = []string{"CS0", "CS1", "CS2", "CS3", "CS4", "CS5", "CS6", "CS7", "AF11", "AF12", "AF13", "AF21", "AF22", "AF23", "AF31", "AF32", "AF33", "AF41", "AF42", "AF43", "EF"}
+	icmpTypes         = []string{"address-mask-reply", "address-mask-request", "any", "communication-prohibited", "destination-unreachable", "echo-reply", "echo-request", "fragmentation-needed", "host-precedence-violation", "host-prohibited", "host-redirect", "host-unknown", "host-unreachable", "ip-header-bad", "network-prohibited", "network-redirect", "network-unknown", "network-unreachable", "parameter-problem", "ping", "pong", "port-unreachable", "precedence-cutoff", "protocol-unreachable", "redirect", "required-option-missing", "router-advertisement", "router-solicitation", "source-quench", "source-route-failed", "time-exceeded", "timestamp-reply", "timestamp-request", "TOS-host-redirect", "TOS-host-unreachable", "TOS-network-redirect", "TOS-network-unreachable", "ttl-exceeded", "ttl-zero-during-reassembly", "ttl-zero-during-transit"}
+	maxZoneNameLength = 11
+	maxIfNameLength   = 15
+	leaseTimeRegexp   = regexp.MustCompile(`^[0-9]+[smhdw]?$`)
+)
+
+func SetupSpecValidateWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(
+		"/validate-sdewan-spec",
+		&webhook.Admission{Handler: &specValidator{Client: mgr.GetClient()}})
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-sdewan-spec,mutating=false,failurePolicy=fail,groups="batch.sdewan.akraino.org",resources=mwan3policies;mwan3rules;networkfirewallrules;firewallzones;firewallforwardings;firewallrules;firewallsnats;firewalldnats;cnfnats;cnfroutes;cnfrouterules;ipsechosts;ipsecsites;wireguardinterfaces;wireguardpeers;bgpinstances;bgpneighbors;trafficshapingpolicies;dhcppools;dhcpstaticleases;dnsforwarders;dnsrecords;cnfstatusactions,verbs=create;update,versions=v1alpha1,name=validate-sdewan-spec.akraino.org,admissionReviewVersions=v1,sideEffects=none
+
+// specValidator rejects the CRs which the CNF would refuse to apply, so that
+// they do not stay in Applying
+type specValidator struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+func (v *specValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var obj client.Object
+	switch req.Kind.Kind {
+	case "Mwan3Policy":
+		obj = &Mwan3Policy{}
+	case "Mwan3Rule":
+		obj = &Mwan3Rule{}
+	case "NetworkFirewallRule":
+		obj = &NetworkFirewallRule{}
+	case "FirewallZone":
+		obj = &FirewallZone{}
+	case "FirewallForwarding":
+		obj = &FirewallForwarding{}
+	case "FirewallRule":
+		obj = &FirewallRule{}
+	case "FirewallSNAT":
+		obj = &FirewallSNAT{}
+	case "FirewallDNAT":
+		obj = &FirewallDNAT{}
+	case "CNFNAT":
+		obj = &CNFNAT{}
+	case "CNFRoute":
+		obj = &CNFRoute{}
+	case "CNFRouteRule":
+		obj = &CNFRouteRule{}
+	case "IpsecHost":
+		obj = &IpsecHost{}
+	case "IpsecSite":
+		obj = &IpsecSite{}
+	case "WireguardInterface":
+		obj = &WireguardInterface{}
+	case "WireguardPeer":
+		obj = &WireguardPeer{}
+	case "BgpInstance":
+		obj = &BgpInstance{}
+	case "BgpNeighbor":
+		obj = &BgpNeighbor{}
+	case "TrafficShapingPolicy":
+		obj = &TrafficShapingPolicy{}
+	case "DhcpPool":
+		obj = &DhcpPool{}
+	case "DhcpStaticLease":
+		obj = &DhcpStaticLease{}
+	case "DnsForwarder":
+		obj = &DnsForwarder{}
+	case "DnsRecord":
+		obj = &DnsRecord{}
+	case "CNFStatusAction":
+		obj = &CNFStatusAction{}
+	default:
+		return admission.Errored(
+			http.StatusBadRequest,
+			fmt.Errorf("Kind is not supported: %v", req.Kind))
+	}
+
+	if req.Operation != "CREATE" && req.Operation != "UPDATE" {
+		return admission.Allowed("")
+	}
+
+	err := v.decoder.Decode(req, obj)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// the object is deleted, its spec does not matter anymore
+	if obj.GetDeletionTimestamp() != nil {
+		return admission.Allowed("")
+	}
+
+	errs, warnings := v.validate(ctx, obj)
+	if len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error()).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// validate checks the spec of the object, it returns the errors rejecting
+// the object and the warnings of the references which may be created after
+// it (see specReferences.expect)
+func (v *specValidator) validate(ctx context.Context, obj client.Object) (field.ErrorList, []string) {
+	spec := field.NewPath("spec")
+	refs := &specReferences{client: v.Client, ctx: ctx, obj: obj}
+
+	var errs field.ErrorList
+	switch o := obj.(type) {
+	case *Mwan3Policy:
+		for i, m := range o.Spec.Members {
+			p := spec.Child("members").Index(i)
+			if m.Network == "" {
+				errs = append(errs, field.Required(p.Child("network"), ""))
+			}
+			if m.Metric <= 0 {
+				errs = append(errs, field.Invalid(p.Child("metric"), m.Metric, "must be greater than 0"))
+			}
+			if m.Weight <= 0 {
+				errs = append(errs, field.Invalid(p.Child("weight"), m.Weight, "must be greater than 0"))
+			}
+		}
+	case *Mwan3Rule:
+		s := o.Spec
+		errs = append(errs, validateIp(spec.Child("src_ip"), s.SrcIp)...)
+		errs = append(errs, validatePort(spec.Child("src_port"), s.SrcPort)...)
+		errs = append(errs, validateIp(spec.Child("dest_ip"), s.DestIp)...)
+		errs = append(errs, validatePort(spec.Child("dest_port"), s.DestPort)...)
+		errs = append(errs, validateEnum(spec.Child("proto"), s.Proto, mwan3Protos)...)
+		errs = append(errs, validateEnum(spec.Child("family"), s.Family, mwan3Families)...)
+		errs = append(errs, validateEnum(spec.Child("sticky"), s.Sticky, firewallSwitches)...)
+		errs = append(errs, validatePositive(spec.Child("timeout"), s.Timeout)...)
+		if s.Policy == "" {
+			errs = append(errs, field.Required(spec.Child("policy"), ""))
+		} else {
+			errs = append(errs, refs.check(spec.Child("policy"), s.Policy, &Mwan3PolicyList{})...)
+		}
+	case *FirewallZone:
+		s := o.Spec
+		if len(o.Name) > maxZoneNameLength {
+			errs = append(errs, field.TooLong(field.NewPath("metadata", "name"), o.Name, maxZoneNameLength))
+		}
+		for _, f := range []struct {
+			name   string
+			value  string
+			values []string
+		}{
+			{"masq", s.Masq, firewallSwitches},
+			{"masq_allow_invalid", s.MasqAllowInvalid, firewallSwitches},
+			{"mtu_fix", s.MtuFix, firewallSwitches},
+			{"input", s.Input, firewallPolicies},
+			{"forward", s.Forward, firewallPolicies},
+			{"output", s.Output, firewallPolicies},
+			{"family", s.Family, firewallFamilies},
+		} {
+			errs = append(errs, validateEnum(spec.Child(f.name), f.value, f.values)...)
+		}
+		for i, ip := range s.MasqSrc {
+			errs = append(errs, validateIp(spec.Child("masq_src").Index(i), strings.TrimPrefix(ip, "!"))...)
+		}
+		for i, ip := range s.MasqDest {
+			errs = append(errs, validateIp(spec.Child("masq_dest").Index(i), strings.TrimPrefix(ip, "!"))...)
+		}
+		for i, ip := range s.Subnet {
+			errs = append(errs, validateIp(spec.Child("subnet").Index(i), ip)...)
+		}
+	case *FirewallForwarding:
+		s := o.Spec
+		errs = append(errs, validateEnum(spec.Child("family"), s.Family, firewallFamilies)...)
+		if s.Src == "" {
+			errs = append(errs, field.Required(spec.Child("src"), ""))
+		}
+		if s.Dest == "" {
+			errs = append(errs, field.Required(spec.Child("dest"), ""))
+		}
+		errs = append(errs, refs.checkZone(spec.Child("src"), s.Src)...)
+		errs = append(errs, refs.checkZone(spec.Child("dest"), s.Dest)...)
+	case *FirewallRule:
+		s := o.Spec
+		errs = append(errs, validateFirewallRule(spec, NetworkFirewallRuleSpec(s))...)
+		errs = append(errs, refs.checkZone(spec.Child("src"), s.Src)...)
+		errs = append(errs, refs.checkZone(spec.Child("dest"), s.Dest)...)
+	case *NetworkFirewallRule:
+		errs = append(errs, validateFirewallRule(spec, o.Spec)...)
+	case *FirewallSNAT:
+		errs = append(errs, validateRedirect(spec, FirewallDNATSpec(o.Spec), "SNAT")...)
+		errs = append(errs, refs.checkZone(spec.Child("src"), o.Spec.Src)...)
+		errs = append(errs, refs.checkZone(spec.Child("dest"), o.Spec.Dest)...)
+	case *FirewallDNAT:
+		errs = append(errs, validateRedirect(spec, o.Spec, "DNAT")...)
+		errs = append(errs, refs.checkZone(spec.Child("src"), o.Spec.Src)...)
+		errs = append(errs, refs.checkZone(spec.Child("dest"), o.Spec.Dest)...)
+	case *CNFNAT:
+		s := o.Spec
+		errs = append(errs, validateIp(spec.Child("src_ip"), s.SrcIp)...)
+		errs = append(errs, validateIp(spec.Child("src_dip"), s.SrcDIp)...)
+		errs = append(errs, validatePort(spec.Child("src_port"), s.SrcPort)...)
+		errs = append(errs, validatePort(spec.Child("src_dport"), s.SrcDPort)...)
+		errs = append(errs, validateIp(spec.Child("dest_ip"), s.DestIp)...)
+		errs = append(errs, validatePort(spec.Child("dest_port"), s.DestPort)...)
+		errs = append(errs, validateEnum(spec.Child("proto"), s.Proto, natProtos)...)
+		errs = append(errs, validateEnum(spec.Child("target"), s.Target, natTargets)...)
+		if s.Index != "" {
+			if n, err := strconv.Atoi(s.Index); err != nil || n < 0 {
+				errs = append(errs, field.Invalid(spec.Child("index"), s.Index, "must be a non-negative integer"))
+			}
+		}
+		if s.Probability != "" {
+			if p, err := strconv.ParseFloat(s.Probability, 64); err != nil || p <= 0 || p > 1 {
+				errs = append(errs, field.Invalid(spec.Child("probability"), s.Probability, "must be greater than 0 and at most 1"))
+			}
+		}
+		if s.Target == "SNAT" {
+			if s.SrcDIp == "" {
+				errs = append(errs, field.Required(spec.Child("src_dip"), "src_dip is required for SNAT"))
+			}
+			if s.Dest == "" {
+				errs = append(errs, field.Required(spec.Child("dest"), "dest is required for SNAT"))
+			}
+		}
+	case *CNFRoute:
+		s := o.Spec
+		if s.Dst == "" {
+			errs = append(errs, field.Required(spec.Child("dst"), ""))
+		} else if s.Dst != "default" {
+			errs = append(errs, validateIp(spec.Child("dst"), s.Dst)...)
+		}
+		errs = append(errs, validateIpAddress(spec.Child("gw"), s.Gw)...)
+	case *CNFRouteRule:
+		s := o.Spec
+		if s.Src == "" && s.Dst == "" {
+			errs = append(errs, field.Required(spec.Child("src"), "src or dst is required"))
+		}
+		errs = append(errs, validateIp(spec.Child("src"), s.Src)...)
+		errs = append(errs, validateIp(spec.Child("dst"), s.Dst)...)
+		errs = append(errs, validatePositive(spec.Child("prio"), s.Prio)...)
+		if s.Table != "" && !contains(routeRuleTables, s.Table) {
+			errs = append(errs, validatePositive(spec.Child("table"), s.Table)...)
+		}
+		if s.Fwmark != "" {
+			if _, err := strconv.ParseUint(strings.TrimPrefix(s.Fwmark, "0x"), 16, 32); err != nil {
+				errs = append(errs, field.Invalid(spec.Child("fwmark"), s.Fwmark, "must be a hexadecimal mark"))
+			}
+		}
+	case *IpsecHost:
+		s := o.Spec
+		errs = append(errs, validateIpsecRemote(spec, s.Remote, s.AuthenticationMethod)...)
+		for i, c := range s.Connections {
+			errs = append(errs, validateIpsecConnection(spec.Child("connections").Index(i), c.ConnectionType, c.Mode, c.LocalFirewall, c.RemoteFirewall)...)
+			refs.expectAll(spec.Child("connections").Index(i).Child("crypto_proposal"), c.CryptoProposal, &IpsecProposalList{})
+		}
+		refs.expectAll(spec.Child("crypto_proposal"), s.CryptoProposal, &IpsecProposalList{})
+	case *IpsecSite:
+		s := o.Spec
+		errs = append(errs, validateIpsecRemote(spec, s.Remote, s.AuthenticationMethod)...)
+		for i, c := range s.Connections {
+			errs = append(errs, validateIpsecConnection(spec.Child("connections").Index(i), c.ConnectionType, c.Mode, c.LocalFirewall, c.RemoteFirewall)...)
+			refs.expectAll(spec.Child("connections").Index(i).Child("crypto_proposal"), c.CryptoProposal, &IpsecProposalList{})
+		}
+		refs.expectAll(spec.Child("crypto_proposal"), s.CryptoProposal, &IpsecProposalList{})
+	case *WireguardInterface:
+		s := o.Spec
+		// the name of the CR is the name of the network device
+		if len(o.Name) > maxIfNameLength {
+			errs = append(errs, field.TooLong(field.NewPath("metadata", "name"), o.Name, maxIfNameLength))
+		}
+		if s.PrivateKey == "" {
+			errs = append(errs, field.Required(spec.Child("private_key"), ""))
+		}
+		errs = append(errs, validateWireguardKey(spec.Child("private_key"), s.PrivateKey)...)
+		errs = append(errs, validatePort(spec.Child("listen_port"), s.ListenPort)...)
+		for i, ip := range s.Addresses {
+			errs = append(errs, validateIp(spec.Child("addresses").Index(i), ip)...)
+		}
+		errs = append(errs, validatePositive(spec.Child("mtu"), s.Mtu)...)
+	case *WireguardPeer:
+		s := o.Spec
+		if s.Interface == "" {
+			errs = append(errs, field.Required(spec.Child("interface"), ""))
+		}
+		if s.PublicKey == "" {
+			errs = append(errs, field.Required(spec.Child("public_key"), ""))
+		}
+		errs = append(errs, validateWireguardKey(spec.Child("public_key"), s.PublicKey)...)
+		errs = append(errs, validateWireguardKey(spec.Child("preshared_key"), s.PresharedKey)...)
+		errs = append(errs, validateHost(spec.Child("endpoint_host"), s.EndpointHost)...)
+		errs = append(errs, validatePort(spec.Child("endpoint_port"), s.EndpointPort)...)
+		if len(s.AllowedIps) == 0 {
+			errs = append(errs, field.Required(spec.Child("allowed_ips"), ""))
+		}
+		for i, ip := range s.AllowedIps {
+			errs = append(errs, validateIp(spec.Child("allowed_ips").Index(i), ip)...)
+		}
+		errs = append(errs, validatePositive(spec.Child("persistent_keepalive"), s.PersistentKeepalive)...)
+		refs.expect(spec.Child("interface"), s.Interface, &WireguardInterfaceList{})
+	case *BgpInstance:
+		s := o.Spec
+		if s.As == "" {
+			errs = append(errs, field.Required(spec.Child("as"), ""))
+		}
+		errs = append(errs, validateAsNumber(spec.Child("as"), s.As)...)
+		if s.RouterId == "" {
+			errs = append(errs, field.Required(spec.Child("router_id"), ""))
+		}
+		errs = append(errs, validateIpAddress(spec.Child("router_id"), s.RouterId)...)
+		for i, ip := range s.Networks {
+			errs = append(errs, validateIp(spec.Child("networks").Index(i), ip)...)
+		}
+		errs = append(errs, validateEnum(spec.Child("table"), s.Table, bgpTables)...)
+	case *BgpNeighbor:
+		s := o.Spec
+		if s.Instance == "" {
+			errs = append(errs, field.Required(spec.Child("instance"), ""))
+		}
+		if s.RemoteAddress == "" {
+			errs = append(errs, field.Required(spec.Child("remote_address"), ""))
+		}
+		errs = append(errs, validateIpAddress(spec.Child("remote_address"), s.RemoteAddress)...)
+		if s.RemoteAs == "" {
+			errs = append(errs, field.Required(spec.Child("remote_as"), ""))
+		}
+		errs = append(errs, validateAsNumber(spec.Child("remote_as"), s.RemoteAs)...)
+		errs = append(errs, validateIpAddress(spec.Child("source_address"), s.SourceAddress)...)
+		if strings.Contains(s.Password, "\"") {
+			errs = append(errs, field.Invalid(spec.Child("password"), "", "must not contain '\"'"))
+		}
+		if s.HoldTime != "" {
+			if n, err := strconv.Atoi(s.HoldTime); err != nil || n < 3 || n > 65535 {
+				errs = append(errs, field.Invalid(spec.Child("hold_time"), s.HoldTime, "must be an integer between 3 and 65535"))
+			}
+		}
+		refs.expect(spec.Child("instance"), s.Instance, &BgpInstanceList{})
+	case *TrafficShapingPolicy:
+		s := o.Spec
+		if s.Network == "" {
+			errs = append(errs, field.Required(spec.Child("network"), ""))
+		}
+		if s.EgressRate == "" {
+			errs = append(errs, field.Required(spec.Child("egress_rate"), ""))
+		}
+		errs = append(errs, validatePositive(spec.Child("egress_rate"), s.EgressRate)...)
+		errs = append(errs, validatePositive(spec.Child("ingress_rate"), s.IngressRate)...)
+		errs = append(errs, validateTrafficClasses(spec.Child("classes"), s.Classes, s.EgressRate)...)
+	case *DhcpPool:
+		s := o.Spec
+		if s.Network == "" {
+			errs = append(errs, field.Required(spec.Child("network"), ""))
+		}
+		if s.Start == "" {
+			errs = append(errs, field.Required(spec.Child("start"), ""))
+		}
+		if s.End == "" {
+			errs = append(errs, field.Required(spec.Child("end"), ""))
+		}
+		errs = append(errs, validateIpAddress(spec.Child("start"), s.Start)...)
+		errs = append(errs, validateIpAddress(spec.Child("end"), s.End)...)
+		start, end := net.ParseIP(s.Start).To4(), net.ParseIP(s.End).To4()
+		if start != nil && end != nil && bytes.Compare(start, end) > 0 {
+			errs = append(errs, field.Invalid(spec.Child("end"), s.End, "must not be lower than start"))
+		}
+		errs = append(errs, validateIpAddress(spec.Child("netmask"), s.Netmask)...)
+		errs = append(errs, validateLeaseTime(spec.Child("lease_time"), s.LeaseTime)...)
+		errs = append(errs, validateIpAddress(spec.Child("gateway"), s.Gateway)...)
+		for i, ip := range s.DnsServers {
+			errs = append(errs, validateIpAddress(spec.Child("dns_servers").Index(i), ip)...)
+		}
+		errs = append(errs, validateDomain(spec.Child("domain"), s.Domain)...)
+	case *DhcpStaticLease:
+		s := o.Spec
+		if s.Mac == "" {
+			errs = append(errs, field.Required(spec.Child("mac"), ""))
+		}
+		errs = append(errs, validateMac(spec.Child("mac"), s.Mac)...)
+		if s.Ip == "" {
+			errs = append(errs, field.Required(spec.Child("ip"), ""))
+		}
+		errs = append(errs, validateIpAddress(spec.Child("ip"), s.Ip)...)
+		if s.Hostname != "" && len(validation.IsDNS1123Label(strings.ToLower(s.Hostname))) > 0 {
+			errs = append(errs, field.Invalid(spec.Child("hostname"), s.Hostname, "must be a host name"))
+		}
+		errs = append(errs, validateLeaseTime(spec.Child("lease_time"), s.LeaseTime)...)
+	case *DnsForwarder:
+		s := o.Spec
+		if s.Domain == "" {
+			errs = append(errs, field.Required(spec.Child("domain"), ""))
+		}
+		errs = append(errs, validateDomain(spec.Child("domain"), s.Domain)...)
+		if len(s.Servers) == 0 {
+			errs = append(errs, field.Required(spec.Child("servers"), ""))
+		}
+		for i, ip := range s.Servers {
+			errs = append(errs, validateIpAddress(spec.Child("servers").Index(i), ip)...)
+		}
+		errs = append(errs, validatePort(spec.Child("port"), s.Port)...)
+	case *DnsRecord:
+		s := o.Spec
+		if s.Domain == "" {
+			errs = append(errs, field.Required(spec.Child("domain"), ""))
+		}
+		errs = append(errs, validateDomain(spec.Child("domain"), s.Domain)...)
+		if s.Ip == "" {
+			errs = append(errs, field.Required(spec.Child("ip"), ""))
+		}
+		errs = append(errs, validateIpAddress(spec.Child("ip"), s.Ip)...)
+	case *CNFStatusAction:
+		s := o.Spec
+		if s.Module == "" {
+			errs = append(errs, field.Required(spec.Child("module"), ""))
+		}
+		errs = append(errs, validateCondition(spec.Child("condition"), s.Condition)...)
+		if s.Action == "" {
+			errs = append(errs, field.Required(spec.Child("action"), ""))
+		}
+		errs = append(errs, validateEnum(spec.Child("action"), s.Action, statusActions)...)
+		if s.Action == RestartServiceAction && s.GetService() == "" {
+			errs = append(errs, field.Required(spec.Child("service"), "module "+s.Module+" has no default service"))
+		}
+		errs = append(errs, validateEnum(spec.Child("service"), s.Service, cnfServices)...)
+		if s.MinInterval != "" {
+			if d, err := time.ParseDuration(s.MinInterval); err != nil || d <= 0 {
+				errs = append(errs, field.Invalid(spec.Child("min_interval"), s.MinInterval, "must be a positive duration, e.g. 10m"))
+			}
+		}
+	}
+
+	return errs, refs.warnings
+}
+
+func validateFirewallRule(spec *field.Path, s NetworkFirewallRuleSpec) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateIp(spec.Child("src_ip"), s.SrcIp)...)
+	errs = append(errs, validateMac(spec.Child("src_mac"), s.SrcMac)...)
+	errs = append(errs, validatePort(spec.Child("src_port"), s.SrcPort)...)
+	errs = append(errs, validateEnum(spec.Child("proto"), s.Proto, firewallProtos)...)
+	for i, t := range s.IcmpType {
+		errs = append(errs, validateEnum(spec.Child("icmp_type").Index(i), t, icmpTypes)...)
+	}
+	errs = append(errs, validateIp(spec.Child("dest_ip"), s.DestIp)...)
+	errs = append(errs, validatePort(spec.Child("dest_port"), s.DestPort)...)
+	errs = append(errs, validateEnum(spec.Child("target"), s.Target, firewallTargets)...)
+	errs = append(errs, validateEnum(spec.Child("family"), s.Family, firewallFamilies)...)
+	if s.Target == "MARK" && s.SetMark == "" && s.SetXmark == "" {
+		errs = append(errs, field.Required(spec.Child("set_mark"), "set_mark or set_xmark is required for MARK"))
+	}
+	return errs
+}
+
+// validateRedirect validates the spec of a FirewallSNAT or FirewallDNAT,
+// the controller sets the target of the redirect to the one of its kind
+func validateRedirect(spec *field.Path, s FirewallDNATSpec, target string) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateIp(spec.Child("src_ip"), s.SrcIp)...)
+	errs = append(errs, validateIp(spec.Child("src_dip"), s.SrcDIp)...)
+	errs = append(errs, validateMac(spec.Child("src_mac"), s.SrcMac)...)
+	errs = append(errs, validatePort(spec.Child("src_port"), s.SrcPort)...)
+	errs = append(errs, validatePort(spec.Child("src_dport"), s.SrcDPort)...)
+	errs = append(errs, validateEnum(spec.Child("proto"), s.Proto, firewallProtos)...)
+	errs = append(errs, validateIp(spec.Child("dest_ip"), s.DestIp)...)
+	errs = append(errs, validatePort(spec.Child("dest_port"), s.DestPort)...)
+	errs = append(errs, validateEnum(spec.Child("family"), s.Family, firewallFamilies)...)
+	if target == "SNAT" {
+		if s.SrcDIp == "" {
+			errs = append(errs, field.Required(spec.Child("src_dip"), "src_dip is required for SNAT"))
+		}
+		if s.Dest == "" {
+			errs = append(errs, field.Required(spec.Child("dest"), "dest is required for SNAT"))
+		}
+	} else if s.Src == "" {
+		errs = append(errs, field.Required(spec.Child("src"), "src is required for DNAT"))
+	}
+	return errs
+}
+
+func validateIpsecRemote(spec *field.Path, remote string, auth string) field.ErrorList {
+	var errs field.ErrorList
+	if remote == "" {
+		errs = append(errs, field.Required(spec.Child("remote"), ""))
+	} else if remote != "%any" && net.ParseIP(remote) == nil && len(validation.IsDNS1123Subdomain(remote)) > 0 {
+		errs = append(errs, field.Invalid(spec.Child("remote"), remote, "must be %any, an IP address or a host name"))
+	}
+	if auth == "" {
+		errs = append(errs, field.Required(spec.Child("authentication_method"), ""))
+	} else {
+		errs = append(errs, validateEnum(spec.Child("authentication_method"), auth, ipsecAuthMethods)...)
+	}
+	return errs
+}
+
+func validateIpsecConnection(p *field.Path, conn_type string, mode string, local_firewall string, remote_firewall string) field.ErrorList {
+	var errs field.ErrorList
+	if conn_type == "" {
+		errs = append(errs, field.Required(p.Child("conn_type"), ""))
+	}
+	if mode == "" {
+		errs = append(errs, field.Required(p.Child("mode"), ""))
+	}
+	errs = append(errs, validateEnum(p.Child("conn_type"), conn_type, ipsecConnTypes)...)
+	errs = append(errs, validateEnum(p.Child("mode"), mode, ipsecConnModes)...)
+	errs = append(errs, validateEnum(p.Child("local_firewall"), local_firewall, ipsecYesNo)...)
+	errs = append(errs, validateEnum(p.Child("remote_firewall"), remote_firewall, ipsecYesNo)...)
+	return errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEnum checks that the value is one of the values if set
+func validateEnum(p *field.Path, value string, values []string) field.ErrorList {
+	if value == "" || contains(values, value) {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(p, value, values)}
+}
+
+// validateIpAddress checks an IPv4 address if set
+func validateIpAddress(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil || ip.To4() == nil {
+		return field.ErrorList{field.Invalid(p, value, "must be an IPv4 address")}
+	}
+	return nil
+}
+
+// validateIp checks an IPv4 address or CIDR if set
+func validateIp(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if strings.Contains(value, "/") {
+		ip, _, err := net.ParseCIDR(value)
+		if err != nil || ip.To4() == nil {
+			return field.ErrorList{field.Invalid(p, value, "must be an IPv4 address or CIDR")}
+		}
+		return nil
+	}
+	if len(validateIpAddress(p, value)) > 0 {
+		return field.ErrorList{field.Invalid(p, value, "must be an IPv4 address or CIDR")}
+	}
+	return nil
+}
+
+func validateMac(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if mac, err := net.ParseMAC(value); err != nil || len(mac) != 6 {
+		return field.ErrorList{field.Invalid(p, value, "must be a MAC address")}
+	}
+	return nil
+}
+
+// validatePort checks a port if set, the CNF does not accept port ranges
+func validatePort(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if port, err := strconv.Atoi(value); err != nil || len(validation.IsValidPortNum(port)) > 0 {
+		return field.ErrorList{field.Invalid(p, value, "must be a port between 1 and 65535")}
+	}
+	return nil
+}
+
+// validateHost checks an IPv4 address or a host name if set
+func validateHost(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+		return nil
+	}
+	if len(validation.IsDNS1123Subdomain(strings.ToLower(value))) > 0 {
+		return field.ErrorList{field.Invalid(p, value, "must be an IPv4 address or a host name")}
+	}
+	return nil
+}
+
+// validateWireguardKey checks a WireGuard key if set, keys are the base64
+// encoding of 32 bytes
+func validateWireguardKey(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(value); err != nil || len(key) != 32 {
+		return field.ErrorList{field.Invalid(p, "", "must be a base64 encoded 32 bytes key")}
+	}
+	return nil
+}
+
+// validateAsNumber checks a 32 bits AS number if set
+func validateAsNumber(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if n, err := strconv.ParseUint(value, 10, 32); err != nil || n == 0 || n == 4294967295 {
+		return field.ErrorList{field.Invalid(p, value, "must be an AS number between 1 and 4294967294")}
+	}
+	return nil
+}
+
+// validateTrafficClasses validates the classes of a TrafficShapingPolicy,
+// the guaranteed rates of the classes must fit in the egress rate
+func validateTrafficClasses(p *field.Path, classes []TrafficClass, egressRate string) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	total := 0
+	for i, c := range classes {
+		cp := p.Index(i)
+		if c.Name == "" {
+			errs = append(errs, field.Required(cp.Child("name"), ""))
+		} else if names[c.Name] {
+			errs = append(errs, field.Duplicate(cp.Child("name"), c.Name))
+		}
+		names[c.Name] = true
+		if c.Priority != "" {
+			if n, err := strconv.Atoi(c.Priority); err != nil || n < 0 || n > 7 {
+				errs = append(errs, field.Invalid(cp.Child("priority"), c.Priority, "must be an integer between 0 and 7"))
+			}
+		}
+		if c.Rate == "" {
+			errs = append(errs, field.Required(cp.Child("rate"), ""))
+		}
+		errs = append(errs, validatePositive(cp.Child("rate"), c.Rate)...)
+		errs = append(errs, validatePositive(cp.Child("ceil"), c.Ceil)...)
+		rate, _ := strconv.Atoi(c.Rate)
+		if ceil, err := strconv.Atoi(c.Ceil); err == nil && ceil < rate {
+			errs = append(errs, field.Invalid(cp.Child("ceil"), c.Ceil, "must not be lower than rate"))
+		}
+		total += rate
+		errs = append(errs, validateDscp(cp.Child("dscp"), c.Dscp)...)
+		errs = append(errs, validateEnum(cp.Child("proto"), c.Proto, qosProtos)...)
+		errs = append(errs, validateIp(cp.Child("src_ip"), c.SrcIp)...)
+		errs = append(errs, validatePort(cp.Child("src_port"), c.SrcPort)...)
+		errs = append(errs, validateIp(cp.Child("dest_ip"), c.DestIp)...)
+		errs = append(errs, validatePort(cp.Child("dest_port"), c.DestPort)...)
+	}
+	if egress, err := strconv.Atoi(egressRate); err == nil && total > egress {
+		errs = append(errs, field.Invalid(p, total, "the sum of the rates must not exceed egress_rate"))
+	}
+	return errs
+}
+
+// validateDscp checks a DSCP value (0-63) or name if set
+func validateDscp(p *field.Path, value string) field.ErrorList {
+	if value == "" || contains(dscpNames, value) {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err != nil || n < 0 || n > 63 {
+		return field.ErrorList{field.Invalid(p, value, "must be a DSCP value between 0 and 63 or a DSCP name")}
+	}
+	return nil
+}
+
+// validateLeaseTime checks a DHCP lease time if set: seconds, a number with
+// a unit (s, m, h, d or w) or infinite
+func validateLeaseTime(p *field.Path, value string) field.ErrorList {
+	if value == "" || value == "infinite" || leaseTimeRegexp.MatchString(value) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(p, value, "must be a number of seconds, a number with a unit (s, m, h, d or w) or infinite")}
+}
+
+// validateDomain checks a DNS domain if set
+func validateDomain(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if len(validation.IsDNS1123Subdomain(strings.ToLower(value))) > 0 {
+		return field.ErrorList{field.Invalid(p, value, "must be a domain name")}
+	}
+	return nil
+}
+
+// validateCondition checks a JSONPath template, e.g. {.InitConnection}
+func validateCondition(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(p, "")}
+	}
+	// a template without expression is plain text which always matches
+	if !strings.Contains(value, "{") {
+		return field.ErrorList{field.Invalid(p, value, "must be a JSONPath template, e.g. {.InitConnection}")}
+	}
+	if err := jsonpath.New("condition").Parse(value); err != nil {
+		return field.ErrorList{field.Invalid(p, value, err.Error())}
+	}
+	return nil
+}
+
+// validatePositive checks a positive integer if set
+func validatePositive(p *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+		return field.ErrorList{field.Invalid(p, value, "must be an integer greater than 0")}
+	}
+	return nil
+}
+
+// specReferences checks that the CRs referenced by an object exist in its
+// namespace for the same sdewanPurpose. A missing reference rejects the
+// object, except for the CRs deployed together with it (see expect)
+type specReferences struct {
+	client   client.Client
+	ctx      context.Context
+	obj      client.Object
+	names    map[string]map[string]bool
+	warnings []string
+}
+
+// getNames returns the names of the CRs of the list, nil if they can not be
+// listed
+func (r *specReferences) getNames(list client.ObjectList) map[string]bool {
+	kind := fmt.Sprintf("%T", list)
+	if names, ok := r.names[kind]; ok {
+		return names
+	}
+
+	if r.names == nil {
+		r.names = make(map[string]map[string]bool)
+	}
+	err := r.client.List(r.ctx, list, client.InNamespace(r.obj.GetNamespace()),
+		client.MatchingLabels{"sdewanPurpose": r.obj.GetLabels()["sdewanPurpose"]})
+	if err != nil {
+		speclog.Error(err, "Failed to check the references", "name", r.obj.GetName())
+		r.names[kind] = nil
+		return nil
+	}
+	items, err := extractItems(list)
+	if err != nil {
+		speclog.Error(err, "Failed to check the references", "name", r.obj.GetName())
+		r.names[kind] = nil
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for _, item := range items {
+		names[item.GetName()] = true
+	}
+	r.names[kind] = names
+	return names
+}
+
+// missing returns the kind of the list if the CR name of the list does not
+// exist, the references are not checked if the CRs can not be listed
+func (r *specReferences) missing(name string, list client.ObjectList) (string, bool) {
+	names := r.getNames(list)
+	if name == "" || names == nil || names[name] {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(fmt.Sprintf("%T", list), "*v1alpha1."), "List"), true
+}
+
+// check rejects a reference to a CR which does not exist, the CNF would
+// refuse to apply the object
+func (r *specReferences) check(p *field.Path, name string, list client.ObjectList) field.ErrorList {
+	kind, missing := r.missing(name, list)
+	if !missing {
+		return nil
+	}
+	return field.ErrorList{field.NotFound(p, fmt.Sprintf("%s %s of sdewanPurpose %s", kind, name,
+		r.obj.GetLabels()["sdewanPurpose"]))}
+}
+
+// expect only warns of a reference to a CR which does not exist. It is used
+// for the CRs which SCC deploys in one app together with the object (the
+// IpsecProposals of an IpsecSite or IpsecHost, the WireguardInterface of a
+// WireguardPeer and the BgpInstance of a BgpNeighbor), they are created in
+// no particular order and the controller retries the object once they exist
+func (r *specReferences) expect(p *field.Path, name string, list client.ObjectList) {
+	kind, missing := r.missing(name, list)
+	if !missing {
+		return
+	}
+	r.warnings = append(r.warnings, fmt.Sprintf("%s: %s %s of sdewanPurpose %s does not exist yet",
+		p.String(), kind, name, r.obj.GetLabels()["sdewanPurpose"]))
+}
+
+func (r *specReferences) expectAll(p *field.Path, names []string, list client.ObjectList) {
+	for i, name := range names {
+		r.expect(p.Index(i), name, list)
+	}
+}
+
+// checkZone checks a zone reference, * stands for any zone
+func (r *specReferences) checkZone(p *field.Path, name string) field.ErrorList {
+	if name == "*" {
+		return nil
+	}
+	return r.check(p, name, &FirewallZoneList{})
+}
+
+// extractItems returns the items of a list
+func extractItems(list client.ObjectList) ([]client.Object, error) {
+	objs, err := apimeta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []client.Object{}
+	for _, o := range objs {
+		if item, ok := o.(client.Object); ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// specValidator implements admission.DecoderInjector.
+// A decoder will be automatically injected.
+
+// InjectDecoder injects the decoder.
+func (v *specValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
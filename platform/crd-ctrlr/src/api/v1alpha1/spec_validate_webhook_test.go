@@ -0,0 +1,442 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// startSpecValidateEnv starts an API server with the SDEWAN CRDs and the
+// spec validating webhook only, it needs the envtest binaries in
+// KUBEBUILDER_ASSETS
+func startSpecValidateEnv(t *testing.T) client.Client {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	failPolicy := admissionv1.Fail
+	sideEffects := admissionv1.SideEffectClassNone
+	path := "/validate-sdewan-spec"
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			ValidatingWebhooks: []*admissionv1.ValidatingWebhookConfiguration{{
+				ObjectMeta: metav1.ObjectMeta{Name: "validating-webhook-configuration"},
+				TypeMeta:   metav1.TypeMeta{Kind: "ValidatingWebhookConfiguration", APIVersion: "admissionregistration.k8s.io/v1"},
+				Webhooks: []admissionv1.ValidatingWebhook{{
+					Name:                    "validate-sdewan-spec.akraino.org",
+					AdmissionReviewVersions: []string{"v1"},
+					ClientConfig: admissionv1.WebhookClientConfig{
+						Service: &admissionv1.ServiceReference{Name: "webhook-service", Namespace: "system", Path: &path},
+					},
+					FailurePolicy: &failPolicy,
+					SideEffects:   &sideEffects,
+					Rules: []admissionv1.RuleWithOperations{{
+						Operations: []admissionv1.OperationType{admissionv1.Create, admissionv1.Update},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{GroupVersion.Group},
+							APIVersions: []string{GroupVersion.Version},
+							Resources:   []string{"mwan3policies", "mwan3rules", "firewallzones", "firewallforwardings", "cnfroutes"},
+						},
+					}},
+				}},
+			}},
+		},
+	}
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("Failed to start the test environment: %v", err)
+	}
+	t.Cleanup(func() {
+		testEnv.Stop()
+	})
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	AddToScheme(scheme)
+
+	opts := &testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme,
+		Host:               opts.LocalServingHost,
+		Port:               opts.LocalServingPort,
+		CertDir:            opts.LocalServingCertDir,
+		LeaderElection:     false,
+		MetricsBindAddress: "0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create the manager: %v", err)
+	}
+	SetupSpecValidateWebhookWithManager(mgr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go mgr.Start(ctx)
+
+	// wait for the webhook server
+	addr := fmt.Sprintf("%s:%d", opts.LocalServingHost, opts.LocalServingPort)
+	dialer := &net.Dialer{Timeout: time.Second}
+	for i := 0; ; i++ {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{InsecureSkipVerify: true})
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if i == 30 {
+			t.Fatalf("Webhook server is not ready: %v", err)
+		}
+		time.Sleep(time.Second)
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		t.Fatalf("Failed to create the client: %v", err)
+	}
+	return c
+}
+
+func specObjectMeta(name string, purpose string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "default",
+		Labels:    map[string]string{"sdewanPurpose": purpose},
+	}
+}
+
+// expectDenied checks that the error is a rejection with all the messages
+func expectDenied(t *testing.T, err error, messages ...string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("Expected the object to be rejected")
+	}
+	for _, m := range messages {
+		if !strings.Contains(err.Error(), m) {
+			t.Errorf("Expected %q in the rejection: %v", m, err)
+		}
+	}
+}
+
+func TestSpecValidateWebhook(t *testing.T) {
+	c := startSpecValidateEnv(t)
+	ctx := context.Background()
+
+	t.Run("Mwan3Policy", func(t *testing.T) {
+		policy := &Mwan3Policy{
+			ObjectMeta: specObjectMeta("balance", "cnf1"),
+			Spec:       Mwan3PolicySpec{Members: []Mwan3PolicyMember{{Network: "net0", Metric: 0, Weight: 2}}},
+		}
+		expectDenied(t, c.Create(ctx, policy), "spec.members[0].metric")
+
+		policy.Spec.Members[0].Metric = 1
+		if err := c.Create(ctx, policy); err != nil {
+			t.Fatalf("Failed to create a valid Mwan3Policy: %v", err)
+		}
+	})
+
+	t.Run("Mwan3Rule", func(t *testing.T) {
+		rule := &Mwan3Rule{
+			ObjectMeta: specObjectMeta("rule1", "cnf1"),
+			Spec: Mwan3RuleSpec{
+				Policy:   "missing",
+				SrcIp:    "10.10.10.300",
+				DestPort: "80-70000",
+				Proto:    "gre",
+			},
+		}
+		expectDenied(t, c.Create(ctx, rule), "spec.src_ip", "spec.dest_port", "spec.proto")
+
+		// the policy may be created after the rule
+		rule.Spec = Mwan3RuleSpec{Policy: "missing", SrcIp: "10.10.10.0/24", DestPort: "8080", Proto: "tcp"}
+		if err := c.Create(ctx, rule); err != nil {
+			t.Fatalf("Failed to create a valid Mwan3Rule: %v", err)
+		}
+	})
+
+	t.Run("FirewallForwarding", func(t *testing.T) {
+		zone := &FirewallZone{
+			ObjectMeta: specObjectMeta("zone-with-long-name", "cnf1"),
+			Spec:       FirewallZoneSpec{Network: []string{"net0"}, Input: "ALLOW"},
+		}
+		expectDenied(t, c.Create(ctx, zone), "metadata.name", "spec.input")
+
+		for _, name := range []string{"lan", "wan"} {
+			zone := &FirewallZone{
+				ObjectMeta: specObjectMeta(name, "cnf1"),
+				Spec:       FirewallZoneSpec{Network: []string{name}, Input: "ACCEPT"},
+			}
+			if err := c.Create(ctx, zone); err != nil {
+				t.Fatalf("Failed to create a valid FirewallZone: %v", err)
+			}
+		}
+
+		forwarding := &FirewallForwarding{
+			ObjectMeta: specObjectMeta("lan-wan", "cnf1"),
+			Spec:       FirewallForwardingSpec{Src: "lan", Dest: "dmz"},
+		}
+		if err := c.Create(ctx, forwarding); err != nil {
+			t.Fatalf("Failed to create a valid FirewallForwarding: %v", err)
+		}
+	})
+
+	t.Run("CNFRoute", func(t *testing.T) {
+		route := &CNFRoute{
+			ObjectMeta: specObjectMeta("route1", "cnf1"),
+			Spec:       CNFRouteSpec{Dst: "10.10.0.0/33", Gw: "10.10.0.1/24", Dev: "net0"},
+		}
+		expectDenied(t, c.Create(ctx, route), "spec.dst", "spec.gw")
+
+		route.Spec = CNFRouteSpec{Dst: "default", Gw: "10.10.0.1", Dev: "net0"}
+		if err := c.Create(ctx, route); err != nil {
+			t.Fatalf("Failed to create a valid CNFRoute: %v", err)
+		}
+	})
+}
+
+func TestValidatePort(t *testing.T) {
+	p := field.NewPath("spec", "port")
+	for value, valid := range map[string]bool{
+		"":        true,
+		"80":      true,
+		"80-8080": false,
+		"0":       false,
+		"65536":   false,
+		"http":    false,
+		"80-":     false,
+	} {
+		if errs := validatePort(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validatePort(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestValidateWireguardKey(t *testing.T) {
+	p := field.NewPath("spec", "public_key")
+	for value, valid := range map[string]bool{
+		"": true,
+		"yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk=": true,
+		"yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBm==": false,
+		"yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3f":     false,
+		"not a key": false,
+	} {
+		if errs := validateWireguardKey(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validateWireguardKey(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestValidateAsNumber(t *testing.T) {
+	p := field.NewPath("spec", "as")
+	for value, valid := range map[string]bool{
+		"":           true,
+		"65000":      true,
+		"4294967294": true,
+		"0":          false,
+		"4294967295": false,
+		"-1":         false,
+		"AS65000":    false,
+	} {
+		if errs := validateAsNumber(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validateAsNumber(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestValidateDscp(t *testing.T) {
+	p := field.NewPath("spec", "classes").Index(0).Child("dscp")
+	for value, valid := range map[string]bool{
+		"":     true,
+		"0":    true,
+		"63":   true,
+		"EF":   true,
+		"AF41": true,
+		"64":   false,
+		"-1":   false,
+		"ef":   false,
+	} {
+		if errs := validateDscp(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validateDscp(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestValidateLeaseTime(t *testing.T) {
+	p := field.NewPath("spec", "lease_time")
+	for value, valid := range map[string]bool{
+		"":         true,
+		"3600":     true,
+		"12h":      true,
+		"infinite": true,
+		"12H":      false,
+		"1h30m":    false,
+		"-1":       false,
+	} {
+		if errs := validateLeaseTime(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validateLeaseTime(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestValidateCondition(t *testing.T) {
+	p := field.NewPath("spec", "condition")
+	for value, valid := range map[string]bool{
+		"{.InitConnection}":                    true,
+		"{.interfaces.*.status}":               true,
+		"{.zones[?(@ == \"wan\")]}":            true,
+		"":                                     false,
+		".InitConnection":                      false,
+		"{.InitConnection":                     false,
+		"{range .interfaces[*]}{.status}{end}": true,
+	} {
+		if errs := validateCondition(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validateCondition(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestValidateIp(t *testing.T) {
+	p := field.NewPath("spec", "ip")
+	for value, valid := range map[string]bool{
+		"":               true,
+		"192.168.1.1":    true,
+		"192.168.1.0/24": true,
+		"192.168.1.0/33": false,
+		"192.168.1":      false,
+		"fe80::1":        false,
+	} {
+		if errs := validateIp(p, value); (len(errs) == 0) != valid {
+			t.Errorf("validateIp(%q) = %v, expected valid %v", value, errs, valid)
+		}
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&Mwan3Policy{ObjectMeta: specObjectMeta("balance", "cnf1")},
+		&FirewallZone{ObjectMeta: specObjectMeta("lan", "cnf1")},
+		&WireguardInterface{ObjectMeta: specObjectMeta("wg0", "cnf1")},
+	).Build()
+	v := &specValidator{Client: c}
+	key := "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="
+
+	tcases := []struct {
+		name     string
+		obj      client.Object
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "Mwan3Rule",
+			obj: &Mwan3Rule{ObjectMeta: specObjectMeta("rule1", "cnf1"),
+				Spec: Mwan3RuleSpec{Policy: "balance", SrcIp: "10.10.10.0/24", DestPort: "8080", Proto: "tcp"}},
+		},
+		{
+			name: "Mwan3RuleInvalid",
+			obj: &Mwan3Rule{ObjectMeta: specObjectMeta("rule1", "cnf1"),
+				Spec: Mwan3RuleSpec{Policy: "balance", SrcIp: "10.10.10.300", DestPort: "80-8080", Proto: "gre"}},
+			errors: []string{"spec.src_ip", "spec.dest_port", "spec.proto"},
+		},
+		{
+			name: "Mwan3RuleMissingPolicy",
+			obj: &Mwan3Rule{ObjectMeta: specObjectMeta("rule1", "cnf1"),
+				Spec: Mwan3RuleSpec{Policy: "missing"}},
+			errors: []string{"spec.policy: Not found: \"Mwan3Policy missing of sdewanPurpose cnf1\""},
+		},
+		{
+			name: "Mwan3RuleOtherPurpose",
+			obj: &Mwan3Rule{ObjectMeta: specObjectMeta("rule1", "cnf2"),
+				Spec: Mwan3RuleSpec{Policy: "balance"}},
+			errors: []string{"spec.policy: Not found: \"Mwan3Policy balance of sdewanPurpose cnf2\""},
+		},
+		{
+			name: "FirewallForwarding",
+			obj: &FirewallForwarding{ObjectMeta: specObjectMeta("lan-wan", "cnf1"),
+				Spec: FirewallForwardingSpec{Src: "lan", Dest: "*"}},
+		},
+		{
+			name: "FirewallForwardingMissingZone",
+			obj: &FirewallForwarding{ObjectMeta: specObjectMeta("lan-wan", "cnf1"),
+				Spec: FirewallForwardingSpec{Src: "lan", Dest: "wan"}},
+			errors: []string{"spec.dest: Not found: \"FirewallZone wan of sdewanPurpose cnf1\""},
+		},
+		{
+			name: "FirewallRuleMissingZone",
+			obj: &FirewallRule{ObjectMeta: specObjectMeta("allow-ssh", "cnf1"),
+				Spec: FirewallRuleSpec{Src: "wan", Dest: "lan", Target: "ACCEPT"}},
+			errors: []string{"spec.src: Not found: \"FirewallZone wan of sdewanPurpose cnf1\""},
+		},
+		{
+			name: "WireguardPeer",
+			obj: &WireguardPeer{ObjectMeta: specObjectMeta("peer1", "cnf1"),
+				Spec: WireguardPeerSpec{Interface: "wg0", PublicKey: key, AllowedIps: []string{"10.0.0.2/32"},
+					EndpointHost: "peer.example.com", EndpointPort: "51820"}},
+		},
+		{
+			// the interface is deployed together with the peer
+			name: "WireguardPeerMissingInterface",
+			obj: &WireguardPeer{ObjectMeta: specObjectMeta("peer1", "cnf1"),
+				Spec: WireguardPeerSpec{Interface: "wg1", PublicKey: key, AllowedIps: []string{"10.0.0.2/32"}}},
+			warnings: []string{"spec.interface: WireguardInterface wg1 of sdewanPurpose cnf1 does not exist yet"},
+		},
+		{
+			name: "IpsecSiteMissingProposal",
+			obj: &IpsecSite{ObjectMeta: specObjectMeta("site1", "cnf1"),
+				Spec: IpsecSiteSpec{Remote: "10.10.10.1", AuthenticationMethod: "psk", CryptoProposal: []string{"ike1"}}},
+			warnings: []string{"spec.crypto_proposal[0]: IpsecProposal ike1 of sdewanPurpose cnf1 does not exist yet"},
+		},
+		{
+			name: "WireguardPeerInvalidEndpoint",
+			obj: &WireguardPeer{ObjectMeta: specObjectMeta("peer1", "cnf1"),
+				Spec: WireguardPeerSpec{Interface: "wg0", PublicKey: key, AllowedIps: []string{"10.0.0.2/32"},
+					EndpointHost: "1.2.3.4; reboot", EndpointPort: "51820-51821"}},
+			errors: []string{"spec.endpoint_host", "spec.endpoint_port"},
+		},
+		{
+			name: "DnsRecord",
+			obj: &DnsRecord{ObjectMeta: specObjectMeta("printer", "cnf1"),
+				Spec: DnsRecordSpec{Domain: "printer.branch.example.com", Ip: "192.168.1.20"}},
+		},
+		{
+			name: "DnsRecordInvalid",
+			obj: &DnsRecord{ObjectMeta: specObjectMeta("printer", "cnf1"),
+				Spec: DnsRecordSpec{Domain: "printer/branch", Ip: "192.168.1.300"}},
+			errors: []string{"spec.domain", "spec.ip"},
+		},
+	}
+
+	for _, tcase := range tcases {
+		errs, warnings := v.validate(context.Background(), tcase.obj)
+		if len(errs) != len(tcase.errors) {
+			t.Errorf("%s: validate() = %v, expected errors %v", tcase.name, errs, tcase.errors)
+		}
+		for i := range errs {
+			if i < len(tcase.errors) && !strings.Contains(errs[i].Error(), tcase.errors[i]) {
+				t.Errorf("%s: validate() = %v, expected errors %v", tcase.name, errs, tcase.errors)
+			}
+		}
+		if strings.Join(warnings, ";") != strings.Join(tcase.warnings, ";") {
+			t.Errorf("%s: validate() warnings = %v, expected %v", tcase.name, warnings, tcase.warnings)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrafficClass is a class of the egress traffic, the packets matching all
+// the fields set are in the class
+type TrafficClass struct {
+	Name string `json:"name"`
+	// 0 (highest) to 7 (lowest), 3 by default
+	// +optional
+	Priority string `json:"priority,omitempty"`
+	// guaranteed rate in kbit/s
+	Rate string `json:"rate"`
+	// maximum rate in kbit/s, the egress rate of the policy by default
+	// +optional
+	Ceil string `json:"ceil,omitempty"`
+	// DSCP value (0-63) or name (e.g. EF, AF41, CS1)
+	// +optional
+	Dscp string `json:"dscp,omitempty"`
+	// +optional
+	Proto string `json:"proto,omitempty"`
+	// +optional
+	SrcIp string `json:"src_ip,omitempty"`
+	// +optional
+	SrcPort string `json:"src_port,omitempty"`
+	// +optional
+	DestIp string `json:"dest_ip,omitempty"`
+	// +optional
+	DestPort string `json:"dest_port,omitempty"`
+}
+
+// TrafficShapingPolicySpec defines the desired state of TrafficShapingPolicy
+type TrafficShapingPolicySpec struct {
+	// network of the CNF, a network has a single policy
+	Network string `json:"network"`
+	// egress rate of the network in kbit/s
+	EgressRate string `json:"egress_rate"`
+	// the ingress traffic above the rate (kbit/s) is dropped
+	// +optional
+	IngressRate string `json:"ingress_rate,omitempty"`
+	// the traffic not matching any class gets the egress rate left with the
+	// lowest priority
+	// +optional
+	Classes []TrafficClass `json:"classes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TrafficShapingPolicy is the Schema for the trafficshapingpolicies API
+type TrafficShapingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrafficShapingPolicySpec `json:"spec,omitempty"`
+	Status SdewanStatus             `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TrafficShapingPolicyList contains a list of TrafficShapingPolicy
+type TrafficShapingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficShapingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrafficShapingPolicy{}, &TrafficShapingPolicyList{})
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WireguardInterfaceSpec defines the desired state of WireguardInterface,
+// the name of the CR is used as the name of the network device in the CNF
+type WireguardInterfaceSpec struct {
+	// base64 encoded private key of the interface
+	PrivateKey string `json:"private_key"`
+	// +optional
+	ListenPort string `json:"listen_port,omitempty"`
+	// IPv4 addresses (CIDR) of the interface
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+	// +optional
+	Mtu string `json:"mtu,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// WireguardInterface is the Schema for the wireguardinterfaces API
+type WireguardInterface struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WireguardInterfaceSpec `json:"spec,omitempty"`
+	Status SdewanStatus           `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WireguardInterfaceList contains a list of WireguardInterface
+type WireguardInterfaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WireguardInterface `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WireguardInterface{}, &WireguardInterfaceList{})
+}
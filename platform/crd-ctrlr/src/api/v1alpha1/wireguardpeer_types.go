@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WireguardPeerSpec defines the desired state of WireguardPeer
+type WireguardPeerSpec struct {
+	// name of the WireguardInterface of the peer
+	Interface string `json:"interface"`
+	// base64 encoded public key of the peer
+	PublicKey string `json:"public_key"`
+	// +optional
+	PresharedKey string `json:"preshared_key,omitempty"`
+	// +optional
+	EndpointHost string `json:"endpoint_host,omitempty"`
+	// +optional
+	EndpointPort string `json:"endpoint_port,omitempty"`
+	// IPv4 addresses (CIDR) allowed from and routed to the peer
+	AllowedIps []string `json:"allowed_ips"`
+	// +optional
+	PersistentKeepalive string `json:"persistent_keepalive,omitempty"`
+	// add routes to the allowed ips through the interface
+	// +optional
+	RouteAllowedIps bool `json:"route_allowed_ips,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// WireguardPeer is the Schema for the wireguardpeers API
+type WireguardPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WireguardPeerSpec `json:"spec,omitempty"`
+	Status SdewanStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WireguardPeerList contains a list of WireguardPeer
+type WireguardPeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WireguardPeer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WireguardPeer{}, &WireguardPeerList{})
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this CNFNAT to the Hub version (v1alpha1)
+func (src *CNFNAT) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.CNFNAT)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = cnfnatSpecTo(src.Spec)
+	kept := v1alpha1.CNFNATSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(cnfnatSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *CNFNAT) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.CNFNAT)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = cnfnatSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, cnfnatSpecTo(dst.Spec))
+}
+
+func cnfnatSpecTo(s CNFNATSpec) v1alpha1.CNFNATSpec {
+	return v1alpha1.CNFNATSpec{
+		Name:        s.Name,
+		Src:         s.Src,
+		SrcIp:       s.SrcIp,
+		SrcDIp:      s.SrcDIp,
+		Proto:       s.Proto,
+		Dest:        s.Dest,
+		DestIp:      s.DestIp,
+		Target:      s.Target,
+		Probability: s.Probability,
+		SrcPort:     int32ToString(s.SrcPort),
+		SrcDPort:    int32ToString(s.SrcDPort),
+		DestPort:    int32ToString(s.DestPort),
+		Index:       int32ToString(s.Index),
+	}
+}
+
+func cnfnatSpecFrom(s v1alpha1.CNFNATSpec) CNFNATSpec {
+	return CNFNATSpec{
+		Name:        s.Name,
+		Src:         s.Src,
+		SrcIp:       s.SrcIp,
+		SrcDIp:      s.SrcDIp,
+		Proto:       s.Proto,
+		Dest:        s.Dest,
+		DestIp:      s.DestIp,
+		Target:      s.Target,
+		Probability: s.Probability,
+		SrcPort:     int32FromString(s.SrcPort),
+		SrcDPort:    int32FromString(s.SrcDPort),
+		DestPort:    int32FromString(s.DestPort),
+		Index:       int32FromString(s.Index),
+	}
+}
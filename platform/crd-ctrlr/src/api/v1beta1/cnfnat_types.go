@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CNFNATSpec defines the desired state of CNFNAT
+type CNFNATSpec struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Src string `json:"src,omitempty"`
+	// +optional
+	SrcIp string `json:"src_ip,omitempty"`
+	// +optional
+	SrcDIp string `json:"src_dip,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SrcPort *int32 `json:"src_port,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SrcDPort *int32 `json:"src_dport,omitempty"`
+	// +kubebuilder:validation:Enum=tcp;udp;tcpudp;udplite;icmp;esp;ah;sctp;all
+	// +optional
+	Proto string `json:"proto,omitempty"`
+	// +optional
+	Dest string `json:"dest,omitempty"`
+	// +optional
+	DestIp string `json:"dest_ip,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	DestPort *int32 `json:"dest_port,omitempty"`
+	// +kubebuilder:validation:Enum=DNAT;SNAT;MASQUERADE
+	// +optional
+	Target string `json:"target,omitempty"`
+	// Position of the rule in the chain
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Index *int32 `json:"index,omitempty"`
+	// Probability of the rule to match, greater than 0 and at most 1
+	// +kubebuilder:validation:Pattern=`^(0?\.[0-9]*[1-9][0-9]*|1(\.0*)?)$`
+	// +optional
+	Probability string `json:"probability,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CNFNAT is the Schema for the cnfnats API
+type CNFNAT struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CNFNATSpec   `json:"spec,omitempty"`
+	Status SdewanStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CNFNATList contains a list of CNFNAT
+type CNFNATList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CNFNAT `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CNFNAT{}, &CNFNATList{})
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this CNFRouteRule to the Hub version (v1alpha1)
+func (src *CNFRouteRule) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.CNFRouteRule)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = cnfRouteRuleSpecTo(src.Spec)
+	kept := v1alpha1.CNFRouteRuleSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(cnfRouteRuleSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *CNFRouteRule) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.CNFRouteRule)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = cnfRouteRuleSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, cnfRouteRuleSpecTo(dst.Spec))
+}
+
+func cnfRouteRuleSpecTo(s CNFRouteRuleSpec) v1alpha1.CNFRouteRuleSpec {
+	return v1alpha1.CNFRouteRuleSpec{
+		Src:    s.Src,
+		Dst:    s.Dst,
+		Not:    s.Not,
+		Fwmark: s.Fwmark,
+		Table:  s.Table,
+		Prio:   int32ToString(s.Prio),
+	}
+}
+
+func cnfRouteRuleSpecFrom(s v1alpha1.CNFRouteRuleSpec) CNFRouteRuleSpec {
+	return CNFRouteRuleSpec{
+		Src:    s.Src,
+		Dst:    s.Dst,
+		Not:    s.Not,
+		Fwmark: s.Fwmark,
+		Table:  s.Table,
+		Prio:   int32FromString(s.Prio),
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CNFRouteRuleSpec defines the desired state of CNFRouteRule
+type CNFRouteRuleSpec struct {
+	// +optional
+	Src string `json:"src,omitempty"`
+	// +optional
+	Dst string `json:"dst,omitempty"`
+	// +optional
+	Not bool `json:"not,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Prio *int32 `json:"prio,omitempty"`
+	// +optional
+	Fwmark string `json:"fwmark,omitempty"`
+	// Routing table, main, local, default or the table id
+	// +kubebuilder:validation:Pattern=`^(main|local|default|[0-9]+)$`
+	// +optional
+	Table string `json:"table,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CNFRouteRule is the Schema for the cnfrouterules API
+type CNFRouteRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CNFRouteRuleSpec `json:"spec,omitempty"`
+	Status SdewanStatus     `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CNFRouteRuleList contains a list of CNFRouteRule
+type CNFRouteRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CNFRouteRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CNFRouteRule{}, &CNFRouteRuleList{})
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+)
+
+// The v1alpha1 API keeps the values as the strings sent to the CNF, these
+// helpers convert them from and to the typed v1beta1 fields. A v1alpha1
+// spec which does not convert back to itself (e.g. a port which is not a
+// number) is kept in an annotation and restored by the conversion back to
+// v1alpha1, unless the v1beta1 spec is changed meanwhile
+
+// keptSpecAnnotation keeps the v1alpha1 spec of an object which can not be
+// represented in v1beta1
+const keptSpecAnnotation = "batch.sdewan.akraino.org/v1alpha1-spec"
+
+// keepSpec annotates the v1beta1 object with the v1alpha1 spec if it
+// differs from the spec converted back
+func keepSpec(meta *metav1.ObjectMeta, spec interface{}, converted interface{}) error {
+	annotations := make(map[string]string)
+	for k, v := range meta.Annotations {
+		if k != keptSpecAnnotation {
+			annotations[k] = v
+		}
+	}
+
+	if !reflect.DeepEqual(spec, converted) {
+		value, err := json.Marshal(spec)
+		if err != nil {
+			return err
+		}
+		annotations[keptSpecAnnotation] = string(value)
+	}
+
+	meta.Annotations = annotations
+	if len(annotations) == 0 {
+		meta.Annotations = nil
+	}
+	return nil
+}
+
+// restoreSpec removes the kept spec from the annotations of the v1alpha1
+// object and reads it, it returns false if no spec is kept
+func restoreSpec(meta *metav1.ObjectMeta, kept interface{}) bool {
+	value, ok := meta.Annotations[keptSpecAnnotation]
+	if !ok {
+		return false
+	}
+
+	annotations := make(map[string]string)
+	for k, v := range meta.Annotations {
+		if k != keptSpecAnnotation {
+			annotations[k] = v
+		}
+	}
+	meta.Annotations = annotations
+	if len(annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	return json.Unmarshal([]byte(value), kept) == nil
+}
+
+func convertStatusTo(src SdewanStatus) v1alpha1.SdewanStatus {
+	return v1alpha1.SdewanStatus{
+		AppliedGeneration: src.AppliedGeneration,
+		AppliedTime:       src.AppliedTime,
+		State:             v1alpha1.SdewanState(src.State),
+		Message:           src.Message,
+		ContentHash:       src.ContentHash,
+		Pods:              convertPodStatusesTo(src.Pods),
+		PodsInSync:        src.PodsInSync,
+		LastError:         src.LastError,
+	}
+}
+
+func convertStatusFrom(src v1alpha1.SdewanStatus) SdewanStatus {
+	return SdewanStatus{
+		AppliedGeneration: src.AppliedGeneration,
+		AppliedTime:       src.AppliedTime,
+		State:             SdewanState(src.State),
+		Message:           src.Message,
+		ContentHash:       src.ContentHash,
+		Pods:              convertPodStatusesFrom(src.Pods),
+		PodsInSync:        src.PodsInSync,
+		LastError:         src.LastError,
+	}
+}
+
+func convertPodStatusesTo(src []SdewanPodStatus) []v1alpha1.SdewanPodStatus {
+	if src == nil {
+		return nil
+	}
+	dst := make([]v1alpha1.SdewanPodStatus, len(src))
+	for i, p := range src {
+		dst[i] = v1alpha1.SdewanPodStatus(p)
+	}
+	return dst
+}
+
+func convertPodStatusesFrom(src []v1alpha1.SdewanPodStatus) []SdewanPodStatus {
+	if src == nil {
+		return nil
+	}
+	dst := make([]SdewanPodStatus, len(src))
+	for i, p := range src {
+		dst[i] = SdewanPodStatus(p)
+	}
+	return dst
+}
+
+func int32ToString(value *int32) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*value))
+}
+
+func int32FromString(value string) *int32 {
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil
+	}
+	ret := int32(n)
+	return &ret
+}
+
+// boolToString returns the true value of the CNF option (e.g. "1" or "yes"),
+// false leaves the option unset
+func boolToString(value bool, trueValue string) string {
+	if value {
+		return trueValue
+	}
+	return ""
+}
+
+// listToString joins the list as a comma separated CNF option
+func listToString(values []string) string {
+	return strings.Join(values, ",")
+}
+
+func listFromString(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
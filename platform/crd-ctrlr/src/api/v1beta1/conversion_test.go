@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+)
+
+func TestListConversion(t *testing.T) {
+	values := listFromString("10.0.0.0/24, 10.0.1.0/24,")
+	expected := []string{"10.0.0.0/24", "10.0.1.0/24"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("listFromString() = %v, expected %v", values, expected)
+	}
+	if s := listToString(values); s != "10.0.0.0/24,10.0.1.0/24" {
+		t.Errorf("listToString() = %q", s)
+	}
+	if values := listFromString(""); values != nil {
+		t.Errorf("listFromString(\"\") = %v, expected nil", values)
+	}
+}
+
+func TestMwan3RuleConversion(t *testing.T) {
+	timeout, srcPort, destPort := int32(200), int32(22), int32(1000)
+	rule := &Mwan3Rule{
+		ObjectMeta: metav1.ObjectMeta{Name: "rule1", Namespace: "default"},
+		Spec: Mwan3RuleSpec{
+			Policy:   "balance1",
+			SrcIp:    "10.10.10.10",
+			SrcPort:  &srcPort,
+			DestPort: &destPort,
+			Proto:    "udp",
+			Family:   "ipv4",
+			Sticky:   true,
+			Timeout:  &timeout,
+		},
+		Status: SdewanStatus{AppliedGeneration: 2, State: InSync, ContentHash: "0123456789abcdef",
+			Pods:       []SdewanPodStatus{{Name: "cnf-1", ContentHash: "0123456789abcdef"}, {Name: "cnf-2", LastError: "timeout"}},
+			PodsInSync: "1/2", LastError: "cnf-2: timeout"},
+	}
+
+	hub := &v1alpha1.Mwan3Rule{}
+	if err := rule.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+	expected := v1alpha1.Mwan3RuleSpec{
+		Policy:   "balance1",
+		SrcIp:    "10.10.10.10",
+		SrcPort:  "22",
+		DestPort: "1000",
+		Proto:    "udp",
+		Family:   "ipv4",
+		Sticky:   "1",
+		Timeout:  "200",
+	}
+	if !reflect.DeepEqual(hub.Spec, expected) {
+		t.Errorf("ConvertTo() spec = %+v, expected %+v", hub.Spec, expected)
+	}
+	if hub.Status.State != v1alpha1.InSync || hub.Status.AppliedGeneration != 2 || len(hub.Status.Pods) != 2 ||
+		hub.Status.PodsInSync != "1/2" || hub.Status.LastError != "cnf-2: timeout" {
+		t.Errorf("ConvertTo() status = %+v", hub.Status)
+	}
+
+	back := &Mwan3Rule{}
+	if err := back.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, rule) {
+		t.Errorf("Round trip = %+v, expected %+v", back, rule)
+	}
+}
+
+func TestKeptSpecConversion(t *testing.T) {
+	hub := &v1alpha1.Mwan3Rule{
+		ObjectMeta: metav1.ObjectMeta{Name: "rule1", Namespace: "default", Annotations: map[string]string{"owner": "team1"}},
+		Spec:       v1alpha1.Mwan3RuleSpec{Policy: "balance1", DestPort: "1000-2000", Sticky: "0"},
+	}
+
+	rule := &Mwan3Rule{}
+	if err := rule.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+	if rule.Spec.DestPort != nil || rule.Annotations[keptSpecAnnotation] == "" || rule.Annotations["owner"] != "team1" {
+		t.Errorf("ConvertFrom() = %+v", rule)
+	}
+	if _, ok := hub.Annotations[keptSpecAnnotation]; ok {
+		t.Errorf("ConvertFrom() changed the annotations of the v1alpha1 object")
+	}
+
+	// the unchanged spec is restored
+	back := &v1alpha1.Mwan3Rule{}
+	if err := rule.ConvertTo(back); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, hub) {
+		t.Errorf("Round trip = %+v, expected %+v", back, hub)
+	}
+
+	// the changed spec is converted
+	port := int32(80)
+	rule.Spec.DestPort = &port
+	if err := rule.ConvertTo(back); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+	if back.Spec.DestPort != "80" || back.Spec.Sticky != "" {
+		t.Errorf("ConvertTo() spec = %+v", back.Spec)
+	}
+	if _, ok := back.Annotations[keptSpecAnnotation]; ok || back.Annotations["owner"] != "team1" {
+		t.Errorf("ConvertTo() annotations = %v", back.Annotations)
+	}
+}
+
+func TestIpsecSiteConversion(t *testing.T) {
+	site := &IpsecSite{
+		ObjectMeta: metav1.ObjectMeta{Name: "site1", Namespace: "default"},
+		Spec: IpsecSiteSpec{
+			Remote:              "10.10.10.1",
+			ForceCryptoProposal: true,
+			Connections: []SiteConnection{{
+				Name:          "conn1",
+				LocalSubnet:   []string{"192.168.1.0/24", "192.168.2.0/24"},
+				LocalFirewall: true,
+			}},
+		},
+	}
+
+	hub := &v1alpha1.IpsecSite{}
+	if err := site.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+	c := hub.Spec.Connections[0]
+	if hub.Spec.ForceCryptoProposal != "1" || c.LocalSubnet != "192.168.1.0/24,192.168.2.0/24" ||
+		c.LocalFirewall != "yes" || c.RemoteFirewall != "" {
+		t.Errorf("ConvertTo() spec = %+v", hub.Spec)
+	}
+
+	back := &IpsecSite{}
+	if err := back.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, site) {
+		t.Errorf("Round trip = %+v, expected %+v", back, site)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	rule := &Mwan3Rule{Spec: Mwan3RuleSpec{Policy: "balance1", Sticky: true}}
+	rule.Default()
+	if rule.Spec.Proto != "all" || rule.Spec.Timeout == nil || *rule.Spec.Timeout != 600 {
+		t.Errorf("Default() = %+v", rule.Spec)
+	}
+
+	fwRule := &FirewallRule{Spec: FirewallRuleSpec{Target: "ACCEPT"}}
+	fwRule.Default()
+	if fwRule.Spec.Proto != "tcpudp" || fwRule.Spec.Target != "ACCEPT" || fwRule.Spec.Family != "any" {
+		t.Errorf("Default() = %+v", fwRule.Spec)
+	}
+
+	site := &IpsecSite{Spec: IpsecSiteSpec{Connections: []SiteConnection{{Name: "conn1"}}}}
+	site.Default()
+	if site.Spec.Type != "policy-based" || site.Spec.Connections[0].ConnectionType != "tunnel" ||
+		site.Spec.Connections[0].Mode != "start" {
+		t.Errorf("Default() = %+v", site.Spec)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this FirewallDNAT to the Hub version (v1alpha1)
+func (src *FirewallDNAT) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.FirewallDNAT)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallDNATSpecTo(src.Spec)
+	kept := v1alpha1.FirewallDNATSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(firewallDNATSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *FirewallDNAT) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.FirewallDNAT)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallDNATSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, firewallDNATSpecTo(dst.Spec))
+}
+
+func firewallDNATSpecTo(s FirewallDNATSpec) v1alpha1.FirewallDNATSpec {
+	return v1alpha1.FirewallDNATSpec{
+		Name:     s.Name,
+		Src:      s.Src,
+		SrcIp:    s.SrcIp,
+		SrcDIp:   s.SrcDIp,
+		SrcMac:   s.SrcMac,
+		Proto:    s.Proto,
+		Dest:     s.Dest,
+		DestIp:   s.DestIp,
+		Mark:     s.Mark,
+		Target:   s.Target,
+		Family:   s.Family,
+		SrcPort:  int32ToString(s.SrcPort),
+		SrcDPort: int32ToString(s.SrcDPort),
+		DestPort: int32ToString(s.DestPort),
+	}
+}
+
+func firewallDNATSpecFrom(s v1alpha1.FirewallDNATSpec) FirewallDNATSpec {
+	return FirewallDNATSpec{
+		Name:     s.Name,
+		Src:      s.Src,
+		SrcIp:    s.SrcIp,
+		SrcDIp:   s.SrcDIp,
+		SrcMac:   s.SrcMac,
+		Proto:    s.Proto,
+		Dest:     s.Dest,
+		DestIp:   s.DestIp,
+		Mark:     s.Mark,
+		Target:   s.Target,
+		Family:   s.Family,
+		SrcPort:  int32FromString(s.SrcPort),
+		SrcDPort: int32FromString(s.SrcDPort),
+		DestPort: int32FromString(s.DestPort),
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FirewallDNATSpec defines the desired state of FirewallDNAT
+type FirewallDNATSpec struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Src string `json:"src,omitempty"`
+	// +optional
+	SrcIp string `json:"src_ip,omitempty"`
+	// +optional
+	SrcDIp string `json:"src_dip,omitempty"`
+	// +optional
+	SrcMac string `json:"src_mac,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SrcPort *int32 `json:"src_port,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SrcDPort *int32 `json:"src_dport,omitempty"`
+	// +kubebuilder:validation:Enum=tcp;udp;tcpudp;udplite;icmp;esp;ah;sctp;all
+	// +optional
+	Proto string `json:"proto,omitempty"`
+	// +optional
+	Dest string `json:"dest,omitempty"`
+	// +optional
+	DestIp string `json:"dest_ip,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	DestPort *int32 `json:"dest_port,omitempty"`
+	// +optional
+	Mark string `json:"mark,omitempty"`
+	// +kubebuilder:validation:Enum=DNAT
+	// +optional
+	Target string `json:"target,omitempty"`
+	// +kubebuilder:validation:Enum=ipv4;ipv6;any
+	// +optional
+	Family string `json:"family,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FirewallDNAT is the Schema for the firewalldnats API
+type FirewallDNAT struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirewallDNATSpec `json:"spec,omitempty"`
+	Status SdewanStatus     `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FirewallDNATList contains a list of FirewallDNAT
+type FirewallDNATList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FirewallDNAT `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FirewallDNAT{}, &FirewallDNATList{})
+}
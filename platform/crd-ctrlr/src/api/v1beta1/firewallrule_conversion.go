@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this FirewallRule to the Hub version (v1alpha1)
+func (src *FirewallRule) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.FirewallRule)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallRuleSpecTo(src.Spec)
+	kept := v1alpha1.FirewallRuleSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(firewallRuleSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *FirewallRule) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.FirewallRule)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallRuleSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, firewallRuleSpecTo(dst.Spec))
+}
+
+func firewallRuleSpecTo(s FirewallRuleSpec) v1alpha1.FirewallRuleSpec {
+	return v1alpha1.FirewallRuleSpec{
+		Name:     s.Name,
+		Src:      s.Src,
+		SrcIp:    s.SrcIp,
+		SrcMac:   s.SrcMac,
+		Proto:    s.Proto,
+		IcmpType: s.IcmpType,
+		Dest:     s.Dest,
+		DestIp:   s.DestIp,
+		Mark:     s.Mark,
+		Target:   s.Target,
+		SetMark:  s.SetMark,
+		SetXmark: s.SetXmark,
+		Family:   s.Family,
+		Extra:    s.Extra,
+		SrcPort:  int32ToString(s.SrcPort),
+		DestPort: int32ToString(s.DestPort),
+	}
+}
+
+func firewallRuleSpecFrom(s v1alpha1.FirewallRuleSpec) FirewallRuleSpec {
+	return FirewallRuleSpec{
+		Name:     s.Name,
+		Src:      s.Src,
+		SrcIp:    s.SrcIp,
+		SrcMac:   s.SrcMac,
+		Proto:    s.Proto,
+		IcmpType: s.IcmpType,
+		Dest:     s.Dest,
+		DestIp:   s.DestIp,
+		Mark:     s.Mark,
+		Target:   s.Target,
+		SetMark:  s.SetMark,
+		SetXmark: s.SetXmark,
+		Family:   s.Family,
+		Extra:    s.Extra,
+		SrcPort:  int32FromString(s.SrcPort),
+		DestPort: int32FromString(s.DestPort),
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FirewallRuleSpec defines the desired state of FirewallRule
+type FirewallRuleSpec struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Src string `json:"src,omitempty"`
+	// +optional
+	SrcIp string `json:"src_ip,omitempty"`
+	// +optional
+	SrcMac string `json:"src_mac,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SrcPort *int32 `json:"src_port,omitempty"`
+	// +kubebuilder:validation:Enum=tcp;udp;tcpudp;udplite;icmp;esp;ah;sctp;all
+	// +optional
+	Proto string `json:"proto,omitempty"`
+	// +optional
+	IcmpType []string `json:"icmp_type,omitempty"`
+	// +optional
+	Dest string `json:"dest,omitempty"`
+	// +optional
+	DestIp string `json:"dest_ip,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	DestPort *int32 `json:"dest_port,omitempty"`
+	// +optional
+	Mark string `json:"mark,omitempty"`
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT;DROP;MARK;NOTRACK
+	// +optional
+	Target string `json:"target,omitempty"`
+	// +optional
+	SetMark string `json:"set_mark,omitempty"`
+	// +optional
+	SetXmark string `json:"set_xmark,omitempty"`
+	// +kubebuilder:validation:Enum=ipv4;ipv6;any
+	// +optional
+	Family string `json:"family,omitempty"`
+	// +optional
+	Extra string `json:"extra,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FirewallRule is the Schema for the firewallrules API
+type FirewallRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirewallRuleSpec `json:"spec,omitempty"`
+	Status SdewanStatus     `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FirewallRuleList contains a list of FirewallRule
+type FirewallRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FirewallRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FirewallRule{}, &FirewallRuleList{})
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this FirewallSNAT to the Hub version (v1alpha1)
+func (src *FirewallSNAT) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.FirewallSNAT)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallSNATSpecTo(src.Spec)
+	kept := v1alpha1.FirewallSNATSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(firewallSNATSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *FirewallSNAT) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.FirewallSNAT)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallSNATSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, firewallSNATSpecTo(dst.Spec))
+}
+
+func firewallSNATSpecTo(s FirewallSNATSpec) v1alpha1.FirewallSNATSpec {
+	return v1alpha1.FirewallSNATSpec{
+		Name:     s.Name,
+		Src:      s.Src,
+		SrcIp:    s.SrcIp,
+		SrcDIp:   s.SrcDIp,
+		SrcMac:   s.SrcMac,
+		Proto:    s.Proto,
+		Dest:     s.Dest,
+		DestIp:   s.DestIp,
+		Mark:     s.Mark,
+		Target:   s.Target,
+		Family:   s.Family,
+		SrcPort:  int32ToString(s.SrcPort),
+		SrcDPort: int32ToString(s.SrcDPort),
+		DestPort: int32ToString(s.DestPort),
+	}
+}
+
+func firewallSNATSpecFrom(s v1alpha1.FirewallSNATSpec) FirewallSNATSpec {
+	return FirewallSNATSpec{
+		Name:     s.Name,
+		Src:      s.Src,
+		SrcIp:    s.SrcIp,
+		SrcDIp:   s.SrcDIp,
+		SrcMac:   s.SrcMac,
+		Proto:    s.Proto,
+		Dest:     s.Dest,
+		DestIp:   s.DestIp,
+		Mark:     s.Mark,
+		Target:   s.Target,
+		Family:   s.Family,
+		SrcPort:  int32FromString(s.SrcPort),
+		SrcDPort: int32FromString(s.SrcDPort),
+		DestPort: int32FromString(s.DestPort),
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this FirewallZone to the Hub version (v1alpha1)
+func (src *FirewallZone) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.FirewallZone)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallZoneSpecTo(src.Spec)
+	kept := v1alpha1.FirewallZoneSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(firewallZoneSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *FirewallZone) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.FirewallZone)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = firewallZoneSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, firewallZoneSpecTo(dst.Spec))
+}
+
+func firewallZoneSpecTo(s FirewallZoneSpec) v1alpha1.FirewallZoneSpec {
+	return v1alpha1.FirewallZoneSpec{
+		Name:             s.Name,
+		Network:          s.Network,
+		MasqSrc:          s.MasqSrc,
+		MasqDest:         s.MasqDest,
+		Input:            s.Input,
+		Forward:          s.Forward,
+		Output:           s.Output,
+		Family:           s.Family,
+		Subnet:           s.Subnet,
+		ExtraSrc:         s.ExtraSrc,
+		ExtraDest:        s.ExtraDest,
+		Masq:             boolToString(s.Masq, "1"),
+		MasqAllowInvalid: boolToString(s.MasqAllowInvalid, "1"),
+		MtuFix:           boolToString(s.MtuFix, "1"),
+	}
+}
+
+func firewallZoneSpecFrom(s v1alpha1.FirewallZoneSpec) FirewallZoneSpec {
+	return FirewallZoneSpec{
+		Name:             s.Name,
+		Network:          s.Network,
+		MasqSrc:          s.MasqSrc,
+		MasqDest:         s.MasqDest,
+		Input:            s.Input,
+		Forward:          s.Forward,
+		Output:           s.Output,
+		Family:           s.Family,
+		Subnet:           s.Subnet,
+		ExtraSrc:         s.ExtraSrc,
+		ExtraDest:        s.ExtraDest,
+		Masq:             s.Masq == "1",
+		MasqAllowInvalid: s.MasqAllowInvalid == "1",
+		MtuFix:           s.MtuFix == "1",
+	}
+}
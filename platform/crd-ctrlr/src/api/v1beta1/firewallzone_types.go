@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FirewallZoneSpec defines the desired state of FirewallZone
+type FirewallZoneSpec struct {
+	// +optional
+	Name    string   `json:"name,omitempty"`
+	Network []string `json:"network"`
+	// +optional
+	Masq bool `json:"masq,omitempty"`
+	// +optional
+	MasqSrc []string `json:"masq_src,omitempty"`
+	// +optional
+	MasqDest []string `json:"masq_dest,omitempty"`
+	// +optional
+	MasqAllowInvalid bool `json:"masq_allow_invalid,omitempty"`
+	// +optional
+	MtuFix bool `json:"mtu_fix,omitempty"`
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT;DROP
+	// +optional
+	Input string `json:"input,omitempty"`
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT;DROP
+	// +optional
+	Forward string `json:"forward,omitempty"`
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT;DROP
+	// +optional
+	Output string `json:"output,omitempty"`
+	// +kubebuilder:validation:Enum=ipv4;ipv6;any
+	// +optional
+	Family string `json:"family,omitempty"`
+	// +optional
+	Subnet []string `json:"subnet,omitempty"`
+	// +optional
+	ExtraSrc string `json:"extra_src,omitempty"`
+	// +optional
+	ExtraDest string `json:"extra_dest,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FirewallZone is the Schema for the firewallzones API
+type FirewallZone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirewallZoneSpec `json:"spec,omitempty"`
+	Status SdewanStatus     `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FirewallZoneList contains a list of FirewallZone
+type FirewallZoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FirewallZone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FirewallZone{}, &FirewallZoneList{})
+}
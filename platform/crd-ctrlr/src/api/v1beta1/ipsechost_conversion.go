@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this IpsecHost to the Hub version (v1alpha1)
+func (src *IpsecHost) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.IpsecHost)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = ipsecHostSpecTo(src.Spec)
+	kept := v1alpha1.IpsecHostSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(ipsecHostSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *IpsecHost) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.IpsecHost)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = ipsecHostSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, ipsecHostSpecTo(dst.Spec))
+}
+
+func ipsecHostSpecTo(s IpsecHostSpec) v1alpha1.IpsecHostSpec {
+	dst := v1alpha1.IpsecHostSpec{
+		Name:                 s.Name,
+		Type:                 s.Type,
+		Remote:               s.Remote,
+		AuthenticationMethod: s.AuthenticationMethod,
+		CryptoProposal:       s.CryptoProposal,
+		LocalIdentifier:      s.LocalIdentifier,
+		RemoteIdentifier:     s.RemoteIdentifier,
+		PresharedKey:         s.PresharedKey,
+		LocalPublicCert:      s.LocalPublicCert,
+		LocalPrivateCert:     s.LocalPrivateCert,
+		SharedCA:             s.SharedCA,
+		ForceCryptoProposal:  boolToString(s.ForceCryptoProposal, "1"),
+	}
+	for _, c := range s.Connections {
+		dst.Connections = append(dst.Connections, v1alpha1.Connection{
+			Name:           c.Name,
+			ConnectionType: c.ConnectionType,
+			Mode:           c.Mode,
+			LocalSourceIp:  c.LocalSourceIp,
+			LocalUpDown:    c.LocalUpDown,
+			RemoteSourceIp: c.RemoteSourceIp,
+			RemoteUpDown:   c.RemoteUpDown,
+			CryptoProposal: c.CryptoProposal,
+			Mark:           c.Mark,
+			IfId:           c.IfId,
+			LocalFirewall:  boolToString(c.LocalFirewall, "yes"),
+			RemoteSubnet:   listToString(c.RemoteSubnet),
+			RemoteFirewall: boolToString(c.RemoteFirewall, "yes"),
+		})
+	}
+	return dst
+}
+
+func ipsecHostSpecFrom(s v1alpha1.IpsecHostSpec) IpsecHostSpec {
+	dst := IpsecHostSpec{
+		Name:                 s.Name,
+		Type:                 s.Type,
+		Remote:               s.Remote,
+		AuthenticationMethod: s.AuthenticationMethod,
+		CryptoProposal:       s.CryptoProposal,
+		LocalIdentifier:      s.LocalIdentifier,
+		RemoteIdentifier:     s.RemoteIdentifier,
+		PresharedKey:         s.PresharedKey,
+		LocalPublicCert:      s.LocalPublicCert,
+		LocalPrivateCert:     s.LocalPrivateCert,
+		SharedCA:             s.SharedCA,
+		ForceCryptoProposal:  s.ForceCryptoProposal == "1",
+	}
+	for _, c := range s.Connections {
+		dst.Connections = append(dst.Connections, Connection{
+			Name:           c.Name,
+			ConnectionType: c.ConnectionType,
+			Mode:           c.Mode,
+			LocalSourceIp:  c.LocalSourceIp,
+			LocalUpDown:    c.LocalUpDown,
+			RemoteSourceIp: c.RemoteSourceIp,
+			RemoteUpDown:   c.RemoteUpDown,
+			CryptoProposal: c.CryptoProposal,
+			Mark:           c.Mark,
+			IfId:           c.IfId,
+			LocalFirewall:  c.LocalFirewall == "yes",
+			RemoteSubnet:   listFromString(c.RemoteSubnet),
+			RemoteFirewall: c.RemoteFirewall == "yes",
+		})
+	}
+	return dst
+}
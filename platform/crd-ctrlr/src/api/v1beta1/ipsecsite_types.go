@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SiteConnection defines a connection of IpsecSite
+type SiteConnection struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=tunnel;transport
+	// +optional
+	ConnectionType string `json:"conn_type,omitempty"`
+	// +kubebuilder:validation:Enum=start;add;route
+	// +optional
+	Mode        string   `json:"mode,omitempty"`
+	LocalSubnet []string `json:"local_subnet"`
+	// +optional
+	LocalUpDown string `json:"local_updown,omitempty"`
+	// +optional
+	LocalFirewall bool `json:"local_firewall,omitempty"`
+	// +optional
+	RemoteSubnet []string `json:"remote_subnet,omitempty"`
+	// +optional
+	RemoteSourceIp string `json:"remote_sourceip,omitempty"`
+	// +optional
+	RemoteUpDown string `json:"remote_updown,omitempty"`
+	// +optional
+	RemoteFirewall bool `json:"remote_firewall,omitempty"`
+	// +optional
+	CryptoProposal []string `json:"crypto_proposal,omitempty"`
+	// +optional
+	Mark string `json:"mark,omitempty"`
+	// +optional
+	IfId string `json:"if_id,omitempty"`
+}
+
+// IpsecSiteSpec defines the desired state of IpsecSite
+type IpsecSiteSpec struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +kubebuilder:validation:Enum=VTI-based;policy-based
+	// +optional
+	Type   string `json:"type,omitempty"`
+	Remote string `json:"remote"`
+	// +kubebuilder:validation:Enum=psk;pubkey
+	AuthenticationMethod string   `json:"authentication_method"`
+	CryptoProposal       []string `json:"crypto_proposal"`
+	// +optional
+	LocalIdentifier string `json:"local_identifier,omitempty"`
+	// +optional
+	RemoteIdentifier string `json:"remote_identifier,omitempty"`
+	// +optional
+	ForceCryptoProposal bool `json:"force_crypto_proposal,omitempty"`
+	// +optional
+	PresharedKey string `json:"pre_shared_key,omitempty"`
+	// +optional
+	LocalPublicCert string `json:"local_public_cert,omitempty"`
+	// +optional
+	LocalPrivateCert string `json:"local_private_cert,omitempty"`
+	// +optional
+	SharedCA    string           `json:"shared_ca,omitempty"`
+	Connections []SiteConnection `json:"connections"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// IpsecSite is the Schema for the ipsecsites API
+type IpsecSite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IpsecSiteSpec `json:"spec,omitempty"`
+	Status SdewanStatus  `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IpsecSiteList contains a list of IpsecSite
+type IpsecSiteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IpsecSite `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IpsecSite{}, &IpsecSiteList{})
+}
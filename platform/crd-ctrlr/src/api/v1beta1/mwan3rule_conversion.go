@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	"reflect"
+
+	"sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this Mwan3Rule to the Hub version (v1alpha1)
+func (src *Mwan3Rule) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Mwan3Rule)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = mwan3RuleSpecTo(src.Spec)
+	kept := v1alpha1.Mwan3RuleSpec{}
+	if restoreSpec(&dst.ObjectMeta, &kept) && reflect.DeepEqual(mwan3RuleSpecFrom(kept), src.Spec) {
+		dst.Spec = kept
+	}
+	dst.Status = convertStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *Mwan3Rule) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Mwan3Rule)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = mwan3RuleSpecFrom(src.Spec)
+	dst.Status = convertStatusFrom(src.Status)
+	return keepSpec(&dst.ObjectMeta, src.Spec, mwan3RuleSpecTo(dst.Spec))
+}
+
+func mwan3RuleSpecTo(s Mwan3RuleSpec) v1alpha1.Mwan3RuleSpec {
+	return v1alpha1.Mwan3RuleSpec{
+		Policy:   s.Policy,
+		SrcIp:    s.SrcIp,
+		DestIp:   s.DestIp,
+		Proto:    s.Proto,
+		Family:   s.Family,
+		SrcPort:  int32ToString(s.SrcPort),
+		DestPort: int32ToString(s.DestPort),
+		Sticky:   boolToString(s.Sticky, "1"),
+		Timeout:  int32ToString(s.Timeout),
+	}
+}
+
+func mwan3RuleSpecFrom(s v1alpha1.Mwan3RuleSpec) Mwan3RuleSpec {
+	return Mwan3RuleSpec{
+		Policy:   s.Policy,
+		SrcIp:    s.SrcIp,
+		DestIp:   s.DestIp,
+		Proto:    s.Proto,
+		Family:   s.Family,
+		SrcPort:  int32FromString(s.SrcPort),
+		DestPort: int32FromString(s.DestPort),
+		Sticky:   s.Sticky == "1",
+		Timeout:  int32FromString(s.Timeout),
+	}
+}
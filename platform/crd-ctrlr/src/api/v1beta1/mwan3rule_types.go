@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Mwan3RuleSpec defines the desired state of Mwan3Rule
+type Mwan3RuleSpec struct {
+	Policy string `json:"policy"`
+	// +optional
+	SrcIp string `json:"src_ip,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	SrcPort *int32 `json:"src_port,omitempty"`
+	// +optional
+	DestIp string `json:"dest_ip,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	DestPort *int32 `json:"dest_port,omitempty"`
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+	// +optional
+	Proto string `json:"proto,omitempty"`
+	// +kubebuilder:validation:Enum=ipv4;ipv6;all
+	// +optional
+	Family string `json:"family,omitempty"`
+	// +optional
+	Sticky bool `json:"sticky,omitempty"`
+	// Timeout of the sticky sessions in seconds
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Timeout *int32 `json:"timeout,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.contentHash`
+// +kubebuilder:printcolumn:name="Pods",type=string,JSONPath=`.status.podsInSync`
+// +kubebuilder:printcolumn:name="Errors",type=string,JSONPath=`.status.lastError`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Mwan3Rule is the Schema for the mwan3rules API
+type Mwan3Rule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Mwan3RuleSpec `json:"spec,omitempty"`
+	Status SdewanStatus  `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Mwan3RuleList contains a list of Mwan3Rule
+type Mwan3RuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Mwan3Rule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Mwan3Rule{}, &Mwan3RuleList{})
+}
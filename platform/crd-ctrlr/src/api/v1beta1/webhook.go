@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// default values of the optional fields, they are the defaults of OpenWrt
+const (
+	defaultMwan3Proto    = "all"
+	defaultStickyTimeout = 600
+	defaultFirewallProto = "tcpudp"
+	defaultFamily        = "any"
+	defaultRuleTarget    = "DROP"
+	defaultRouteTable    = "main"
+	defaultIpsecType     = "policy-based"
+	defaultConnType      = "tunnel"
+	defaultConnMode      = "start"
+)
+
+// SetupWebhooksWithManager registers the defaulting webhooks of the v1beta1
+// kinds which have defaults and the conversion webhook
+func SetupWebhooksWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []client.Object{
+		&Mwan3Rule{},
+		&FirewallZone{},
+		&FirewallRule{},
+		&NetworkFirewallRule{},
+		&FirewallSNAT{},
+		&FirewallDNAT{},
+		&CNFNAT{},
+		&CNFRouteRule{},
+		&IpsecHost{},
+		&IpsecSite{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-mwan3rule,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=mwan3rules,verbs=create;update,versions=v1beta1,name=mutate-mwan3rule.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-firewallzone,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=firewallzones,verbs=create;update,versions=v1beta1,name=mutate-firewallzone.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-firewallrule,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=firewallrules,verbs=create;update,versions=v1beta1,name=mutate-firewallrule.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-networkfirewallrule,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=networkfirewallrules,verbs=create;update,versions=v1beta1,name=mutate-networkfirewallrule.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-firewallsnat,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=firewallsnats,verbs=create;update,versions=v1beta1,name=mutate-firewallsnat.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-firewalldnat,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=firewalldnats,verbs=create;update,versions=v1beta1,name=mutate-firewalldnat.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-cnfrouterule,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=cnfrouterules,verbs=create;update,versions=v1beta1,name=mutate-cnfrouterule.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-ipsechost,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=ipsechosts,verbs=create;update,versions=v1beta1,name=mutate-ipsechost.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+// +kubebuilder:webhook:path=/mutate-batch-sdewan-akraino-org-v1beta1-ipsecsite,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch.sdewan.akraino.org,resources=ipsecsites,verbs=create;update,versions=v1beta1,name=mutate-ipsecsite.akraino.org,admissionReviewVersions=v1,matchPolicy=Exact
+
+var _ webhook.Defaulter = &Mwan3Rule{}
+var _ webhook.Defaulter = &FirewallZone{}
+var _ webhook.Defaulter = &FirewallRule{}
+var _ webhook.Defaulter = &NetworkFirewallRule{}
+var _ webhook.Defaulter = &FirewallSNAT{}
+var _ webhook.Defaulter = &FirewallDNAT{}
+var _ webhook.Defaulter = &CNFRouteRule{}
+var _ webhook.Defaulter = &IpsecHost{}
+var _ webhook.Defaulter = &IpsecSite{}
+
+// Default implements webhook.Defaulter
+func (r *Mwan3Rule) Default() {
+	defaultString(&r.Spec.Proto, defaultMwan3Proto)
+	if r.Spec.Sticky && r.Spec.Timeout == nil {
+		timeout := int32(defaultStickyTimeout)
+		r.Spec.Timeout = &timeout
+	}
+}
+
+// Default implements webhook.Defaulter
+func (r *FirewallZone) Default() {
+	defaultString(&r.Spec.Family, defaultFamily)
+}
+
+// Default implements webhook.Defaulter
+func (r *FirewallRule) Default() {
+	defaultString(&r.Spec.Proto, defaultFirewallProto)
+	defaultString(&r.Spec.Target, defaultRuleTarget)
+	defaultString(&r.Spec.Family, defaultFamily)
+}
+
+// Default implements webhook.Defaulter
+func (r *NetworkFirewallRule) Default() {
+	defaultString(&r.Spec.Proto, defaultFirewallProto)
+	defaultString(&r.Spec.Target, defaultRuleTarget)
+	defaultString(&r.Spec.Family, defaultFamily)
+}
+
+// Default implements webhook.Defaulter
+func (r *FirewallSNAT) Default() {
+	defaultString(&r.Spec.Proto, defaultFirewallProto)
+	defaultString(&r.Spec.Target, "SNAT")
+	defaultString(&r.Spec.Family, defaultFamily)
+}
+
+// Default implements webhook.Defaulter
+func (r *FirewallDNAT) Default() {
+	defaultString(&r.Spec.Proto, defaultFirewallProto)
+	defaultString(&r.Spec.Target, "DNAT")
+	defaultString(&r.Spec.Family, defaultFamily)
+}
+
+// Default implements webhook.Defaulter
+func (r *CNFRouteRule) Default() {
+	defaultString(&r.Spec.Table, defaultRouteTable)
+}
+
+// Default implements webhook.Defaulter
+func (r *IpsecHost) Default() {
+	defaultString(&r.Spec.Type, defaultIpsecType)
+	for i := range r.Spec.Connections {
+		defaultString(&r.Spec.Connections[i].ConnectionType, defaultConnType)
+		defaultString(&r.Spec.Connections[i].Mode, defaultConnMode)
+	}
+}
+
+// Default implements webhook.Defaulter
+func (r *IpsecSite) Default() {
+	defaultString(&r.Spec.Type, defaultIpsecType)
+	for i := range r.Spec.Connections {
+		defaultString(&r.Spec.Connections[i].ConnectionType, defaultConnType)
+		defaultString(&r.Spec.Connections[i].Mode, defaultConnMode)
+	}
+}
+
+func defaultString(value *string, defaultValue string) {
+	if *value == "" {
+		*value = defaultValue
+	}
+}
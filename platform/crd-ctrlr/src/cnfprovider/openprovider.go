@@ -5,10 +5,14 @@ package cnfprovider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
 	basehandler "sdewan.akraino.org/sdewan/basehandler"
 	"sdewan.akraino.org/sdewan/openwrt"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -79,67 +83,161 @@ func NewOpenWrt(namespace string, sdewanPurpose string, k8sClient client.Client)
 	return &OpenWrtProvider{namespace, sdewanPurpose, deployments.Items[0], k8sClient}, nil
 }
 
-func (p *OpenWrtProvider) AddOrUpdateObject(handler basehandler.ISdewanHandler, instance client.Object) (bool, error) {
+// AddOrUpdateObject applies the object to all the pods of the cnf, it returns
+// whether the object is changed in any pod and the status of the object in
+// each pod. The error returned is the first one met, the object is applied to
+// the other pods nevertheless
+func (p *OpenWrtProvider) AddOrUpdateObject(handler basehandler.ISdewanHandler, instance client.Object) (bool, []batchv1alpha1.SdewanPodStatus, error) {
 	reqLogger := log.WithValues(handler.GetType(), handler.GetName(instance), "cnf", p.Deployment.Name)
 	ctx := context.Background()
 	ReplicaSetList := &appsv1.ReplicaSetList{}
 	err := p.K8sClient.List(ctx, ReplicaSetList, client.MatchingLabels{"sdewanPurpose": p.SdewanPurpose})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	if len(ReplicaSetList.Items) != 1 {
-		return false, fmt.Errorf("More than one of repicaset exist with label: sdewanPurpose=%s", p.SdewanPurpose)
+		return false, nil, fmt.Errorf("More than one of repicaset exist with label: sdewanPurpose=%s", p.SdewanPurpose)
 	}
 	podList := &corev1.PodList{}
 	err = p.K8sClient.List(ctx, podList, client.MatchingFields{"OwnBy": ReplicaSetList.Items[0].ObjectMeta.Name})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	new_instance, err := handler.Convert(instance, p.Deployment)
 	//new_instance.SetFullName(p.Namespace)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	cnfChanged := false
+	var firstErr error
+	podStatus := []batchv1alpha1.SdewanPodStatus{}
 	for _, pod := range podList.Items {
-		if pod.Status.PodIP == "" {
-			return false, errors.New("The target pod doesn't have an IP address")
-		}
-		clientInfo := CreateOpenwrtClient(pod, p.K8sClient)
-		runtime_instance, err := handler.GetObject(clientInfo, new_instance.GetName())
-		changed := false
-
+		changed, hash, err := p.addOrUpdatePodObject(handler, pod, new_instance, reqLogger.WithValues("pod", pod.Name))
+		status := batchv1alpha1.SdewanPodStatus{Name: pod.Name, ContentHash: hash}
 		if err != nil {
-			err2, ok := err.(*openwrt.OpenwrtError)
-			if ok && err2.Code == 404 {
-				_, err3 := handler.CreateObject(clientInfo, new_instance)
-				if err3 != nil {
-					return false, err3
-				}
-				changed = true
-			} else {
-				reqLogger.Error(err, "Failed to get object")
-				return false, err
+			reqLogger.Error(err, "Failed to add/update object", "pod", pod.Name)
+			status.LastError = err.Error()
+			if firstErr == nil {
+				firstErr = err
 			}
-		} else if handler.IsEqual(runtime_instance, new_instance) {
-			reqLogger.Info("Equal to the runtime instance, so no update")
-		} else {
-			_, err := handler.UpdateObject(clientInfo, new_instance)
-			if err != nil {
-				return false, err
+		}
+		cnfChanged = cnfChanged || changed
+		podStatus = append(podStatus, status)
+	}
+	// We say the AddUpdate succeed only when the add/update for all pods succeed
+	return cnfChanged, podStatus, firstErr
+}
+
+// addOrUpdatePodObject applies the object to a pod, it returns whether the
+// object is changed and the hash of the object as reported by the pod
+func (p *OpenWrtProvider) addOrUpdatePodObject(handler basehandler.ISdewanHandler, pod corev1.Pod, new_instance openwrt.IOpenWrtObject, reqLogger logr.Logger) (bool, string, error) {
+	if pod.Status.PodIP == "" {
+		return false, "", errors.New("The target pod doesn't have an IP address")
+	}
+	clientInfo := CreateOpenwrtClient(pod, p.K8sClient)
+	runtime_instance, err := handler.GetObject(clientInfo, new_instance.GetName())
+	changed := false
+
+	if err != nil {
+		err2, ok := err.(*openwrt.OpenwrtError)
+		if ok && err2.Code == 404 {
+			_, err3 := handler.CreateObject(clientInfo, new_instance)
+			if err3 != nil {
+				return false, "", err3
 			}
 			changed = true
+		} else {
+			return false, "", err
 		}
-		if changed {
-			_, err = handler.Restart(clientInfo)
-			if err != nil {
-				return changed, err
-			}
-			cnfChanged = true
+	} else if handler.IsEqual(runtime_instance, new_instance) {
+		reqLogger.Info("Equal to the runtime instance, so no update")
+	} else {
+		_, err := handler.UpdateObject(clientInfo, new_instance)
+		if err != nil {
+			return false, "", err
 		}
+		changed = true
 	}
-	// We say the AddUpdate succeed only when the add/update for all pods succeed
-	return cnfChanged, nil
+	if changed {
+		_, err = handler.Restart(clientInfo)
+		if err != nil {
+			return changed, "", err
+		}
+		// hash what the pod reports after the change
+		runtime_instance, err = handler.GetObject(clientInfo, new_instance.GetName())
+		if err != nil {
+			return changed, "", err
+		}
+	}
+	return changed, contentHash(runtime_instance), nil
+}
+
+// contentHash returns a short hash of the object as reported by the CNF
+func contentHash(obj openwrt.IOpenWrtObject) string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16]
+}
+
+// ContentHash returns the hash shared by the pods, or an empty string if the
+// pods report different objects
+func ContentHash(podStatus []batchv1alpha1.SdewanPodStatus) string {
+	hash := ""
+	for i, status := range podStatus {
+		if i > 0 && status.ContentHash != hash {
+			return ""
+		}
+		hash = status.ContentHash
+	}
+	return hash
+}
+
+// PodSummary returns the number of the pods the object is applied to out of
+// all the pods (e.g. 2/3) and the first error with the number of the other
+// pods which failed, both are printed by kubectl get
+func PodSummary(podStatus []batchv1alpha1.SdewanPodStatus) (string, string) {
+	if len(podStatus) == 0 {
+		return "", ""
+	}
+	inSync := 0
+	failed := 0
+	lastError := ""
+	for _, status := range podStatus {
+		if status.LastError == "" {
+			inSync++
+			continue
+		}
+		if failed == 0 {
+			lastError = status.Name + ": " + status.LastError
+		}
+		failed++
+	}
+	if failed > 1 {
+		lastError = fmt.Sprintf("%s (+%d more)", lastError, failed-1)
+	}
+	return fmt.Sprintf("%d/%d", inSync, len(podStatus)), lastError
+}
+
+// ReapplyObject applies the object to a pod even if the pod reports the same
+// object, the caller restarts the service of the object
+func (p *OpenWrtProvider) ReapplyObject(handler basehandler.ISdewanHandler, instance client.Object, pod corev1.Pod) error {
+	new_instance, err := handler.Convert(instance, p.Deployment)
+	if err != nil {
+		return err
+	}
+	clientInfo := CreateOpenwrtClient(pod, p.K8sClient)
+	_, err = handler.GetObject(clientInfo, new_instance.GetName())
+	if err != nil {
+		err2, ok := err.(*openwrt.OpenwrtError)
+		if ok && err2.Code == 404 {
+			_, err = handler.CreateObject(clientInfo, new_instance)
+		}
+		return err
+	}
+	_, err = handler.UpdateObject(clientInfo, new_instance)
+	return err
 }
 
 func (p *OpenWrtProvider) DeleteObject(handler basehandler.ISdewanHandler, instance client.Object) (bool, error) {
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package cnfprovider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type podStatuses []batchv1alpha1.SdewanPodStatus
+
+func TestContentHash(t *testing.T) {
+	tcases := []struct {
+		name     string
+		pods     podStatuses
+		expected string
+	}{
+		{"NoPod", nil, ""},
+		{"OnePod", podStatuses{{Name: "cnf-1", ContentHash: "0123"}}, "0123"},
+		{"SameHash", podStatuses{{Name: "cnf-1", ContentHash: "0123"}, {Name: "cnf-2", ContentHash: "0123"}}, "0123"},
+		{"DifferentHash", podStatuses{{Name: "cnf-1", ContentHash: "0123"}, {Name: "cnf-2", ContentHash: "4567"}}, ""},
+		{"FailedPod", podStatuses{{Name: "cnf-1", ContentHash: "0123"}, {Name: "cnf-2", LastError: "timeout"}}, ""},
+	}
+
+	for _, tcase := range tcases {
+		if hash := ContentHash(tcase.pods); hash != tcase.expected {
+			t.Errorf("%s: ContentHash() = %q, expected %q", tcase.name, hash, tcase.expected)
+		}
+	}
+}
+
+func TestObjectContentHash(t *testing.T) {
+	policy := &openwrt.SdewanPolicy{Name: "balance", Members: []openwrt.SdewanMember{{Interface: "net0", Metric: "1", Weight: "2"}}}
+	same := &openwrt.SdewanPolicy{Name: "balance", Members: []openwrt.SdewanMember{{Interface: "net0", Metric: "1", Weight: "2"}}}
+	other := &openwrt.SdewanPolicy{Name: "balance", Members: []openwrt.SdewanMember{{Interface: "net0", Metric: "1", Weight: "3"}}}
+
+	hash := contentHash(policy)
+	if len(hash) != 16 {
+		t.Errorf("contentHash() = %q, expected 16 hex digits", hash)
+	}
+	if contentHash(same) != hash {
+		t.Errorf("contentHash() = %q, expected %q for the same object", contentHash(same), hash)
+	}
+	if contentHash(other) == hash {
+		t.Errorf("contentHash() = %q for a different object", hash)
+	}
+}
+
+func TestPodSummary(t *testing.T) {
+	tcases := []struct {
+		name      string
+		pods      podStatuses
+		inSync    string
+		lastError string
+	}{
+		{"NoPod", nil, "", ""},
+		{"InSync", podStatuses{{Name: "cnf-1"}, {Name: "cnf-2"}}, "2/2", ""},
+		{"OneFailed", podStatuses{{Name: "cnf-1"}, {Name: "cnf-2", LastError: "timeout"}}, "1/2", "cnf-2: timeout"},
+		{"AllFailed", podStatuses{{Name: "cnf-1", LastError: "refused"}, {Name: "cnf-2", LastError: "timeout"},
+			{Name: "cnf-3", LastError: "timeout"}}, "0/3", "cnf-1: refused (+2 more)"},
+	}
+
+	for _, tcase := range tcases {
+		inSync, lastError := PodSummary(tcase.pods)
+		if inSync != tcase.inSync || lastError != tcase.lastError {
+			t.Errorf("%s: PodSummary() = %q, %q, expected %q, %q", tcase.name, inSync, lastError, tcase.inSync, tcase.lastError)
+		}
+	}
+}
+
+// fakeHandler serves the objects of the pods from memory
+type fakeHandler struct {
+	objects map[string]*openwrt.SdewanPolicy
+}
+
+func (h *fakeHandler) GetType() string                       { return "Mwan3Policy" }
+func (h *fakeHandler) GetName(instance client.Object) string { return instance.GetName() }
+func (h *fakeHandler) GetFinalizer() string                  { return "" }
+func (h *fakeHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	return nil, errors.New("not supported")
+}
+func (h *fakeHandler) Convert(o client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	return &openwrt.SdewanPolicy{Name: o.GetName()}, nil
+}
+func (h *fakeHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	return instance1.GetName() == instance2.GetName()
+}
+func (h *fakeHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	if obj, ok := h.objects[clientInfo.Ip]; ok {
+		return obj, nil
+	}
+	return nil, &openwrt.OpenwrtError{Code: 404, Message: "not found"}
+}
+func (h *fakeHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	h.objects[clientInfo.Ip] = instance.(*openwrt.SdewanPolicy)
+	return instance, nil
+}
+func (h *fakeHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	return h.CreateObject(clientInfo, instance)
+}
+func (h *fakeHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	delete(h.objects, clientInfo.Ip)
+	return nil
+}
+func (h *fakeHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+func TestAddOrUpdateObjectContinuesAfterFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	labels := map[string]string{"sdewanPurpose": "cnf1"}
+	pod := func(name string, ip string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Status:     corev1.PodStatus{PodIP: ip},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "cnf-rs", Namespace: "default", Labels: labels}},
+		// the first pod has no ip yet, so applying the object to it fails
+		pod("cnf-1", ""),
+		pod("cnf-2", "10.0.0.2"),
+		pod("cnf-3", "10.0.0.3"),
+	).Build()
+	p := &OpenWrtProvider{Namespace: "default", SdewanPurpose: "cnf1", K8sClient: c}
+	h := &fakeHandler{objects: map[string]*openwrt.SdewanPolicy{}}
+	instance := &batchv1alpha1.Mwan3Policy{ObjectMeta: metav1.ObjectMeta{Name: "balance", Namespace: "default", Labels: labels}}
+
+	changed, podStatus, err := p.AddOrUpdateObject(h, instance)
+	if err == nil || !strings.Contains(err.Error(), "IP address") {
+		t.Errorf("AddOrUpdateObject() error = %v, expected the error of cnf-1", err)
+	}
+	if !changed {
+		t.Errorf("AddOrUpdateObject() changed = false, expected true")
+	}
+	if len(podStatus) != 3 {
+		t.Fatalf("AddOrUpdateObject() pod status = %+v, expected 3 pods", podStatus)
+	}
+	for _, status := range podStatus {
+		failed := status.Name == "cnf-1"
+		if failed != (status.LastError != "") || failed != (status.ContentHash == "") {
+			t.Errorf("AddOrUpdateObject() pod status = %+v", status)
+		}
+	}
+	for _, ip := range []string{"10.0.0.2", "10.0.0.3"} {
+		if _, ok := h.objects[ip]; !ok {
+			t.Errorf("AddOrUpdateObject() did not apply the object to the pod %s", ip)
+		}
+	}
+}
@@ -186,6 +186,27 @@ func setStatus(instance client.Object, status batchv1alpha1.SdewanStatus) {
 	field_status.Set(reflect.ValueOf(status))
 }
 
+// setPodStatus records the status of the object in the pods of the cnf, it
+// returns whether the status is changed
+func setPodStatus(instance client.Object, podStatus []batchv1alpha1.SdewanPodStatus) bool {
+	value := reflect.ValueOf(instance)
+	status := reflect.Indirect(value).FieldByName("Status").Addr().Interface().(*batchv1alpha1.SdewanStatus)
+	if len(podStatus) == 0 {
+		podStatus = nil
+	}
+	hash := cnfprovider.ContentHash(podStatus)
+	inSync, lastError := cnfprovider.PodSummary(podStatus)
+	if status.ContentHash == hash && reflect.DeepEqual(status.Pods, podStatus) &&
+		status.PodsInSync == inSync && status.LastError == lastError {
+		return false
+	}
+	status.ContentHash = hash
+	status.Pods = podStatus
+	status.PodsInSync = inSync
+	status.LastError = lastError
+	return true
+}
+
 func appendFinalizer(instance client.Object, item string) {
 	value := reflect.ValueOf(instance)
 	field := reflect.Indirect(value).FieldByName("ObjectMeta")
@@ -233,7 +254,11 @@ func net2iface(net string, deployment appsv1.Deployment) (string, error) {
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 
 // Common Reconcile Processing
-func ProcessReconcile(r client.Client, logger logr.Logger, ctx context.Context, req ctrl.Request, handler basehandler.ISdewanHandler) (ctrl.Result, error) {
+func ProcessReconcile(r client.Client, logger logr.Logger, ctx context.Context, req ctrl.Request, handler basehandler.ISdewanHandler) (res ctrl.Result, err error) {
+	start := time.Now()
+	defer func() {
+		observeReconcile(handler.GetType(), start, res, err)
+	}()
 	log := logger.WithValues(handler.GetType(), req.NamespacedName)
 	during, _ := time.ParseDuration("5s")
 
@@ -275,10 +300,18 @@ func ProcessReconcile(r client.Client, logger logr.Logger, ctx context.Context,
 			log.Info("No cnf exist, so not create/update " + handler.GetType())
 			return ctrl.Result{}, nil
 		}
-		changed, err := cnf.AddOrUpdateObject(handler, instance)
+		// the pods queued by a ReapplyCRs action of the CNF status, an error
+		// is not retried as the action is taken again if the status persists
+		for _, pod := range reapplyRequests.take(handler.GetType(), req.NamespacedName) {
+			if err := reapplyObject(r, ctx, cnf, handler, instance, pod); err != nil {
+				log.Error(err, "Failed to re-apply "+handler.GetType(), "pod", pod.Name)
+			}
+		}
+		changed, podStatus, err := cnf.AddOrUpdateObject(handler, instance)
 		if err != nil {
 			log.Error(err, "Failed to add/update "+handler.GetType())
 			setStatus(instance, batchv1alpha1.SdewanStatus{State: batchv1alpha1.Applying, Message: err.Error()})
+			setPodStatus(instance, podStatus)
 			err2, ok := err.(*openwrt.OpenwrtError)
 			err = r.Status().Update(ctx, instance)
 			if err != nil {
@@ -302,7 +335,10 @@ func ProcessReconcile(r client.Client, logger logr.Logger, ctx context.Context,
 		}
 		if changed {
 			setStatus(instance, batchv1alpha1.SdewanStatus{State: batchv1alpha1.InSync})
-
+		}
+		// the pod status changes without the object, e.g. when a pod restarts
+		podChanged := setPodStatus(instance, podStatus)
+		if changed || podChanged {
 			err = r.Status().Update(ctx, instance)
 			if err != nil {
 				log.Error(err, "Failed to update status for "+handler.GetType())
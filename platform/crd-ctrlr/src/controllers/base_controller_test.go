@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"testing"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+)
+
+func TestSetPodStatus(t *testing.T) {
+	inSync := []batchv1alpha1.SdewanPodStatus{{Name: "cnf-1", ContentHash: "0123"}, {Name: "cnf-2", ContentHash: "0123"}}
+	failed := []batchv1alpha1.SdewanPodStatus{{Name: "cnf-1", ContentHash: "0123"}, {Name: "cnf-2", LastError: "timeout"}}
+	rule := &batchv1alpha1.Mwan3Rule{}
+
+	tcases := []struct {
+		name     string
+		pods     []batchv1alpha1.SdewanPodStatus
+		changed  bool
+		expected batchv1alpha1.SdewanStatus
+	}{
+		{"InSync", inSync, true, batchv1alpha1.SdewanStatus{ContentHash: "0123", Pods: inSync, PodsInSync: "2/2"}},
+		{"Unchanged", inSync, false, batchv1alpha1.SdewanStatus{ContentHash: "0123", Pods: inSync, PodsInSync: "2/2"}},
+		{"Failed", failed, true, batchv1alpha1.SdewanStatus{Pods: failed, PodsInSync: "1/2", LastError: "cnf-2: timeout"}},
+		{"NoPod", []batchv1alpha1.SdewanPodStatus{}, true, batchv1alpha1.SdewanStatus{}},
+		{"NoPodUnchanged", nil, false, batchv1alpha1.SdewanStatus{}},
+	}
+
+	for _, tcase := range tcases {
+		if changed := setPodStatus(rule, tcase.pods); changed != tcase.changed {
+			t.Errorf("%s: setPodStatus() = %v, expected %v", tcase.name, changed, tcase.changed)
+		}
+		s := rule.Status
+		e := tcase.expected
+		if s.ContentHash != e.ContentHash || len(s.Pods) != len(e.Pods) || s.PodsInSync != e.PodsInSync || s.LastError != e.LastError {
+			t.Errorf("%s: setPodStatus() status = %+v, expected %+v", tcase.name, s, e)
+		}
+	}
+}
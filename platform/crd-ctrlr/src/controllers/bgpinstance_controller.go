@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var bgpInstanceHandler = new(BgpInstanceHandler)
+
+type BgpInstanceHandler struct {
+}
+
+func (m *BgpInstanceHandler) GetType() string {
+	return "BgpInstance"
+}
+
+func (m *BgpInstanceHandler) GetName(instance client.Object) string {
+	bgpInstance := instance.(*batchv1alpha1.BgpInstance)
+	return bgpInstance.Name
+}
+
+func (m *BgpInstanceHandler) GetFinalizer() string {
+	return "bgp.instance.finalizers.sdewan.akraino.org"
+}
+
+func (m *BgpInstanceHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.BgpInstance{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *BgpInstanceHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	bgpInstance := instance.(*batchv1alpha1.BgpInstance)
+	bgpInstanceObject := openwrt.SdewanBgpInstance{
+		Name:     bgpInstance.Name,
+		As:       bgpInstance.Spec.As,
+		RouterId: bgpInstance.Spec.RouterId,
+		Networks: bgpInstance.Spec.Networks,
+		Table:    bgpInstance.Spec.Table,
+	}
+	return &bgpInstanceObject, nil
+}
+
+func (m *BgpInstanceHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	bgpInstance1 := instance1.(*openwrt.SdewanBgpInstance)
+	bgpInstance2 := instance2.(*openwrt.SdewanBgpInstance)
+	return reflect.DeepEqual(*bgpInstance1, *bgpInstance2)
+}
+
+func (m *BgpInstanceHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	ret, err := bgp.GetInstance(name)
+	return ret, err
+}
+
+func (m *BgpInstanceHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	bgpInstance := instance.(*openwrt.SdewanBgpInstance)
+	return bgp.CreateInstance(*bgpInstance)
+}
+
+func (m *BgpInstanceHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	bgpInstance := instance.(*openwrt.SdewanBgpInstance)
+	return bgp.UpdateInstance(*bgpInstance)
+}
+
+func (m *BgpInstanceHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	return bgp.DeleteInstance(name)
+}
+
+func (m *BgpInstanceHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// BgpInstanceReconciler reconciles a BgpInstance object
+type BgpInstanceReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=bgpinstances,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=bgpinstances/status,verbs=get;update;patch
+
+func (r *BgpInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, bgpInstanceHandler)
+}
+
+func (r *BgpInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.BgpInstance{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.BgpInstanceList{})),
+			Filter).
+		Complete(r)
+}
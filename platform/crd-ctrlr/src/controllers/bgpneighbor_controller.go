@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var bgpNeighborHandler = new(BgpNeighborHandler)
+
+type BgpNeighborHandler struct {
+}
+
+func (m *BgpNeighborHandler) GetType() string {
+	return "BgpNeighbor"
+}
+
+func (m *BgpNeighborHandler) GetName(instance client.Object) string {
+	neighbor := instance.(*batchv1alpha1.BgpNeighbor)
+	return neighbor.Name
+}
+
+func (m *BgpNeighborHandler) GetFinalizer() string {
+	return "bgp.neighbor.finalizers.sdewan.akraino.org"
+}
+
+func (m *BgpNeighborHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.BgpNeighbor{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *BgpNeighborHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	neighbor := instance.(*batchv1alpha1.BgpNeighbor)
+	neighborObject := openwrt.SdewanBgpNeighbor{
+		Name:                 neighbor.Name,
+		Instance:             neighbor.Spec.Instance,
+		RemoteAddress:        neighbor.Spec.RemoteAddress,
+		RemoteAs:             neighbor.Spec.RemoteAs,
+		SourceAddress:        neighbor.Spec.SourceAddress,
+		Password:             neighbor.Spec.Password,
+		HoldTime:             neighbor.Spec.HoldTime,
+		NextHopSelf:          neighbor.Spec.NextHopSelf,
+		RouteReflectorClient: neighbor.Spec.RouteReflectorClient,
+		Dev:                  neighbor.Spec.Dev,
+	}
+	return &neighborObject, nil
+}
+
+func (m *BgpNeighborHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	neighbor1 := instance1.(*openwrt.SdewanBgpNeighbor)
+	neighbor2 := instance2.(*openwrt.SdewanBgpNeighbor)
+	return reflect.DeepEqual(*neighbor1, *neighbor2)
+}
+
+func (m *BgpNeighborHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	ret, err := bgp.GetNeighbor(name)
+	return ret, err
+}
+
+func (m *BgpNeighborHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	neighbor := instance.(*openwrt.SdewanBgpNeighbor)
+	return bgp.CreateNeighbor(*neighbor)
+}
+
+func (m *BgpNeighborHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	neighbor := instance.(*openwrt.SdewanBgpNeighbor)
+	return bgp.UpdateNeighbor(*neighbor)
+}
+
+func (m *BgpNeighborHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	bgp := openwrt.BgpClient{OpenwrtClient: openwrtClient}
+	return bgp.DeleteNeighbor(name)
+}
+
+func (m *BgpNeighborHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// BgpNeighborReconciler reconciles a BgpNeighbor object
+type BgpNeighborReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=bgpneighbors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=bgpneighbors/status,verbs=get;update;patch
+
+func (r *BgpNeighborReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, bgpNeighborHandler)
+}
+
+func (r *BgpNeighborReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.BgpNeighbor{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.BgpNeighborList{})),
+			Filter).
+		Complete(r)
+}
@@ -185,13 +185,24 @@ func (r *CNFHubSiteReconciler) processInstance(instance *batchv1alpha1.CNFHubSit
 		}
 	}
 
+	// check Probability
+	pb := instance.Spec.Probability
+	if pb != "" {
+		p, err := strconv.ParseFloat(pb, 64)
+		if err != nil || p <= 0 || p > 1 {
+			return errors.New("Invalid Probability: " + pb)
+		}
+	}
+
 	var curStatus = batchv1alpha1.CNFHubSiteStatus{
-		Type:      t,
-		SiteIPs:   lips,
-		Subnet:    sn,
-		HubIP:     hip,
-		DevicePIP: dpip,
-		Message:   "",
+		Type:        t,
+		SiteIPs:     lips,
+		Subnet:      sn,
+		HubIP:       hip,
+		DevicePIP:   dpip,
+		Priority:    instance.Spec.Priority,
+		Probability: pb,
+		Message:     "",
 	}
 
 	if !curStatus.IsEqual(&instance.Status) {
@@ -261,7 +272,12 @@ func (r *CNFHubSiteReconciler) addCRs(instance *batchv1alpha1.CNFHubSite, status
 				r.Log.Error(err, "Creating Route CR : "+route_name)
 			}
 
-			// SNAT CR
+			// SNAT CR, the rules of the primary hub are inserted in front
+			// of the ones of the other hubs
+			index := "1"
+			if status.Priority > 1 {
+				index = "0"
+			}
 			nat_name := nat_base_name + strconv.Itoa(i)
 			nat_instance := &batchv1alpha1.CNFNAT{
 				ObjectMeta: metav1.ObjectMeta{
@@ -270,11 +286,12 @@ func (r *CNFHubSiteReconciler) addCRs(instance *batchv1alpha1.CNFHubSite, status
 					Labels:    instance.Labels,
 				},
 				Spec: batchv1alpha1.CNFNATSpec{
-					DestIp: ip,
-					Dest:   "#source",
-					SrcDIp: status.DevicePIP,
-					Index:  "1",
-					Target: "SNAT",
+					DestIp:      ip,
+					Dest:        "#source",
+					SrcDIp:      status.DevicePIP,
+					Index:       index,
+					Target:      "SNAT",
+					Probability: status.Probability,
 				},
 			}
 
@@ -112,5 +112,6 @@ func (r *CNFRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.CNFRouteList{})),
 			Filter).
+		Watches(reapplySource(cnfRouteHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -114,5 +114,6 @@ func (r *CNFRouteRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.CNFRouteRuleList{})),
 			Filter).
+		Watches(reapplySource(cnfRouteRuleHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
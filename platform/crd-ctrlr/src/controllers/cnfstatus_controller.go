@@ -12,6 +12,7 @@ import (
 	errs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 
 	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
 	"sdewan.akraino.org/sdewan/cnfprovider"
@@ -56,20 +57,36 @@ func (r *IpsecStatusAction) Execute(clientInfo *openwrt.OpenwrtClientInfo, statu
 	return nil
 }
 
-// SdewanCNFStatusController: query CNF status periodically
+// SdewanCNFStatusController: query CNF status periodically and take the
+// actions of the CNFStatusActions whose condition matches the status. The
+// registered actions are only taken for the modules without CNFStatusAction
 type SdewanCNFStatusController struct {
 	client.Client
 	Log           logr.Logger
+	Recorder      record.EventRecorder
 	CheckInterval time.Duration
 	actions       map[string]IStatusAction
-	mux           sync.Mutex
+	lastActions   map[actionKey]time.Time
+	// the pods whose availability is exported
+	available map[cnfLabels]bool
+	mux       sync.Mutex
+}
+
+// cnfLabels are the labels of the availability of a CNF pod
+type cnfLabels struct {
+	namespace string
+	pod       string
+	purpose   string
 }
 
 // +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=cnfstatuses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=cnfstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=cnfstatusactions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *SdewanCNFStatusController) SetupWithManager() error {
 	r.actions = make(map[string]IStatusAction)
+	r.lastActions = make(map[actionKey]time.Time)
 	r.RegisterAction("ipsec", &IpsecStatusAction{r.Client, r.Log})
 
 	go wait.Until(r.SafeQuery, r.CheckInterval, wait.NeverStop)
@@ -134,6 +151,17 @@ func (r *SdewanCNFStatusController) SafeQuery() {
 	}
 }
 
+// forgetAvailable deletes the availability of the pods which are gone (or
+// whose purpose changed), the pods seen are kept
+func (r *SdewanCNFStatusController) forgetAvailable(seen map[cnfLabels]bool) {
+	for labels := range r.available {
+		if !seen[labels] {
+			cnfAvailable.DeleteLabelValues(labels.namespace, labels.pod, labels.purpose)
+		}
+	}
+	r.available = seen
+}
+
 func (r *SdewanCNFStatusController) query() {
 	ctx := context.Background()
 
@@ -156,6 +184,14 @@ func (r *SdewanCNFStatusController) query() {
 		return
 	}
 
+	statusActions := &batchv1alpha1.CNFStatusActionList{}
+	err = r.List(ctx, statusActions)
+	if err != nil {
+		r.Log.Info(err.Error())
+	}
+	pods := make(map[string]bool)
+	seen := make(map[cnfLabels]bool)
+
 	for _, cnfPod := range cnfPodList.Items {
 		info := &batchv1alpha1.CNFStatusInformation{}
 		info.Name = cnfPod.ObjectMeta.Name
@@ -163,20 +199,26 @@ func (r *SdewanCNFStatusController) query() {
 		info.Node = cnfPod.Spec.NodeName
 		info.Purpose = cnfPod.ObjectMeta.Labels["sdewanPurpose"]
 		info.IP = cnfPod.Status.PodIP
+		pods[cnfPod.Namespace+"/"+cnfPod.Name] = true
 
 		// Get CNF Status
 		clientInfo := cnfprovider.CreateOpenwrtClient(cnfPod, r)
 		openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
 		status_client := openwrt.StatusClient{OpenwrtClient: openwrtClient}
 		cnf_status, err := status_client.GetStatus()
+		available := cnfAvailable.WithLabelValues(info.NameSpace, info.Name, info.Purpose)
+		seen[cnfLabels{info.NameSpace, info.Name, info.Purpose}] = true
 		if err != nil {
 			info.Status = "Not Available"
+			available.Set(0)
 		} else {
+			available.Set(1)
 			// ececute registered actions
+			declared := podActions(statusActions.Items, cnfPod)
 			r.mux.Lock()
 			var wg sync.WaitGroup
 			for i, _ := range *cnf_status {
-				if r.actions[(*cnf_status)[i].Name] != nil {
+				if r.actions[(*cnf_status)[i].Name] != nil && len(declared[(*cnf_status)[i].Name]) == 0 {
 					wg.Add(1)
 					go func(index int) {
 						defer wg.Done()
@@ -190,12 +232,33 @@ func (r *SdewanCNFStatusController) query() {
 			wg.Wait()
 			r.mux.Unlock()
 
+			// take the actions of the CNFStatusActions
+			for _, m := range *cnf_status {
+				for _, action := range declared[m.Name] {
+					rec := r.takeAction(ctx, action, cnfPod, clientInfo, m.Status)
+					if rec != nil {
+						instance.Status.Actions = append(instance.Status.Actions, *rec)
+					}
+				}
+			}
+
 			p_data, _ := json.Marshal(cnf_status)
 			info.Status = string(p_data)
 		}
 		instance.Status.Information = append(instance.Status.Information, *info)
 	}
 
+	if n := len(instance.Status.Actions); n > maxActionRecords {
+		instance.Status.Actions = instance.Status.Actions[n-maxActionRecords:]
+	}
+	// forget the last actions on the pods which are gone
+	for key := range r.lastActions {
+		if !pods[key.pod] {
+			delete(r.lastActions, key)
+		}
+	}
+	r.forgetAvailable(seen)
+
 	// Update the CNFStatus CR
 	err = r.Status().Update(ctx, instance)
 	if err != nil {
@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/basehandler"
+	"sdewan.akraino.org/sdewan/cnfprovider"
+	"sdewan.akraino.org/sdewan/openwrt"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// maximum number of action records kept in the CNFStatus
+const maxActionRecords = 20
+
+// number of the CRs of a type waiting to be re-applied by their controller
+const reapplyQueueSize = 100
+
+type moduleKind struct {
+	list    func() client.ObjectList
+	handler basehandler.ISdewanHandler
+}
+
+// the CRs re-applied by ReapplyCRs for each module of the CNF status, in
+// the order they are queued
+var moduleKinds = map[string][]moduleKind{
+	"ipsec": {
+		{func() client.ObjectList { return &batchv1alpha1.IpsecProposalList{} }, ipsecProposalHandler},
+		{func() client.ObjectList { return &batchv1alpha1.IpsecHostList{} }, ipsecHostHandler},
+		{func() client.ObjectList { return &batchv1alpha1.IpsecSiteList{} }, ipsecSiteHandler},
+	},
+	"wan": {
+		{func() client.ObjectList { return &batchv1alpha1.Mwan3PolicyList{} }, mwan3PolicyHandler},
+		{func() client.ObjectList { return &batchv1alpha1.Mwan3RuleList{} }, mwan3RuleHandler},
+	},
+	"firewall": {
+		{func() client.ObjectList { return &batchv1alpha1.FirewallZoneList{} }, firewallZoneHandler},
+		{func() client.ObjectList { return &batchv1alpha1.FirewallForwardingList{} }, firewallForwardingHandler},
+		{func() client.ObjectList { return &batchv1alpha1.FirewallRuleList{} }, firewallRuleHandler},
+		{func() client.ObjectList { return &batchv1alpha1.FirewallSNATList{} }, firewallSnatHandler},
+		{func() client.ObjectList { return &batchv1alpha1.FirewallDNATList{} }, firewallDnatHandler},
+	},
+	"route": {
+		{func() client.ObjectList { return &batchv1alpha1.CNFRouteList{} }, cnfRouteHandler},
+	},
+	"rule": {
+		{func() client.ObjectList { return &batchv1alpha1.CNFRouteRuleList{} }, cnfRouteRuleHandler},
+	},
+}
+
+// key of the last action taken on a pod
+type actionKey struct {
+	action string
+	pod    string
+}
+
+// podActions returns the CNFStatusActions which apply to the pod by module
+func podActions(actions []batchv1alpha1.CNFStatusAction, pod corev1.Pod) map[string][]*batchv1alpha1.CNFStatusAction {
+	ret := make(map[string][]*batchv1alpha1.CNFStatusAction)
+	for i := range actions {
+		action := &actions[i]
+		if action.Namespace != pod.Namespace {
+			continue
+		}
+		if purpose := action.Labels["sdewanPurpose"]; purpose != "" && purpose != pod.Labels["sdewanPurpose"] {
+			continue
+		}
+		ret[action.Spec.Module] = append(ret[action.Spec.Module], action)
+	}
+	return ret
+}
+
+// matchCondition checks whether a result of the condition on the module
+// status matches the value of the action
+func matchCondition(spec *batchv1alpha1.CNFStatusActionSpec, status interface{}) (bool, error) {
+	j := jsonpath.New("condition")
+	j.AllowMissingKeys(true)
+	err := j.Parse(spec.Condition)
+	if err != nil {
+		return false, err
+	}
+	results, err := j.FindResults(status)
+	if err != nil {
+		return false, err
+	}
+	for _, result := range results {
+		for _, v := range result {
+			if !v.IsValid() || (v.Kind() == reflect.Interface && v.IsNil()) {
+				continue
+			}
+			value := fmt.Sprint(v.Interface())
+			if (spec.Value == "" && value != "") || (spec.Value != "" && value == spec.Value) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// takeAction takes the action on the pod if the module status matches its
+// condition and the action was not taken on the pod in the minimum interval,
+// it returns the record of the action taken
+func (r *SdewanCNFStatusController) takeAction(ctx context.Context, action *batchv1alpha1.CNFStatusAction, pod corev1.Pod, clientInfo *openwrt.OpenwrtClientInfo, status interface{}) *batchv1alpha1.CNFStatusActionRecord {
+	name := action.Namespace + "/" + action.Name
+	matched, err := matchCondition(&action.Spec, status)
+	if err != nil {
+		r.Log.Info("Invalid condition of CNFStatusAction " + name + ": " + err.Error())
+		return nil
+	}
+	if !matched {
+		return nil
+	}
+
+	key := actionKey{action: name, pod: pod.Namespace + "/" + pod.Name}
+	if last, ok := r.lastActions[key]; ok && time.Since(last) < action.Spec.GetMinInterval() {
+		return nil
+	}
+	r.lastActions[key] = time.Now()
+
+	r.Log.Info("Take action " + action.Spec.Action + " of " + name + " on " + pod.Name)
+	switch action.Spec.Action {
+	case batchv1alpha1.RestartServiceAction:
+		openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+		service := openwrt.ServiceClient{OpenwrtClient: openwrtClient}
+		_, err = service.ExecuteService(action.Spec.GetService(), "restart")
+	case batchv1alpha1.ReapplyCRsAction:
+		err = r.reapplyCRs(ctx, action.Spec.Module, pod)
+	case batchv1alpha1.EventAction:
+		if r.Recorder == nil {
+			err = errors.New("No event recorder")
+		} else {
+			r.Recorder.Eventf(&pod, corev1.EventTypeWarning, "CNFStatusAction",
+				"Status of module %s matches %s of CNFStatusAction %s", action.Spec.Module, action.Spec.Condition, name)
+		}
+	default:
+		err = fmt.Errorf("Action is not supported: %s", action.Spec.Action)
+	}
+
+	record := &batchv1alpha1.CNFStatusActionRecord{
+		Name:   name,
+		Pod:    pod.Name,
+		Module: action.Spec.Module,
+		Action: action.Spec.Action,
+		Time:   metav1.Now(),
+	}
+	result := "success"
+	if err != nil {
+		r.Log.Info("Failed to take action of " + name + " on " + pod.Name + ": " + err.Error())
+		record.Error = err.Error()
+		result = "error"
+	}
+	statusActionsTotal.WithLabelValues(action.Spec.Module, action.Spec.Action, result).Inc()
+	return record
+}
+
+// reapplyCRs queues the CRs of the module to be applied to the pod again,
+// the controllers of the CRs re-apply them and restart their service
+func (r *SdewanCNFStatusController) reapplyCRs(ctx context.Context, module string, pod corev1.Pod) error {
+	kinds, ok := moduleKinds[module]
+	if !ok {
+		return fmt.Errorf("No CR to re-apply for module %s", module)
+	}
+	purpose := pod.Labels["sdewanPurpose"]
+	podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	for _, kind := range kinds {
+		list := kind.list()
+		err := r.List(ctx, list, client.InNamespace(pod.Namespace), client.MatchingLabels{"sdewanPurpose": purpose})
+		if err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			if obj.GetDeletionTimestamp() != nil {
+				continue
+			}
+			err = reapplyRequests.add(kind.handler.GetType(), obj, podName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// key of a CR to re-apply
+type reapplyKey struct {
+	crType string
+	name   types.NamespacedName
+}
+
+// reapplyQueue holds the pods to re-apply each CR to, the CRs are enqueued
+// to their controller so that ProcessReconcile re-applies them serialized
+// with the other changes of the CRs
+type reapplyQueue struct {
+	channels map[string]chan event.GenericEvent
+	pods     map[reapplyKey]map[types.NamespacedName]bool
+	mux      sync.Mutex
+}
+
+var reapplyRequests = &reapplyQueue{
+	channels: make(map[string]chan event.GenericEvent),
+	pods:     make(map[reapplyKey]map[types.NamespacedName]bool),
+}
+
+// reapplySource returns the source of the CRs to re-apply watched by the
+// controller of the handler
+func reapplySource(h basehandler.ISdewanHandler) source.Source {
+	return &source.Channel{Source: reapplyRequests.channel(h.GetType())}
+}
+
+func (q *reapplyQueue) channel(crType string) chan event.GenericEvent {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	ch, ok := q.channels[crType]
+	if !ok {
+		ch = make(chan event.GenericEvent, reapplyQueueSize)
+		q.channels[crType] = ch
+	}
+	return ch
+}
+
+// add queues the CR to be re-applied to the pod by its controller. A CR is
+// enqueued once until its pods are taken, it never blocks the caller
+func (q *reapplyQueue) add(crType string, obj client.Object, pod types.NamespacedName) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	ch, ok := q.channels[crType]
+	if !ok {
+		return fmt.Errorf("No controller to re-apply %s", crType)
+	}
+	key := reapplyKey{crType: crType, name: client.ObjectKeyFromObject(obj)}
+	if q.pods[key] != nil {
+		// the CR is enqueued already, the pod is re-applied with the others
+		q.pods[key][pod] = true
+		return nil
+	}
+
+	select {
+	case ch <- event.GenericEvent{Object: obj}:
+	default:
+		return fmt.Errorf("Too many %s to re-apply, %s is skipped", crType, key.name)
+	}
+	q.pods[key] = map[types.NamespacedName]bool{pod: true}
+	return nil
+}
+
+// take returns the pods to re-apply the CR to and removes them from the
+// queue
+func (q *reapplyQueue) take(crType string, name types.NamespacedName) []types.NamespacedName {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	key := reapplyKey{crType: crType, name: name}
+	pods := []types.NamespacedName{}
+	for pod := range q.pods[key] {
+		pods = append(pods, pod)
+	}
+	delete(q.pods, key)
+	return pods
+}
+
+// reapplyObject applies the CR to a pod of the cnf again and restarts its
+// service, a pod which no longer exists is skipped
+func reapplyObject(r client.Client, ctx context.Context, cnf *cnfprovider.OpenWrtProvider, handler basehandler.ISdewanHandler, instance client.Object, podName types.NamespacedName) error {
+	pod := corev1.Pod{}
+	err := r.Get(ctx, podName, &pod)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	err = cnf.ReapplyObject(handler, instance, pod)
+	if err != nil {
+		return err
+	}
+	_, err = handler.Restart(cnfprovider.CreateOpenwrtClient(pod, r))
+	return err
+}
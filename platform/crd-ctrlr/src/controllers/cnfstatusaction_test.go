@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func newStatusAction(namespace string, name string, purpose string, spec batchv1alpha1.CNFStatusActionSpec) batchv1alpha1.CNFStatusAction {
+	action := batchv1alpha1.CNFStatusAction{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}
+	if purpose != "" {
+		action.Labels = map[string]string{"sdewanPurpose": purpose}
+	}
+	return action
+}
+
+func newCNFPod(namespace string, name string, purpose string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{"sdewanPurpose": purpose},
+	}}
+}
+
+func TestPodActions(t *testing.T) {
+	ipsec := batchv1alpha1.CNFStatusActionSpec{Module: "ipsec"}
+	wan := batchv1alpha1.CNFStatusActionSpec{Module: "wan"}
+	actions := []batchv1alpha1.CNFStatusAction{
+		newStatusAction("default", "ipsec-all", "", ipsec),
+		newStatusAction("default", "ipsec-cnf1", "cnf1", ipsec),
+		newStatusAction("default", "wan-cnf2", "cnf2", wan),
+		newStatusAction("other", "wan-all", "", wan),
+	}
+
+	tcases := []struct {
+		name     string
+		pod      corev1.Pod
+		expected map[string][]string
+	}{
+		{"Purpose", newCNFPod("default", "cnf1-0", "cnf1"), map[string][]string{"ipsec": {"ipsec-all", "ipsec-cnf1"}}},
+		{"OtherPurpose", newCNFPod("default", "cnf2-0", "cnf2"), map[string][]string{"ipsec": {"ipsec-all"}, "wan": {"wan-cnf2"}}},
+		{"OtherNamespace", newCNFPod("other", "cnf1-0", "cnf1"), map[string][]string{"wan": {"wan-all"}}},
+		{"NoAction", newCNFPod("kube-system", "cnf1-0", "cnf1"), map[string][]string{}},
+	}
+
+	for _, tcase := range tcases {
+		names := make(map[string][]string)
+		for module, moduleActions := range podActions(actions, tcase.pod) {
+			for _, action := range moduleActions {
+				names[module] = append(names[module], action.Name)
+			}
+		}
+		if !reflect.DeepEqual(names, tcase.expected) {
+			t.Errorf("%s: podActions() = %v, expected %v", tcase.name, names, tcase.expected)
+		}
+	}
+}
+
+func TestMatchCondition(t *testing.T) {
+	status := map[string]interface{}{
+		"InitConnection": "site1",
+		"State":          "down",
+		"Empty":          "",
+		"Connections": []interface{}{
+			map[string]interface{}{"Name": "conn1", "State": "up"},
+			map[string]interface{}{"Name": "conn2", "State": "down"},
+		},
+	}
+
+	tcases := []struct {
+		name      string
+		condition string
+		value     string
+		expected  bool
+		err       bool
+	}{
+		{"NonEmpty", "{.InitConnection}", "", true, false},
+		{"Empty", "{.Empty}", "", false, false},
+		{"Missing", "{.Missing}", "", false, false},
+		{"Value", "{.State}", "down", true, false},
+		{"OtherValue", "{.State}", "up", false, false},
+		{"ValueMissing", "{.Missing}", "down", false, false},
+		{"ValueInList", "{.Connections[*].State}", "down", true, false},
+		{"ValueNotInList", "{.Connections[*].State}", "connecting", false, false},
+		{"InvalidCondition", "{.State", "", false, true},
+	}
+
+	for _, tcase := range tcases {
+		spec := &batchv1alpha1.CNFStatusActionSpec{Condition: tcase.condition, Value: tcase.value}
+		matched, err := matchCondition(spec, status)
+		if (err != nil) != tcase.err {
+			t.Errorf("%s: matchCondition() error = %v, expected error: %v", tcase.name, err, tcase.err)
+		}
+		if matched != tcase.expected {
+			t.Errorf("%s: matchCondition() = %v, expected %v", tcase.name, matched, tcase.expected)
+		}
+	}
+}
+
+func TestTakeActionMinInterval(t *testing.T) {
+	pod := newCNFPod("default", "cnf1-0", "cnf1")
+	status := map[string]interface{}{"State": "down"}
+
+	tcases := []struct {
+		name        string
+		minInterval string
+		// how long ago the action was last taken on the pod, 0 if never
+		last     time.Duration
+		expected bool
+	}{
+		{"NeverTaken", "", 0, true},
+		{"InDefaultInterval", "", 4 * time.Minute, false},
+		{"AfterDefaultInterval", "", 6 * time.Minute, true},
+		{"InInterval", "1h", 30 * time.Minute, false},
+		{"AfterInterval", "1m", 2 * time.Minute, true},
+		{"InvalidInterval", "never", 4 * time.Minute, false},
+	}
+
+	for _, tcase := range tcases {
+		r := &SdewanCNFStatusController{
+			Log:         logr.Discard(),
+			Recorder:    record.NewFakeRecorder(10),
+			lastActions: make(map[actionKey]time.Time),
+		}
+		action := newStatusAction("default", "event", "", batchv1alpha1.CNFStatusActionSpec{
+			Module:      "wan",
+			Condition:   "{.State}",
+			Value:       "down",
+			Action:      batchv1alpha1.EventAction,
+			MinInterval: tcase.minInterval,
+		})
+		key := actionKey{action: "default/event", pod: "default/cnf1-0"}
+		if tcase.last > 0 {
+			r.lastActions[key] = time.Now().Add(-tcase.last)
+		}
+		rec := r.takeAction(context.Background(), &action, pod, nil, status)
+		if (rec != nil) != tcase.expected {
+			t.Errorf("%s: takeAction() = %+v, expected action taken: %v", tcase.name, rec, tcase.expected)
+			continue
+		}
+		if rec != nil && rec.Error != "" {
+			t.Errorf("%s: takeAction() error = %s", tcase.name, rec.Error)
+		}
+		// the action taken is not taken again in the interval
+		if rec != nil && r.takeAction(context.Background(), &action, pod, nil, status) != nil {
+			t.Errorf("%s: takeAction() was taken again in the minimum interval", tcase.name)
+		}
+	}
+}
+
+func TestReapplyCRs(t *testing.T) {
+	saved := reapplyRequests
+	defer func() { reapplyRequests = saved }()
+	reapplyRequests = &reapplyQueue{
+		channels: make(map[string]chan event.GenericEvent),
+		pods:     make(map[reapplyKey]map[types.NamespacedName]bool),
+	}
+	policies := reapplyRequests.channel(mwan3PolicyHandler.GetType())
+	rules := reapplyRequests.channel(mwan3RuleHandler.GetType())
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	batchv1alpha1.AddToScheme(scheme)
+	meta := func(namespace string, name string, purpose string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"sdewanPurpose": purpose}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&batchv1alpha1.Mwan3Policy{ObjectMeta: meta("default", "balance", "cnf1")},
+		&batchv1alpha1.Mwan3Policy{ObjectMeta: meta("default", "other", "cnf2")},
+		&batchv1alpha1.Mwan3Rule{ObjectMeta: meta("default", "rule1", "cnf1")},
+		&batchv1alpha1.Mwan3Rule{ObjectMeta: meta("other", "rule2", "cnf1")},
+	).Build()
+	r := &SdewanCNFStatusController{Client: c, Log: logr.Discard()}
+	pod := newCNFPod("default", "cnf1-0", "cnf1")
+
+	if err := r.reapplyCRs(context.Background(), "wan", pod); err != nil {
+		t.Fatalf("reapplyCRs() error = %v", err)
+	}
+	if err := r.reapplyCRs(context.Background(), "dhcp", pod); err == nil {
+		t.Errorf("reapplyCRs() of a module without CRs succeeded")
+	}
+	// the CRs enqueued already are re-applied to the other pod too
+	other := newCNFPod("default", "cnf1-1", "cnf1")
+	if err := r.reapplyCRs(context.Background(), "wan", other); err != nil {
+		t.Fatalf("reapplyCRs() error = %v", err)
+	}
+
+	received := func(ch chan event.GenericEvent) []string {
+		names := []string{}
+		for len(ch) > 0 {
+			names = append(names, client.ObjectKeyFromObject((<-ch).Object).String())
+		}
+		sort.Strings(names)
+		return names
+	}
+	if names := received(policies); !reflect.DeepEqual(names, []string{"default/balance"}) {
+		t.Errorf("reapplyCRs() enqueued the Mwan3Policies %v, expected [default/balance]", names)
+	}
+	if names := received(rules); !reflect.DeepEqual(names, []string{"default/rule1"}) {
+		t.Errorf("reapplyCRs() enqueued the Mwan3Rules %v, expected [default/rule1]", names)
+	}
+
+	expected := []types.NamespacedName{{Namespace: "default", Name: "cnf1-0"}, {Namespace: "default", Name: "cnf1-1"}}
+	name := types.NamespacedName{Namespace: "default", Name: "balance"}
+	pods := reapplyRequests.take(mwan3PolicyHandler.GetType(), name)
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	if !reflect.DeepEqual(pods, expected) {
+		t.Errorf("take() = %v, expected %v", pods, expected)
+	}
+	if pods := reapplyRequests.take(mwan3PolicyHandler.GetType(), name); len(pods) != 0 {
+		t.Errorf("take() = %v after the pods were taken, expected none", pods)
+	}
+	if err := reapplyRequests.add(firewallZoneHandler.GetType(), &batchv1alpha1.FirewallZone{}, expected[0]); err == nil {
+		t.Errorf("add() of a CR without controller succeeded")
+	}
+
+	// the CR is enqueued again once its pods are taken
+	policy := &batchv1alpha1.Mwan3Policy{ObjectMeta: meta("default", "balance", "cnf1")}
+	if err := reapplyRequests.add(mwan3PolicyHandler.GetType(), policy, expected[0]); err != nil {
+		t.Errorf("add() error = %v", err)
+	}
+	if names := received(policies); !reflect.DeepEqual(names, []string{"default/balance"}) {
+		t.Errorf("add() enqueued the Mwan3Policies %v, expected [default/balance]", names)
+	}
+}
+
+func TestReapplyQueueFull(t *testing.T) {
+	q := &reapplyQueue{
+		channels: make(map[string]chan event.GenericEvent),
+		pods:     make(map[reapplyKey]map[types.NamespacedName]bool),
+	}
+	ch := q.channel(mwan3PolicyHandler.GetType())
+	pod := types.NamespacedName{Namespace: "default", Name: "cnf1-0"}
+	policy := func(i int) *batchv1alpha1.Mwan3Policy {
+		return &batchv1alpha1.Mwan3Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("policy%d", i)}}
+	}
+
+	for i := 0; i < reapplyQueueSize; i++ {
+		if err := q.add(mwan3PolicyHandler.GetType(), policy(i), pod); err != nil {
+			t.Fatalf("add() of policy%d error = %v", i, err)
+		}
+	}
+	// a full queue does not block, the CR is not queued
+	if err := q.add(mwan3PolicyHandler.GetType(), policy(reapplyQueueSize), pod); err == nil {
+		t.Errorf("add() to a full queue succeeded")
+	}
+	if pods := q.take(mwan3PolicyHandler.GetType(), client.ObjectKeyFromObject(policy(reapplyQueueSize))); len(pods) != 0 {
+		t.Errorf("take() = %v of a CR which was not queued, expected none", pods)
+	}
+	// a CR queued already is not queued again
+	if err := q.add(mwan3PolicyHandler.GetType(), policy(0), types.NamespacedName{Namespace: "default", Name: "cnf1-1"}); err != nil {
+		t.Errorf("add() of a queued CR error = %v", err)
+	}
+	if len(ch) != reapplyQueueSize {
+		t.Errorf("add() queued %d CRs, expected %d", len(ch), reapplyQueueSize)
+	}
+}
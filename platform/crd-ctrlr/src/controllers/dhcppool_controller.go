@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var dhcpPoolHandler = new(DhcpPoolHandler)
+
+type DhcpPoolHandler struct {
+}
+
+func (m *DhcpPoolHandler) GetType() string {
+	return "DhcpPool"
+}
+
+func (m *DhcpPoolHandler) GetName(instance client.Object) string {
+	pool := instance.(*batchv1alpha1.DhcpPool)
+	return pool.Name
+}
+
+func (m *DhcpPoolHandler) GetFinalizer() string {
+	return "dhcp.pool.finalizers.sdewan.akraino.org"
+}
+
+func (m *DhcpPoolHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.DhcpPool{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *DhcpPoolHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	pool := instance.(*batchv1alpha1.DhcpPool)
+	iface, err := net2iface(pool.Spec.Network, deployment)
+	if err != nil {
+		return nil, err
+	}
+	poolObject := openwrt.SdewanDhcpPool{
+		Name:       pool.Name,
+		Interface:  iface,
+		Start:      pool.Spec.Start,
+		End:        pool.Spec.End,
+		Netmask:    pool.Spec.Netmask,
+		LeaseTime:  pool.Spec.LeaseTime,
+		Gateway:    pool.Spec.Gateway,
+		DnsServers: pool.Spec.DnsServers,
+		Domain:     pool.Spec.Domain,
+	}
+	return &poolObject, nil
+}
+
+func (m *DhcpPoolHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	pool1 := instance1.(*openwrt.SdewanDhcpPool)
+	pool2 := instance2.(*openwrt.SdewanDhcpPool)
+	return reflect.DeepEqual(*pool1, *pool2)
+}
+
+func (m *DhcpPoolHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	ret, err := dhcp.GetPool(name)
+	return ret, err
+}
+
+func (m *DhcpPoolHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	pool := instance.(*openwrt.SdewanDhcpPool)
+	return dhcp.CreatePool(*pool)
+}
+
+func (m *DhcpPoolHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	pool := instance.(*openwrt.SdewanDhcpPool)
+	return dhcp.UpdatePool(*pool)
+}
+
+func (m *DhcpPoolHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	return dhcp.DeletePool(name)
+}
+
+func (m *DhcpPoolHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// DhcpPoolReconciler reconciles a DhcpPool object
+type DhcpPoolReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dhcppools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dhcppools/status,verbs=get;update;patch
+
+func (r *DhcpPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, dhcpPoolHandler)
+}
+
+func (r *DhcpPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.DhcpPool{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.DhcpPoolList{})),
+			Filter).
+		Complete(r)
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var dhcpStaticLeaseHandler = new(DhcpStaticLeaseHandler)
+
+type DhcpStaticLeaseHandler struct {
+}
+
+func (m *DhcpStaticLeaseHandler) GetType() string {
+	return "DhcpStaticLease"
+}
+
+func (m *DhcpStaticLeaseHandler) GetName(instance client.Object) string {
+	lease := instance.(*batchv1alpha1.DhcpStaticLease)
+	return lease.Name
+}
+
+func (m *DhcpStaticLeaseHandler) GetFinalizer() string {
+	return "dhcp.lease.finalizers.sdewan.akraino.org"
+}
+
+func (m *DhcpStaticLeaseHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.DhcpStaticLease{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *DhcpStaticLeaseHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	lease := instance.(*batchv1alpha1.DhcpStaticLease)
+	leaseObject := openwrt.SdewanDhcpStaticLease{
+		Name:      lease.Name,
+		Mac:       lease.Spec.Mac,
+		Ip:        lease.Spec.Ip,
+		Hostname:  lease.Spec.Hostname,
+		LeaseTime: lease.Spec.LeaseTime,
+	}
+	return &leaseObject, nil
+}
+
+func (m *DhcpStaticLeaseHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	lease1 := instance1.(*openwrt.SdewanDhcpStaticLease)
+	lease2 := instance2.(*openwrt.SdewanDhcpStaticLease)
+	return reflect.DeepEqual(*lease1, *lease2)
+}
+
+func (m *DhcpStaticLeaseHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	ret, err := dhcp.GetLease(name)
+	return ret, err
+}
+
+func (m *DhcpStaticLeaseHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	lease := instance.(*openwrt.SdewanDhcpStaticLease)
+	return dhcp.CreateLease(*lease)
+}
+
+func (m *DhcpStaticLeaseHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	lease := instance.(*openwrt.SdewanDhcpStaticLease)
+	return dhcp.UpdateLease(*lease)
+}
+
+func (m *DhcpStaticLeaseHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	return dhcp.DeleteLease(name)
+}
+
+func (m *DhcpStaticLeaseHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// DhcpStaticLeaseReconciler reconciles a DhcpStaticLease object
+type DhcpStaticLeaseReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dhcpstaticleases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dhcpstaticleases/status,verbs=get;update;patch
+
+func (r *DhcpStaticLeaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, dhcpStaticLeaseHandler)
+}
+
+func (r *DhcpStaticLeaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.DhcpStaticLease{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.DhcpStaticLeaseList{})),
+			Filter).
+		Complete(r)
+}
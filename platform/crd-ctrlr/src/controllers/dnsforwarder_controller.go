@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var dnsForwarderHandler = new(DnsForwarderHandler)
+
+type DnsForwarderHandler struct {
+}
+
+func (m *DnsForwarderHandler) GetType() string {
+	return "DnsForwarder"
+}
+
+func (m *DnsForwarderHandler) GetName(instance client.Object) string {
+	forwarder := instance.(*batchv1alpha1.DnsForwarder)
+	return forwarder.Name
+}
+
+func (m *DnsForwarderHandler) GetFinalizer() string {
+	return "dns.forwarder.finalizers.sdewan.akraino.org"
+}
+
+func (m *DnsForwarderHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.DnsForwarder{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *DnsForwarderHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	forwarder := instance.(*batchv1alpha1.DnsForwarder)
+	forwarderObject := openwrt.SdewanDnsForwarder{
+		Name:    forwarder.Name,
+		Domain:  forwarder.Spec.Domain,
+		Servers: forwarder.Spec.Servers,
+		Port:    forwarder.Spec.Port,
+	}
+	return &forwarderObject, nil
+}
+
+func (m *DnsForwarderHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	forwarder1 := instance1.(*openwrt.SdewanDnsForwarder)
+	forwarder2 := instance2.(*openwrt.SdewanDnsForwarder)
+	return reflect.DeepEqual(*forwarder1, *forwarder2)
+}
+
+func (m *DnsForwarderHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	ret, err := dhcp.GetForwarder(name)
+	return ret, err
+}
+
+func (m *DnsForwarderHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	forwarder := instance.(*openwrt.SdewanDnsForwarder)
+	return dhcp.CreateForwarder(*forwarder)
+}
+
+func (m *DnsForwarderHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	forwarder := instance.(*openwrt.SdewanDnsForwarder)
+	return dhcp.UpdateForwarder(*forwarder)
+}
+
+func (m *DnsForwarderHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	return dhcp.DeleteForwarder(name)
+}
+
+func (m *DnsForwarderHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// DnsForwarderReconciler reconciles a DnsForwarder object
+type DnsForwarderReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dnsforwarders,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dnsforwarders/status,verbs=get;update;patch
+
+func (r *DnsForwarderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, dnsForwarderHandler)
+}
+
+func (r *DnsForwarderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.DnsForwarder{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.DnsForwarderList{})),
+			Filter).
+		Complete(r)
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var dnsRecordHandler = new(DnsRecordHandler)
+
+type DnsRecordHandler struct {
+}
+
+func (m *DnsRecordHandler) GetType() string {
+	return "DnsRecord"
+}
+
+func (m *DnsRecordHandler) GetName(instance client.Object) string {
+	record := instance.(*batchv1alpha1.DnsRecord)
+	return record.Name
+}
+
+func (m *DnsRecordHandler) GetFinalizer() string {
+	return "dns.record.finalizers.sdewan.akraino.org"
+}
+
+func (m *DnsRecordHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.DnsRecord{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *DnsRecordHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	record := instance.(*batchv1alpha1.DnsRecord)
+	recordObject := openwrt.SdewanDnsRecord{
+		Name:   record.Name,
+		Domain: record.Spec.Domain,
+		Ip:     record.Spec.Ip,
+	}
+	return &recordObject, nil
+}
+
+func (m *DnsRecordHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	record1 := instance1.(*openwrt.SdewanDnsRecord)
+	record2 := instance2.(*openwrt.SdewanDnsRecord)
+	return reflect.DeepEqual(*record1, *record2)
+}
+
+func (m *DnsRecordHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	ret, err := dhcp.GetRecord(name)
+	return ret, err
+}
+
+func (m *DnsRecordHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	record := instance.(*openwrt.SdewanDnsRecord)
+	return dhcp.CreateRecord(*record)
+}
+
+func (m *DnsRecordHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	record := instance.(*openwrt.SdewanDnsRecord)
+	return dhcp.UpdateRecord(*record)
+}
+
+func (m *DnsRecordHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	dhcp := openwrt.DhcpClient{OpenwrtClient: openwrtClient}
+	return dhcp.DeleteRecord(name)
+}
+
+func (m *DnsRecordHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// DnsRecordReconciler reconciles a DnsRecord object
+type DnsRecordReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dnsrecords,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=dnsrecords/status,verbs=get;update;patch
+
+func (r *DnsRecordReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, dnsRecordHandler)
+}
+
+func (r *DnsRecordReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.DnsRecord{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.DnsRecordList{})),
+			Filter).
+		Complete(r)
+}
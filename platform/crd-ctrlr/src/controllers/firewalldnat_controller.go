@@ -115,5 +115,6 @@ func (r *FirewallDNATReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.FirewallDNATList{})),
 			Filter).
+		Watches(reapplySource(firewallDnatHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -112,5 +112,6 @@ func (r *FirewallForwardingReconciler) SetupWithManager(mgr ctrl.Manager) error
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.FirewallForwardingList{})),
 			Filter).
+		Watches(reapplySource(firewallForwardingHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
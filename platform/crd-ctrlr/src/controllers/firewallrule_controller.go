@@ -112,5 +112,6 @@ func (r *FirewallRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.FirewallRuleList{})),
 			Filter).
+		Watches(reapplySource(firewallRuleHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
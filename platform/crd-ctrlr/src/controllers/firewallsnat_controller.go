@@ -115,5 +115,6 @@ func (r *FirewallSNATReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.FirewallSNATList{})),
 			Filter).
+		Watches(reapplySource(firewallSnatHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -122,5 +122,6 @@ func (r *FirewallZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.FirewallZoneList{})),
 			Filter).
+		Watches(reapplySource(firewallZoneHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -145,5 +145,6 @@ func (r *IpsecHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.IpsecHostList{})),
 			Filter).
+		Watches(reapplySource(ipsecHostHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
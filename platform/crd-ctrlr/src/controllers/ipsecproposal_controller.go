@@ -112,5 +112,6 @@ func (r *IpsecProposalReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.IpsecProposalList{})),
 			Filter).
+		Watches(reapplySource(ipsecProposalHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -145,5 +145,6 @@ func (r *IpsecSiteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.IpsecSiteList{})),
 			Filter).
+		Watches(reapplySource(ipsecSiteHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
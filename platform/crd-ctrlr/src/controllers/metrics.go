@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Metrics of the reconciliation of the Sdewan CRs and of the CNFs, they are
+// served with the controller-runtime metrics
+var (
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sdewan_reconcile_total",
+			Help: "Number of reconciliations by CR type and result (success, requeue or error)",
+		},
+		[]string{"type", "result"},
+	)
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "sdewan_reconcile_duration_seconds",
+			Help:    "Duration of the reconciliations by CR type",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"type"},
+	)
+	cnfAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sdewan_cnf_available",
+			Help: "Whether the CNF pod reports its status (1) or not (0)",
+		},
+		[]string{"namespace", "pod", "purpose"},
+	)
+	statusActionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sdewan_cnf_status_actions_total",
+			Help: "Number of actions taken by the CNFStatusActions by module, action and result",
+		},
+		[]string{"module", "action", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileTotal, reconcileDuration, cnfAvailable, statusActionsTotal)
+}
+
+// observeReconcile records a reconciliation started at start
+func observeReconcile(crType string, start time.Time, res ctrl.Result, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	} else if res.Requeue || res.RequeueAfter > 0 {
+		result = "requeue"
+	}
+	reconcileTotal.WithLabelValues(crType, result).Inc()
+	reconcileDuration.WithLabelValues(crType).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	tcases := []struct {
+		name     string
+		res      ctrl.Result
+		err      error
+		expected string
+	}{
+		{"Success", ctrl.Result{}, nil, "success"},
+		{"Requeue", ctrl.Result{Requeue: true}, nil, "requeue"},
+		{"RequeueAfter", ctrl.Result{RequeueAfter: 5 * time.Second}, nil, "requeue"},
+		{"Error", ctrl.Result{}, errors.New("failed"), "error"},
+		{"ErrorWithRequeue", ctrl.Result{RequeueAfter: 5 * time.Second}, errors.New("failed"), "error"},
+	}
+
+	for _, tcase := range tcases {
+		crType := "Test" + tcase.name
+		observeReconcile(crType, time.Now(), tcase.res, tcase.err)
+		for _, result := range []string{"success", "requeue", "error"} {
+			expected := 0.0
+			if result == tcase.expected {
+				expected = 1
+			}
+			if count := testutil.ToFloat64(reconcileTotal.WithLabelValues(crType, result)); count != expected {
+				t.Errorf("%s: observeReconcile() counted %v %s reconciliations, expected %v", tcase.name, count, result, expected)
+			}
+		}
+		if count := testutil.CollectAndCount(reconcileDuration); count < 1 {
+			t.Errorf("%s: observeReconcile() did not observe the duration", tcase.name)
+		}
+	}
+}
+
+func TestForgetAvailable(t *testing.T) {
+	r := &SdewanCNFStatusController{}
+	cnf1 := cnfLabels{"default", "cnf1-0", "cnf1"}
+	cnf2 := cnfLabels{"default", "cnf2-0", "cnf2"}
+	for _, labels := range []cnfLabels{cnf1, cnf2} {
+		cnfAvailable.WithLabelValues(labels.namespace, labels.pod, labels.purpose).Set(1)
+	}
+	r.forgetAvailable(map[cnfLabels]bool{cnf1: true, cnf2: true})
+
+	// the pod of cnf2 is gone
+	r.forgetAvailable(map[cnfLabels]bool{cnf1: true})
+	if count := testutil.CollectAndCount(cnfAvailable); count != 1 {
+		t.Errorf("forgetAvailable() kept %d pods, expected 1", count)
+	}
+	if value := testutil.ToFloat64(cnfAvailable.WithLabelValues(cnf1.namespace, cnf1.pod, cnf1.purpose)); value != 1 {
+		t.Errorf("forgetAvailable() changed the availability of %s to %v", cnf1.pod, value)
+	}
+	r.forgetAvailable(map[cnfLabels]bool{})
+	if count := testutil.CollectAndCount(cnfAvailable); count != 0 {
+		t.Errorf("forgetAvailable() kept %d pods, expected none", count)
+	}
+}
@@ -123,5 +123,6 @@ func (r *Mwan3PolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.Mwan3PolicyList{})),
 			Filter).
+		Watches(reapplySource(mwan3PolicyHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
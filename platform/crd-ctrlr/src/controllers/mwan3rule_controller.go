@@ -122,5 +122,6 @@ func (r *Mwan3RuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &appsv1.Deployment{}},
 			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.Mwan3RuleList{})),
 			Filter).
+		Watches(reapplySource(mwan3RuleHandler), &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
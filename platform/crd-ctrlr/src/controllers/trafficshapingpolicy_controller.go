@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var trafficShapingPolicyHandler = new(TrafficShapingPolicyHandler)
+
+type TrafficShapingPolicyHandler struct {
+}
+
+func (m *TrafficShapingPolicyHandler) GetType() string {
+	return "TrafficShapingPolicy"
+}
+
+func (m *TrafficShapingPolicyHandler) GetName(instance client.Object) string {
+	policy := instance.(*batchv1alpha1.TrafficShapingPolicy)
+	return policy.Name
+}
+
+func (m *TrafficShapingPolicyHandler) GetFinalizer() string {
+	return "qos.policy.finalizers.sdewan.akraino.org"
+}
+
+func (m *TrafficShapingPolicyHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.TrafficShapingPolicy{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *TrafficShapingPolicyHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	policy := instance.(*batchv1alpha1.TrafficShapingPolicy)
+	iface, err := net2iface(policy.Spec.Network, deployment)
+	if err != nil {
+		return nil, err
+	}
+	// the names of the classes are unique in the CNF
+	var classes []openwrt.SdewanTrafficClass
+	for _, c := range policy.Spec.Classes {
+		classes = append(classes, openwrt.SdewanTrafficClass{
+			Name:     policy.Name + "/" + c.Name,
+			Priority: c.Priority,
+			Rate:     c.Rate,
+			Ceil:     c.Ceil,
+			Dscp:     c.Dscp,
+			Proto:    c.Proto,
+			SrcIp:    c.SrcIp,
+			SrcPort:  c.SrcPort,
+			DestIp:   c.DestIp,
+			DestPort: c.DestPort,
+		})
+	}
+	policyObject := openwrt.SdewanTrafficShapingPolicy{
+		Name:        policy.Name,
+		Interface:   iface,
+		EgressRate:  policy.Spec.EgressRate,
+		IngressRate: policy.Spec.IngressRate,
+		Classes:     classes,
+	}
+	return &policyObject, nil
+}
+
+func (m *TrafficShapingPolicyHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	policy1 := instance1.(*openwrt.SdewanTrafficShapingPolicy)
+	policy2 := instance2.(*openwrt.SdewanTrafficShapingPolicy)
+	return reflect.DeepEqual(*policy1, *policy2)
+}
+
+func (m *TrafficShapingPolicyHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	qos := openwrt.QosClient{OpenwrtClient: openwrtClient}
+	ret, err := qos.GetPolicy(name)
+	return ret, err
+}
+
+func (m *TrafficShapingPolicyHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	qos := openwrt.QosClient{OpenwrtClient: openwrtClient}
+	policy := instance.(*openwrt.SdewanTrafficShapingPolicy)
+	return qos.CreatePolicy(*policy)
+}
+
+func (m *TrafficShapingPolicyHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	qos := openwrt.QosClient{OpenwrtClient: openwrtClient}
+	policy := instance.(*openwrt.SdewanTrafficShapingPolicy)
+	return qos.UpdatePolicy(*policy)
+}
+
+func (m *TrafficShapingPolicyHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	qos := openwrt.QosClient{OpenwrtClient: openwrtClient}
+	return qos.DeletePolicy(name)
+}
+
+func (m *TrafficShapingPolicyHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// TrafficShapingPolicyReconciler reconciles a TrafficShapingPolicy object
+type TrafficShapingPolicyReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=trafficshapingpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=trafficshapingpolicies/status,verbs=get;update;patch
+
+func (r *TrafficShapingPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, trafficShapingPolicyHandler)
+}
+
+func (r *TrafficShapingPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.TrafficShapingPolicy{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.TrafficShapingPolicyList{})),
+			Filter).
+		Complete(r)
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var wireguardInterfaceHandler = new(WireguardInterfaceHandler)
+
+type WireguardInterfaceHandler struct {
+}
+
+func (m *WireguardInterfaceHandler) GetType() string {
+	return "WireguardInterface"
+}
+
+func (m *WireguardInterfaceHandler) GetName(instance client.Object) string {
+	iface := instance.(*batchv1alpha1.WireguardInterface)
+	return iface.Name
+}
+
+func (m *WireguardInterfaceHandler) GetFinalizer() string {
+	return "wireguard.interface.finalizers.sdewan.akraino.org"
+}
+
+func (m *WireguardInterfaceHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.WireguardInterface{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *WireguardInterfaceHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	iface := instance.(*batchv1alpha1.WireguardInterface)
+	ifaceObject := openwrt.SdewanWireguardInterface{
+		Name:       iface.Name,
+		PrivateKey: iface.Spec.PrivateKey,
+		ListenPort: iface.Spec.ListenPort,
+		Addresses:  iface.Spec.Addresses,
+		Mtu:        iface.Spec.Mtu,
+	}
+	return &ifaceObject, nil
+}
+
+func (m *WireguardInterfaceHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	iface1 := instance1.(*openwrt.SdewanWireguardInterface)
+	iface2 := instance2.(*openwrt.SdewanWireguardInterface)
+	return reflect.DeepEqual(*iface1, *iface2)
+}
+
+func (m *WireguardInterfaceHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	ret, err := wireguard.GetInterface(name)
+	return ret, err
+}
+
+func (m *WireguardInterfaceHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	iface := instance.(*openwrt.SdewanWireguardInterface)
+	return wireguard.CreateInterface(*iface)
+}
+
+func (m *WireguardInterfaceHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	iface := instance.(*openwrt.SdewanWireguardInterface)
+	return wireguard.UpdateInterface(*iface)
+}
+
+func (m *WireguardInterfaceHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	return wireguard.DeleteInterface(name)
+}
+
+func (m *WireguardInterfaceHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// WireguardInterfaceReconciler reconciles a WireguardInterface object
+type WireguardInterfaceReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=wireguardinterfaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=wireguardinterfaces/status,verbs=get;update;patch
+
+func (r *WireguardInterfaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, wireguardInterfaceHandler)
+}
+
+func (r *WireguardInterfaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.WireguardInterface{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.WireguardInterfaceList{})),
+			Filter).
+		Complete(r)
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	"sdewan.akraino.org/sdewan/openwrt"
+)
+
+var wireguardPeerHandler = new(WireguardPeerHandler)
+
+type WireguardPeerHandler struct {
+}
+
+func (m *WireguardPeerHandler) GetType() string {
+	return "WireguardPeer"
+}
+
+func (m *WireguardPeerHandler) GetName(instance client.Object) string {
+	peer := instance.(*batchv1alpha1.WireguardPeer)
+	return peer.Name
+}
+
+func (m *WireguardPeerHandler) GetFinalizer() string {
+	return "wireguard.peer.finalizers.sdewan.akraino.org"
+}
+
+func (m *WireguardPeerHandler) GetInstance(r client.Client, ctx context.Context, req ctrl.Request) (client.Object, error) {
+	instance := &batchv1alpha1.WireguardPeer{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	return instance, err
+}
+
+func (m *WireguardPeerHandler) Convert(instance client.Object, deployment appsv1.Deployment) (openwrt.IOpenWrtObject, error) {
+	peer := instance.(*batchv1alpha1.WireguardPeer)
+	peerObject := openwrt.SdewanWireguardPeer{
+		Name:                peer.Name,
+		Interface:           peer.Spec.Interface,
+		PublicKey:           peer.Spec.PublicKey,
+		PresharedKey:        peer.Spec.PresharedKey,
+		EndpointHost:        peer.Spec.EndpointHost,
+		EndpointPort:        peer.Spec.EndpointPort,
+		AllowedIps:          peer.Spec.AllowedIps,
+		PersistentKeepalive: peer.Spec.PersistentKeepalive,
+		RouteAllowedIps:     peer.Spec.RouteAllowedIps,
+	}
+	return &peerObject, nil
+}
+
+func (m *WireguardPeerHandler) IsEqual(instance1 openwrt.IOpenWrtObject, instance2 openwrt.IOpenWrtObject) bool {
+	peer1 := instance1.(*openwrt.SdewanWireguardPeer)
+	peer2 := instance2.(*openwrt.SdewanWireguardPeer)
+	return reflect.DeepEqual(*peer1, *peer2)
+}
+
+func (m *WireguardPeerHandler) GetObject(clientInfo *openwrt.OpenwrtClientInfo, name string) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	ret, err := wireguard.GetPeer(name)
+	return ret, err
+}
+
+func (m *WireguardPeerHandler) CreateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	peer := instance.(*openwrt.SdewanWireguardPeer)
+	return wireguard.CreatePeer(*peer)
+}
+
+func (m *WireguardPeerHandler) UpdateObject(clientInfo *openwrt.OpenwrtClientInfo, instance openwrt.IOpenWrtObject) (openwrt.IOpenWrtObject, error) {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	peer := instance.(*openwrt.SdewanWireguardPeer)
+	return wireguard.UpdatePeer(*peer)
+}
+
+func (m *WireguardPeerHandler) DeleteObject(clientInfo *openwrt.OpenwrtClientInfo, name string) error {
+	openwrtClient := openwrt.GetOpenwrtClient(*clientInfo)
+	wireguard := openwrt.WireguardClient{OpenwrtClient: openwrtClient}
+	return wireguard.DeletePeer(name)
+}
+
+func (m *WireguardPeerHandler) Restart(clientInfo *openwrt.OpenwrtClientInfo) (bool, error) {
+	return true, nil
+}
+
+// WireguardPeerReconciler reconciles a WireguardPeer object
+type WireguardPeerReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=wireguardpeers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch.sdewan.akraino.org,resources=wireguardpeers/status,verbs=get;update;patch
+
+func (r *WireguardPeerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return ProcessReconcile(r.Client, r.Log, ctx, req, wireguardPeerHandler)
+}
+
+func (r *WireguardPeerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ps := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1alpha1.WireguardPeer{}, ps).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			handler.EnqueueRequestsFromMapFunc(GetToRequestsFunc(r.Client, &batchv1alpha1.WireguardPeerList{})),
+			Filter).
+		Complete(r)
+}
@@ -5,7 +5,6 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"os"
 	"time"
 
@@ -20,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	batchv1alpha1 "sdewan.akraino.org/sdewan/api/v1alpha1"
+	batchv1beta1 "sdewan.akraino.org/sdewan/api/v1beta1"
 	"sdewan.akraino.org/sdewan/controllers"
 	// +kubebuilder:scaffold:imports
 )
@@ -33,6 +33,7 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 
 	_ = batchv1alpha1.AddToScheme(scheme)
+	_ = batchv1beta1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -64,33 +65,17 @@ func main() {
 	}
 
 	// Add indexer for rolebinding so that we can filter rolebindings by .subject
-	err = mgr.GetFieldIndexer().IndexField(context.Background(), &rbacv1.RoleBinding{}, ".subjects", func(rawObj client.Object) []string {
-		var fieldValues []string
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &rbacv1.RoleBinding{}, batchv1alpha1.BindingSubjectsIndex, func(rawObj client.Object) []string {
 		rolebinding := rawObj.(*rbacv1.RoleBinding)
-		for _, subject := range rolebinding.Subjects {
-			if subject.Kind == "ServiceAccount" {
-				fieldValues = append(fieldValues, fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name))
-			} else {
-				fieldValues = append(fieldValues, subject.Name)
-			}
-		}
-		return fieldValues
+		return batchv1alpha1.BindingSubjectKeys(rolebinding.Namespace, rolebinding.Subjects)
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
-	err = mgr.GetFieldIndexer().IndexField(context.Background(), &rbacv1.ClusterRoleBinding{}, ".subjects", func(rawObj client.Object) []string {
-		var fieldValues []string
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &rbacv1.ClusterRoleBinding{}, batchv1alpha1.BindingSubjectsIndex, func(rawObj client.Object) []string {
 		clusterrolebinding := rawObj.(*rbacv1.ClusterRoleBinding)
-		for _, subject := range clusterrolebinding.Subjects {
-			if subject.Kind == "ServiceAccount" {
-				fieldValues = append(fieldValues, fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name))
-			} else {
-				fieldValues = append(fieldValues, subject.Name)
-			}
-		}
-		return fieldValues
+		return batchv1alpha1.BindingSubjectKeys("", clusterrolebinding.Subjects)
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -209,6 +194,14 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CNFLabelWebhook")
 		os.Exit(1)
 	}
+	if err = batchv1alpha1.SetupSpecValidateWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SpecValidateWebhook")
+		os.Exit(1)
+	}
+	if err = batchv1beta1.SetupWebhooksWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "v1beta1")
+		os.Exit(1)
+	}
 	if err = (&controllers.IpsecSiteReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("IpsecSite"),
@@ -250,6 +243,78 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "CNFRouteRule")
 		os.Exit(1)
 	}
+	if err = (&controllers.WireguardInterfaceReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("WireguardInterface"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WireguardInterface")
+		os.Exit(1)
+	}
+	if err = (&controllers.WireguardPeerReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("WireguardPeer"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WireguardPeer")
+		os.Exit(1)
+	}
+	if err = (&controllers.BgpInstanceReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("BgpInstance"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BgpInstance")
+		os.Exit(1)
+	}
+	if err = (&controllers.BgpNeighborReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("BgpNeighbor"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BgpNeighbor")
+		os.Exit(1)
+	}
+	if err = (&controllers.TrafficShapingPolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("TrafficShapingPolicy"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TrafficShapingPolicy")
+		os.Exit(1)
+	}
+	if err = (&controllers.DhcpPoolReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DhcpPool"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DhcpPool")
+		os.Exit(1)
+	}
+	if err = (&controllers.DhcpStaticLeaseReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DhcpStaticLease"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DhcpStaticLease")
+		os.Exit(1)
+	}
+	if err = (&controllers.DnsForwarderReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DnsForwarder"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DnsForwarder")
+		os.Exit(1)
+	}
+	if err = (&controllers.DnsRecordReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DnsRecord"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DnsRecord")
+		os.Exit(1)
+	}
 	if err = (&controllers.CNFLocalServiceReconciler{
 		Client:        mgr.GetClient(),
 		Log:           ctrl.Log.WithName("controllers").WithName("CNFLocalService"),
@@ -274,6 +339,7 @@ func main() {
 	if err = (&controllers.SdewanCNFStatusController{
 		Client:        mgr.GetClient(),
 		Log:           ctrl.Log.WithName("controllers").WithName("SdewanCNFStatus"),
+		Recorder:      mgr.GetEventRecorderFor("sdewan-cnf-status"),
 		CheckInterval: time.Duration(checkInterval) * time.Second,
 	}).SetupWithManager(); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SdewanCNFStatus")
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package openwrt
+
+import (
+	"encoding/json"
+)
+
+const (
+	bgpBaseURL = "sdewan/bgp/v1/"
+)
+
+type BgpClient struct {
+	OpenwrtClient *openwrtClient
+}
+
+// Instances
+type SdewanBgpInstance struct {
+	Name     string   `json:"name"`
+	As       string   `json:"as"`
+	RouterId string   `json:"router_id"`
+	Networks []string `json:"networks"`
+	Table    string   `json:"table"`
+}
+
+type SdewanBgpInstances struct {
+	Instances []SdewanBgpInstance `json:"instances"`
+}
+
+func (o *SdewanBgpInstance) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanBgpInstance) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Neighbors
+type SdewanBgpNeighbor struct {
+	Name                 string `json:"name"`
+	Instance             string `json:"instance"`
+	RemoteAddress        string `json:"remote_address"`
+	RemoteAs             string `json:"remote_as"`
+	SourceAddress        string `json:"source_address"`
+	Password             string `json:"password"`
+	HoldTime             string `json:"hold_time"`
+	NextHopSelf          bool   `json:"next_hop_self"`
+	RouteReflectorClient bool   `json:"route_reflector_client"`
+	Dev                  string `json:"dev"`
+}
+
+type SdewanBgpNeighbors struct {
+	Neighbors []SdewanBgpNeighbor `json:"neighbors"`
+}
+
+func (o *SdewanBgpNeighbor) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanBgpNeighbor) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Instance APIs
+// get instances
+func (m *BgpClient) GetInstances() (*SdewanBgpInstances, error) {
+	response, err := m.OpenwrtClient.Get(bgpBaseURL + "instances")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpInstances SdewanBgpInstances
+	err = json.Unmarshal([]byte(response), &sdewanBgpInstances)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpInstances, nil
+}
+
+// get instance
+func (m *BgpClient) GetInstance(name string) (*SdewanBgpInstance, error) {
+	response, err := m.OpenwrtClient.Get(bgpBaseURL + "instances/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpInstance SdewanBgpInstance
+	err = json.Unmarshal([]byte(response), &sdewanBgpInstance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpInstance, nil
+}
+
+// create instance
+func (m *BgpClient) CreateInstance(instance SdewanBgpInstance) (*SdewanBgpInstance, error) {
+	instance_obj, _ := json.Marshal(instance)
+	response, err := m.OpenwrtClient.Post(bgpBaseURL+"instances", string(instance_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpInstance SdewanBgpInstance
+	err = json.Unmarshal([]byte(response), &sdewanBgpInstance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpInstance, nil
+}
+
+// delete instance
+func (m *BgpClient) DeleteInstance(name string) error {
+	_, err := m.OpenwrtClient.Delete(bgpBaseURL + "instances/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update instance
+func (m *BgpClient) UpdateInstance(instance SdewanBgpInstance) (*SdewanBgpInstance, error) {
+	instance_obj, _ := json.Marshal(instance)
+	response, err := m.OpenwrtClient.Put(bgpBaseURL+"instances/"+instance.Name, string(instance_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpInstance SdewanBgpInstance
+	err = json.Unmarshal([]byte(response), &sdewanBgpInstance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpInstance, nil
+}
+
+// Neighbor APIs
+// get neighbors
+func (m *BgpClient) GetNeighbors() (*SdewanBgpNeighbors, error) {
+	response, err := m.OpenwrtClient.Get(bgpBaseURL + "neighbors")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpNeighbors SdewanBgpNeighbors
+	err = json.Unmarshal([]byte(response), &sdewanBgpNeighbors)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpNeighbors, nil
+}
+
+// get neighbor
+func (m *BgpClient) GetNeighbor(name string) (*SdewanBgpNeighbor, error) {
+	response, err := m.OpenwrtClient.Get(bgpBaseURL + "neighbors/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpNeighbor SdewanBgpNeighbor
+	err = json.Unmarshal([]byte(response), &sdewanBgpNeighbor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpNeighbor, nil
+}
+
+// create neighbor
+func (m *BgpClient) CreateNeighbor(neighbor SdewanBgpNeighbor) (*SdewanBgpNeighbor, error) {
+	neighbor_obj, _ := json.Marshal(neighbor)
+	response, err := m.OpenwrtClient.Post(bgpBaseURL+"neighbors", string(neighbor_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpNeighbor SdewanBgpNeighbor
+	err = json.Unmarshal([]byte(response), &sdewanBgpNeighbor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpNeighbor, nil
+}
+
+// delete neighbor
+func (m *BgpClient) DeleteNeighbor(name string) error {
+	_, err := m.OpenwrtClient.Delete(bgpBaseURL + "neighbors/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update neighbor
+func (m *BgpClient) UpdateNeighbor(neighbor SdewanBgpNeighbor) (*SdewanBgpNeighbor, error) {
+	neighbor_obj, _ := json.Marshal(neighbor)
+	response, err := m.OpenwrtClient.Put(bgpBaseURL+"neighbors/"+neighbor.Name, string(neighbor_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanBgpNeighbor SdewanBgpNeighbor
+	err = json.Unmarshal([]byte(response), &sdewanBgpNeighbor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanBgpNeighbor, nil
+}
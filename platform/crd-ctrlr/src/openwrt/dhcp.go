@@ -0,0 +1,409 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package openwrt
+
+import (
+	"encoding/json"
+)
+
+const (
+	dhcpBaseURL = "sdewan/dhcp/v1/"
+)
+
+type DhcpClient struct {
+	OpenwrtClient *openwrtClient
+}
+
+// Pools
+type SdewanDhcpPool struct {
+	Name       string   `json:"name"`
+	Interface  string   `json:"interface"`
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Netmask    string   `json:"netmask"`
+	LeaseTime  string   `json:"lease_time"`
+	Gateway    string   `json:"gateway"`
+	DnsServers []string `json:"dns_servers"`
+	Domain     string   `json:"domain"`
+}
+
+type SdewanDhcpPools struct {
+	Pools []SdewanDhcpPool `json:"pools"`
+}
+
+func (o *SdewanDhcpPool) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanDhcpPool) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Leases
+type SdewanDhcpStaticLease struct {
+	Name      string `json:"name"`
+	Mac       string `json:"mac"`
+	Ip        string `json:"ip"`
+	Hostname  string `json:"hostname"`
+	LeaseTime string `json:"lease_time"`
+}
+
+type SdewanDhcpStaticLeases struct {
+	Leases []SdewanDhcpStaticLease `json:"leases"`
+}
+
+func (o *SdewanDhcpStaticLease) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanDhcpStaticLease) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Forwarders
+type SdewanDnsForwarder struct {
+	Name    string   `json:"name"`
+	Domain  string   `json:"domain"`
+	Servers []string `json:"servers"`
+	Port    string   `json:"port"`
+}
+
+type SdewanDnsForwarders struct {
+	Forwarders []SdewanDnsForwarder `json:"forwarders"`
+}
+
+func (o *SdewanDnsForwarder) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanDnsForwarder) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Records
+type SdewanDnsRecord struct {
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+	Ip     string `json:"ip"`
+}
+
+type SdewanDnsRecords struct {
+	Records []SdewanDnsRecord `json:"records"`
+}
+
+func (o *SdewanDnsRecord) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanDnsRecord) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Pool APIs
+// get pools
+func (m *DhcpClient) GetPools() (*SdewanDhcpPools, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "pools")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpPools SdewanDhcpPools
+	err = json.Unmarshal([]byte(response), &sdewanDhcpPools)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpPools, nil
+}
+
+// get pool
+func (m *DhcpClient) GetPool(name string) (*SdewanDhcpPool, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "pools/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpPool SdewanDhcpPool
+	err = json.Unmarshal([]byte(response), &sdewanDhcpPool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpPool, nil
+}
+
+// create pool
+func (m *DhcpClient) CreatePool(pool SdewanDhcpPool) (*SdewanDhcpPool, error) {
+	pool_obj, _ := json.Marshal(pool)
+	response, err := m.OpenwrtClient.Post(dhcpBaseURL+"pools", string(pool_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpPool SdewanDhcpPool
+	err = json.Unmarshal([]byte(response), &sdewanDhcpPool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpPool, nil
+}
+
+// delete pool
+func (m *DhcpClient) DeletePool(name string) error {
+	_, err := m.OpenwrtClient.Delete(dhcpBaseURL + "pools/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update pool
+func (m *DhcpClient) UpdatePool(pool SdewanDhcpPool) (*SdewanDhcpPool, error) {
+	pool_obj, _ := json.Marshal(pool)
+	response, err := m.OpenwrtClient.Put(dhcpBaseURL+"pools/"+pool.Name, string(pool_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpPool SdewanDhcpPool
+	err = json.Unmarshal([]byte(response), &sdewanDhcpPool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpPool, nil
+}
+
+// Lease APIs
+// get leases
+func (m *DhcpClient) GetLeases() (*SdewanDhcpStaticLeases, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "leases")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpStaticLeases SdewanDhcpStaticLeases
+	err = json.Unmarshal([]byte(response), &sdewanDhcpStaticLeases)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpStaticLeases, nil
+}
+
+// get lease
+func (m *DhcpClient) GetLease(name string) (*SdewanDhcpStaticLease, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "leases/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpStaticLease SdewanDhcpStaticLease
+	err = json.Unmarshal([]byte(response), &sdewanDhcpStaticLease)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpStaticLease, nil
+}
+
+// create lease
+func (m *DhcpClient) CreateLease(lease SdewanDhcpStaticLease) (*SdewanDhcpStaticLease, error) {
+	lease_obj, _ := json.Marshal(lease)
+	response, err := m.OpenwrtClient.Post(dhcpBaseURL+"leases", string(lease_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpStaticLease SdewanDhcpStaticLease
+	err = json.Unmarshal([]byte(response), &sdewanDhcpStaticLease)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpStaticLease, nil
+}
+
+// delete lease
+func (m *DhcpClient) DeleteLease(name string) error {
+	_, err := m.OpenwrtClient.Delete(dhcpBaseURL + "leases/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update lease
+func (m *DhcpClient) UpdateLease(lease SdewanDhcpStaticLease) (*SdewanDhcpStaticLease, error) {
+	lease_obj, _ := json.Marshal(lease)
+	response, err := m.OpenwrtClient.Put(dhcpBaseURL+"leases/"+lease.Name, string(lease_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDhcpStaticLease SdewanDhcpStaticLease
+	err = json.Unmarshal([]byte(response), &sdewanDhcpStaticLease)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDhcpStaticLease, nil
+}
+
+// Forwarder APIs
+// get forwarders
+func (m *DhcpClient) GetForwarders() (*SdewanDnsForwarders, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "forwarders")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsForwarders SdewanDnsForwarders
+	err = json.Unmarshal([]byte(response), &sdewanDnsForwarders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsForwarders, nil
+}
+
+// get forwarder
+func (m *DhcpClient) GetForwarder(name string) (*SdewanDnsForwarder, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "forwarders/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsForwarder SdewanDnsForwarder
+	err = json.Unmarshal([]byte(response), &sdewanDnsForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsForwarder, nil
+}
+
+// create forwarder
+func (m *DhcpClient) CreateForwarder(forwarder SdewanDnsForwarder) (*SdewanDnsForwarder, error) {
+	forwarder_obj, _ := json.Marshal(forwarder)
+	response, err := m.OpenwrtClient.Post(dhcpBaseURL+"forwarders", string(forwarder_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsForwarder SdewanDnsForwarder
+	err = json.Unmarshal([]byte(response), &sdewanDnsForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsForwarder, nil
+}
+
+// delete forwarder
+func (m *DhcpClient) DeleteForwarder(name string) error {
+	_, err := m.OpenwrtClient.Delete(dhcpBaseURL + "forwarders/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update forwarder
+func (m *DhcpClient) UpdateForwarder(forwarder SdewanDnsForwarder) (*SdewanDnsForwarder, error) {
+	forwarder_obj, _ := json.Marshal(forwarder)
+	response, err := m.OpenwrtClient.Put(dhcpBaseURL+"forwarders/"+forwarder.Name, string(forwarder_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsForwarder SdewanDnsForwarder
+	err = json.Unmarshal([]byte(response), &sdewanDnsForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsForwarder, nil
+}
+
+// Record APIs
+// get records
+func (m *DhcpClient) GetRecords() (*SdewanDnsRecords, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "records")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsRecords SdewanDnsRecords
+	err = json.Unmarshal([]byte(response), &sdewanDnsRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsRecords, nil
+}
+
+// get record
+func (m *DhcpClient) GetRecord(name string) (*SdewanDnsRecord, error) {
+	response, err := m.OpenwrtClient.Get(dhcpBaseURL + "records/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsRecord SdewanDnsRecord
+	err = json.Unmarshal([]byte(response), &sdewanDnsRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsRecord, nil
+}
+
+// create record
+func (m *DhcpClient) CreateRecord(record SdewanDnsRecord) (*SdewanDnsRecord, error) {
+	record_obj, _ := json.Marshal(record)
+	response, err := m.OpenwrtClient.Post(dhcpBaseURL+"records", string(record_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsRecord SdewanDnsRecord
+	err = json.Unmarshal([]byte(response), &sdewanDnsRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsRecord, nil
+}
+
+// delete record
+func (m *DhcpClient) DeleteRecord(name string) error {
+	_, err := m.OpenwrtClient.Delete(dhcpBaseURL + "records/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update record
+func (m *DhcpClient) UpdateRecord(record SdewanDnsRecord) (*SdewanDnsRecord, error) {
+	record_obj, _ := json.Marshal(record)
+	response, err := m.OpenwrtClient.Put(dhcpBaseURL+"records/"+record.Name, string(record_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanDnsRecord SdewanDnsRecord
+	err = json.Unmarshal([]byte(response), &sdewanDnsRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanDnsRecord, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package openwrt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Metrics of the calls to the OpenWrt API of the CNFs, they are served with
+// the controller-runtime metrics
+var (
+	apiRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sdewan_openwrt_requests_total",
+			Help: "Number of OpenWrt API requests by module, method and status code (error if no response)",
+		},
+		[]string{"module", "method", "code"},
+	)
+	apiLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "sdewan_openwrt_request_duration_seconds",
+			Help:    "Latency of the OpenWrt API requests by module and method",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"module", "method"},
+	)
+	logins = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sdewan_openwrt_logins_total",
+			Help: "Number of logins to the OpenWrt API by result",
+		},
+		[]string{"result"},
+	)
+	tokenRefreshes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "sdewan_openwrt_token_refreshes_total",
+			Help: "Number of OpenWrt API tokens refreshed after they expired",
+		},
+	)
+	serviceExecutions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sdewan_openwrt_service_executions_total",
+			Help: "Number of operations (e.g. restart) executed on the CNF services by service, operation and result",
+		},
+		[]string{"service", "operation", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiLatency, logins, tokenRefreshes, serviceExecutions)
+}
+
+// apiModule returns the module of an API url, e.g. mwan3 for
+// sdewan/mwan3/v1/rules and status for sdewan/v1/status
+func apiModule(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) > 2 && parts[0] == "sdewan" {
+		if parts[1] == "v1" {
+			return parts[2]
+		}
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// observeRequest records a request, code is 0 if no response is received
+func observeRequest(url string, method string, code int, start time.Time) {
+	module := apiModule(url)
+	label := "error"
+	if code != 0 {
+		label = strconv.Itoa(code)
+	}
+	apiRequests.WithLabelValues(module, method, label).Inc()
+	apiLatency.WithLabelValues(module, method).Observe(time.Since(start).Seconds())
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
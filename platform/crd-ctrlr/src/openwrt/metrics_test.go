@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package openwrt
+
+import (
+	"testing"
+)
+
+func TestApiModule(t *testing.T) {
+	tcases := []struct {
+		url      string
+		expected string
+	}{
+		{"sdewan/mwan3/v1/rules", "mwan3"},
+		{"sdewan/mwan3/v1/policies/balance", "mwan3"},
+		{"sdewan/v1/status", "status"},
+		{"sdewan/v1/service/restart/mwan3", "service"},
+		{"sdewan/dhcp/v1/records", "dhcp"},
+		{"cgi-bin/luci/rpc/auth", "cgi-bin"},
+	}
+
+	for _, tcase := range tcases {
+		if module := apiModule(tcase.url); module != tcase.expected {
+			t.Errorf("apiModule(%q) = %q, expected %q", tcase.url, module, tcase.expected)
+		}
+	}
+}
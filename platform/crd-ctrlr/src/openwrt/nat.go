@@ -17,18 +17,19 @@ type NatClient struct {
 
 // Nat
 type SdewanNat struct {
-	Name     string `json:"name"`
-	Src      string `json:"src"`
-	SrcIp    string `json:"src_ip"`
-	SrcDIp   string `json:"src_dip"`
-	SrcPort  string `json:"src_port"`
-	SrcDPort string `json:"src_dport"`
-	Proto    string `json:"proto"`
-	Dest     string `json:"dest"`
-	DestIp   string `json:"dest_ip"`
-	DestPort string `json:"dest_port"`
-	Target   string `json:"target"`
-	Index    string `json:"index"`
+	Name        string `json:"name"`
+	Src         string `json:"src"`
+	SrcIp       string `json:"src_ip"`
+	SrcDIp      string `json:"src_dip"`
+	SrcPort     string `json:"src_port"`
+	SrcDPort    string `json:"src_dport"`
+	Proto       string `json:"proto"`
+	Dest        string `json:"dest"`
+	DestIp      string `json:"dest_ip"`
+	DestPort    string `json:"dest_port"`
+	Target      string `json:"target"`
+	Index       string `json:"index"`
+	Probability string `json:"probability"`
 }
 
 func (o *SdewanNat) GetName() string {
@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 )
 
 type IOpenWrtObject interface {
@@ -95,7 +96,10 @@ func (o *openwrtClient) getBaseURL() string {
 }
 
 // login to openwrt http server
-func (o *openwrtClient) login() error {
+func (o *openwrtClient) login() (err error) {
+	defer func() {
+		logins.WithLabelValues(resultLabel(err)).Inc()
+	}()
 	if o.Password == "" {
 		return &OpenwrtError{Code: 403, Message: "Unauthorized"}
 	}
@@ -183,17 +187,21 @@ func (o *openwrtClient) call(method string, url string, request string) (string,
 		req_body := bytes.NewBuffer([]byte(request))
 		req, _ := http.NewRequest(method, o.getBaseURL()+url, req_body)
 		req.Header.Add("Cookie", "sysauth="+o.token)
+		start := time.Now()
 		resp, err := client.Do(req)
 		if err != nil {
+			observeRequest(url, method, 0, start)
 			return "", err
 		}
 		defer resp.Body.Close()
 
 		body, _ := ioutil.ReadAll(resp.Body)
+		observeRequest(url, method, resp.StatusCode, start)
 		if resp.StatusCode >= 400 {
 			if resp.StatusCode == 403 {
 				// token expired, retry
 				o.token = ""
+				tokenRefreshes.Inc()
 				continue
 			} else {
 				// error request
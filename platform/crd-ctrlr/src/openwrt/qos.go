@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package openwrt
+
+import (
+	"encoding/json"
+)
+
+const (
+	qosBaseURL = "sdewan/qos/v1/"
+)
+
+type QosClient struct {
+	OpenwrtClient *openwrtClient
+}
+
+// Classes
+type SdewanTrafficClass struct {
+	Name     string `json:"name"`
+	Priority string `json:"priority"`
+	Rate     string `json:"rate"`
+	Ceil     string `json:"ceil"`
+	Dscp     string `json:"dscp"`
+	Proto    string `json:"proto"`
+	SrcIp    string `json:"src_ip"`
+	SrcPort  string `json:"src_port"`
+	DestIp   string `json:"dest_ip"`
+	DestPort string `json:"dest_port"`
+}
+
+// Policies
+type SdewanTrafficShapingPolicy struct {
+	Name        string               `json:"name"`
+	Interface   string               `json:"interface"`
+	EgressRate  string               `json:"egress_rate"`
+	IngressRate string               `json:"ingress_rate"`
+	Classes     []SdewanTrafficClass `json:"classes"`
+}
+
+type SdewanTrafficShapingPolicies struct {
+	Policies []SdewanTrafficShapingPolicy `json:"policies"`
+}
+
+func (o *SdewanTrafficShapingPolicy) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanTrafficShapingPolicy) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Policy APIs
+// get policies
+func (m *QosClient) GetPolicies() (*SdewanTrafficShapingPolicies, error) {
+	response, err := m.OpenwrtClient.Get(qosBaseURL + "policies")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanTrafficShapingPolicies SdewanTrafficShapingPolicies
+	err = json.Unmarshal([]byte(response), &sdewanTrafficShapingPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanTrafficShapingPolicies, nil
+}
+
+// get policy
+func (m *QosClient) GetPolicy(name string) (*SdewanTrafficShapingPolicy, error) {
+	response, err := m.OpenwrtClient.Get(qosBaseURL + "policies/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanTrafficShapingPolicy SdewanTrafficShapingPolicy
+	err = json.Unmarshal([]byte(response), &sdewanTrafficShapingPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanTrafficShapingPolicy, nil
+}
+
+// create policy
+func (m *QosClient) CreatePolicy(policy SdewanTrafficShapingPolicy) (*SdewanTrafficShapingPolicy, error) {
+	policy_obj, _ := json.Marshal(policy)
+	response, err := m.OpenwrtClient.Post(qosBaseURL+"policies", string(policy_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanTrafficShapingPolicy SdewanTrafficShapingPolicy
+	err = json.Unmarshal([]byte(response), &sdewanTrafficShapingPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanTrafficShapingPolicy, nil
+}
+
+// delete policy
+func (m *QosClient) DeletePolicy(name string) error {
+	_, err := m.OpenwrtClient.Delete(qosBaseURL + "policies/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update policy
+func (m *QosClient) UpdatePolicy(policy SdewanTrafficShapingPolicy) (*SdewanTrafficShapingPolicy, error) {
+	policy_obj, _ := json.Marshal(policy)
+	response, err := m.OpenwrtClient.Put(qosBaseURL+"policies/"+policy.Name, string(policy_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanTrafficShapingPolicy SdewanTrafficShapingPolicy
+	err = json.Unmarshal([]byte(response), &sdewanTrafficShapingPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanTrafficShapingPolicy, nil
+}
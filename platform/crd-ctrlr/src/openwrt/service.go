@@ -49,6 +49,7 @@ func (s *ServiceClient) ExecuteService(service string, operation string) (bool,
 	}
 
 	_, err := s.OpenwrtClient.Put(serviceBaseURL+"service/"+service, s.formatExecuteServiceBody(operation))
+	serviceExecutions.WithLabelValues(service, operation, resultLabel(err)).Inc()
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2021 Intel Corporation
+
+package openwrt
+
+import (
+	"encoding/json"
+)
+
+const (
+	wireguardBaseURL = "sdewan/wireguard/v1/"
+)
+
+type WireguardClient struct {
+	OpenwrtClient *openwrtClient
+}
+
+// Interfaces
+type SdewanWireguardInterface struct {
+	Name       string   `json:"name"`
+	PrivateKey string   `json:"private_key"`
+	ListenPort string   `json:"listen_port"`
+	Addresses  []string `json:"addresses"`
+	Mtu        string   `json:"mtu"`
+}
+
+type SdewanWireguardInterfaces struct {
+	Interfaces []SdewanWireguardInterface `json:"interfaces"`
+}
+
+func (o *SdewanWireguardInterface) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanWireguardInterface) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Peers
+type SdewanWireguardPeer struct {
+	Name                string   `json:"name"`
+	Interface           string   `json:"interface"`
+	PublicKey           string   `json:"public_key"`
+	PresharedKey        string   `json:"preshared_key"`
+	EndpointHost        string   `json:"endpoint_host"`
+	EndpointPort        string   `json:"endpoint_port"`
+	AllowedIps          []string `json:"allowed_ips"`
+	PersistentKeepalive string   `json:"persistent_keepalive"`
+	RouteAllowedIps     bool     `json:"route_allowed_ips"`
+}
+
+type SdewanWireguardPeers struct {
+	Peers []SdewanWireguardPeer `json:"peers"`
+}
+
+func (o *SdewanWireguardPeer) GetName() string {
+	return o.Name
+}
+
+func (o *SdewanWireguardPeer) SetFullName(namespace string) {
+	o.Name = namespace + o.Name
+}
+
+// Interface APIs
+// get interfaces
+func (m *WireguardClient) GetInterfaces() (*SdewanWireguardInterfaces, error) {
+	response, err := m.OpenwrtClient.Get(wireguardBaseURL + "interfaces")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardInterfaces SdewanWireguardInterfaces
+	err = json.Unmarshal([]byte(response), &sdewanWireguardInterfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardInterfaces, nil
+}
+
+// get interface
+func (m *WireguardClient) GetInterface(name string) (*SdewanWireguardInterface, error) {
+	response, err := m.OpenwrtClient.Get(wireguardBaseURL + "interfaces/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardInterface SdewanWireguardInterface
+	err = json.Unmarshal([]byte(response), &sdewanWireguardInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardInterface, nil
+}
+
+// create interface
+func (m *WireguardClient) CreateInterface(iface SdewanWireguardInterface) (*SdewanWireguardInterface, error) {
+	iface_obj, _ := json.Marshal(iface)
+	response, err := m.OpenwrtClient.Post(wireguardBaseURL+"interfaces", string(iface_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardInterface SdewanWireguardInterface
+	err = json.Unmarshal([]byte(response), &sdewanWireguardInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardInterface, nil
+}
+
+// delete interface
+func (m *WireguardClient) DeleteInterface(name string) error {
+	_, err := m.OpenwrtClient.Delete(wireguardBaseURL + "interfaces/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update interface
+func (m *WireguardClient) UpdateInterface(iface SdewanWireguardInterface) (*SdewanWireguardInterface, error) {
+	iface_obj, _ := json.Marshal(iface)
+	response, err := m.OpenwrtClient.Put(wireguardBaseURL+"interfaces/"+iface.Name, string(iface_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardInterface SdewanWireguardInterface
+	err = json.Unmarshal([]byte(response), &sdewanWireguardInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardInterface, nil
+}
+
+// Peer APIs
+// get peers
+func (m *WireguardClient) GetPeers() (*SdewanWireguardPeers, error) {
+	response, err := m.OpenwrtClient.Get(wireguardBaseURL + "peers")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardPeers SdewanWireguardPeers
+	err = json.Unmarshal([]byte(response), &sdewanWireguardPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardPeers, nil
+}
+
+// get peer
+func (m *WireguardClient) GetPeer(name string) (*SdewanWireguardPeer, error) {
+	response, err := m.OpenwrtClient.Get(wireguardBaseURL + "peers/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardPeer SdewanWireguardPeer
+	err = json.Unmarshal([]byte(response), &sdewanWireguardPeer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardPeer, nil
+}
+
+// create peer
+func (m *WireguardClient) CreatePeer(peer SdewanWireguardPeer) (*SdewanWireguardPeer, error) {
+	peer_obj, _ := json.Marshal(peer)
+	response, err := m.OpenwrtClient.Post(wireguardBaseURL+"peers", string(peer_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardPeer SdewanWireguardPeer
+	err = json.Unmarshal([]byte(response), &sdewanWireguardPeer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardPeer, nil
+}
+
+// delete peer
+func (m *WireguardClient) DeletePeer(name string) error {
+	_, err := m.OpenwrtClient.Delete(wireguardBaseURL + "peers/" + name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// update peer
+func (m *WireguardClient) UpdatePeer(peer SdewanWireguardPeer) (*SdewanWireguardPeer, error) {
+	peer_obj, _ := json.Marshal(peer)
+	response, err := m.OpenwrtClient.Put(wireguardBaseURL+"peers/"+peer.Name, string(peer_obj))
+	if err != nil {
+		return nil, err
+	}
+
+	var sdewanWireguardPeer SdewanWireguardPeer
+	err = json.Unmarshal([]byte(response), &sdewanWireguardPeer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdewanWireguardPeer, nil
+}